@@ -0,0 +1,155 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring/prometheus"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/decisions"
+)
+
+// decisionsCmd is the parent command for the authorization decision log.
+var decisionsCmd = &cobra.Command{
+	Use:   "decisions",
+	Short: "Inspect the authorization decision log",
+	Long:  `Inspect the authorization decisions recorded by the token hook.`,
+}
+
+// decisionsListCmd lists recorded decisions, newest first.
+var decisionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List authorization decisions, newest first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runDecisionsList(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	decisionsListCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	decisionsListCmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	decisionsListCmd.Flags().StringP("user", "u", "", "Only list decisions for this user ID")
+	decisionsListCmd.Flags().StringP("client", "c", "", "Only list decisions for this client ID")
+	decisionsListCmd.Flags().String("since", "", "Only list decisions at or after this time (RFC 3339 or a duration ago, e.g. 2h)")
+	decisionsListCmd.Flags().String("until", "", "Only list decisions before this time (RFC 3339 or a duration ago, e.g. 2h)")
+	decisionsListCmd.Flags().Int("size", decisions.DefaultPageSize, "Number of decisions per page")
+	decisionsListCmd.Flags().String("page-token", "", "Page token returned by a previous call")
+	_ = decisionsListCmd.MarkFlagRequired("dsn")
+
+	decisionsCmd.AddCommand(decisionsListCmd)
+
+	rootCmd.AddCommand(decisionsCmd)
+}
+
+// runDecisionsList lists a page of decisions matching the filter flags.
+func runDecisionsList(cmd *cobra.Command) error {
+	opts := new(decisions.ListOptions)
+	opts.UserID, _ = cmd.Flags().GetString("user")
+	opts.ClientID, _ = cmd.Flags().GetString("client")
+	opts.PageSize, _ = cmd.Flags().GetInt("size")
+	opts.PageToken, _ = cmd.Flags().GetString("page-token")
+
+	var err error
+	since, _ := cmd.Flags().GetString("since")
+	if opts.Since, err = parseTimeFlag(since, time.Now()); err != nil {
+		return fmt.Errorf("invalid --since: %v", err)
+	}
+	until, _ := cmd.Flags().GetString("until")
+	if opts.Until, err = parseTimeFlag(until, time.Now()); err != nil {
+		return fmt.Errorf("invalid --until: %v", err)
+	}
+
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	logger := logging.NewLogger("error")
+	svc := decisions.NewService(
+		s,
+		tracing.NewTracer(tracing.NewConfig(false, "", "", logger)),
+		prometheus.NewMonitor("hook-service", logger),
+		logger,
+	)
+
+	page, next, err := svc.ListDecisions(cmd.Context(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to list decisions: %v", err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		if page == nil {
+			page = []*types.Decision{}
+		}
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"decisions":       page,
+			"next_page_token": next,
+		})
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tUSER\tCLIENT\tTENANT\tRESULT\tREASON\tGROUPS\tLATENCY")
+	for _, d := range page {
+		result := "denied"
+		if d.Allowed {
+			result = "allowed"
+		}
+		if d.Shadow {
+			result += " (shadow)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.CreatedAt.Format(time.RFC3339),
+			d.UserID,
+			d.ClientID,
+			d.TenantID,
+			result,
+			d.Reason,
+			strings.Join(d.Groups, ","),
+			d.Latency,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if next != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nNext page: --page-token %s\n", next)
+	}
+	return nil
+}
+
+// parseTimeFlag accepts an RFC 3339 time or a duration before now, an empty
+// value yields the zero time.
+func parseTimeFlag(v string, now time.Time) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 time nor a positive duration", v)
+	}
+
+	return now.Add(-d), nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newDecisionsListTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+	cmd.Flags().StringP("user", "u", "", "")
+	cmd.Flags().StringP("client", "c", "", "")
+	cmd.Flags().String("since", "", "")
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().Int("size", 50, "")
+	cmd.Flags().String("page-token", "", "")
+	return cmd
+}
+
+func TestDecisionsListRequiresDSN(t *testing.T) {
+	cmd := newDecisionsListTestCmd()
+
+	err := runDecisionsList(cmd)
+	if err == nil {
+		t.Fatal("expected error when dsn is empty")
+	}
+}
+
+func TestDecisionsListInvalidSince(t *testing.T) {
+	cmd := newDecisionsListTestCmd()
+	_ = cmd.Flags().Set("dsn", "postgres://localhost/db")
+	_ = cmd.Flags().Set("since", "yesterday")
+
+	err := runDecisionsList(cmd)
+	if err == nil {
+		t.Fatal("expected error when since is invalid")
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 14, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			value:    "",
+			expected: time.Time{},
+		},
+		{
+			name:     "RFC 3339",
+			value:    "2026-03-02T09:00:00Z",
+			expected: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "duration ago",
+			value:    "2h",
+			expected: now.Add(-2 * time.Hour),
+		},
+		{
+			name:    "negative duration",
+			value:   "-2h",
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			value:   "yesterday",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeFlag(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("parseTimeFlag() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/canonical/hook-service/gen/hook/explain/v1"
+)
+
+// explainTimeout bounds the whole explain request, the server queries the
+// group sources, OpenFGA and the tenant service.
+const explainTimeout = 30 * time.Second
+
+// explainCmd asks a running server how it would handle a token hook request.
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain how the token hook would handle a login",
+	Long: `Simulate a token hook request for a user and a client on a running server.
+The groups, OpenFGA tuples, tenant check, decision and emitted claims are
+printed, no token is issued and no decision is recorded.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runExplain(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	explainCmd.Flags().String("url", "http://localhost:8080", "Base URL of the hook service")
+	explainCmd.Flags().String("token", "", "Bearer token for the authz API (defaults to $HOOK_SERVICE_TOKEN)")
+	explainCmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	explainCmd.Flags().StringP("user", "u", "", "Subject ID of the user")
+	explainCmd.Flags().String("email", "", "Email the user's groups are stored under (defaults to --user)")
+	explainCmd.Flags().StringP("client", "c", "", "Client ID of the OAuth client")
+	explainCmd.Flags().StringSlice("grant-type", nil, "Grant type of the request (repeatable, defaults to authorization_code)")
+	explainCmd.Flags().StringSlice("audience", nil, "Granted audience (repeatable, or comma-separated)")
+	explainCmd.Flags().StringP("tenant", "t", "", "Tenant ID selected at login")
+	_ = explainCmd.MarkFlagRequired("client")
+
+	rootCmd.AddCommand(explainCmd)
+}
+
+// runExplain calls the explain endpoint and prints the explanation.
+func runExplain(cmd *cobra.Command) error {
+	baseURL, _ := cmd.Flags().GetString("url")
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv("HOOK_SERVICE_TOKEN")
+	}
+
+	clientID, _ := cmd.Flags().GetString("client")
+	if clientID == "" {
+		return fmt.Errorf("--client is required")
+	}
+
+	q := url.Values{}
+	q.Set("client_id", clientID)
+	for _, p := range [][2]string{{"user", "user_id"}, {"email", "email"}, {"tenant", "tenant_id"}} {
+		if v, _ := cmd.Flags().GetString(p[0]); v != "" {
+			q.Set(p[1], v)
+		}
+	}
+	for _, p := range [][2]string{{"grant-type", "grant_types"}, {"audience", "audience"}} {
+		vs, _ := cmd.Flags().GetStringSlice(p[0])
+		for _, v := range vs {
+			q.Add(p[1], v)
+		}
+	}
+
+	explanation, err := fetchExplanation(cmd, strings.TrimSuffix(baseURL, "/")+"/api/v0/authz/explain?"+q.Encode(), token)
+	if err != nil {
+		return err
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(explanation)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(b))
+		return err
+	}
+
+	return printExplanation(cmd.OutOrStdout(), explanation)
+}
+
+func fetchExplanation(cmd *cobra.Command, u, token string) (*pb.Explanation, error) {
+	req, err := http.NewRequestWithContext(cmd.Context(), http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := (&http.Client{Timeout: explainTimeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the hook service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("explain failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	r := new(pb.ExplainResp)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, r); err != nil {
+		return nil, fmt.Errorf("failed to decode the response: %v", err)
+	}
+	if r.GetData() == nil {
+		return nil, fmt.Errorf("empty explanation in response")
+	}
+	return r.GetData(), nil
+}
+
+func printExplanation(out io.Writer, e *pb.Explanation) error {
+	result := "denied"
+	if e.GetAllowed() {
+		result = "allowed"
+	}
+	if e.GetShadow() {
+		result += " (shadow)"
+	}
+
+	fmt.Fprintf(out, "User:     %s\n", e.GetUserId())
+	fmt.Fprintf(out, "Client:   %s\n", e.GetClientId())
+	fmt.Fprintf(out, "Decision: %s (%s)\n", result, e.GetReason())
+	fmt.Fprintf(out, "Issued:   %v\n", e.GetIssued())
+	if e.Error != nil {
+		fmt.Fprintf(out, "Error:    %s\n", e.GetError())
+	}
+
+	fmt.Fprintf(out, "\nGroups:\n")
+	if e.GroupsError != nil {
+		fmt.Fprintf(out, "  error: %s\n", e.GetGroupsError())
+	}
+	if len(e.GetDegradedSources()) > 0 {
+		fmt.Fprintf(out, "  degraded sources: %s\n", strings.Join(e.GetDegradedSources(), ", "))
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, g := range e.GetGroups() {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", g.GetId(), g.GetName(), g.GetTenantId(), g.GetType())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nOpenFGA checks:\n")
+	for _, t := range e.GetChecks() {
+		fmt.Fprintf(out, "  %s %s %s\n", t.GetUser(), t.GetRelation(), t.GetObject())
+	}
+	fmt.Fprintf(out, "Contextual tuples:\n")
+	for _, t := range e.GetContextualTuples() {
+		fmt.Fprintf(out, "  %s %s %s\n", t.GetUser(), t.GetRelation(), t.GetObject())
+	}
+
+	if tenant := e.GetTenant(); tenant.GetTenantId() != "" {
+		status := "not a member"
+		switch {
+		case tenant.Error != nil:
+			status = "error: " + tenant.GetError()
+		case tenant.GetMember():
+			status = "member"
+		}
+		fmt.Fprintf(out, "\nTenant:   %s (%s)\n", tenant.GetTenantId(), status)
+	}
+
+	if e.GetIssued() {
+		opts := protojson.MarshalOptions{Multiline: true, Indent: "  "}
+		at, err := opts.Marshal(e.GetAccessToken())
+		if err != nil {
+			return err
+		}
+		id, err := opts.Marshal(e.GetIdToken())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\nAccess token claims:\n%s\n", at)
+		fmt.Fprintf(out, "ID token claims:\n%s\n", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const explainResponse = `{
+  "data": {
+    "user_id": "alice",
+    "client_id": "app",
+    "groups": [{"id": "g1", "name": "admins", "tenant_id": "default", "type": "local"}],
+    "contextual_tuples": [{"user": "user:alice", "relation": "member", "object": "group:ZzE="}],
+    "checks": [{"user": "user:alice", "relation": "can_access", "object": "client:app"}],
+    "tenant": {"tenant_id": "t-1", "member": true},
+    "allowed": true,
+    "reason": "openfga_check",
+    "issued": true,
+    "access_token": {"groups": ["admins"], "tenant_id": "t-1"},
+    "id_token": {"groups": ["admins"]}
+  },
+  "status": 200,
+  "message": "Explanation"
+}`
+
+func newExplainTestCmd(url string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.Flags().String("url", url, "")
+	cmd.Flags().String("token", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+	cmd.Flags().StringP("user", "u", "", "")
+	cmd.Flags().String("email", "", "")
+	cmd.Flags().StringP("client", "c", "", "")
+	cmd.Flags().StringSlice("grant-type", nil, "")
+	cmd.Flags().StringSlice("audience", nil, "")
+	cmd.Flags().StringP("tenant", "t", "", "")
+	return cmd
+}
+
+func TestExplainRequiresClient(t *testing.T) {
+	cmd := newExplainTestCmd("http://localhost:8080")
+	_ = cmd.Flags().Set("user", "alice")
+
+	if err := runExplain(cmd); err == nil {
+		t.Fatal("expected error when client is empty")
+	}
+}
+
+func TestExplainPrintsExplanation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/authz/explain" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		q := r.URL.Query()
+		if q.Get("user_id") != "alice" || q.Get("client_id") != "app" || q.Get("tenant_id") != "t-1" {
+			t.Errorf("unexpected query %v", q)
+		}
+		if audience := q["audience"]; len(audience) != 2 {
+			t.Errorf("expected 2 audiences, got %v", audience)
+		}
+		_, _ = w.Write([]byte(explainResponse))
+	}))
+	defer srv.Close()
+
+	cmd := newExplainTestCmd(srv.URL)
+	_ = cmd.Flags().Set("token", "secret")
+	_ = cmd.Flags().Set("user", "alice")
+	_ = cmd.Flags().Set("client", "app")
+	_ = cmd.Flags().Set("tenant", "t-1")
+	_ = cmd.Flags().Set("audience", "api1,api2")
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+
+	if err := runExplain(cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, want := range []string{
+		"Decision: allowed (openfga_check)",
+		"user:alice can_access client:app",
+		"user:alice member group:ZzE=",
+		"Tenant:   t-1 (member)",
+		`"admins"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestExplainServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	cmd := newExplainTestCmd(srv.URL)
+	_ = cmd.Flags().Set("user", "alice")
+	_ = cmd.Flags().Set("client", "app")
+
+	if err := runExplain(cmd); err == nil {
+		t.Fatal("expected error when the server rejects the request")
+	}
+}
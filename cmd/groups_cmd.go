@@ -6,9 +6,19 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring/prometheus"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+	groups_api "github.com/canonical/hook-service/pkg/groups"
 )
 
 // groupsCmd is the parent command for group membership management operations.
@@ -47,7 +57,7 @@ var groupsRemoveUsersCmd = &cobra.Command{
 // groupsListUsersCmd lists all users in a group.
 var groupsListUsersCmd = &cobra.Command{
 	Use:   "list-users <group-id>",
-	Short: "List all users in a group",
+	Short: "List all users in a group with their role",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := runGroupsListUsers(cmd, args[0]); err != nil {
@@ -57,8 +67,151 @@ var groupsListUsersCmd = &cobra.Command{
 	},
 }
 
+// groupsAddOwnersCmd makes users owners of a group.
+var groupsAddOwnersCmd = &cobra.Command{
+	Use:   "add-owners <group-id>",
+	Short: "Make users owners of a group, owners can manage its members",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsAddOwners(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsRemoveOwnersCmd makes owners of a group regular members.
+var groupsRemoveOwnersCmd = &cobra.Command{
+	Use:   "remove-owners <group-id>",
+	Short: "Make owners of a group regular members",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsRemoveOwners(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsAddSubgroupsCmd nests groups in a group.
+var groupsAddSubgroupsCmd = &cobra.Command{
+	Use:   "add-subgroups <group-id>",
+	Short: "Nest groups in a group, their members become members of the group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsAddSubgroups(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsRemoveSubgroupsCmd removes groups nested in a group.
+var groupsRemoveSubgroupsCmd = &cobra.Command{
+	Use:   "remove-subgroups <group-id>",
+	Short: "Remove groups nested in a group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsRemoveSubgroups(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsListSubgroupsCmd lists the groups nested in a group.
+var groupsListSubgroupsCmd = &cobra.Command{
+	Use:   "list-subgroups <group-id>",
+	Short: "List the groups directly nested in a group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsListSubgroups(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsListMembersCmd lists the direct and inherited members of a group.
+var groupsListMembersCmd = &cobra.Command{
+	Use:   "list-members <group-id>",
+	Short: "List the direct and inherited members of a group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsListMembers(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsSetAttributesCmd sets custom attributes of a group.
+var groupsSetAttributesCmd = &cobra.Command{
+	Use:   "set-attributes <group-id>",
+	Short: "Set custom attributes of a group, overwriting the existing values",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsSetAttributes(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsRemoveAttributesCmd removes custom attributes of a group.
+var groupsRemoveAttributesCmd = &cobra.Command{
+	Use:   "remove-attributes <group-id>",
+	Short: "Remove custom attributes of a group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsRemoveAttributes(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsListAttributesCmd lists the custom attributes of a group.
+var groupsListAttributesCmd = &cobra.Command{
+	Use:   "list-attributes <group-id>",
+	Short: "List the custom attributes of a group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsListAttributes(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsListDeletedCmd lists the deleted groups awaiting their purge.
+var groupsListDeletedCmd = &cobra.Command{
+	Use:   "list-deleted",
+	Short: "List the deleted groups that can be restored",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsListDeleted(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupsRestoreCmd restores a deleted group.
+var groupsRestoreCmd = &cobra.Command{
+	Use:   "restore <group-id>",
+	Short: "Restore a deleted group with its members, subgroups and app grants",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGroupsRestore(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
 func init() {
-	for _, sub := range []*cobra.Command{groupsAddUsersCmd, groupsRemoveUsersCmd, groupsListUsersCmd} {
+	for _, sub := range []*cobra.Command{groupsAddUsersCmd, groupsRemoveUsersCmd, groupsListUsersCmd, groupsAddOwnersCmd, groupsRemoveOwnersCmd, groupsAddSubgroupsCmd, groupsRemoveSubgroupsCmd, groupsListSubgroupsCmd, groupsListMembersCmd, groupsSetAttributesCmd, groupsRemoveAttributesCmd, groupsListAttributesCmd, groupsListDeletedCmd, groupsRestoreCmd} {
 		sub.Flags().String("dsn", "", "PostgreSQL DSN connection string")
 		sub.Flags().StringP("format", "f", "text", "Output format (text or json)")
 		_ = sub.MarkFlagRequired("dsn")
@@ -68,9 +221,40 @@ func init() {
 	_ = groupsAddUsersCmd.MarkFlagRequired("user")
 	groupsRemoveUsersCmd.Flags().StringSliceP("user", "u", nil, "User ID to remove (repeatable, or comma-separated)")
 
+	groupsAddOwnersCmd.Flags().StringSliceP("user", "u", nil, "User ID to make owner (repeatable, or comma-separated)")
+	_ = groupsAddOwnersCmd.MarkFlagRequired("user")
+	groupsRemoveOwnersCmd.Flags().StringSliceP("user", "u", nil, "Owner ID to make member (repeatable, or comma-separated)")
+	_ = groupsRemoveOwnersCmd.MarkFlagRequired("user")
+
+	groupsAddSubgroupsCmd.Flags().StringSliceP("subgroup", "s", nil, "Group ID to nest (repeatable, or comma-separated)")
+	_ = groupsAddSubgroupsCmd.MarkFlagRequired("subgroup")
+	groupsRemoveSubgroupsCmd.Flags().StringSliceP("subgroup", "s", nil, "Group ID to remove (repeatable, or comma-separated)")
+	_ = groupsRemoveSubgroupsCmd.MarkFlagRequired("subgroup")
+
+	groupsSetAttributesCmd.Flags().StringSliceP("attribute", "a", nil, "Attribute to set as key=value (repeatable, or comma-separated)")
+	_ = groupsSetAttributesCmd.MarkFlagRequired("attribute")
+	groupsRemoveAttributesCmd.Flags().StringSliceP("key", "k", nil, "Attribute key to remove (repeatable, or comma-separated)")
+	_ = groupsRemoveAttributesCmd.MarkFlagRequired("key")
+
+	groupsRestoreCmd.Flags().String("openfga-host", "", "OpenFGA API host (optional, to grant the group its apps again)")
+	groupsRestoreCmd.Flags().String("openfga-store-id", "", "OpenFGA store ID")
+	groupsRestoreCmd.Flags().String("openfga-token", "", "OpenFGA API token")
+	groupsRestoreCmd.Flags().String("openfga-model-id", "", "OpenFGA authorization model ID")
+
 	groupsCmd.AddCommand(groupsAddUsersCmd)
 	groupsCmd.AddCommand(groupsRemoveUsersCmd)
 	groupsCmd.AddCommand(groupsListUsersCmd)
+	groupsCmd.AddCommand(groupsAddOwnersCmd)
+	groupsCmd.AddCommand(groupsRemoveOwnersCmd)
+	groupsCmd.AddCommand(groupsAddSubgroupsCmd)
+	groupsCmd.AddCommand(groupsRemoveSubgroupsCmd)
+	groupsCmd.AddCommand(groupsListSubgroupsCmd)
+	groupsCmd.AddCommand(groupsListMembersCmd)
+	groupsCmd.AddCommand(groupsSetAttributesCmd)
+	groupsCmd.AddCommand(groupsRemoveAttributesCmd)
+	groupsCmd.AddCommand(groupsListAttributesCmd)
+	groupsCmd.AddCommand(groupsListDeletedCmd)
+	groupsCmd.AddCommand(groupsRestoreCmd)
 
 	rootCmd.AddCommand(groupsCmd)
 }
@@ -135,21 +319,308 @@ func runGroupsListUsers(cmd *cobra.Command, groupID string) error {
 	}
 	defer cleanup()
 
-	userIDs, err := s.ListUsersInGroup(cmd.Context(), groupID)
+	users, _, err := s.ListUsersInGroup(cmd.Context(), groupID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to list users in group %q: %v", groupID, err)
 	}
 
 	format, _ := cmd.Flags().GetString("format")
 	if format == "json" {
-		if userIDs == nil {
-			userIDs = []string{}
+		if users == nil {
+			users = []*types.GroupUser{}
 		}
-		return json.NewEncoder(cmd.OutOrStdout()).Encode(userIDs)
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(users)
 	}
 
-	for _, uid := range userIDs {
-		fmt.Fprintln(cmd.OutOrStdout(), uid)
+	for _, u := range users {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", u.ID, u.Role)
 	}
 	return nil
 }
+
+// runGroupsAddOwners makes users owners of a group.
+func runGroupsAddOwners(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	userIDs, _ := cmd.Flags().GetStringSlice("user")
+
+	if err := s.AddOwnersToGroup(cmd.Context(), groupID, userIDs); err != nil {
+		return fmt.Errorf("failed to add owners to group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"group_id":     groupID,
+			"owners_added": len(userIDs),
+		})
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Added %d owners to group %s\n", len(userIDs), groupID)
+	return nil
+}
+
+// runGroupsRemoveOwners makes owners of a group regular members.
+func runGroupsRemoveOwners(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	userIDs, _ := cmd.Flags().GetStringSlice("user")
+
+	if err := s.RemoveOwnersFromGroup(cmd.Context(), groupID, userIDs); err != nil {
+		return fmt.Errorf("failed to remove owners from group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"group_id":       groupID,
+			"owners_removed": len(userIDs),
+		})
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Removed %d owners from group %s\n", len(userIDs), groupID)
+	return nil
+}
+
+// runGroupsAddSubgroups nests groups in a group.
+func runGroupsAddSubgroups(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	subgroupIDs, _ := cmd.Flags().GetStringSlice("subgroup")
+
+	if err := s.AddSubgroups(cmd.Context(), groupID, subgroupIDs); err != nil {
+		return fmt.Errorf("failed to add subgroups to group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"group_id":        groupID,
+			"subgroups_added": len(subgroupIDs),
+		})
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Added %d subgroups to group %s\n", len(subgroupIDs), groupID)
+	return nil
+}
+
+// runGroupsRemoveSubgroups removes groups nested in a group.
+func runGroupsRemoveSubgroups(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	subgroupIDs, _ := cmd.Flags().GetStringSlice("subgroup")
+
+	if err := s.RemoveSubgroups(cmd.Context(), groupID, subgroupIDs); err != nil {
+		return fmt.Errorf("failed to remove subgroups from group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"group_id":          groupID,
+			"subgroups_removed": len(subgroupIDs),
+		})
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Removed %d subgroups from group %s\n", len(subgroupIDs), groupID)
+	return nil
+}
+
+// runGroupsListSubgroups lists the groups directly nested in a group.
+func runGroupsListSubgroups(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	groups, err := s.ListSubgroups(cmd.Context(), groupID)
+	if err != nil {
+		return fmt.Errorf("failed to list subgroups of group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(groups)
+	}
+
+	for _, g := range groups {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", g.ID, g.Name)
+	}
+	return nil
+}
+
+// runGroupsListMembers lists the direct and inherited members of a group.
+func runGroupsListMembers(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	memberships, err := s.ListMembersOfGroup(cmd.Context(), groupID)
+	if err != nil {
+		return fmt.Errorf("failed to list members of group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(memberships)
+	}
+
+	printMemberships(cmd, memberships, func(m *types.Membership) string { return m.UserID })
+	return nil
+}
+
+// runGroupsSetAttributes sets custom attributes of a group.
+func runGroupsSetAttributes(cmd *cobra.Command, groupID string) error {
+	pairs, _ := cmd.Flags().GetStringSlice("attribute")
+	attributes, err := types.ParseAttributes(pairs)
+	if err != nil {
+		return err
+	}
+
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	updated, err := s.SetGroupAttributes(cmd.Context(), groupID, attributes)
+	if err != nil {
+		return fmt.Errorf("failed to set attributes of group %q: %v", groupID, err)
+	}
+
+	return printAttributes(cmd, updated)
+}
+
+// runGroupsRemoveAttributes removes custom attributes of a group.
+func runGroupsRemoveAttributes(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	keys, _ := cmd.Flags().GetStringSlice("key")
+
+	updated, err := s.RemoveGroupAttributes(cmd.Context(), groupID, keys)
+	if err != nil {
+		return fmt.Errorf("failed to remove attributes of group %q: %v", groupID, err)
+	}
+
+	return printAttributes(cmd, updated)
+}
+
+// runGroupsListAttributes lists the custom attributes of a group.
+func runGroupsListAttributes(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	group, err := s.GetGroup(cmd.Context(), groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group %q: %v", groupID, err)
+	}
+
+	return printAttributes(cmd, group.Attributes)
+}
+
+// runGroupsListDeleted lists the deleted groups awaiting their purge.
+func runGroupsListDeleted(cmd *cobra.Command) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	groups, err := s.ListDeletedGroups(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list deleted groups: %v", err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(groups)
+	}
+
+	for _, g := range groups {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", g.ID, g.Name, g.DeletedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// runGroupsRestore restores a deleted group, and grants it its apps again
+// in OpenFGA when the --openfga-* flags are set.
+func runGroupsRestore(cmd *cobra.Command, groupID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	logger := logging.NewLogger("error")
+	monitor := prometheus.NewMonitor("hook-service", logger)
+	tracer := tracing.NewTracer(tracing.NewConfig(false, "", "", logger))
+
+	svc := groups_api.NewService(s, buildAuthorizer(cmd, tracer, monitor, logger), nil, tracer, monitor, logger)
+
+	group, err := svc.RestoreGroup(cmd.Context(), groupID)
+	if err != nil {
+		return fmt.Errorf("failed to restore group %q: %v", groupID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(group)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Restored group %s (%s)\n", group.ID, group.Name)
+	return nil
+}
+
+// printAttributes prints one `key=value` attribute per line, sorted by key.
+func printAttributes(cmd *cobra.Command, attributes map[string]string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(attributes)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(attributes)) {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s=%s\n", k, attributes[k])
+	}
+	return nil
+}
+
+// printMemberships prints one membership per line, followed by the chain of
+// groups it is inherited through.
+func printMemberships(cmd *cobra.Command, memberships []*types.Membership, name func(*types.Membership) string) {
+	for _, m := range memberships {
+		if m.Direct() {
+			fmt.Fprintln(cmd.OutOrStdout(), name(m))
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t(via %s)\n", name(m), strings.Join(m.Path, " > "))
+	}
+}
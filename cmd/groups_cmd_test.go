@@ -4,9 +4,13 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/internal/types"
 )
 
 func TestGroupsAddUsersRequiresDSN(t *testing.T) {
@@ -197,3 +201,132 @@ func TestGroupsAddUsersRequiresUserFlag(t *testing.T) {
 		t.Fatal("expected error when --user flag is omitted")
 	}
 }
+
+func TestGroupsOwnersRequireDSN(t *testing.T) {
+	for name, run := range map[string]func(*cobra.Command, string) error{
+		"add-owners":    runGroupsAddOwners,
+		"remove-owners": runGroupsRemoveOwners,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("dsn", "", "")
+			cmd.Flags().StringP("format", "f", "text", "")
+			cmd.Flags().StringSliceP("user", "u", nil, "")
+
+			if err := run(cmd, "group-id-1"); err == nil {
+				t.Fatal("expected error when dsn is empty")
+			}
+		})
+	}
+}
+
+func TestGroupsSubgroupsRequireDSN(t *testing.T) {
+	for name, run := range map[string]func(*cobra.Command, string) error{
+		"add-subgroups":    runGroupsAddSubgroups,
+		"remove-subgroups": runGroupsRemoveSubgroups,
+		"list-subgroups":   runGroupsListSubgroups,
+		"list-members":     runGroupsListMembers,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("dsn", "", "")
+			cmd.Flags().StringP("format", "f", "text", "")
+			cmd.Flags().StringSliceP("subgroup", "s", nil, "")
+
+			if err := run(cmd, "group-id-1"); err == nil {
+				t.Fatal("expected error when dsn is empty")
+			}
+		})
+	}
+}
+
+func TestPrintMemberships(t *testing.T) {
+	cmd := &cobra.Command{}
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+
+	printMemberships(cmd, []*types.Membership{
+		{UserID: "alice", Path: []string{"engineering"}},
+		{UserID: "bob", Path: []string{"identity", "platform", "engineering"}},
+	}, func(m *types.Membership) string { return m.UserID })
+
+	expected := "alice\nbob\t(via identity > platform > engineering)\n"
+	if out.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestGroupsAttributesRequireDSN(t *testing.T) {
+	for name, run := range map[string]func(*cobra.Command, string) error{
+		"set-attributes":    runGroupsSetAttributes,
+		"remove-attributes": runGroupsRemoveAttributes,
+		"list-attributes":   runGroupsListAttributes,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("dsn", "", "")
+			cmd.Flags().StringP("format", "f", "text", "")
+			cmd.Flags().StringSliceP("attribute", "a", []string{"department=engineering"}, "")
+			cmd.Flags().StringSliceP("key", "k", []string{"department"}, "")
+
+			if err := run(cmd, "group-id-1"); err == nil {
+				t.Fatal("expected error when dsn is empty")
+			}
+		})
+	}
+}
+
+func TestGroupsSetAttributesRejectsInvalidAttribute(t *testing.T) {
+	for _, attribute := range []string{"department", "1department=engineering"} {
+		t.Run(attribute, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("dsn", "postgres://localhost/hook", "")
+			cmd.Flags().StringP("format", "f", "text", "")
+			cmd.Flags().StringSliceP("attribute", "a", []string{attribute}, "")
+
+			err := runGroupsSetAttributes(cmd, "group-id-1")
+			if !errors.Is(err, types.ErrInvalidAttribute) {
+				t.Fatalf("expected an invalid attribute error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPrintAttributes(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("format", "f", "text", "")
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+
+	if err := printAttributes(cmd, map[string]string{"slack": "#identity", "department": "engineering"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "department=engineering\nslack=#identity\n"
+	if out.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestGroupsListDeletedRequiresDSN(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+
+	err := runGroupsListDeleted(cmd)
+	if err == nil {
+		t.Fatal("expected error when dsn is empty")
+	}
+}
+
+func TestGroupsRestoreRequiresDSN(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+	cmd.Flags().String("openfga-host", "", "")
+
+	err := runGroupsRestore(cmd, "group-id-1")
+	if err == nil {
+		t.Fatal("expected error when dsn is empty")
+	}
+}
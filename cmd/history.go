@@ -0,0 +1,152 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring/prometheus"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/history"
+)
+
+// historyCmd is the parent command for the group and membership history.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Inspect the group and membership history",
+	Long:  `Inspect who changed the groups, their members and their app grants, and when.`,
+}
+
+// historyGroupCmd lists the history of a group, newest first.
+var historyGroupCmd = &cobra.Command{
+	Use:   "group <group-id>",
+	Short: "List the changes to a group, its members and its app grants, newest first",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runHistoryList(cmd, &history.ListOptions{GroupID: args[0]}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// historyUserCmd lists the history of a user, newest first.
+var historyUserCmd = &cobra.Command{
+	Use:   "user <user-id>",
+	Short: "List the changes to a user and its memberships, newest first",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runHistoryList(cmd, &history.ListOptions{UserID: args[0]}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{historyGroupCmd, historyUserCmd} {
+		c.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+		c.Flags().StringP("format", "f", "text", "Output format (text or json)")
+		c.Flags().String("since", "", "Only list changes at or after this time (RFC 3339 or a duration ago, e.g. 2h)")
+		c.Flags().String("until", "", "Only list changes before this time (RFC 3339 or a duration ago, e.g. 2h)")
+		c.Flags().Int("size", history.DefaultPageSize, "Number of changes per page")
+		c.Flags().String("page-token", "", "Page token returned by a previous call")
+		_ = c.MarkFlagRequired("dsn")
+
+		historyCmd.AddCommand(c)
+	}
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+// runHistoryList lists a page of the history of the group or user of opts.
+func runHistoryList(cmd *cobra.Command, opts *history.ListOptions) error {
+	opts.PageSize, _ = cmd.Flags().GetInt("size")
+	opts.PageToken, _ = cmd.Flags().GetString("page-token")
+
+	var err error
+	since, _ := cmd.Flags().GetString("since")
+	if opts.Since, err = parseTimeFlag(since, time.Now()); err != nil {
+		return fmt.Errorf("invalid --since: %v", err)
+	}
+	until, _ := cmd.Flags().GetString("until")
+	if opts.Until, err = parseTimeFlag(until, time.Now()); err != nil {
+		return fmt.Errorf("invalid --until: %v", err)
+	}
+
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	logger := logging.NewLogger("error")
+	svc := history.NewService(
+		s,
+		tracing.NewTracer(tracing.NewConfig(false, "", "", logger)),
+		prometheus.NewMonitor("hook-service", logger),
+		logger,
+	)
+
+	page, next, err := svc.ListHistory(cmd.Context(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to list history: %v", err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		if page == nil {
+			page = []*types.HistoryEntry{}
+		}
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"history":         page,
+			"next_page_token": next,
+		})
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tACTION\tGROUP\tUSER\tACTOR\tSOURCE\tBEFORE\tAFTER")
+	for _, e := range page {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.CreatedAt.Format(time.RFC3339),
+			e.Action,
+			e.GroupID,
+			e.UserID,
+			e.Actor,
+			e.Source,
+			historyValuesText(e.Before),
+			historyValuesText(e.After),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if next != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nNext page: --page-token %s\n", next)
+	}
+	return nil
+}
+
+// historyValuesText renders the before or after values of a change as
+// compact JSON, or "-" when there are none.
+func historyValuesText(values map[string]interface{}) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "-"
+	}
+	return string(b)
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/pkg/history"
+)
+
+func newHistoryTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+	cmd.Flags().String("since", "", "")
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().Int("size", 50, "")
+	cmd.Flags().String("page-token", "", "")
+	return cmd
+}
+
+func TestHistoryListRequiresDSN(t *testing.T) {
+	cmd := newHistoryTestCmd()
+
+	err := runHistoryList(cmd, &history.ListOptions{UserID: "alice"})
+	if err == nil {
+		t.Fatal("expected error when dsn is empty")
+	}
+}
+
+func TestHistoryListInvalidUntil(t *testing.T) {
+	cmd := newHistoryTestCmd()
+	_ = cmd.Flags().Set("dsn", "postgres://localhost/db")
+	_ = cmd.Flags().Set("until", "tomorrow")
+
+	err := runHistoryList(cmd, &history.ListOptions{UserID: "alice"})
+	if err == nil {
+		t.Fatal("expected error when until is invalid")
+	}
+}
+
+func TestHistoryValuesText(t *testing.T) {
+	if got := historyValuesText(nil); got != "-" {
+		t.Errorf("expected -, got %q", got)
+	}
+	if got := historyValuesText(map[string]interface{}{"name": "platform"}); got != `{"name":"platform"}` {
+		t.Errorf("expected compact JSON, got %q", got)
+	}
+}
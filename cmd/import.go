@@ -96,11 +96,16 @@ func runImport(cmd *cobra.Command) error {
 
 	imp := importer.NewImporter(importDriver, s, buildAuthorizer(cmd, tracer, monitor, logger), logger)
 
+	// The changes of the import are recorded with the driver as their source.
+	actor := cliActor()
+	actor.Source = storage.SourceImport + ":" + driver
+	ctx := storage.WithActor(cmd.Context(), actor)
+
 	sync, _ := cmd.Flags().GetBool("sync")
 	if sync {
-		return imp.Sync(cmd.Context())
+		return imp.Sync(ctx)
 	}
-	return imp.Run(cmd.Context())
+	return imp.Run(ctx)
 }
 
 // buildAuthorizer constructs an Authorizer from the --openfga-* flags.
@@ -5,8 +5,11 @@ package cmd
 
 import (
 	"os"
+	"os/user"
 
 	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/internal/storage"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -14,6 +17,19 @@ var rootCmd = &cobra.Command{
 	Use:   "",
 	Short: "Hook Service",
 	Long:  `A service for handling the Token Hydra Hook`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// The changes made by the commands are recorded with the local user.
+		cmd.SetContext(storage.WithActor(cmd.Context(), cliActor()))
+	},
+}
+
+// cliActor is the local user running a command.
+func cliActor() storage.Actor {
+	actor := storage.Actor{ID: os.Getenv("USER"), Source: storage.SourceCLI}
+	if u, err := user.Current(); err == nil {
+		actor.ID = u.Username
+	}
+	return actor
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -29,12 +29,16 @@ import (
 	"github.com/canonical/hook-service/internal/monitoring/prometheus"
 	"github.com/canonical/hook-service/internal/openfga"
 	"github.com/canonical/hook-service/internal/pool"
+	"github.com/canonical/hook-service/internal/signing"
 	"github.com/canonical/hook-service/internal/storage"
 	"github.com/canonical/hook-service/internal/tenants"
 	"github.com/canonical/hook-service/internal/tracing"
 	"github.com/canonical/hook-service/pkg/authentication"
+	"github.com/canonical/hook-service/pkg/decisions"
 	groups_api "github.com/canonical/hook-service/pkg/groups"
+	"github.com/canonical/hook-service/pkg/hooks"
 	"github.com/canonical/hook-service/pkg/web"
+	"github.com/canonical/hook-service/pkg/webhooks"
 )
 
 var serveCmd = &cobra.Command{
@@ -52,6 +56,23 @@ func init() {
 
 var errShutdownSignal = errors.New("shutdown signal received")
 
+// decisionLogPurgeInterval is how often decisions older than
+// DECISION_LOG_RETENTION are deleted.
+const decisionLogPurgeInterval = time.Hour
+
+// groupPurgeInterval is how often groups deleted more than
+// GROUP_DELETION_RETENTION ago are purged.
+const groupPurgeInterval = time.Hour
+
+// outboxPurgeInterval is how often outbox events older than
+// OUTBOX_RETENTION are deleted.
+const outboxPurgeInterval = time.Hour
+
+// webhookSettleMargin is added to the transaction timeout to get the age of
+// the outbox events delivered to the webhooks, so that events are only read
+// once every transaction that could write an earlier one has ended.
+const webhookSettleMargin = 5 * time.Second
+
 func serve() error {
 	specs := new(config.EnvSpec)
 	if err := envconfig.Process("", specs); err != nil {
@@ -131,19 +152,140 @@ func serve() error {
 		}
 		defer tenantServiceConn.Close()
 
-		tenantValidator = tenants.NewClient(
+		tenantClient := tenants.NewClient(
 			tenantpb.NewTenantServiceClient(tenantServiceConn),
 			specs.TenantServiceGRPCTimeout,
 			tracer,
 			monitor,
 			logger,
 		)
-		logger.Infof("Tenant validation enabled (tenant-service: %s, tls: %v, timeout: %s)", specs.TenantServiceGRPCAddress, specs.TenantServiceTLSEnabled, specs.TenantServiceGRPCTimeout)
+		tenantValidator = tenantClient
+		if specs.TenantServiceCacheTTL > 0 && specs.TenantServiceCacheMaxEntries > 0 {
+			tenantValidator, err = tenants.NewCachedClient(
+				tenantClient,
+				specs.TenantServiceCacheMaxEntries,
+				specs.TenantServiceCacheTTL,
+				specs.TenantServiceCacheStaleTTL,
+				tracer,
+				logger,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to setup tenant cache: %v", err)
+			}
+		}
+		logger.Infof("Tenant validation enabled (tenant-service: %s, tls: %v, timeout: %s, cache ttl: %s)", specs.TenantServiceGRPCAddress, specs.TenantServiceTLSEnabled, specs.TenantServiceGRPCTimeout, specs.TenantServiceCacheTTL)
 	} else {
 		tenantValidator = tenants.NewNoopValidator()
 		logger.Info("Tenant validation disabled (no TENANT_SERVICE_GRPC_ADDRESS)")
 	}
 
+	claimMappings, err := hooks.ParseClaimMappings(specs.TokenClaimMappings)
+	if err != nil {
+		return fmt.Errorf("failed to parse token claim mappings: %v", err)
+	}
+	claimMapper, err := hooks.NewClaimMapper(claimMappings)
+	if err != nil {
+		return fmt.Errorf("failed to setup token claim mappings: %v", err)
+	}
+
+	policy, err := hooks.ParsePolicyConfig(specs.AuthorizationPolicy)
+	if err != nil {
+		return fmt.Errorf("failed to parse authorization policy: %v", err)
+	}
+
+	tenantConfig := &hooks.TenantConfig{
+		SessionKey:  specs.TenantSessionKey,
+		AutoSelect:  specs.TenantAutoSelect,
+		ListTenants: claimMapper.Uses(hooks.ClaimSourceTenants),
+	}
+
+	signatureVerifier, err := hooks.NewSignatureVerifier(signing.ParseSecrets(specs.HookSigningSecrets), specs.HookSigningWindow)
+	if err != nil {
+		return fmt.Errorf("failed to setup hook request signing: %v", err)
+	}
+	if signatureVerifier != nil {
+		logger.Infof("Hook request signing enabled (window: %s)", specs.HookSigningWindow)
+	}
+
+	decisionCache := hooks.NewDecisionCache(specs.HookCacheMaxEntries, specs.HookCacheTTL, logger)
+	if decisionCache != nil {
+		logger.Infof("Hook decision cache enabled (ttl: %s, max entries: %d)", specs.HookCacheTTL, specs.HookCacheMaxEntries)
+	}
+
+	groupSourceConfigs, err := hooks.ParseGroupSourceConfigs(specs.GroupSources)
+	if err != nil {
+		return fmt.Errorf("failed to parse group sources: %v", err)
+	}
+	groupClients := map[string]hooks.ClientInterface{
+		hooks.GroupSourceLocal: decisionCache.Client(hooks.NewLocalStorageClient(s, tracer, monitor, logger)),
+	}
+	if specs.LDAPURL != "" {
+		ldapClient, err := hooks.NewLDAPClient(
+			hooks.LDAPConfig{
+				URL:                specs.LDAPURL,
+				BindDN:             specs.LDAPBindDN,
+				BindPassword:       specs.LDAPBindPassword,
+				StartTLS:           specs.LDAPStartTLS,
+				BaseDN:             specs.LDAPBaseDN,
+				UserBaseDN:         specs.LDAPUserBaseDN,
+				GroupBaseDN:        specs.LDAPGroupBaseDN,
+				UserFilter:         specs.LDAPUserFilter,
+				GroupFilter:        specs.LDAPGroupFilter,
+				UserAttribute:      specs.LDAPUserAttribute,
+				GroupIDAttribute:   specs.LDAPGroupIDAttribute,
+				GroupNameAttribute: specs.LDAPGroupNameAttribute,
+				TenantID:           specs.LDAPTenantID,
+				Timeout:            specs.LDAPTimeout,
+				PoolSize:           specs.LDAPPoolSize,
+				CacheTTL:           specs.LDAPCacheTTL,
+				CacheSize:          specs.LDAPCacheMaxEntries,
+			},
+			tracer, monitor, logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to setup LDAP client: %v", err)
+		}
+		defer ldapClient.Close()
+		groupClients[hooks.GroupSourceLDAP] = ldapClient
+		logger.Infof("LDAP group source available (url: %s)", specs.LDAPURL)
+	}
+	groupSources, err := hooks.NewGroupSources(groupSourceConfigs, groupClients)
+	if err != nil {
+		return fmt.Errorf("failed to setup group sources: %v", err)
+	}
+
+	var decisionLog *hooks.DecisionLog
+	if specs.DecisionLogEnabled {
+		decisionLog = hooks.NewDecisionLog(s, logger)
+		logger.Infof("Decision log enabled (retention: %s)", specs.DecisionLogRetention)
+	}
+
+	webhookSubscribers, err := webhooks.ParseSubscribers(specs.WebhookSubscribers)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook subscribers: %v", err)
+	}
+	dispatcher, err := webhooks.NewDispatcher(
+		s,
+		webhooks.Config{
+			Subscribers:  webhookSubscribers,
+			MaxAttempts:  specs.WebhookMaxAttempts,
+			MinBackoff:   specs.WebhookMinBackoff,
+			MaxBackoff:   specs.WebhookMaxBackoff,
+			Timeout:      specs.WebhookTimeout,
+			PollInterval: specs.WebhookPollInterval,
+			SettleDelay:  db.TxTimeout + webhookSettleMargin,
+		},
+		tracer,
+		monitor,
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to setup webhook dispatcher: %v", err)
+	}
+	if dispatcher != nil {
+		logger.Infof("Webhook dispatcher enabled (%d subscribers)", len(webhookSubscribers))
+	}
+
 	var jwtVerifier authentication.TokenVerifierInterface
 	if specs.AuthenticationEnabled {
 		var allowedSubjects []string
@@ -181,19 +323,27 @@ func serve() error {
 
 	router := web.NewRouter(
 		specs.ApiToken,
+		signatureVerifier,
 		specs.AuthenticationEnabled,
 		wpool,
 		s,
 		dbClient,
 		authorizer,
 		tenantValidator,
+		groupSources,
+		claimMapper,
+		policy,
+		tenantConfig,
+		decisionCache,
+		decisionLog,
 		jwtVerifier,
 		tracer,
 		monitor,
 		logger,
 	)
 
-	groupService := groups_api.NewService(s, authorizer, tracer, monitor, logger)
+	groupService := groups_api.NewService(s, authorizer, decisionCache, tracer, monitor, logger)
+	groupService.SetOutboxRetention(specs.OutboxRetention)
 
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf("0.0.0.0:%v", specs.Port),
@@ -284,6 +434,40 @@ func serve() error {
 		}
 	})
 
+	if decisionCache != nil {
+		eg.Go(func() error {
+			// Writes made by any instance invalidate the local caches.
+			return dbClient.Listen(ctx, storage.ChangesChannel, storage.NewChangeSubscriber(decisionCache, logger))
+		})
+	}
+
+	eg.Go(func() error {
+		return decisionLog.Run(ctx)
+	})
+
+	if specs.DecisionLogRetention > 0 {
+		eg.Go(func() error {
+			return decisions.NewService(s, tracer, monitor, logger).RunRetention(ctx, specs.DecisionLogRetention, decisionLogPurgeInterval)
+		})
+	}
+
+	eg.Go(func() error {
+		return dispatcher.Run(ctx)
+	})
+
+	if specs.OutboxRetention > 0 {
+		eg.Go(func() error {
+			return webhooks.NewService(s, tracer, monitor, logger).RunRetention(ctx, specs.OutboxRetention, outboxPurgeInterval)
+		})
+	}
+
+	if specs.GroupDeletionRetention > 0 {
+		eg.Go(func() error {
+			purgeCtx := storage.WithActor(ctx, storage.Actor{Source: storage.SourceSystem})
+			return groupService.RunRetention(purgeCtx, specs.GroupDeletionRetention, groupPurgeInterval)
+		})
+	}
+
 	eg.Go(func() error {
 		select {
 		case <-sigCh:
@@ -51,6 +51,19 @@ var usersListGroupsCmd = &cobra.Command{
 	},
 }
 
+// usersListMembershipsCmd lists the direct and inherited memberships of a user.
+var usersListMembershipsCmd = &cobra.Command{
+	Use:   "list-memberships <user-id>",
+	Short: "List the direct and inherited group memberships of a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runUsersListMemberships(cmd, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
 // usersSetGroupsCmd replaces a user's group memberships.
 var usersSetGroupsCmd = &cobra.Command{
 	Use:   "set-groups <user-id>",
@@ -65,7 +78,7 @@ var usersSetGroupsCmd = &cobra.Command{
 }
 
 func init() {
-	for _, sub := range []*cobra.Command{usersDeleteCmd, usersListGroupsCmd, usersSetGroupsCmd} {
+	for _, sub := range []*cobra.Command{usersDeleteCmd, usersListGroupsCmd, usersListMembershipsCmd, usersSetGroupsCmd} {
 		sub.Flags().String("dsn", "", "PostgreSQL DSN connection string")
 		sub.Flags().StringP("format", "f", "text", "Output format (text or json)")
 		_ = sub.MarkFlagRequired("dsn")
@@ -76,6 +89,7 @@ func init() {
 
 	usersCmd.AddCommand(usersDeleteCmd)
 	usersCmd.AddCommand(usersListGroupsCmd)
+	usersCmd.AddCommand(usersListMembershipsCmd)
 	usersCmd.AddCommand(usersSetGroupsCmd)
 
 	rootCmd.AddCommand(usersCmd)
@@ -163,6 +177,28 @@ func runUsersListGroups(cmd *cobra.Command, userID string) error {
 	return nil
 }
 
+// runUsersListMemberships lists the direct and inherited memberships of a user.
+func runUsersListMemberships(cmd *cobra.Command, userID string) error {
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	memberships, err := s.ListMembershipsForUser(cmd.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to list memberships for user %q: %v", userID, err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(memberships)
+	}
+
+	printMemberships(cmd, memberships, func(m *types.Membership) string { return m.Group.Name })
+	return nil
+}
+
 // runUsersSetGroups replaces a user's group memberships.
 func runUsersSetGroups(cmd *cobra.Command, userID string) error {
 	s, cleanup, err := newStorageFromCmd(cmd)
@@ -162,3 +162,14 @@ func TestUsersSetGroupsRequiresGroupFlag(t *testing.T) {
 		t.Fatal("expected error when --group flag is omitted")
 	}
 }
+
+func TestUsersListMembershipsRequiresDSN(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+
+	err := runUsersListMemberships(cmd, "alice@example.com")
+	if err == nil {
+		t.Fatal("expected error when dsn is empty")
+	}
+}
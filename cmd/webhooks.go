@@ -0,0 +1,245 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring/prometheus"
+	"github.com/canonical/hook-service/internal/signing"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/webhooks"
+)
+
+// webhooksCmd is the parent command for the webhook deliveries.
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Inspect and replay webhook deliveries",
+	Long:  `Inspect and replay the membership and app grant events the webhook subscribers failed to accept.`,
+}
+
+// webhooksDeliveriesCmd lists dead-lettered deliveries, oldest first.
+var webhooksDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries",
+	Short: "List dead-lettered webhook deliveries, oldest first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runWebhooksDeliveries(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// webhooksReplayCmd hands failed deliveries back to the dispatcher.
+var webhooksReplayCmd = &cobra.Command{
+	Use:   "replay [delivery-id...]",
+	Short: "Replay failed webhook deliveries",
+	Long: `Replay failed webhook deliveries, by ID or every failed delivery with --all.
+The dispatcher of a running instance delivers them again on its next poll.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runWebhooksReplay(cmd, args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// webhooksReceiveCmd runs a stand-in webhook subscriber.
+var webhooksReceiveCmd = &cobra.Command{
+	Use:   "receive",
+	Short: "Run a local webhook receiver printing the events it gets",
+	Long: `Run a local webhook receiver which checks the signature of the requests and
+prints their events as JSON lines, to try out a subscriber configuration.
+With --fail every event is rejected, to try out the retries and dead-lettering.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runWebhooksReceive(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	webhooksDeliveriesCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	webhooksDeliveriesCmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	webhooksDeliveriesCmd.Flags().StringP("subscriber", "s", "", "Only list deliveries to this subscriber")
+	webhooksDeliveriesCmd.Flags().String("status", string(types.WebhookDeliveryFailed), "Only list deliveries in this status (failed, pending, delivered or empty for all)")
+	webhooksDeliveriesCmd.Flags().Int("size", webhooks.DefaultPageSize, "Number of deliveries per page")
+	webhooksDeliveriesCmd.Flags().String("page-token", "", "Page token returned by a previous call")
+	_ = webhooksDeliveriesCmd.MarkFlagRequired("dsn")
+
+	webhooksReplayCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	webhooksReplayCmd.Flags().StringP("subscriber", "s", "", "Only replay deliveries to this subscriber")
+	webhooksReplayCmd.Flags().Bool("all", false, "Replay every failed delivery, of the subscriber if set")
+	_ = webhooksReplayCmd.MarkFlagRequired("dsn")
+
+	webhooksReceiveCmd.Flags().String("addr", "localhost:8000", "Address to listen on")
+	webhooksReceiveCmd.Flags().String("secret", "", "Secret of the subscriber, or a comma separated current and previous secret during rotation")
+	webhooksReceiveCmd.Flags().Bool("fail", false, "Reject every event with a 500")
+	_ = webhooksReceiveCmd.MarkFlagRequired("secret")
+
+	webhooksCmd.AddCommand(webhooksDeliveriesCmd)
+	webhooksCmd.AddCommand(webhooksReplayCmd)
+	webhooksCmd.AddCommand(webhooksReceiveCmd)
+
+	rootCmd.AddCommand(webhooksCmd)
+}
+
+func newWebhooksService(s webhooks.DatabaseInterface) *webhooks.Service {
+	logger := logging.NewLogger("error")
+	return webhooks.NewService(
+		s,
+		tracing.NewTracer(tracing.NewConfig(false, "", "", logger)),
+		prometheus.NewMonitor("hook-service", logger),
+		logger,
+	)
+}
+
+// runWebhooksDeliveries lists a page of deliveries matching the filter flags.
+func runWebhooksDeliveries(cmd *cobra.Command) error {
+	opts := new(webhooks.ListOptions)
+	opts.Subscriber, _ = cmd.Flags().GetString("subscriber")
+	status, _ := cmd.Flags().GetString("status")
+	opts.Status = types.WebhookDeliveryStatus(status)
+	opts.PageSize, _ = cmd.Flags().GetInt("size")
+	opts.PageToken, _ = cmd.Flags().GetString("page-token")
+
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	page, next, err := newWebhooksService(s).ListDeliveries(cmd.Context(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook deliveries: %v", err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		if page == nil {
+			page = []*types.WebhookDelivery{}
+		}
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]interface{}{
+			"deliveries":      page,
+			"next_page_token": next,
+		})
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSUBSCRIBER\tSTATUS\tATTEMPTS\tEVENT\tTYPE\tGROUP\tUSER\tUPDATED\tLAST ERROR")
+	for _, d := range page {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			d.ID,
+			d.Subscriber,
+			d.Status,
+			d.Attempts,
+			d.Event.ID,
+			d.Event.Type,
+			d.Event.GroupID,
+			d.Event.UserID,
+			d.UpdatedAt.Format(time.RFC3339),
+			d.LastError,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if next != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nNext page: --page-token %s\n", next)
+	}
+	return nil
+}
+
+// runWebhooksReplay replays the failed deliveries of args, or every failed
+// delivery with --all.
+func runWebhooksReplay(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		return fmt.Errorf("pass either delivery IDs or --all")
+	}
+
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid delivery ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	subscriber, _ := cmd.Flags().GetString("subscriber")
+
+	s, cleanup, err := newStorageFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	n, err := newWebhooksService(s).ReplayDeliveries(cmd.Context(), subscriber, ids)
+	if err != nil {
+		return fmt.Errorf("failed to replay webhook deliveries: %v", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Replayed %d deliveries\n", n)
+	return nil
+}
+
+// runWebhooksReceive serves a webhooks.Receiver until interrupted.
+func runWebhooksReceive(cmd *cobra.Command) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	secret, _ := cmd.Flags().GetString("secret")
+	fail, _ := cmd.Flags().GetBool("fail")
+
+	var mu sync.Mutex
+	out := json.NewEncoder(cmd.OutOrStdout())
+	receiver, err := webhooks.NewReceiver(signing.ParseSecrets(secret), func(_ context.Context, e *types.OutboxEvent) error {
+		mu.Lock()
+		err := out.Encode(e)
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if fail {
+			return fmt.Errorf("rejected with --fail")
+		}
+		return nil
+	}, logging.NewLogger("info"))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Addr: addr, Handler: receiver, ReadTimeout: 15 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Receiving webhook events on http://%s\n", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
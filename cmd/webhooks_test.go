@@ -0,0 +1,59 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newWebhooksReplayTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("subscriber", "s", "", "")
+	cmd.Flags().Bool("all", false, "")
+	return cmd
+}
+
+func TestWebhooksDeliveriesRequiresDSN(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dsn", "", "")
+	cmd.Flags().StringP("format", "f", "text", "")
+	cmd.Flags().StringP("subscriber", "s", "", "")
+	cmd.Flags().String("status", "failed", "")
+	cmd.Flags().Int("size", 50, "")
+	cmd.Flags().String("page-token", "", "")
+
+	if err := runWebhooksDeliveries(cmd); err == nil {
+		t.Fatal("expected error when dsn is empty")
+	}
+}
+
+func TestWebhooksReplayArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		all  bool
+	}{
+		{name: "Neither IDs nor --all"},
+		{name: "Both IDs and --all", args: []string{"1"}, all: true},
+		{name: "Invalid ID", args: []string{"abc"}},
+		{name: "Negative ID", args: []string{"-1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := newWebhooksReplayTestCmd()
+			_ = cmd.Flags().Set("dsn", "postgres://localhost/db")
+			if test.all {
+				_ = cmd.Flags().Set("all", "true")
+			}
+
+			if err := runWebhooksReplay(cmd, test.args); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
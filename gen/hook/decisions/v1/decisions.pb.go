@@ -0,0 +1,408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/decisions/v1/decisions.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListDecisionsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDecisionsReq) Reset() {
+	*x = ListDecisionsReq{}
+	mi := &file_hook_decisions_v1_decisions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecisionsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionsReq) ProtoMessage() {}
+
+func (x *ListDecisionsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_decisions_v1_decisions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionsReq.ProtoReflect.Descriptor instead.
+func (*ListDecisionsReq) Descriptor() ([]byte, []int) {
+	return file_hook_decisions_v1_decisions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListDecisionsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListDecisionsReq) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ListDecisionsReq) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListDecisionsReq) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListDecisionsReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDecisionsReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDecisionsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Decision            `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDecisionsResp) Reset() {
+	*x = ListDecisionsResp{}
+	mi := &file_hook_decisions_v1_decisions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecisionsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionsResp) ProtoMessage() {}
+
+func (x *ListDecisionsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_decisions_v1_decisions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionsResp.ProtoReflect.Descriptor instead.
+func (*ListDecisionsResp) Descriptor() ([]byte, []int) {
+	return file_hook_decisions_v1_decisions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDecisionsResp) GetData() []*Decision {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListDecisionsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListDecisionsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ListDecisionsResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Decision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	GrantTypes    []string               `protobuf:"bytes,5,rep,name=grant_types,json=grantTypes,proto3" json:"grant_types,omitempty"`
+	Audience      []string               `protobuf:"bytes,6,rep,name=audience,proto3" json:"audience,omitempty"`
+	TenantId      string                 `protobuf:"bytes,7,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Groups        []string               `protobuf:"bytes,8,rep,name=groups,proto3" json:"groups,omitempty"`
+	Allowed       bool                   `protobuf:"varint,9,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Shadow        bool                   `protobuf:"varint,10,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	Reason        string                 `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`
+	Latency       *durationpb.Duration   `protobuf:"bytes,12,opt,name=latency,proto3" json:"latency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decision) Reset() {
+	*x = Decision{}
+	mi := &file_hook_decisions_v1_decisions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decision) ProtoMessage() {}
+
+func (x *Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_decisions_v1_decisions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decision.ProtoReflect.Descriptor instead.
+func (*Decision) Descriptor() ([]byte, []int) {
+	return file_hook_decisions_v1_decisions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Decision) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Decision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Decision) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Decision) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Decision) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *Decision) GetAudience() []string {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+func (x *Decision) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Decision) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Decision) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *Decision) GetShadow() bool {
+	if x != nil {
+		return x.Shadow
+	}
+	return false
+}
+
+func (x *Decision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Decision) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+var File_hook_decisions_v1_decisions_proto protoreflect.FileDescriptor
+
+const file_hook_decisions_v1_decisions_proto_rawDesc = "" +
+	"\n" +
+	"!hook/decisions/v1/decisions.proto\x12\x11hook.decisions.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe8\x01\n" +
+	"\x10ListDecisionsReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\xaf\x01\n" +
+	"\x11ListDecisionsResp\x12/\n" +
+	"\x04data\x18\x01 \x03(\v2\x1b.hook.decisions.v1.DecisionR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageTokenB\n" +
+	"\n" +
+	"\b_message\"\xfc\x02\n" +
+	"\bDecision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vgrant_types\x18\x05 \x03(\tR\n" +
+	"grantTypes\x12\x1a\n" +
+	"\baudience\x18\x06 \x03(\tR\baudience\x12\x1b\n" +
+	"\ttenant_id\x18\a \x01(\tR\btenantId\x12\x16\n" +
+	"\x06groups\x18\b \x03(\tR\x06groups\x12\x18\n" +
+	"\aallowed\x18\t \x01(\bR\aallowed\x12\x16\n" +
+	"\x06shadow\x18\n" +
+	" \x01(\bR\x06shadow\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\x123\n" +
+	"\alatency\x18\f \x01(\v2\x19.google.protobuf.DurationR\alatency2\x8f\x01\n" +
+	"\x10DecisionsService\x12{\n" +
+	"\rListDecisions\x12#.hook.decisions.v1.ListDecisionsReq\x1a$.hook.decisions.v1.ListDecisionsResp\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v0/authz/decisionsB9Z7github.com/canonical/hook-service/gen/hook/decisions/v1b\x06proto3"
+
+var (
+	file_hook_decisions_v1_decisions_proto_rawDescOnce sync.Once
+	file_hook_decisions_v1_decisions_proto_rawDescData []byte
+)
+
+func file_hook_decisions_v1_decisions_proto_rawDescGZIP() []byte {
+	file_hook_decisions_v1_decisions_proto_rawDescOnce.Do(func() {
+		file_hook_decisions_v1_decisions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_decisions_v1_decisions_proto_rawDesc), len(file_hook_decisions_v1_decisions_proto_rawDesc)))
+	})
+	return file_hook_decisions_v1_decisions_proto_rawDescData
+}
+
+var file_hook_decisions_v1_decisions_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_hook_decisions_v1_decisions_proto_goTypes = []any{
+	(*ListDecisionsReq)(nil),      // 0: hook.decisions.v1.ListDecisionsReq
+	(*ListDecisionsResp)(nil),     // 1: hook.decisions.v1.ListDecisionsResp
+	(*Decision)(nil),              // 2: hook.decisions.v1.Decision
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 4: google.protobuf.Duration
+}
+var file_hook_decisions_v1_decisions_proto_depIdxs = []int32{
+	3, // 0: hook.decisions.v1.ListDecisionsReq.since:type_name -> google.protobuf.Timestamp
+	3, // 1: hook.decisions.v1.ListDecisionsReq.until:type_name -> google.protobuf.Timestamp
+	2, // 2: hook.decisions.v1.ListDecisionsResp.data:type_name -> hook.decisions.v1.Decision
+	3, // 3: hook.decisions.v1.Decision.created_at:type_name -> google.protobuf.Timestamp
+	4, // 4: hook.decisions.v1.Decision.latency:type_name -> google.protobuf.Duration
+	0, // 5: hook.decisions.v1.DecisionsService.ListDecisions:input_type -> hook.decisions.v1.ListDecisionsReq
+	1, // 6: hook.decisions.v1.DecisionsService.ListDecisions:output_type -> hook.decisions.v1.ListDecisionsResp
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_hook_decisions_v1_decisions_proto_init() }
+func file_hook_decisions_v1_decisions_proto_init() {
+	if File_hook_decisions_v1_decisions_proto != nil {
+		return
+	}
+	file_hook_decisions_v1_decisions_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_decisions_v1_decisions_proto_rawDesc), len(file_hook_decisions_v1_decisions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_decisions_v1_decisions_proto_goTypes,
+		DependencyIndexes: file_hook_decisions_v1_decisions_proto_depIdxs,
+		MessageInfos:      file_hook_decisions_v1_decisions_proto_msgTypes,
+	}.Build()
+	File_hook_decisions_v1_decisions_proto = out.File
+	file_hook_decisions_v1_decisions_proto_goTypes = nil
+	file_hook_decisions_v1_decisions_proto_depIdxs = nil
+}
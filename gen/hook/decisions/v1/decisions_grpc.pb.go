@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/decisions/v1/decisions.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DecisionsService_ListDecisions_FullMethodName = "/hook.decisions.v1.DecisionsService/ListDecisions"
+)
+
+// DecisionsServiceClient is the client API for DecisionsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DecisionsServiceClient interface {
+	ListDecisions(ctx context.Context, in *ListDecisionsReq, opts ...grpc.CallOption) (*ListDecisionsResp, error)
+}
+
+type decisionsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDecisionsServiceClient(cc grpc.ClientConnInterface) DecisionsServiceClient {
+	return &decisionsServiceClient{cc}
+}
+
+func (c *decisionsServiceClient) ListDecisions(ctx context.Context, in *ListDecisionsReq, opts ...grpc.CallOption) (*ListDecisionsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDecisionsResp)
+	err := c.cc.Invoke(ctx, DecisionsService_ListDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecisionsServiceServer is the server API for DecisionsService service.
+// All implementations must embed UnimplementedDecisionsServiceServer
+// for forward compatibility.
+type DecisionsServiceServer interface {
+	ListDecisions(context.Context, *ListDecisionsReq) (*ListDecisionsResp, error)
+	mustEmbedUnimplementedDecisionsServiceServer()
+}
+
+// UnimplementedDecisionsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDecisionsServiceServer struct{}
+
+func (UnimplementedDecisionsServiceServer) ListDecisions(context.Context, *ListDecisionsReq) (*ListDecisionsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDecisions not implemented")
+}
+func (UnimplementedDecisionsServiceServer) mustEmbedUnimplementedDecisionsServiceServer() {}
+func (UnimplementedDecisionsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeDecisionsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DecisionsServiceServer will
+// result in compilation errors.
+type UnsafeDecisionsServiceServer interface {
+	mustEmbedUnimplementedDecisionsServiceServer()
+}
+
+func RegisterDecisionsServiceServer(s grpc.ServiceRegistrar, srv DecisionsServiceServer) {
+	// If the following call panics, it indicates UnimplementedDecisionsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DecisionsService_ServiceDesc, srv)
+}
+
+func _DecisionsService_ListDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDecisionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionsServiceServer).ListDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionsService_ListDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionsServiceServer).ListDecisions(ctx, req.(*ListDecisionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DecisionsService_ServiceDesc is the grpc.ServiceDesc for DecisionsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DecisionsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.decisions.v1.DecisionsService",
+	HandlerType: (*DecisionsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDecisions",
+			Handler:    _DecisionsService_ListDecisions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/decisions/v1/decisions.proto",
+}
@@ -0,0 +1,643 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/explain/v1/explain.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExplainReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	GrantTypes    []string               `protobuf:"bytes,4,rep,name=grant_types,json=grantTypes,proto3" json:"grant_types,omitempty"`
+	Audience      []string               `protobuf:"bytes,5,rep,name=audience,proto3" json:"audience,omitempty"`
+	TenantId      string                 `protobuf:"bytes,6,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainReq) Reset() {
+	*x = ExplainReq{}
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainReq) ProtoMessage() {}
+
+func (x *ExplainReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainReq.ProtoReflect.Descriptor instead.
+func (*ExplainReq) Descriptor() ([]byte, []int) {
+	return file_hook_explain_v1_explain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExplainReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExplainReq) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ExplainReq) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ExplainReq) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *ExplainReq) GetAudience() []string {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+func (x *ExplainReq) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ExplainResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *Explanation           `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainResp) Reset() {
+	*x = ExplainResp{}
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResp) ProtoMessage() {}
+
+func (x *ExplainResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResp.ProtoReflect.Descriptor instead.
+func (*ExplainResp) Descriptor() ([]byte, []int) {
+	return file_hook_explain_v1_explain_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExplainResp) GetData() *Explanation {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExplainResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ExplainResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type Explanation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ClientId         string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Groups           []*Group               `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	GroupsError      *string                `protobuf:"bytes,4,opt,name=groups_error,json=groupsError,proto3,oneof" json:"groups_error,omitempty"`
+	ContextualTuples []*Tuple               `protobuf:"bytes,5,rep,name=contextual_tuples,json=contextualTuples,proto3" json:"contextual_tuples,omitempty"`
+	Checks           []*Tuple               `protobuf:"bytes,6,rep,name=checks,proto3" json:"checks,omitempty"`
+	Tenant           *TenantCheck           `protobuf:"bytes,7,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Allowed          bool                   `protobuf:"varint,8,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Shadow           bool                   `protobuf:"varint,9,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	Reason           string                 `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error            *string                `protobuf:"bytes,11,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Issued           bool                   `protobuf:"varint,12,opt,name=issued,proto3" json:"issued,omitempty"`
+	AccessToken      *structpb.Struct       `protobuf:"bytes,13,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	IdToken          *structpb.Struct       `protobuf:"bytes,14,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	DegradedSources  []string               `protobuf:"bytes,15,rep,name=degraded_sources,json=degradedSources,proto3" json:"degraded_sources,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Explanation) Reset() {
+	*x = Explanation{}
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Explanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Explanation) ProtoMessage() {}
+
+func (x *Explanation) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Explanation.ProtoReflect.Descriptor instead.
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return file_hook_explain_v1_explain_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Explanation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Explanation) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Explanation) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Explanation) GetGroupsError() string {
+	if x != nil && x.GroupsError != nil {
+		return *x.GroupsError
+	}
+	return ""
+}
+
+func (x *Explanation) GetContextualTuples() []*Tuple {
+	if x != nil {
+		return x.ContextualTuples
+	}
+	return nil
+}
+
+func (x *Explanation) GetChecks() []*Tuple {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *Explanation) GetTenant() *TenantCheck {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *Explanation) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *Explanation) GetShadow() bool {
+	if x != nil {
+		return x.Shadow
+	}
+	return false
+}
+
+func (x *Explanation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Explanation) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *Explanation) GetIssued() bool {
+	if x != nil {
+		return x.Issued
+	}
+	return false
+}
+
+func (x *Explanation) GetAccessToken() *structpb.Struct {
+	if x != nil {
+		return x.AccessToken
+	}
+	return nil
+}
+
+func (x *Explanation) GetIdToken() *structpb.Struct {
+	if x != nil {
+		return x.IdToken
+	}
+	return nil
+}
+
+func (x *Explanation) GetDegradedSources() []string {
+	if x != nil {
+		return x.DegradedSources
+	}
+	return nil
+}
+
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_hook_explain_v1_explain_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Group) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Group) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Tuple struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Object        string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tuple) Reset() {
+	*x = Tuple{}
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tuple) ProtoMessage() {}
+
+func (x *Tuple) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tuple.ProtoReflect.Descriptor instead.
+func (*Tuple) Descriptor() ([]byte, []int) {
+	return file_hook_explain_v1_explain_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Tuple) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Tuple) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *Tuple) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+type TenantCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Member        bool                   `protobuf:"varint,2,opt,name=member,proto3" json:"member,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	AutoSelected  bool                   `protobuf:"varint,4,opt,name=auto_selected,json=autoSelected,proto3" json:"auto_selected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantCheck) Reset() {
+	*x = TenantCheck{}
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantCheck) ProtoMessage() {}
+
+func (x *TenantCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_explain_v1_explain_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantCheck.ProtoReflect.Descriptor instead.
+func (*TenantCheck) Descriptor() ([]byte, []int) {
+	return file_hook_explain_v1_explain_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TenantCheck) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TenantCheck) GetMember() bool {
+	if x != nil {
+		return x.Member
+	}
+	return false
+}
+
+func (x *TenantCheck) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *TenantCheck) GetAutoSelected() bool {
+	if x != nil {
+		return x.AutoSelected
+	}
+	return false
+}
+
+var File_hook_explain_v1_explain_proto protoreflect.FileDescriptor
+
+const file_hook_explain_v1_explain_proto_rawDesc = "" +
+	"\n" +
+	"\x1dhook/explain/v1/explain.proto\x12\x0fhook.explain.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xb2\x01\n" +
+	"\n" +
+	"ExplainReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vgrant_types\x18\x04 \x03(\tR\n" +
+	"grantTypes\x12\x1a\n" +
+	"\baudience\x18\x05 \x03(\tR\baudience\x12\x1b\n" +
+	"\ttenant_id\x18\x06 \x01(\tR\btenantId\"\x82\x01\n" +
+	"\vExplainResp\x120\n" +
+	"\x04data\x18\x01 \x01(\v2\x1c.hook.explain.v1.ExplanationR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"\xf9\x04\n" +
+	"\vExplanation\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12.\n" +
+	"\x06groups\x18\x03 \x03(\v2\x16.hook.explain.v1.GroupR\x06groups\x12&\n" +
+	"\fgroups_error\x18\x04 \x01(\tH\x00R\vgroupsError\x88\x01\x01\x12C\n" +
+	"\x11contextual_tuples\x18\x05 \x03(\v2\x16.hook.explain.v1.TupleR\x10contextualTuples\x12.\n" +
+	"\x06checks\x18\x06 \x03(\v2\x16.hook.explain.v1.TupleR\x06checks\x124\n" +
+	"\x06tenant\x18\a \x01(\v2\x1c.hook.explain.v1.TenantCheckR\x06tenant\x12\x18\n" +
+	"\aallowed\x18\b \x01(\bR\aallowed\x12\x16\n" +
+	"\x06shadow\x18\t \x01(\bR\x06shadow\x12\x16\n" +
+	"\x06reason\x18\n" +
+	" \x01(\tR\x06reason\x12\x19\n" +
+	"\x05error\x18\v \x01(\tH\x01R\x05error\x88\x01\x01\x12\x16\n" +
+	"\x06issued\x18\f \x01(\bR\x06issued\x12:\n" +
+	"\faccess_token\x18\r \x01(\v2\x17.google.protobuf.StructR\vaccessToken\x122\n" +
+	"\bid_token\x18\x0e \x01(\v2\x17.google.protobuf.StructR\aidToken\x12)\n" +
+	"\x10degraded_sources\x18\x0f \x03(\tR\x0fdegradedSourcesB\x0f\n" +
+	"\r_groups_errorB\b\n" +
+	"\x06_error\"\\\n" +
+	"\x05Group\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\"O\n" +
+	"\x05Tuple\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x16\n" +
+	"\x06object\x18\x03 \x01(\tR\x06object\"\x8c\x01\n" +
+	"\vTenantCheck\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\bR\x06member\x12\x19\n" +
+	"\x05error\x18\x03 \x01(\tH\x00R\x05error\x88\x01\x01\x12#\n" +
+	"\rauto_selected\x18\x04 \x01(\bR\fautoSelectedB\b\n" +
+	"\x06_error2u\n" +
+	"\x0eExplainService\x12c\n" +
+	"\aExplain\x12\x1b.hook.explain.v1.ExplainReq\x1a\x1c.hook.explain.v1.ExplainResp\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v0/authz/explainB7Z5github.com/canonical/hook-service/gen/hook/explain/v1b\x06proto3"
+
+var (
+	file_hook_explain_v1_explain_proto_rawDescOnce sync.Once
+	file_hook_explain_v1_explain_proto_rawDescData []byte
+)
+
+func file_hook_explain_v1_explain_proto_rawDescGZIP() []byte {
+	file_hook_explain_v1_explain_proto_rawDescOnce.Do(func() {
+		file_hook_explain_v1_explain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_explain_v1_explain_proto_rawDesc), len(file_hook_explain_v1_explain_proto_rawDesc)))
+	})
+	return file_hook_explain_v1_explain_proto_rawDescData
+}
+
+var file_hook_explain_v1_explain_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_hook_explain_v1_explain_proto_goTypes = []any{
+	(*ExplainReq)(nil),      // 0: hook.explain.v1.ExplainReq
+	(*ExplainResp)(nil),     // 1: hook.explain.v1.ExplainResp
+	(*Explanation)(nil),     // 2: hook.explain.v1.Explanation
+	(*Group)(nil),           // 3: hook.explain.v1.Group
+	(*Tuple)(nil),           // 4: hook.explain.v1.Tuple
+	(*TenantCheck)(nil),     // 5: hook.explain.v1.TenantCheck
+	(*structpb.Struct)(nil), // 6: google.protobuf.Struct
+}
+var file_hook_explain_v1_explain_proto_depIdxs = []int32{
+	2, // 0: hook.explain.v1.ExplainResp.data:type_name -> hook.explain.v1.Explanation
+	3, // 1: hook.explain.v1.Explanation.groups:type_name -> hook.explain.v1.Group
+	4, // 2: hook.explain.v1.Explanation.contextual_tuples:type_name -> hook.explain.v1.Tuple
+	4, // 3: hook.explain.v1.Explanation.checks:type_name -> hook.explain.v1.Tuple
+	5, // 4: hook.explain.v1.Explanation.tenant:type_name -> hook.explain.v1.TenantCheck
+	6, // 5: hook.explain.v1.Explanation.access_token:type_name -> google.protobuf.Struct
+	6, // 6: hook.explain.v1.Explanation.id_token:type_name -> google.protobuf.Struct
+	0, // 7: hook.explain.v1.ExplainService.Explain:input_type -> hook.explain.v1.ExplainReq
+	1, // 8: hook.explain.v1.ExplainService.Explain:output_type -> hook.explain.v1.ExplainResp
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_hook_explain_v1_explain_proto_init() }
+func file_hook_explain_v1_explain_proto_init() {
+	if File_hook_explain_v1_explain_proto != nil {
+		return
+	}
+	file_hook_explain_v1_explain_proto_msgTypes[1].OneofWrappers = []any{}
+	file_hook_explain_v1_explain_proto_msgTypes[2].OneofWrappers = []any{}
+	file_hook_explain_v1_explain_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_explain_v1_explain_proto_rawDesc), len(file_hook_explain_v1_explain_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_explain_v1_explain_proto_goTypes,
+		DependencyIndexes: file_hook_explain_v1_explain_proto_depIdxs,
+		MessageInfos:      file_hook_explain_v1_explain_proto_msgTypes,
+	}.Build()
+	File_hook_explain_v1_explain_proto = out.File
+	file_hook_explain_v1_explain_proto_goTypes = nil
+	file_hook_explain_v1_explain_proto_depIdxs = nil
+}
@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/explain/v1/explain.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExplainService_Explain_FullMethodName = "/hook.explain.v1.ExplainService/Explain"
+)
+
+// ExplainServiceClient is the client API for ExplainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExplainServiceClient interface {
+	Explain(ctx context.Context, in *ExplainReq, opts ...grpc.CallOption) (*ExplainResp, error)
+}
+
+type explainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExplainServiceClient(cc grpc.ClientConnInterface) ExplainServiceClient {
+	return &explainServiceClient{cc}
+}
+
+func (c *explainServiceClient) Explain(ctx context.Context, in *ExplainReq, opts ...grpc.CallOption) (*ExplainResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainResp)
+	err := c.cc.Invoke(ctx, ExplainService_Explain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExplainServiceServer is the server API for ExplainService service.
+// All implementations must embed UnimplementedExplainServiceServer
+// for forward compatibility.
+type ExplainServiceServer interface {
+	Explain(context.Context, *ExplainReq) (*ExplainResp, error)
+	mustEmbedUnimplementedExplainServiceServer()
+}
+
+// UnimplementedExplainServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExplainServiceServer struct{}
+
+func (UnimplementedExplainServiceServer) Explain(context.Context, *ExplainReq) (*ExplainResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method Explain not implemented")
+}
+func (UnimplementedExplainServiceServer) mustEmbedUnimplementedExplainServiceServer() {}
+func (UnimplementedExplainServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeExplainServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExplainServiceServer will
+// result in compilation errors.
+type UnsafeExplainServiceServer interface {
+	mustEmbedUnimplementedExplainServiceServer()
+}
+
+func RegisterExplainServiceServer(s grpc.ServiceRegistrar, srv ExplainServiceServer) {
+	// If the following call panics, it indicates UnimplementedExplainServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExplainService_ServiceDesc, srv)
+}
+
+func _ExplainService_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExplainServiceServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExplainService_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExplainServiceServer).Explain(ctx, req.(*ExplainReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExplainService_ServiceDesc is the grpc.ServiceDesc for ExplainService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExplainService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.explain.v1.ExplainService",
+	HandlerType: (*ExplainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Explain",
+			Handler:    _ExplainService_Explain_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/explain/v1/explain.proto",
+}
@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/groups/v1/attributes.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetGroupAttributesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupAttributesReq) Reset() {
+	*x = GetGroupAttributesReq{}
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupAttributesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupAttributesReq) ProtoMessage() {}
+
+func (x *GetGroupAttributesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupAttributesReq.ProtoReflect.Descriptor instead.
+func (*GetGroupAttributesReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_attributes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetGroupAttributesReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SetGroupAttributesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGroupAttributesReq) Reset() {
+	*x = SetGroupAttributesReq{}
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGroupAttributesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGroupAttributesReq) ProtoMessage() {}
+
+func (x *SetGroupAttributesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGroupAttributesReq.ProtoReflect.Descriptor instead.
+func (*SetGroupAttributesReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_attributes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetGroupAttributesReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetGroupAttributesReq) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type RemoveGroupAttributeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupAttributeReq) Reset() {
+	*x = RemoveGroupAttributeReq{}
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupAttributeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupAttributeReq) ProtoMessage() {}
+
+func (x *RemoveGroupAttributeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupAttributeReq.ProtoReflect.Descriptor instead.
+func (*RemoveGroupAttributeReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_attributes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemoveGroupAttributeReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveGroupAttributeReq) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GroupAttributesResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          map[string]string      `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupAttributesResp) Reset() {
+	*x = GroupAttributesResp{}
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAttributesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAttributesResp) ProtoMessage() {}
+
+func (x *GroupAttributesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_attributes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAttributesResp.ProtoReflect.Descriptor instead.
+func (*GroupAttributesResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_attributes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GroupAttributesResp) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupAttributesResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *GroupAttributesResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+var File_hook_groups_v1_attributes_proto protoreflect.FileDescriptor
+
+const file_hook_groups_v1_attributes_proto_rawDesc = "" +
+	"\n" +
+	"\x1fhook/groups/v1/attributes.proto\x12\x0ehook.groups.v1\x1a\x1cgoogle/api/annotations.proto\"'\n" +
+	"\x15GetGroupAttributesReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbd\x01\n" +
+	"\x15SetGroupAttributesReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12U\n" +
+	"\n" +
+	"attributes\x18\x02 \x03(\v25.hook.groups.v1.SetGroupAttributesReq.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\";\n" +
+	"\x17RemoveGroupAttributeReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"\xd4\x01\n" +
+	"\x13GroupAttributesResp\x12A\n" +
+	"\x04data\x18\x01 \x03(\v2-.hook.groups.v1.GroupAttributesResp.DataEntryR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
+	"\n" +
+	"\b_message2\xd8\x03\n" +
+	"\x16GroupAttributesService\x12\x8e\x01\n" +
+	"\x12GetGroupAttributes\x12%.hook.groups.v1.GetGroupAttributesReq\x1a#.hook.groups.v1.GroupAttributesResp\",\x82\xd3\xe4\x93\x02&\x12$/api/v0/authz/groups/{id}/attributes\x12\x91\x01\n" +
+	"\x12SetGroupAttributes\x12%.hook.groups.v1.SetGroupAttributesReq\x1a#.hook.groups.v1.GroupAttributesResp\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v0/authz/groups/{id}/attributes\x12\x98\x01\n" +
+	"\x14RemoveGroupAttribute\x12'.hook.groups.v1.RemoveGroupAttributeReq\x1a#.hook.groups.v1.GroupAttributesResp\"2\x82\xd3\xe4\x93\x02,**/api/v0/authz/groups/{id}/attributes/{key}B6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
+
+var (
+	file_hook_groups_v1_attributes_proto_rawDescOnce sync.Once
+	file_hook_groups_v1_attributes_proto_rawDescData []byte
+)
+
+func file_hook_groups_v1_attributes_proto_rawDescGZIP() []byte {
+	file_hook_groups_v1_attributes_proto_rawDescOnce.Do(func() {
+		file_hook_groups_v1_attributes_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_groups_v1_attributes_proto_rawDesc), len(file_hook_groups_v1_attributes_proto_rawDesc)))
+	})
+	return file_hook_groups_v1_attributes_proto_rawDescData
+}
+
+var file_hook_groups_v1_attributes_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_hook_groups_v1_attributes_proto_goTypes = []any{
+	(*GetGroupAttributesReq)(nil),   // 0: hook.groups.v1.GetGroupAttributesReq
+	(*SetGroupAttributesReq)(nil),   // 1: hook.groups.v1.SetGroupAttributesReq
+	(*RemoveGroupAttributeReq)(nil), // 2: hook.groups.v1.RemoveGroupAttributeReq
+	(*GroupAttributesResp)(nil),     // 3: hook.groups.v1.GroupAttributesResp
+	nil,                             // 4: hook.groups.v1.SetGroupAttributesReq.AttributesEntry
+	nil,                             // 5: hook.groups.v1.GroupAttributesResp.DataEntry
+}
+var file_hook_groups_v1_attributes_proto_depIdxs = []int32{
+	4, // 0: hook.groups.v1.SetGroupAttributesReq.attributes:type_name -> hook.groups.v1.SetGroupAttributesReq.AttributesEntry
+	5, // 1: hook.groups.v1.GroupAttributesResp.data:type_name -> hook.groups.v1.GroupAttributesResp.DataEntry
+	0, // 2: hook.groups.v1.GroupAttributesService.GetGroupAttributes:input_type -> hook.groups.v1.GetGroupAttributesReq
+	1, // 3: hook.groups.v1.GroupAttributesService.SetGroupAttributes:input_type -> hook.groups.v1.SetGroupAttributesReq
+	2, // 4: hook.groups.v1.GroupAttributesService.RemoveGroupAttribute:input_type -> hook.groups.v1.RemoveGroupAttributeReq
+	3, // 5: hook.groups.v1.GroupAttributesService.GetGroupAttributes:output_type -> hook.groups.v1.GroupAttributesResp
+	3, // 6: hook.groups.v1.GroupAttributesService.SetGroupAttributes:output_type -> hook.groups.v1.GroupAttributesResp
+	3, // 7: hook.groups.v1.GroupAttributesService.RemoveGroupAttribute:output_type -> hook.groups.v1.GroupAttributesResp
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_hook_groups_v1_attributes_proto_init() }
+func file_hook_groups_v1_attributes_proto_init() {
+	if File_hook_groups_v1_attributes_proto != nil {
+		return
+	}
+	file_hook_groups_v1_attributes_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_groups_v1_attributes_proto_rawDesc), len(file_hook_groups_v1_attributes_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_groups_v1_attributes_proto_goTypes,
+		DependencyIndexes: file_hook_groups_v1_attributes_proto_depIdxs,
+		MessageInfos:      file_hook_groups_v1_attributes_proto_msgTypes,
+	}.Build()
+	File_hook_groups_v1_attributes_proto = out.File
+	file_hook_groups_v1_attributes_proto_goTypes = nil
+	file_hook_groups_v1_attributes_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/groups/v1/attributes.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GroupAttributesService_GetGroupAttributes_FullMethodName   = "/hook.groups.v1.GroupAttributesService/GetGroupAttributes"
+	GroupAttributesService_SetGroupAttributes_FullMethodName   = "/hook.groups.v1.GroupAttributesService/SetGroupAttributes"
+	GroupAttributesService_RemoveGroupAttribute_FullMethodName = "/hook.groups.v1.GroupAttributesService/RemoveGroupAttribute"
+)
+
+// GroupAttributesServiceClient is the client API for GroupAttributesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GroupAttributesService manages the custom key/value attributes of groups,
+// which the token hook can emit as claims.
+type GroupAttributesServiceClient interface {
+	GetGroupAttributes(ctx context.Context, in *GetGroupAttributesReq, opts ...grpc.CallOption) (*GroupAttributesResp, error)
+	SetGroupAttributes(ctx context.Context, in *SetGroupAttributesReq, opts ...grpc.CallOption) (*GroupAttributesResp, error)
+	RemoveGroupAttribute(ctx context.Context, in *RemoveGroupAttributeReq, opts ...grpc.CallOption) (*GroupAttributesResp, error)
+}
+
+type groupAttributesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupAttributesServiceClient(cc grpc.ClientConnInterface) GroupAttributesServiceClient {
+	return &groupAttributesServiceClient{cc}
+}
+
+func (c *groupAttributesServiceClient) GetGroupAttributes(ctx context.Context, in *GetGroupAttributesReq, opts ...grpc.CallOption) (*GroupAttributesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupAttributesResp)
+	err := c.cc.Invoke(ctx, GroupAttributesService_GetGroupAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupAttributesServiceClient) SetGroupAttributes(ctx context.Context, in *SetGroupAttributesReq, opts ...grpc.CallOption) (*GroupAttributesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupAttributesResp)
+	err := c.cc.Invoke(ctx, GroupAttributesService_SetGroupAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupAttributesServiceClient) RemoveGroupAttribute(ctx context.Context, in *RemoveGroupAttributeReq, opts ...grpc.CallOption) (*GroupAttributesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupAttributesResp)
+	err := c.cc.Invoke(ctx, GroupAttributesService_RemoveGroupAttribute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupAttributesServiceServer is the server API for GroupAttributesService service.
+// All implementations must embed UnimplementedGroupAttributesServiceServer
+// for forward compatibility.
+//
+// GroupAttributesService manages the custom key/value attributes of groups,
+// which the token hook can emit as claims.
+type GroupAttributesServiceServer interface {
+	GetGroupAttributes(context.Context, *GetGroupAttributesReq) (*GroupAttributesResp, error)
+	SetGroupAttributes(context.Context, *SetGroupAttributesReq) (*GroupAttributesResp, error)
+	RemoveGroupAttribute(context.Context, *RemoveGroupAttributeReq) (*GroupAttributesResp, error)
+	mustEmbedUnimplementedGroupAttributesServiceServer()
+}
+
+// UnimplementedGroupAttributesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupAttributesServiceServer struct{}
+
+func (UnimplementedGroupAttributesServiceServer) GetGroupAttributes(context.Context, *GetGroupAttributesReq) (*GroupAttributesResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetGroupAttributes not implemented")
+}
+func (UnimplementedGroupAttributesServiceServer) SetGroupAttributes(context.Context, *SetGroupAttributesReq) (*GroupAttributesResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetGroupAttributes not implemented")
+}
+func (UnimplementedGroupAttributesServiceServer) RemoveGroupAttribute(context.Context, *RemoveGroupAttributeReq) (*GroupAttributesResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveGroupAttribute not implemented")
+}
+func (UnimplementedGroupAttributesServiceServer) mustEmbedUnimplementedGroupAttributesServiceServer() {
+}
+func (UnimplementedGroupAttributesServiceServer) testEmbeddedByValue() {}
+
+// UnsafeGroupAttributesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupAttributesServiceServer will
+// result in compilation errors.
+type UnsafeGroupAttributesServiceServer interface {
+	mustEmbedUnimplementedGroupAttributesServiceServer()
+}
+
+func RegisterGroupAttributesServiceServer(s grpc.ServiceRegistrar, srv GroupAttributesServiceServer) {
+	// If the following call panics, it indicates UnimplementedGroupAttributesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupAttributesService_ServiceDesc, srv)
+}
+
+func _GroupAttributesService_GetGroupAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupAttributesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupAttributesServiceServer).GetGroupAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupAttributesService_GetGroupAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupAttributesServiceServer).GetGroupAttributes(ctx, req.(*GetGroupAttributesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupAttributesService_SetGroupAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGroupAttributesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupAttributesServiceServer).SetGroupAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupAttributesService_SetGroupAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupAttributesServiceServer).SetGroupAttributes(ctx, req.(*SetGroupAttributesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupAttributesService_RemoveGroupAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupAttributeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupAttributesServiceServer).RemoveGroupAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupAttributesService_RemoveGroupAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupAttributesServiceServer).RemoveGroupAttribute(ctx, req.(*RemoveGroupAttributeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupAttributesService_ServiceDesc is the grpc.ServiceDesc for GroupAttributesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupAttributesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.groups.v1.GroupAttributesService",
+	HandlerType: (*GroupAttributesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetGroupAttributes",
+			Handler:    _GroupAttributesService_GetGroupAttributes_Handler,
+		},
+		{
+			MethodName: "SetGroupAttributes",
+			Handler:    _GroupAttributesService_SetGroupAttributes_Handler,
+		},
+		{
+			MethodName: "RemoveGroupAttribute",
+			Handler:    _GroupAttributesService_RemoveGroupAttribute_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/groups/v1/attributes.proto",
+}
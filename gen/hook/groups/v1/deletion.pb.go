@@ -0,0 +1,308 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/groups/v1/deletion.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListDeletedGroupsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedGroupsReq) Reset() {
+	*x = ListDeletedGroupsReq{}
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedGroupsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedGroupsReq) ProtoMessage() {}
+
+func (x *ListDeletedGroupsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedGroupsReq.ProtoReflect.Descriptor instead.
+func (*ListDeletedGroupsReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_deletion_proto_rawDescGZIP(), []int{0}
+}
+
+type ListDeletedGroupsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*GroupMapping        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedGroupsResp) Reset() {
+	*x = ListDeletedGroupsResp{}
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedGroupsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedGroupsResp) ProtoMessage() {}
+
+func (x *ListDeletedGroupsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedGroupsResp.ProtoReflect.Descriptor instead.
+func (*ListDeletedGroupsResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_deletion_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDeletedGroupsResp) GetData() []*GroupMapping {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListDeletedGroupsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListDeletedGroupsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type RestoreGroupReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreGroupReq) Reset() {
+	*x = RestoreGroupReq{}
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreGroupReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreGroupReq) ProtoMessage() {}
+
+func (x *RestoreGroupReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreGroupReq.ProtoReflect.Descriptor instead.
+func (*RestoreGroupReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_deletion_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RestoreGroupReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreGroupResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*GroupMapping        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreGroupResp) Reset() {
+	*x = RestoreGroupResp{}
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreGroupResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreGroupResp) ProtoMessage() {}
+
+func (x *RestoreGroupResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_deletion_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreGroupResp.ProtoReflect.Descriptor instead.
+func (*RestoreGroupResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_deletion_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RestoreGroupResp) GetData() []*GroupMapping {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *RestoreGroupResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *RestoreGroupResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+var File_hook_groups_v1_deletion_proto protoreflect.FileDescriptor
+
+const file_hook_groups_v1_deletion_proto_rawDesc = "" +
+	"\n" +
+	"\x1dhook/groups/v1/deletion.proto\x12\x0ehook.groups.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1chook/groups/v1/mapping.proto\"\x16\n" +
+	"\x14ListDeletedGroupsReq\"\x8c\x01\n" +
+	"\x15ListDeletedGroupsResp\x120\n" +
+	"\x04data\x18\x01 \x03(\v2\x1c.hook.groups.v1.GroupMappingR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"!\n" +
+	"\x0fRestoreGroupReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x87\x01\n" +
+	"\x10RestoreGroupResp\x120\n" +
+	"\x04data\x18\x01 \x03(\v2\x1c.hook.groups.v1.GroupMappingR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message2\x9d\x02\n" +
+	"\x14GroupDeletionService\x12\x86\x01\n" +
+	"\x11ListDeletedGroups\x12$.hook.groups.v1.ListDeletedGroupsReq\x1a%.hook.groups.v1.ListDeletedGroupsResp\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v0/authz/deleted-groups\x12|\n" +
+	"\fRestoreGroup\x12\x1f.hook.groups.v1.RestoreGroupReq\x1a .hook.groups.v1.RestoreGroupResp\")\x82\xd3\xe4\x93\x02#\"!/api/v0/authz/groups/{id}:restoreB6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
+
+var (
+	file_hook_groups_v1_deletion_proto_rawDescOnce sync.Once
+	file_hook_groups_v1_deletion_proto_rawDescData []byte
+)
+
+func file_hook_groups_v1_deletion_proto_rawDescGZIP() []byte {
+	file_hook_groups_v1_deletion_proto_rawDescOnce.Do(func() {
+		file_hook_groups_v1_deletion_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_groups_v1_deletion_proto_rawDesc), len(file_hook_groups_v1_deletion_proto_rawDesc)))
+	})
+	return file_hook_groups_v1_deletion_proto_rawDescData
+}
+
+var file_hook_groups_v1_deletion_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_hook_groups_v1_deletion_proto_goTypes = []any{
+	(*ListDeletedGroupsReq)(nil),  // 0: hook.groups.v1.ListDeletedGroupsReq
+	(*ListDeletedGroupsResp)(nil), // 1: hook.groups.v1.ListDeletedGroupsResp
+	(*RestoreGroupReq)(nil),       // 2: hook.groups.v1.RestoreGroupReq
+	(*RestoreGroupResp)(nil),      // 3: hook.groups.v1.RestoreGroupResp
+	(*GroupMapping)(nil),          // 4: hook.groups.v1.GroupMapping
+}
+var file_hook_groups_v1_deletion_proto_depIdxs = []int32{
+	4, // 0: hook.groups.v1.ListDeletedGroupsResp.data:type_name -> hook.groups.v1.GroupMapping
+	4, // 1: hook.groups.v1.RestoreGroupResp.data:type_name -> hook.groups.v1.GroupMapping
+	0, // 2: hook.groups.v1.GroupDeletionService.ListDeletedGroups:input_type -> hook.groups.v1.ListDeletedGroupsReq
+	2, // 3: hook.groups.v1.GroupDeletionService.RestoreGroup:input_type -> hook.groups.v1.RestoreGroupReq
+	1, // 4: hook.groups.v1.GroupDeletionService.ListDeletedGroups:output_type -> hook.groups.v1.ListDeletedGroupsResp
+	3, // 5: hook.groups.v1.GroupDeletionService.RestoreGroup:output_type -> hook.groups.v1.RestoreGroupResp
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_hook_groups_v1_deletion_proto_init() }
+func file_hook_groups_v1_deletion_proto_init() {
+	if File_hook_groups_v1_deletion_proto != nil {
+		return
+	}
+	file_hook_groups_v1_mapping_proto_init()
+	file_hook_groups_v1_deletion_proto_msgTypes[1].OneofWrappers = []any{}
+	file_hook_groups_v1_deletion_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_groups_v1_deletion_proto_rawDesc), len(file_hook_groups_v1_deletion_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_groups_v1_deletion_proto_goTypes,
+		DependencyIndexes: file_hook_groups_v1_deletion_proto_depIdxs,
+		MessageInfos:      file_hook_groups_v1_deletion_proto_msgTypes,
+	}.Build()
+	File_hook_groups_v1_deletion_proto = out.File
+	file_hook_groups_v1_deletion_proto_goTypes = nil
+	file_hook_groups_v1_deletion_proto_depIdxs = nil
+}
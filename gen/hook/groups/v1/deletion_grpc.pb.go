@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/groups/v1/deletion.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GroupDeletionService_ListDeletedGroups_FullMethodName = "/hook.groups.v1.GroupDeletionService/ListDeletedGroups"
+	GroupDeletionService_RestoreGroup_FullMethodName      = "/hook.groups.v1.GroupDeletionService/RestoreGroup"
+)
+
+// GroupDeletionServiceClient is the client API for GroupDeletionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GroupDeletionService lists the deleted groups awaiting their purge and
+// restores them with their members, subgroups and app grants.
+type GroupDeletionServiceClient interface {
+	ListDeletedGroups(ctx context.Context, in *ListDeletedGroupsReq, opts ...grpc.CallOption) (*ListDeletedGroupsResp, error)
+	RestoreGroup(ctx context.Context, in *RestoreGroupReq, opts ...grpc.CallOption) (*RestoreGroupResp, error)
+}
+
+type groupDeletionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupDeletionServiceClient(cc grpc.ClientConnInterface) GroupDeletionServiceClient {
+	return &groupDeletionServiceClient{cc}
+}
+
+func (c *groupDeletionServiceClient) ListDeletedGroups(ctx context.Context, in *ListDeletedGroupsReq, opts ...grpc.CallOption) (*ListDeletedGroupsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedGroupsResp)
+	err := c.cc.Invoke(ctx, GroupDeletionService_ListDeletedGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupDeletionServiceClient) RestoreGroup(ctx context.Context, in *RestoreGroupReq, opts ...grpc.CallOption) (*RestoreGroupResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreGroupResp)
+	err := c.cc.Invoke(ctx, GroupDeletionService_RestoreGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupDeletionServiceServer is the server API for GroupDeletionService service.
+// All implementations must embed UnimplementedGroupDeletionServiceServer
+// for forward compatibility.
+//
+// GroupDeletionService lists the deleted groups awaiting their purge and
+// restores them with their members, subgroups and app grants.
+type GroupDeletionServiceServer interface {
+	ListDeletedGroups(context.Context, *ListDeletedGroupsReq) (*ListDeletedGroupsResp, error)
+	RestoreGroup(context.Context, *RestoreGroupReq) (*RestoreGroupResp, error)
+	mustEmbedUnimplementedGroupDeletionServiceServer()
+}
+
+// UnimplementedGroupDeletionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupDeletionServiceServer struct{}
+
+func (UnimplementedGroupDeletionServiceServer) ListDeletedGroups(context.Context, *ListDeletedGroupsReq) (*ListDeletedGroupsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeletedGroups not implemented")
+}
+func (UnimplementedGroupDeletionServiceServer) RestoreGroup(context.Context, *RestoreGroupReq) (*RestoreGroupResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreGroup not implemented")
+}
+func (UnimplementedGroupDeletionServiceServer) mustEmbedUnimplementedGroupDeletionServiceServer() {}
+func (UnimplementedGroupDeletionServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeGroupDeletionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupDeletionServiceServer will
+// result in compilation errors.
+type UnsafeGroupDeletionServiceServer interface {
+	mustEmbedUnimplementedGroupDeletionServiceServer()
+}
+
+func RegisterGroupDeletionServiceServer(s grpc.ServiceRegistrar, srv GroupDeletionServiceServer) {
+	// If the following call panics, it indicates UnimplementedGroupDeletionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupDeletionService_ServiceDesc, srv)
+}
+
+func _GroupDeletionService_ListDeletedGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedGroupsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupDeletionServiceServer).ListDeletedGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupDeletionService_ListDeletedGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupDeletionServiceServer).ListDeletedGroups(ctx, req.(*ListDeletedGroupsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupDeletionService_RestoreGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreGroupReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupDeletionServiceServer).RestoreGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupDeletionService_RestoreGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupDeletionServiceServer).RestoreGroup(ctx, req.(*RestoreGroupReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupDeletionService_ServiceDesc is the grpc.ServiceDesc for GroupDeletionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupDeletionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.groups.v1.GroupDeletionService",
+	HandlerType: (*GroupDeletionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeletedGroups",
+			Handler:    _GroupDeletionService_ListDeletedGroups_Handler,
+		},
+		{
+			MethodName: "RestoreGroup",
+			Handler:    _GroupDeletionService_RestoreGroup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/groups/v1/deletion.proto",
+}
@@ -0,0 +1,605 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/groups/v1/listing.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SearchReq filters, sorts and paginates a listing. The search matches a
+// case-insensitive substring of the group name, or of the user or app ID.
+// Listings are sorted by name, created_at or updated_at, the name of members
+// and grants is their ID. A page token is only valid with the same sort.
+type SearchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Search        string                 `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	TenantId      string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	SortBy        string                 `protobuf:"bytes,9,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Descending    bool                   `protobuf:"varint,10,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize      int32                  `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Attributes only selects the groups holding every `key=value` attribute.
+	Attributes    []string `protobuf:"bytes,13,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchReq) Reset() {
+	*x = SearchReq{}
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReq) ProtoMessage() {}
+
+func (x *SearchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReq.ProtoReflect.Descriptor instead.
+func (*SearchReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_listing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchReq) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *SearchReq) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchReq) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SearchReq) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *SearchReq) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *SearchReq) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *SearchReq) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *SearchReq) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SearchReq) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *SearchReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchReq) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type SearchGroupsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*GroupMapping        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Total         *int64                 `protobuf:"varint,5,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchGroupsResp) Reset() {
+	*x = SearchGroupsResp{}
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchGroupsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchGroupsResp) ProtoMessage() {}
+
+func (x *SearchGroupsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchGroupsResp.ProtoReflect.Descriptor instead.
+func (*SearchGroupsResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_listing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchGroupsResp) GetData() []*GroupMapping {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SearchGroupsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *SearchGroupsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *SearchGroupsResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchGroupsResp) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type SearchUsersInGroupResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*GroupMember         `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Total         *int64                 `protobuf:"varint,5,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersInGroupResp) Reset() {
+	*x = SearchUsersInGroupResp{}
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersInGroupResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersInGroupResp) ProtoMessage() {}
+
+func (x *SearchUsersInGroupResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersInGroupResp.ProtoReflect.Descriptor instead.
+func (*SearchUsersInGroupResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_listing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchUsersInGroupResp) GetData() []*GroupMember {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SearchUsersInGroupResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *SearchUsersInGroupResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *SearchUsersInGroupResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchUsersInGroupResp) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type SearchIDsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []string               `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Total         *int64                 `protobuf:"varint,5,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchIDsResp) Reset() {
+	*x = SearchIDsResp{}
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchIDsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchIDsResp) ProtoMessage() {}
+
+func (x *SearchIDsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchIDsResp.ProtoReflect.Descriptor instead.
+func (*SearchIDsResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_listing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchIDsResp) GetData() []string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SearchIDsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *SearchIDsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *SearchIDsResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchIDsResp) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type GroupMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMember) Reset() {
+	*x = GroupMember{}
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMember) ProtoMessage() {}
+
+func (x *GroupMember) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_listing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_listing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GroupMember) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GroupMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GroupMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GroupMember) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_hook_groups_v1_listing_proto protoreflect.FileDescriptor
+
+const file_hook_groups_v1_listing_proto_rawDesc = "" +
+	"\n" +
+	"\x1chook/groups/v1/listing.proto\x12\x0ehook.groups.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1chook/groups/v1/mapping.proto\"\x81\x04\n" +
+	"\tSearchReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06search\x18\x02 \x01(\tR\x06search\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\tR\btenantId\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12\x17\n" +
+	"\asort_by\x18\t \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\n" +
+	" \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\v \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\f \x01(\tR\tpageToken\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\r \x03(\tR\n" +
+	"attributes\"\xd4\x01\n" +
+	"\x10SearchGroupsResp\x120\n" +
+	"\x04data\x18\x01 \x03(\v2\x1c.hook.groups.v1.GroupMappingR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12\x19\n" +
+	"\x05total\x18\x05 \x01(\x03H\x01R\x05total\x88\x01\x01B\n" +
+	"\n" +
+	"\b_messageB\b\n" +
+	"\x06_total\"\xd9\x01\n" +
+	"\x16SearchUsersInGroupResp\x12/\n" +
+	"\x04data\x18\x01 \x03(\v2\x1b.hook.groups.v1.GroupMemberR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12\x19\n" +
+	"\x05total\x18\x05 \x01(\x03H\x01R\x05total\x88\x01\x01B\n" +
+	"\n" +
+	"\b_messageB\b\n" +
+	"\x06_total\"\xb3\x01\n" +
+	"\rSearchIDsResp\x12\x12\n" +
+	"\x04data\x18\x01 \x03(\tR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12\x19\n" +
+	"\x05total\x18\x05 \x01(\x03H\x01R\x05total\x88\x01\x01B\n" +
+	"\n" +
+	"\b_messageB\b\n" +
+	"\x06_total\"\xa7\x01\n" +
+	"\vGroupMember\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\x91\x02\n" +
+	"\x13GroupListingService\x12p\n" +
+	"\fSearchGroups\x12\x19.hook.groups.v1.SearchReq\x1a .hook.groups.v1.SearchGroupsResp\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v0/authz/groups:search\x12\x87\x01\n" +
+	"\x12SearchUsersInGroup\x12\x19.hook.groups.v1.SearchReq\x1a&.hook.groups.v1.SearchUsersInGroupResp\".\x82\xd3\xe4\x93\x02(\x12&/api/v0/authz/groups/{id}/users:search2\x96\x02\n" +
+	"\x16AppGrantListingService\x12|\n" +
+	"\x11SearchAllowedApps\x12\x19.hook.groups.v1.SearchReq\x1a\x1d.hook.groups.v1.SearchIDsResp\"-\x82\xd3\xe4\x93\x02'\x12%/api/v0/authz/groups/{id}/apps:search\x12~\n" +
+	"\x13SearchAllowedGroups\x12\x19.hook.groups.v1.SearchReq\x1a\x1d.hook.groups.v1.SearchIDsResp\"-\x82\xd3\xe4\x93\x02'\x12%/api/v0/authz/apps/{id}/groups:searchB6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
+
+var (
+	file_hook_groups_v1_listing_proto_rawDescOnce sync.Once
+	file_hook_groups_v1_listing_proto_rawDescData []byte
+)
+
+func file_hook_groups_v1_listing_proto_rawDescGZIP() []byte {
+	file_hook_groups_v1_listing_proto_rawDescOnce.Do(func() {
+		file_hook_groups_v1_listing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_groups_v1_listing_proto_rawDesc), len(file_hook_groups_v1_listing_proto_rawDesc)))
+	})
+	return file_hook_groups_v1_listing_proto_rawDescData
+}
+
+var file_hook_groups_v1_listing_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_hook_groups_v1_listing_proto_goTypes = []any{
+	(*SearchReq)(nil),              // 0: hook.groups.v1.SearchReq
+	(*SearchGroupsResp)(nil),       // 1: hook.groups.v1.SearchGroupsResp
+	(*SearchUsersInGroupResp)(nil), // 2: hook.groups.v1.SearchUsersInGroupResp
+	(*SearchIDsResp)(nil),          // 3: hook.groups.v1.SearchIDsResp
+	(*GroupMember)(nil),            // 4: hook.groups.v1.GroupMember
+	(*timestamppb.Timestamp)(nil),  // 5: google.protobuf.Timestamp
+	(*GroupMapping)(nil),           // 6: hook.groups.v1.GroupMapping
+}
+var file_hook_groups_v1_listing_proto_depIdxs = []int32{
+	5,  // 0: hook.groups.v1.SearchReq.created_after:type_name -> google.protobuf.Timestamp
+	5,  // 1: hook.groups.v1.SearchReq.created_before:type_name -> google.protobuf.Timestamp
+	5,  // 2: hook.groups.v1.SearchReq.updated_after:type_name -> google.protobuf.Timestamp
+	5,  // 3: hook.groups.v1.SearchReq.updated_before:type_name -> google.protobuf.Timestamp
+	6,  // 4: hook.groups.v1.SearchGroupsResp.data:type_name -> hook.groups.v1.GroupMapping
+	4,  // 5: hook.groups.v1.SearchUsersInGroupResp.data:type_name -> hook.groups.v1.GroupMember
+	5,  // 6: hook.groups.v1.GroupMember.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 7: hook.groups.v1.GroupMember.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: hook.groups.v1.GroupListingService.SearchGroups:input_type -> hook.groups.v1.SearchReq
+	0,  // 9: hook.groups.v1.GroupListingService.SearchUsersInGroup:input_type -> hook.groups.v1.SearchReq
+	0,  // 10: hook.groups.v1.AppGrantListingService.SearchAllowedApps:input_type -> hook.groups.v1.SearchReq
+	0,  // 11: hook.groups.v1.AppGrantListingService.SearchAllowedGroups:input_type -> hook.groups.v1.SearchReq
+	1,  // 12: hook.groups.v1.GroupListingService.SearchGroups:output_type -> hook.groups.v1.SearchGroupsResp
+	2,  // 13: hook.groups.v1.GroupListingService.SearchUsersInGroup:output_type -> hook.groups.v1.SearchUsersInGroupResp
+	3,  // 14: hook.groups.v1.AppGrantListingService.SearchAllowedApps:output_type -> hook.groups.v1.SearchIDsResp
+	3,  // 15: hook.groups.v1.AppGrantListingService.SearchAllowedGroups:output_type -> hook.groups.v1.SearchIDsResp
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_hook_groups_v1_listing_proto_init() }
+func file_hook_groups_v1_listing_proto_init() {
+	if File_hook_groups_v1_listing_proto != nil {
+		return
+	}
+	file_hook_groups_v1_mapping_proto_init()
+	file_hook_groups_v1_listing_proto_msgTypes[1].OneofWrappers = []any{}
+	file_hook_groups_v1_listing_proto_msgTypes[2].OneofWrappers = []any{}
+	file_hook_groups_v1_listing_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_groups_v1_listing_proto_rawDesc), len(file_hook_groups_v1_listing_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_hook_groups_v1_listing_proto_goTypes,
+		DependencyIndexes: file_hook_groups_v1_listing_proto_depIdxs,
+		MessageInfos:      file_hook_groups_v1_listing_proto_msgTypes,
+	}.Build()
+	File_hook_groups_v1_listing_proto = out.File
+	file_hook_groups_v1_listing_proto_goTypes = nil
+	file_hook_groups_v1_listing_proto_depIdxs = nil
+}
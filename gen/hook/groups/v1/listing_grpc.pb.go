@@ -0,0 +1,312 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/groups/v1/listing.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GroupListingService_SearchGroups_FullMethodName       = "/hook.groups.v1.GroupListingService/SearchGroups"
+	GroupListingService_SearchUsersInGroup_FullMethodName = "/hook.groups.v1.GroupListingService/SearchUsersInGroup"
+)
+
+// GroupListingServiceClient is the client API for GroupListingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GroupListingService lists groups and their members with filters, sorting
+// and pagination.
+type GroupListingServiceClient interface {
+	SearchGroups(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchGroupsResp, error)
+	SearchUsersInGroup(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchUsersInGroupResp, error)
+}
+
+type groupListingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupListingServiceClient(cc grpc.ClientConnInterface) GroupListingServiceClient {
+	return &groupListingServiceClient{cc}
+}
+
+func (c *groupListingServiceClient) SearchGroups(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchGroupsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchGroupsResp)
+	err := c.cc.Invoke(ctx, GroupListingService_SearchGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupListingServiceClient) SearchUsersInGroup(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchUsersInGroupResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchUsersInGroupResp)
+	err := c.cc.Invoke(ctx, GroupListingService_SearchUsersInGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupListingServiceServer is the server API for GroupListingService service.
+// All implementations must embed UnimplementedGroupListingServiceServer
+// for forward compatibility.
+//
+// GroupListingService lists groups and their members with filters, sorting
+// and pagination.
+type GroupListingServiceServer interface {
+	SearchGroups(context.Context, *SearchReq) (*SearchGroupsResp, error)
+	SearchUsersInGroup(context.Context, *SearchReq) (*SearchUsersInGroupResp, error)
+	mustEmbedUnimplementedGroupListingServiceServer()
+}
+
+// UnimplementedGroupListingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupListingServiceServer struct{}
+
+func (UnimplementedGroupListingServiceServer) SearchGroups(context.Context, *SearchReq) (*SearchGroupsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchGroups not implemented")
+}
+func (UnimplementedGroupListingServiceServer) SearchUsersInGroup(context.Context, *SearchReq) (*SearchUsersInGroupResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchUsersInGroup not implemented")
+}
+func (UnimplementedGroupListingServiceServer) mustEmbedUnimplementedGroupListingServiceServer() {}
+func (UnimplementedGroupListingServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeGroupListingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupListingServiceServer will
+// result in compilation errors.
+type UnsafeGroupListingServiceServer interface {
+	mustEmbedUnimplementedGroupListingServiceServer()
+}
+
+func RegisterGroupListingServiceServer(s grpc.ServiceRegistrar, srv GroupListingServiceServer) {
+	// If the following call panics, it indicates UnimplementedGroupListingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupListingService_ServiceDesc, srv)
+}
+
+func _GroupListingService_SearchGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupListingServiceServer).SearchGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupListingService_SearchGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupListingServiceServer).SearchGroups(ctx, req.(*SearchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupListingService_SearchUsersInGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupListingServiceServer).SearchUsersInGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupListingService_SearchUsersInGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupListingServiceServer).SearchUsersInGroup(ctx, req.(*SearchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupListingService_ServiceDesc is the grpc.ServiceDesc for GroupListingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupListingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.groups.v1.GroupListingService",
+	HandlerType: (*GroupListingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchGroups",
+			Handler:    _GroupListingService_SearchGroups_Handler,
+		},
+		{
+			MethodName: "SearchUsersInGroup",
+			Handler:    _GroupListingService_SearchUsersInGroup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/groups/v1/listing.proto",
+}
+
+const (
+	AppGrantListingService_SearchAllowedApps_FullMethodName   = "/hook.groups.v1.AppGrantListingService/SearchAllowedApps"
+	AppGrantListingService_SearchAllowedGroups_FullMethodName = "/hook.groups.v1.AppGrantListingService/SearchAllowedGroups"
+)
+
+// AppGrantListingServiceClient is the client API for AppGrantListingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AppGrantListingService lists the apps granted to a group and the groups
+// granted an app with filters, sorting and pagination.
+type AppGrantListingServiceClient interface {
+	SearchAllowedApps(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchIDsResp, error)
+	SearchAllowedGroups(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchIDsResp, error)
+}
+
+type appGrantListingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppGrantListingServiceClient(cc grpc.ClientConnInterface) AppGrantListingServiceClient {
+	return &appGrantListingServiceClient{cc}
+}
+
+func (c *appGrantListingServiceClient) SearchAllowedApps(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchIDsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchIDsResp)
+	err := c.cc.Invoke(ctx, AppGrantListingService_SearchAllowedApps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appGrantListingServiceClient) SearchAllowedGroups(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchIDsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchIDsResp)
+	err := c.cc.Invoke(ctx, AppGrantListingService_SearchAllowedGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppGrantListingServiceServer is the server API for AppGrantListingService service.
+// All implementations must embed UnimplementedAppGrantListingServiceServer
+// for forward compatibility.
+//
+// AppGrantListingService lists the apps granted to a group and the groups
+// granted an app with filters, sorting and pagination.
+type AppGrantListingServiceServer interface {
+	SearchAllowedApps(context.Context, *SearchReq) (*SearchIDsResp, error)
+	SearchAllowedGroups(context.Context, *SearchReq) (*SearchIDsResp, error)
+	mustEmbedUnimplementedAppGrantListingServiceServer()
+}
+
+// UnimplementedAppGrantListingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAppGrantListingServiceServer struct{}
+
+func (UnimplementedAppGrantListingServiceServer) SearchAllowedApps(context.Context, *SearchReq) (*SearchIDsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchAllowedApps not implemented")
+}
+func (UnimplementedAppGrantListingServiceServer) SearchAllowedGroups(context.Context, *SearchReq) (*SearchIDsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchAllowedGroups not implemented")
+}
+func (UnimplementedAppGrantListingServiceServer) mustEmbedUnimplementedAppGrantListingServiceServer() {
+}
+func (UnimplementedAppGrantListingServiceServer) testEmbeddedByValue() {}
+
+// UnsafeAppGrantListingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AppGrantListingServiceServer will
+// result in compilation errors.
+type UnsafeAppGrantListingServiceServer interface {
+	mustEmbedUnimplementedAppGrantListingServiceServer()
+}
+
+func RegisterAppGrantListingServiceServer(s grpc.ServiceRegistrar, srv AppGrantListingServiceServer) {
+	// If the following call panics, it indicates UnimplementedAppGrantListingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AppGrantListingService_ServiceDesc, srv)
+}
+
+func _AppGrantListingService_SearchAllowedApps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppGrantListingServiceServer).SearchAllowedApps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppGrantListingService_SearchAllowedApps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppGrantListingServiceServer).SearchAllowedApps(ctx, req.(*SearchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppGrantListingService_SearchAllowedGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppGrantListingServiceServer).SearchAllowedGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppGrantListingService_SearchAllowedGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppGrantListingServiceServer).SearchAllowedGroups(ctx, req.(*SearchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AppGrantListingService_ServiceDesc is the grpc.ServiceDesc for AppGrantListingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AppGrantListingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.groups.v1.AppGrantListingService",
+	HandlerType: (*AppGrantListingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchAllowedApps",
+			Handler:    _AppGrantListingService_SearchAllowedApps_Handler,
+		},
+		{
+			MethodName: "SearchAllowedGroups",
+			Handler:    _AppGrantListingService_SearchAllowedGroups_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/groups/v1/listing.proto",
+}
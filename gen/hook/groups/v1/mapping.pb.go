@@ -23,9 +23,14 @@ const (
 )
 
 type GetGroupsForUserReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TenantId      *string                `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TenantId *string                `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	// after resumes the stream after the message with this resume token.
+	After *string `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// page_size is the number of groups read per storage query, 500 by
+	// default and at most 1000.
+	PageSize      *int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,10 +79,29 @@ func (x *GetGroupsForUserReq) GetTenantId() string {
 	return ""
 }
 
+func (x *GetGroupsForUserReq) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *GetGroupsForUserReq) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
 type GetUsersInGroupReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	TenantId      *string                `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GroupId  string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	TenantId *string                `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	// after resumes the stream after the message with this resume token.
+	After *string `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// page_size is the number of users read per storage query, 500 by
+	// default and at most 1000.
+	PageSize      *int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -126,22 +150,163 @@ func (x *GetUsersInGroupReq) GetTenantId() string {
 	return ""
 }
 
-type GroupMapping struct {
+func (x *GetUsersInGroupReq) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *GetUsersInGroupReq) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type BatchGetGroupsForUsersReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	TenantId      *string                `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetGroupsForUsersReq) Reset() {
+	*x = BatchGetGroupsForUsersReq{}
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetGroupsForUsersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetGroupsForUsersReq) ProtoMessage() {}
+
+func (x *BatchGetGroupsForUsersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetGroupsForUsersReq.ProtoReflect.Descriptor instead.
+func (*BatchGetGroupsForUsersReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchGetGroupsForUsersReq) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BatchGetGroupsForUsersReq) GetTenantId() string {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return ""
+}
+
+type WatchMembershipsReq struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId *string                `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	GroupId  *string                `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	UserId   *string                `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// cursor resumes a watch after the last event received, skipping the
+	// snapshot.
+	Cursor        *string `protobuf:"bytes,4,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMembershipsReq) Reset() {
+	*x = WatchMembershipsReq{}
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMembershipsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMembershipsReq) ProtoMessage() {}
+
+func (x *WatchMembershipsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMembershipsReq.ProtoReflect.Descriptor instead.
+func (*WatchMembershipsReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchMembershipsReq) GetTenantId() string {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return ""
+}
+
+func (x *WatchMembershipsReq) GetGroupId() string {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return ""
+}
+
+func (x *WatchMembershipsReq) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *WatchMembershipsReq) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GroupMapping struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId    string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Type        string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Attributes  map[string]string      `protobuf:"bytes,8,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// deleted_at is only set on the deleted groups.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// resume_token resumes GetGroupsForUser after this group.
+	ResumeToken   string `protobuf:"bytes,10,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GroupMapping) Reset() {
 	*x = GroupMapping{}
-	mi := &file_hook_groups_v1_mapping_proto_msgTypes[2]
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -153,7 +318,7 @@ func (x *GroupMapping) String() string {
 func (*GroupMapping) ProtoMessage() {}
 
 func (x *GroupMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_hook_groups_v1_mapping_proto_msgTypes[2]
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,7 +331,7 @@ func (x *GroupMapping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupMapping.ProtoReflect.Descriptor instead.
 func (*GroupMapping) Descriptor() ([]byte, []int) {
-	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{2}
+	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GroupMapping) GetId() string {
@@ -218,16 +383,39 @@ func (x *GroupMapping) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GroupMapping) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *GroupMapping) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *GroupMapping) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 type UserMapping struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// resume_token resumes GetUsersInGroup after this user.
+	ResumeToken   string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UserMapping) Reset() {
 	*x = UserMapping{}
-	mi := &file_hook_groups_v1_mapping_proto_msgTypes[3]
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -239,7 +427,7 @@ func (x *UserMapping) String() string {
 func (*UserMapping) ProtoMessage() {}
 
 func (x *UserMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_hook_groups_v1_mapping_proto_msgTypes[3]
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -252,7 +440,7 @@ func (x *UserMapping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserMapping.ProtoReflect.Descriptor instead.
 func (*UserMapping) Descriptor() ([]byte, []int) {
-	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{3}
+	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UserMapping) GetId() string {
@@ -262,21 +450,212 @@ func (x *UserMapping) GetId() string {
 	return ""
 }
 
+func (x *UserMapping) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type UserGroups struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Groups []*GroupMapping        `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	// error is set instead of the groups when they could not be looked up.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserGroups) Reset() {
+	*x = UserGroups{}
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserGroups) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserGroups) ProtoMessage() {}
+
+func (x *UserGroups) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserGroups.ProtoReflect.Descriptor instead.
+func (*UserGroups) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserGroups) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserGroups) GetGroups() []*GroupMapping {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *UserGroups) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type MembershipEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is one of snapshot, snapshot_end, added, removed, updated and
+	// heartbeat.
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	TenantId string `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	GroupId  string `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId   string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// role is the role of the user in the group, before the removal on the
+	// removed events.
+	Role string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	// cursor resumes the watch after this event.
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembershipEvent) Reset() {
+	*x = MembershipEvent{}
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembershipEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembershipEvent) ProtoMessage() {}
+
+func (x *MembershipEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_mapping_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembershipEvent.ProtoReflect.Descriptor instead.
+func (*MembershipEvent) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_mapping_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MembershipEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MembershipEvent) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *MembershipEvent) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *MembershipEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MembershipEvent) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *MembershipEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *MembershipEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
 var File_hook_groups_v1_mapping_proto protoreflect.FileDescriptor
 
 const file_hook_groups_v1_mapping_proto_rawDesc = "" +
 	"\n" +
-	"\x1chook/groups/v1/mapping.proto\x12\x0ehook.groups.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"^\n" +
+	"\x1chook/groups/v1/mapping.proto\x12\x0ehook.groups.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
 	"\x13GetGroupsForUserReq\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12 \n" +
-	"\ttenant_id\x18\x02 \x01(\tH\x00R\btenantId\x88\x01\x01B\f\n" +
+	"\ttenant_id\x18\x02 \x01(\tH\x00R\btenantId\x88\x01\x01\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x01R\x05after\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\x05H\x02R\bpageSize\x88\x01\x01B\f\n" +
+	"\n" +
+	"_tenant_idB\b\n" +
+	"\x06_afterB\f\n" +
 	"\n" +
-	"_tenant_id\"_\n" +
+	"_page_size\"\xb4\x01\n" +
 	"\x12GetUsersInGroupReq\x12\x19\n" +
 	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12 \n" +
+	"\ttenant_id\x18\x02 \x01(\tH\x00R\btenantId\x88\x01\x01\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x01R\x05after\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\x05H\x02R\bpageSize\x88\x01\x01B\f\n" +
+	"\n" +
+	"_tenant_idB\b\n" +
+	"\x06_afterB\f\n" +
+	"\n" +
+	"_page_size\"f\n" +
+	"\x19BatchGetGroupsForUsersReq\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12 \n" +
 	"\ttenant_id\x18\x02 \x01(\tH\x00R\btenantId\x88\x01\x01B\f\n" +
 	"\n" +
-	"_tenant_id\"\xfb\x01\n" +
+	"_tenant_id\"\xc4\x01\n" +
+	"\x13WatchMembershipsReq\x12 \n" +
+	"\ttenant_id\x18\x01 \x01(\tH\x00R\btenantId\x88\x01\x01\x12\x1e\n" +
+	"\bgroup_id\x18\x02 \x01(\tH\x01R\agroupId\x88\x01\x01\x12\x1c\n" +
+	"\auser_id\x18\x03 \x01(\tH\x02R\x06userId\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x04 \x01(\tH\x03R\x06cursor\x88\x01\x01B\f\n" +
+	"\n" +
+	"_tenant_idB\v\n" +
+	"\t_group_idB\n" +
+	"\n" +
+	"\b_user_idB\t\n" +
+	"\a_cursor\"\xe6\x03\n" +
 	"\fGroupMapping\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
@@ -286,12 +665,38 @@ const file_hook_groups_v1_mapping_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1d\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12L\n" +
+	"\n" +
+	"attributes\x18\b \x03(\v2,.hook.groups.v1.GroupMapping.AttributesEntryR\n" +
+	"attributes\x129\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12!\n" +
+	"\fresume_token\x18\n" +
+	" \x01(\tR\vresumeToken\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
 	"\vUserMapping\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xc5\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"q\n" +
+	"\n" +
+	"UserGroups\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x124\n" +
+	"\x06groups\x18\x02 \x03(\v2\x1c.hook.groups.v1.GroupMappingR\x06groups\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xd2\x01\n" +
+	"\x0fMembershipEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12.\n" +
+	"\x04time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x04time2\x84\x03\n" +
 	"\x14GroupsMappingService\x12W\n" +
 	"\x10GetGroupsForUser\x12#.hook.groups.v1.GetGroupsForUserReq\x1a\x1c.hook.groups.v1.GroupMapping0\x01\x12T\n" +
-	"\x0fGetUsersInGroup\x12\".hook.groups.v1.GetUsersInGroupReq\x1a\x1b.hook.groups.v1.UserMapping0\x01B6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
+	"\x0fGetUsersInGroup\x12\".hook.groups.v1.GetUsersInGroupReq\x1a\x1b.hook.groups.v1.UserMapping0\x01\x12a\n" +
+	"\x16BatchGetGroupsForUsers\x12).hook.groups.v1.BatchGetGroupsForUsersReq\x1a\x1a.hook.groups.v1.UserGroups0\x01\x12Z\n" +
+	"\x10WatchMemberships\x12#.hook.groups.v1.WatchMembershipsReq\x1a\x1f.hook.groups.v1.MembershipEvent0\x01B6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
 
 var (
 	file_hook_groups_v1_mapping_proto_rawDescOnce sync.Once
@@ -305,26 +710,39 @@ func file_hook_groups_v1_mapping_proto_rawDescGZIP() []byte {
 	return file_hook_groups_v1_mapping_proto_rawDescData
 }
 
-var file_hook_groups_v1_mapping_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_hook_groups_v1_mapping_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_hook_groups_v1_mapping_proto_goTypes = []any{
-	(*GetGroupsForUserReq)(nil),   // 0: hook.groups.v1.GetGroupsForUserReq
-	(*GetUsersInGroupReq)(nil),    // 1: hook.groups.v1.GetUsersInGroupReq
-	(*GroupMapping)(nil),          // 2: hook.groups.v1.GroupMapping
-	(*UserMapping)(nil),           // 3: hook.groups.v1.UserMapping
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*GetGroupsForUserReq)(nil),       // 0: hook.groups.v1.GetGroupsForUserReq
+	(*GetUsersInGroupReq)(nil),        // 1: hook.groups.v1.GetUsersInGroupReq
+	(*BatchGetGroupsForUsersReq)(nil), // 2: hook.groups.v1.BatchGetGroupsForUsersReq
+	(*WatchMembershipsReq)(nil),       // 3: hook.groups.v1.WatchMembershipsReq
+	(*GroupMapping)(nil),              // 4: hook.groups.v1.GroupMapping
+	(*UserMapping)(nil),               // 5: hook.groups.v1.UserMapping
+	(*UserGroups)(nil),                // 6: hook.groups.v1.UserGroups
+	(*MembershipEvent)(nil),           // 7: hook.groups.v1.MembershipEvent
+	nil,                               // 8: hook.groups.v1.GroupMapping.AttributesEntry
+	(*timestamppb.Timestamp)(nil),     // 9: google.protobuf.Timestamp
 }
 var file_hook_groups_v1_mapping_proto_depIdxs = []int32{
-	4, // 0: hook.groups.v1.GroupMapping.created_at:type_name -> google.protobuf.Timestamp
-	4, // 1: hook.groups.v1.GroupMapping.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: hook.groups.v1.GroupsMappingService.GetGroupsForUser:input_type -> hook.groups.v1.GetGroupsForUserReq
-	1, // 3: hook.groups.v1.GroupsMappingService.GetUsersInGroup:input_type -> hook.groups.v1.GetUsersInGroupReq
-	2, // 4: hook.groups.v1.GroupsMappingService.GetGroupsForUser:output_type -> hook.groups.v1.GroupMapping
-	3, // 5: hook.groups.v1.GroupsMappingService.GetUsersInGroup:output_type -> hook.groups.v1.UserMapping
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	9,  // 0: hook.groups.v1.GroupMapping.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: hook.groups.v1.GroupMapping.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: hook.groups.v1.GroupMapping.attributes:type_name -> hook.groups.v1.GroupMapping.AttributesEntry
+	9,  // 3: hook.groups.v1.GroupMapping.deleted_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: hook.groups.v1.UserGroups.groups:type_name -> hook.groups.v1.GroupMapping
+	9,  // 5: hook.groups.v1.MembershipEvent.time:type_name -> google.protobuf.Timestamp
+	0,  // 6: hook.groups.v1.GroupsMappingService.GetGroupsForUser:input_type -> hook.groups.v1.GetGroupsForUserReq
+	1,  // 7: hook.groups.v1.GroupsMappingService.GetUsersInGroup:input_type -> hook.groups.v1.GetUsersInGroupReq
+	2,  // 8: hook.groups.v1.GroupsMappingService.BatchGetGroupsForUsers:input_type -> hook.groups.v1.BatchGetGroupsForUsersReq
+	3,  // 9: hook.groups.v1.GroupsMappingService.WatchMemberships:input_type -> hook.groups.v1.WatchMembershipsReq
+	4,  // 10: hook.groups.v1.GroupsMappingService.GetGroupsForUser:output_type -> hook.groups.v1.GroupMapping
+	5,  // 11: hook.groups.v1.GroupsMappingService.GetUsersInGroup:output_type -> hook.groups.v1.UserMapping
+	6,  // 12: hook.groups.v1.GroupsMappingService.BatchGetGroupsForUsers:output_type -> hook.groups.v1.UserGroups
+	7,  // 13: hook.groups.v1.GroupsMappingService.WatchMemberships:output_type -> hook.groups.v1.MembershipEvent
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_hook_groups_v1_mapping_proto_init() }
@@ -334,13 +752,15 @@ func file_hook_groups_v1_mapping_proto_init() {
 	}
 	file_hook_groups_v1_mapping_proto_msgTypes[0].OneofWrappers = []any{}
 	file_hook_groups_v1_mapping_proto_msgTypes[1].OneofWrappers = []any{}
+	file_hook_groups_v1_mapping_proto_msgTypes[2].OneofWrappers = []any{}
+	file_hook_groups_v1_mapping_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_groups_v1_mapping_proto_rawDesc), len(file_hook_groups_v1_mapping_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GroupsMappingService_GetGroupsForUser_FullMethodName = "/hook.groups.v1.GroupsMappingService/GetGroupsForUser"
-	GroupsMappingService_GetUsersInGroup_FullMethodName  = "/hook.groups.v1.GroupsMappingService/GetUsersInGroup"
+	GroupsMappingService_GetGroupsForUser_FullMethodName       = "/hook.groups.v1.GroupsMappingService/GetGroupsForUser"
+	GroupsMappingService_GetUsersInGroup_FullMethodName        = "/hook.groups.v1.GroupsMappingService/GetUsersInGroup"
+	GroupsMappingService_BatchGetGroupsForUsers_FullMethodName = "/hook.groups.v1.GroupsMappingService/BatchGetGroupsForUsers"
+	GroupsMappingService_WatchMemberships_FullMethodName       = "/hook.groups.v1.GroupsMappingService/WatchMemberships"
 )
 
 // GroupsMappingServiceClient is the client API for GroupsMappingService service.
@@ -29,6 +31,12 @@ const (
 type GroupsMappingServiceClient interface {
 	GetGroupsForUser(ctx context.Context, in *GetGroupsForUserReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GroupMapping], error)
 	GetUsersInGroup(ctx context.Context, in *GetUsersInGroupReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserMapping], error)
+	// BatchGetGroupsForUsers streams the groups of each user, one message per
+	// user.
+	BatchGetGroupsForUsers(ctx context.Context, in *BatchGetGroupsForUsersReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserGroups], error)
+	// WatchMemberships streams the direct memberships matching the filters,
+	// then their changes as they happen, with heartbeats.
+	WatchMemberships(ctx context.Context, in *WatchMembershipsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MembershipEvent], error)
 }
 
 type groupsMappingServiceClient struct {
@@ -77,12 +85,56 @@ func (c *groupsMappingServiceClient) GetUsersInGroup(ctx context.Context, in *Ge
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GroupsMappingService_GetUsersInGroupClient = grpc.ServerStreamingClient[UserMapping]
 
+func (c *groupsMappingServiceClient) BatchGetGroupsForUsers(ctx context.Context, in *BatchGetGroupsForUsersReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserGroups], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GroupsMappingService_ServiceDesc.Streams[2], GroupsMappingService_BatchGetGroupsForUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchGetGroupsForUsersReq, UserGroups]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GroupsMappingService_BatchGetGroupsForUsersClient = grpc.ServerStreamingClient[UserGroups]
+
+func (c *groupsMappingServiceClient) WatchMemberships(ctx context.Context, in *WatchMembershipsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MembershipEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GroupsMappingService_ServiceDesc.Streams[3], GroupsMappingService_WatchMemberships_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMembershipsReq, MembershipEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GroupsMappingService_WatchMembershipsClient = grpc.ServerStreamingClient[MembershipEvent]
+
 // GroupsMappingServiceServer is the server API for GroupsMappingService service.
 // All implementations must embed UnimplementedGroupsMappingServiceServer
 // for forward compatibility.
 type GroupsMappingServiceServer interface {
 	GetGroupsForUser(*GetGroupsForUserReq, grpc.ServerStreamingServer[GroupMapping]) error
 	GetUsersInGroup(*GetUsersInGroupReq, grpc.ServerStreamingServer[UserMapping]) error
+	// BatchGetGroupsForUsers streams the groups of each user, one message per
+	// user.
+	BatchGetGroupsForUsers(*BatchGetGroupsForUsersReq, grpc.ServerStreamingServer[UserGroups]) error
+	// WatchMemberships streams the direct memberships matching the filters,
+	// then their changes as they happen, with heartbeats.
+	WatchMemberships(*WatchMembershipsReq, grpc.ServerStreamingServer[MembershipEvent]) error
 	mustEmbedUnimplementedGroupsMappingServiceServer()
 }
 
@@ -99,6 +151,12 @@ func (UnimplementedGroupsMappingServiceServer) GetGroupsForUser(*GetGroupsForUse
 func (UnimplementedGroupsMappingServiceServer) GetUsersInGroup(*GetUsersInGroupReq, grpc.ServerStreamingServer[UserMapping]) error {
 	return status.Error(codes.Unimplemented, "method GetUsersInGroup not implemented")
 }
+func (UnimplementedGroupsMappingServiceServer) BatchGetGroupsForUsers(*BatchGetGroupsForUsersReq, grpc.ServerStreamingServer[UserGroups]) error {
+	return status.Error(codes.Unimplemented, "method BatchGetGroupsForUsers not implemented")
+}
+func (UnimplementedGroupsMappingServiceServer) WatchMemberships(*WatchMembershipsReq, grpc.ServerStreamingServer[MembershipEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchMemberships not implemented")
+}
 func (UnimplementedGroupsMappingServiceServer) mustEmbedUnimplementedGroupsMappingServiceServer() {}
 func (UnimplementedGroupsMappingServiceServer) testEmbeddedByValue()                              {}
 
@@ -142,6 +200,28 @@ func _GroupsMappingService_GetUsersInGroup_Handler(srv interface{}, stream grpc.
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GroupsMappingService_GetUsersInGroupServer = grpc.ServerStreamingServer[UserMapping]
 
+func _GroupsMappingService_BatchGetGroupsForUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchGetGroupsForUsersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GroupsMappingServiceServer).BatchGetGroupsForUsers(m, &grpc.GenericServerStream[BatchGetGroupsForUsersReq, UserGroups]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GroupsMappingService_BatchGetGroupsForUsersServer = grpc.ServerStreamingServer[UserGroups]
+
+func _GroupsMappingService_WatchMemberships_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMembershipsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GroupsMappingServiceServer).WatchMemberships(m, &grpc.GenericServerStream[WatchMembershipsReq, MembershipEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GroupsMappingService_WatchMembershipsServer = grpc.ServerStreamingServer[MembershipEvent]
+
 // GroupsMappingService_ServiceDesc is the grpc.ServiceDesc for GroupsMappingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -160,6 +240,16 @@ var GroupsMappingService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GroupsMappingService_GetUsersInGroup_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BatchGetGroupsForUsers",
+			Handler:       _GroupsMappingService_BatchGetGroupsForUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchMemberships",
+			Handler:       _GroupsMappingService_WatchMemberships_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hook/groups/v1/mapping.proto",
 }
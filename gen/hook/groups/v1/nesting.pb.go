@@ -0,0 +1,751 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/groups/v1/nesting.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddSubgroupsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubgroupIds   []string               `protobuf:"bytes,2,rep,name=subgroup_ids,json=subgroupIds,proto3" json:"subgroup_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSubgroupsReq) Reset() {
+	*x = AddSubgroupsReq{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSubgroupsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSubgroupsReq) ProtoMessage() {}
+
+func (x *AddSubgroupsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSubgroupsReq.ProtoReflect.Descriptor instead.
+func (*AddSubgroupsReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddSubgroupsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddSubgroupsReq) GetSubgroupIds() []string {
+	if x != nil {
+		return x.SubgroupIds
+	}
+	return nil
+}
+
+type AddSubgroupsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSubgroupsResp) Reset() {
+	*x = AddSubgroupsResp{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSubgroupsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSubgroupsResp) ProtoMessage() {}
+
+func (x *AddSubgroupsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSubgroupsResp.ProtoReflect.Descriptor instead.
+func (*AddSubgroupsResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddSubgroupsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *AddSubgroupsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type ListSubgroupsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubgroupsReq) Reset() {
+	*x = ListSubgroupsReq{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubgroupsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubgroupsReq) ProtoMessage() {}
+
+func (x *ListSubgroupsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubgroupsReq.ProtoReflect.Descriptor instead.
+func (*ListSubgroupsReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSubgroupsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListSubgroupsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*GroupMapping        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubgroupsResp) Reset() {
+	*x = ListSubgroupsResp{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubgroupsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubgroupsResp) ProtoMessage() {}
+
+func (x *ListSubgroupsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubgroupsResp.ProtoReflect.Descriptor instead.
+func (*ListSubgroupsResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListSubgroupsResp) GetData() []*GroupMapping {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListSubgroupsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListSubgroupsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type RemoveSubgroupReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubgroupId    string                 `protobuf:"bytes,2,opt,name=subgroup_id,json=subgroupId,proto3" json:"subgroup_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSubgroupReq) Reset() {
+	*x = RemoveSubgroupReq{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSubgroupReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSubgroupReq) ProtoMessage() {}
+
+func (x *RemoveSubgroupReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSubgroupReq.ProtoReflect.Descriptor instead.
+func (*RemoveSubgroupReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoveSubgroupReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveSubgroupReq) GetSubgroupId() string {
+	if x != nil {
+		return x.SubgroupId
+	}
+	return ""
+}
+
+type RemoveSubgroupResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSubgroupResp) Reset() {
+	*x = RemoveSubgroupResp{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSubgroupResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSubgroupResp) ProtoMessage() {}
+
+func (x *RemoveSubgroupResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSubgroupResp.ProtoReflect.Descriptor instead.
+func (*RemoveSubgroupResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveSubgroupResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *RemoveSubgroupResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type ListGroupMembersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupMembersReq) Reset() {
+	*x = ListGroupMembersReq{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupMembersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupMembersReq) ProtoMessage() {}
+
+func (x *ListGroupMembersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupMembersReq.ProtoReflect.Descriptor instead.
+func (*ListGroupMembersReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListGroupMembersReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListGroupMembersResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Membership          `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupMembersResp) Reset() {
+	*x = ListGroupMembersResp{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupMembersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupMembersResp) ProtoMessage() {}
+
+func (x *ListGroupMembersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupMembersResp.ProtoReflect.Descriptor instead.
+func (*ListGroupMembersResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListGroupMembersResp) GetData() []*Membership {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListGroupMembersResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListGroupMembersResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type ListUserMembershipsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserMembershipsReq) Reset() {
+	*x = ListUserMembershipsReq{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserMembershipsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserMembershipsReq) ProtoMessage() {}
+
+func (x *ListUserMembershipsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserMembershipsReq.ProtoReflect.Descriptor instead.
+func (*ListUserMembershipsReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListUserMembershipsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListUserMembershipsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Membership          `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserMembershipsResp) Reset() {
+	*x = ListUserMembershipsResp{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserMembershipsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserMembershipsResp) ProtoMessage() {}
+
+func (x *ListUserMembershipsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserMembershipsResp.ProtoReflect.Descriptor instead.
+func (*ListUserMembershipsResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListUserMembershipsResp) GetData() []*Membership {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListUserMembershipsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListUserMembershipsResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+// Membership is held directly or inherited through nested groups, path lists
+// the names of the groups from the one the user is a direct member of.
+type Membership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Group         *GroupMapping          `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Direct        bool                   `protobuf:"varint,3,opt,name=direct,proto3" json:"direct,omitempty"`
+	Path          []string               `protobuf:"bytes,4,rep,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_nesting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_nesting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Membership) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Membership) GetGroup() *GroupMapping {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *Membership) GetDirect() bool {
+	if x != nil {
+		return x.Direct
+	}
+	return false
+}
+
+func (x *Membership) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+var File_hook_groups_v1_nesting_proto protoreflect.FileDescriptor
+
+const file_hook_groups_v1_nesting_proto_rawDesc = "" +
+	"\n" +
+	"\x1chook/groups/v1/nesting.proto\x12\x0ehook.groups.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1chook/groups/v1/mapping.proto\"D\n" +
+	"\x0fAddSubgroupsReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fsubgroup_ids\x18\x02 \x03(\tR\vsubgroupIds\"U\n" +
+	"\x10AddSubgroupsResp\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x02 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"\"\n" +
+	"\x10ListSubgroupsReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x88\x01\n" +
+	"\x11ListSubgroupsResp\x120\n" +
+	"\x04data\x18\x01 \x03(\v2\x1c.hook.groups.v1.GroupMappingR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"D\n" +
+	"\x11RemoveSubgroupReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vsubgroup_id\x18\x02 \x01(\tR\n" +
+	"subgroupId\"W\n" +
+	"\x12RemoveSubgroupResp\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x02 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"%\n" +
+	"\x13ListGroupMembersReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x89\x01\n" +
+	"\x14ListGroupMembersResp\x12.\n" +
+	"\x04data\x18\x01 \x03(\v2\x1a.hook.groups.v1.MembershipR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"(\n" +
+	"\x16ListUserMembershipsReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8c\x01\n" +
+	"\x17ListUserMembershipsResp\x12.\n" +
+	"\x04data\x18\x01 \x03(\v2\x1a.hook.groups.v1.MembershipR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"\x85\x01\n" +
+	"\n" +
+	"Membership\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\x05group\x18\x02 \x01(\v2\x1c.hook.groups.v1.GroupMappingR\x05group\x12\x16\n" +
+	"\x06direct\x18\x03 \x01(\bR\x06direct\x12\x12\n" +
+	"\x04path\x18\x04 \x03(\tR\x04path2\xd4\x05\n" +
+	"\x13GroupNestingService\x12\x81\x01\n" +
+	"\fAddSubgroups\x12\x1f.hook.groups.v1.AddSubgroupsReq\x1a .hook.groups.v1.AddSubgroupsResp\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v0/authz/groups/{id}/subgroups\x12\x81\x01\n" +
+	"\rListSubgroups\x12 .hook.groups.v1.ListSubgroupsReq\x1a!.hook.groups.v1.ListSubgroupsResp\"+\x82\xd3\xe4\x93\x02%\x12#/api/v0/authz/groups/{id}/subgroups\x12\x92\x01\n" +
+	"\x0eRemoveSubgroup\x12!.hook.groups.v1.RemoveSubgroupReq\x1a\".hook.groups.v1.RemoveSubgroupResp\"9\x82\xd3\xe4\x93\x023*1/api/v0/authz/groups/{id}/subgroups/{subgroup_id}\x12\x88\x01\n" +
+	"\x10ListGroupMembers\x12#.hook.groups.v1.ListGroupMembersReq\x1a$.hook.groups.v1.ListGroupMembersResp\")\x82\xd3\xe4\x93\x02#\x12!/api/v0/authz/groups/{id}/members\x12\x94\x01\n" +
+	"\x13ListUserMemberships\x12&.hook.groups.v1.ListUserMembershipsReq\x1a'.hook.groups.v1.ListUserMembershipsResp\",\x82\xd3\xe4\x93\x02&\x12$/api/v0/authz/users/{id}/membershipsB6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
+
+var (
+	file_hook_groups_v1_nesting_proto_rawDescOnce sync.Once
+	file_hook_groups_v1_nesting_proto_rawDescData []byte
+)
+
+func file_hook_groups_v1_nesting_proto_rawDescGZIP() []byte {
+	file_hook_groups_v1_nesting_proto_rawDescOnce.Do(func() {
+		file_hook_groups_v1_nesting_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_groups_v1_nesting_proto_rawDesc), len(file_hook_groups_v1_nesting_proto_rawDesc)))
+	})
+	return file_hook_groups_v1_nesting_proto_rawDescData
+}
+
+var file_hook_groups_v1_nesting_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hook_groups_v1_nesting_proto_goTypes = []any{
+	(*AddSubgroupsReq)(nil),         // 0: hook.groups.v1.AddSubgroupsReq
+	(*AddSubgroupsResp)(nil),        // 1: hook.groups.v1.AddSubgroupsResp
+	(*ListSubgroupsReq)(nil),        // 2: hook.groups.v1.ListSubgroupsReq
+	(*ListSubgroupsResp)(nil),       // 3: hook.groups.v1.ListSubgroupsResp
+	(*RemoveSubgroupReq)(nil),       // 4: hook.groups.v1.RemoveSubgroupReq
+	(*RemoveSubgroupResp)(nil),      // 5: hook.groups.v1.RemoveSubgroupResp
+	(*ListGroupMembersReq)(nil),     // 6: hook.groups.v1.ListGroupMembersReq
+	(*ListGroupMembersResp)(nil),    // 7: hook.groups.v1.ListGroupMembersResp
+	(*ListUserMembershipsReq)(nil),  // 8: hook.groups.v1.ListUserMembershipsReq
+	(*ListUserMembershipsResp)(nil), // 9: hook.groups.v1.ListUserMembershipsResp
+	(*Membership)(nil),              // 10: hook.groups.v1.Membership
+	(*GroupMapping)(nil),            // 11: hook.groups.v1.GroupMapping
+}
+var file_hook_groups_v1_nesting_proto_depIdxs = []int32{
+	11, // 0: hook.groups.v1.ListSubgroupsResp.data:type_name -> hook.groups.v1.GroupMapping
+	10, // 1: hook.groups.v1.ListGroupMembersResp.data:type_name -> hook.groups.v1.Membership
+	10, // 2: hook.groups.v1.ListUserMembershipsResp.data:type_name -> hook.groups.v1.Membership
+	11, // 3: hook.groups.v1.Membership.group:type_name -> hook.groups.v1.GroupMapping
+	0,  // 4: hook.groups.v1.GroupNestingService.AddSubgroups:input_type -> hook.groups.v1.AddSubgroupsReq
+	2,  // 5: hook.groups.v1.GroupNestingService.ListSubgroups:input_type -> hook.groups.v1.ListSubgroupsReq
+	4,  // 6: hook.groups.v1.GroupNestingService.RemoveSubgroup:input_type -> hook.groups.v1.RemoveSubgroupReq
+	6,  // 7: hook.groups.v1.GroupNestingService.ListGroupMembers:input_type -> hook.groups.v1.ListGroupMembersReq
+	8,  // 8: hook.groups.v1.GroupNestingService.ListUserMemberships:input_type -> hook.groups.v1.ListUserMembershipsReq
+	1,  // 9: hook.groups.v1.GroupNestingService.AddSubgroups:output_type -> hook.groups.v1.AddSubgroupsResp
+	3,  // 10: hook.groups.v1.GroupNestingService.ListSubgroups:output_type -> hook.groups.v1.ListSubgroupsResp
+	5,  // 11: hook.groups.v1.GroupNestingService.RemoveSubgroup:output_type -> hook.groups.v1.RemoveSubgroupResp
+	7,  // 12: hook.groups.v1.GroupNestingService.ListGroupMembers:output_type -> hook.groups.v1.ListGroupMembersResp
+	9,  // 13: hook.groups.v1.GroupNestingService.ListUserMemberships:output_type -> hook.groups.v1.ListUserMembershipsResp
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_hook_groups_v1_nesting_proto_init() }
+func file_hook_groups_v1_nesting_proto_init() {
+	if File_hook_groups_v1_nesting_proto != nil {
+		return
+	}
+	file_hook_groups_v1_mapping_proto_init()
+	file_hook_groups_v1_nesting_proto_msgTypes[1].OneofWrappers = []any{}
+	file_hook_groups_v1_nesting_proto_msgTypes[3].OneofWrappers = []any{}
+	file_hook_groups_v1_nesting_proto_msgTypes[5].OneofWrappers = []any{}
+	file_hook_groups_v1_nesting_proto_msgTypes[7].OneofWrappers = []any{}
+	file_hook_groups_v1_nesting_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_groups_v1_nesting_proto_rawDesc), len(file_hook_groups_v1_nesting_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_groups_v1_nesting_proto_goTypes,
+		DependencyIndexes: file_hook_groups_v1_nesting_proto_depIdxs,
+		MessageInfos:      file_hook_groups_v1_nesting_proto_msgTypes,
+	}.Build()
+	File_hook_groups_v1_nesting_proto = out.File
+	file_hook_groups_v1_nesting_proto_goTypes = nil
+	file_hook_groups_v1_nesting_proto_depIdxs = nil
+}
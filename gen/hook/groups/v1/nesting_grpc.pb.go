@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/groups/v1/nesting.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GroupNestingService_AddSubgroups_FullMethodName        = "/hook.groups.v1.GroupNestingService/AddSubgroups"
+	GroupNestingService_ListSubgroups_FullMethodName       = "/hook.groups.v1.GroupNestingService/ListSubgroups"
+	GroupNestingService_RemoveSubgroup_FullMethodName      = "/hook.groups.v1.GroupNestingService/RemoveSubgroup"
+	GroupNestingService_ListGroupMembers_FullMethodName    = "/hook.groups.v1.GroupNestingService/ListGroupMembers"
+	GroupNestingService_ListUserMemberships_FullMethodName = "/hook.groups.v1.GroupNestingService/ListUserMemberships"
+)
+
+// GroupNestingServiceClient is the client API for GroupNestingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GroupNestingServiceClient interface {
+	AddSubgroups(ctx context.Context, in *AddSubgroupsReq, opts ...grpc.CallOption) (*AddSubgroupsResp, error)
+	ListSubgroups(ctx context.Context, in *ListSubgroupsReq, opts ...grpc.CallOption) (*ListSubgroupsResp, error)
+	RemoveSubgroup(ctx context.Context, in *RemoveSubgroupReq, opts ...grpc.CallOption) (*RemoveSubgroupResp, error)
+	ListGroupMembers(ctx context.Context, in *ListGroupMembersReq, opts ...grpc.CallOption) (*ListGroupMembersResp, error)
+	ListUserMemberships(ctx context.Context, in *ListUserMembershipsReq, opts ...grpc.CallOption) (*ListUserMembershipsResp, error)
+}
+
+type groupNestingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupNestingServiceClient(cc grpc.ClientConnInterface) GroupNestingServiceClient {
+	return &groupNestingServiceClient{cc}
+}
+
+func (c *groupNestingServiceClient) AddSubgroups(ctx context.Context, in *AddSubgroupsReq, opts ...grpc.CallOption) (*AddSubgroupsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddSubgroupsResp)
+	err := c.cc.Invoke(ctx, GroupNestingService_AddSubgroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupNestingServiceClient) ListSubgroups(ctx context.Context, in *ListSubgroupsReq, opts ...grpc.CallOption) (*ListSubgroupsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubgroupsResp)
+	err := c.cc.Invoke(ctx, GroupNestingService_ListSubgroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupNestingServiceClient) RemoveSubgroup(ctx context.Context, in *RemoveSubgroupReq, opts ...grpc.CallOption) (*RemoveSubgroupResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveSubgroupResp)
+	err := c.cc.Invoke(ctx, GroupNestingService_RemoveSubgroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupNestingServiceClient) ListGroupMembers(ctx context.Context, in *ListGroupMembersReq, opts ...grpc.CallOption) (*ListGroupMembersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupMembersResp)
+	err := c.cc.Invoke(ctx, GroupNestingService_ListGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupNestingServiceClient) ListUserMemberships(ctx context.Context, in *ListUserMembershipsReq, opts ...grpc.CallOption) (*ListUserMembershipsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserMembershipsResp)
+	err := c.cc.Invoke(ctx, GroupNestingService_ListUserMemberships_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupNestingServiceServer is the server API for GroupNestingService service.
+// All implementations must embed UnimplementedGroupNestingServiceServer
+// for forward compatibility.
+type GroupNestingServiceServer interface {
+	AddSubgroups(context.Context, *AddSubgroupsReq) (*AddSubgroupsResp, error)
+	ListSubgroups(context.Context, *ListSubgroupsReq) (*ListSubgroupsResp, error)
+	RemoveSubgroup(context.Context, *RemoveSubgroupReq) (*RemoveSubgroupResp, error)
+	ListGroupMembers(context.Context, *ListGroupMembersReq) (*ListGroupMembersResp, error)
+	ListUserMemberships(context.Context, *ListUserMembershipsReq) (*ListUserMembershipsResp, error)
+	mustEmbedUnimplementedGroupNestingServiceServer()
+}
+
+// UnimplementedGroupNestingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupNestingServiceServer struct{}
+
+func (UnimplementedGroupNestingServiceServer) AddSubgroups(context.Context, *AddSubgroupsReq) (*AddSubgroupsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSubgroups not implemented")
+}
+func (UnimplementedGroupNestingServiceServer) ListSubgroups(context.Context, *ListSubgroupsReq) (*ListSubgroupsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSubgroups not implemented")
+}
+func (UnimplementedGroupNestingServiceServer) RemoveSubgroup(context.Context, *RemoveSubgroupReq) (*RemoveSubgroupResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveSubgroup not implemented")
+}
+func (UnimplementedGroupNestingServiceServer) ListGroupMembers(context.Context, *ListGroupMembersReq) (*ListGroupMembersResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListGroupMembers not implemented")
+}
+func (UnimplementedGroupNestingServiceServer) ListUserMemberships(context.Context, *ListUserMembershipsReq) (*ListUserMembershipsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUserMemberships not implemented")
+}
+func (UnimplementedGroupNestingServiceServer) mustEmbedUnimplementedGroupNestingServiceServer() {}
+func (UnimplementedGroupNestingServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeGroupNestingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupNestingServiceServer will
+// result in compilation errors.
+type UnsafeGroupNestingServiceServer interface {
+	mustEmbedUnimplementedGroupNestingServiceServer()
+}
+
+func RegisterGroupNestingServiceServer(s grpc.ServiceRegistrar, srv GroupNestingServiceServer) {
+	// If the following call panics, it indicates UnimplementedGroupNestingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupNestingService_ServiceDesc, srv)
+}
+
+func _GroupNestingService_AddSubgroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSubgroupsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupNestingServiceServer).AddSubgroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupNestingService_AddSubgroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupNestingServiceServer).AddSubgroups(ctx, req.(*AddSubgroupsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupNestingService_ListSubgroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubgroupsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupNestingServiceServer).ListSubgroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupNestingService_ListSubgroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupNestingServiceServer).ListSubgroups(ctx, req.(*ListSubgroupsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupNestingService_RemoveSubgroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSubgroupReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupNestingServiceServer).RemoveSubgroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupNestingService_RemoveSubgroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupNestingServiceServer).RemoveSubgroup(ctx, req.(*RemoveSubgroupReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupNestingService_ListGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupMembersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupNestingServiceServer).ListGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupNestingService_ListGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupNestingServiceServer).ListGroupMembers(ctx, req.(*ListGroupMembersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupNestingService_ListUserMemberships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserMembershipsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupNestingServiceServer).ListUserMemberships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupNestingService_ListUserMemberships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupNestingServiceServer).ListUserMemberships(ctx, req.(*ListUserMembershipsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupNestingService_ServiceDesc is the grpc.ServiceDesc for GroupNestingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupNestingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.groups.v1.GroupNestingService",
+	HandlerType: (*GroupNestingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddSubgroups",
+			Handler:    _GroupNestingService_AddSubgroups_Handler,
+		},
+		{
+			MethodName: "ListSubgroups",
+			Handler:    _GroupNestingService_ListSubgroups_Handler,
+		},
+		{
+			MethodName: "RemoveSubgroup",
+			Handler:    _GroupNestingService_RemoveSubgroup_Handler,
+		},
+		{
+			MethodName: "ListGroupMembers",
+			Handler:    _GroupNestingService_ListGroupMembers_Handler,
+		},
+		{
+			MethodName: "ListUserMemberships",
+			Handler:    _GroupNestingService_ListUserMemberships_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/groups/v1/nesting.proto",
+}
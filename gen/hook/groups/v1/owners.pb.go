@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/groups/v1/owners.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddGroupOwnersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupOwnersReq) Reset() {
+	*x = AddGroupOwnersReq{}
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupOwnersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupOwnersReq) ProtoMessage() {}
+
+func (x *AddGroupOwnersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupOwnersReq.ProtoReflect.Descriptor instead.
+func (*AddGroupOwnersReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_owners_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddGroupOwnersReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddGroupOwnersReq) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type AddGroupOwnersResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupOwnersResp) Reset() {
+	*x = AddGroupOwnersResp{}
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupOwnersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupOwnersResp) ProtoMessage() {}
+
+func (x *AddGroupOwnersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupOwnersResp.ProtoReflect.Descriptor instead.
+func (*AddGroupOwnersResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_owners_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddGroupOwnersResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *AddGroupOwnersResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type RemoveGroupOwnerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupOwnerReq) Reset() {
+	*x = RemoveGroupOwnerReq{}
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupOwnerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupOwnerReq) ProtoMessage() {}
+
+func (x *RemoveGroupOwnerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupOwnerReq.ProtoReflect.Descriptor instead.
+func (*RemoveGroupOwnerReq) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_owners_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemoveGroupOwnerReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveGroupOwnerReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveGroupOwnerResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupOwnerResp) Reset() {
+	*x = RemoveGroupOwnerResp{}
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupOwnerResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupOwnerResp) ProtoMessage() {}
+
+func (x *RemoveGroupOwnerResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_groups_v1_owners_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupOwnerResp.ProtoReflect.Descriptor instead.
+func (*RemoveGroupOwnerResp) Descriptor() ([]byte, []int) {
+	return file_hook_groups_v1_owners_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoveGroupOwnerResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *RemoveGroupOwnerResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+var File_hook_groups_v1_owners_proto protoreflect.FileDescriptor
+
+const file_hook_groups_v1_owners_proto_rawDesc = "" +
+	"\n" +
+	"\x1bhook/groups/v1/owners.proto\x12\x0ehook.groups.v1\x1a\x1cgoogle/api/annotations.proto\">\n" +
+	"\x11AddGroupOwnersReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"W\n" +
+	"\x12AddGroupOwnersResp\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x02 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\">\n" +
+	"\x13RemoveGroupOwnerReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"Y\n" +
+	"\x14RemoveGroupOwnerResp\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x02 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message2\xaf\x02\n" +
+	"\x12GroupOwnersService\x12\x84\x01\n" +
+	"\x0eAddGroupOwners\x12!.hook.groups.v1.AddGroupOwnersReq\x1a\".hook.groups.v1.AddGroupOwnersResp\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v0/authz/groups/{id}/owners\x12\x91\x01\n" +
+	"\x10RemoveGroupOwner\x12#.hook.groups.v1.RemoveGroupOwnerReq\x1a$.hook.groups.v1.RemoveGroupOwnerResp\"2\x82\xd3\xe4\x93\x02,**/api/v0/authz/groups/{id}/owners/{user_id}B6Z4github.com/canonical/hook-service/gen/hook/groups/v1b\x06proto3"
+
+var (
+	file_hook_groups_v1_owners_proto_rawDescOnce sync.Once
+	file_hook_groups_v1_owners_proto_rawDescData []byte
+)
+
+func file_hook_groups_v1_owners_proto_rawDescGZIP() []byte {
+	file_hook_groups_v1_owners_proto_rawDescOnce.Do(func() {
+		file_hook_groups_v1_owners_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_groups_v1_owners_proto_rawDesc), len(file_hook_groups_v1_owners_proto_rawDesc)))
+	})
+	return file_hook_groups_v1_owners_proto_rawDescData
+}
+
+var file_hook_groups_v1_owners_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_hook_groups_v1_owners_proto_goTypes = []any{
+	(*AddGroupOwnersReq)(nil),    // 0: hook.groups.v1.AddGroupOwnersReq
+	(*AddGroupOwnersResp)(nil),   // 1: hook.groups.v1.AddGroupOwnersResp
+	(*RemoveGroupOwnerReq)(nil),  // 2: hook.groups.v1.RemoveGroupOwnerReq
+	(*RemoveGroupOwnerResp)(nil), // 3: hook.groups.v1.RemoveGroupOwnerResp
+}
+var file_hook_groups_v1_owners_proto_depIdxs = []int32{
+	0, // 0: hook.groups.v1.GroupOwnersService.AddGroupOwners:input_type -> hook.groups.v1.AddGroupOwnersReq
+	2, // 1: hook.groups.v1.GroupOwnersService.RemoveGroupOwner:input_type -> hook.groups.v1.RemoveGroupOwnerReq
+	1, // 2: hook.groups.v1.GroupOwnersService.AddGroupOwners:output_type -> hook.groups.v1.AddGroupOwnersResp
+	3, // 3: hook.groups.v1.GroupOwnersService.RemoveGroupOwner:output_type -> hook.groups.v1.RemoveGroupOwnerResp
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hook_groups_v1_owners_proto_init() }
+func file_hook_groups_v1_owners_proto_init() {
+	if File_hook_groups_v1_owners_proto != nil {
+		return
+	}
+	file_hook_groups_v1_owners_proto_msgTypes[1].OneofWrappers = []any{}
+	file_hook_groups_v1_owners_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_groups_v1_owners_proto_rawDesc), len(file_hook_groups_v1_owners_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_groups_v1_owners_proto_goTypes,
+		DependencyIndexes: file_hook_groups_v1_owners_proto_depIdxs,
+		MessageInfos:      file_hook_groups_v1_owners_proto_msgTypes,
+	}.Build()
+	File_hook_groups_v1_owners_proto = out.File
+	file_hook_groups_v1_owners_proto_goTypes = nil
+	file_hook_groups_v1_owners_proto_depIdxs = nil
+}
@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: hook/groups/v1/owners.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GroupOwnersService_AddGroupOwners_FullMethodName   = "/hook.groups.v1.GroupOwnersService/AddGroupOwners"
+	GroupOwnersService_RemoveGroupOwner_FullMethodName = "/hook.groups.v1.GroupOwnersService/RemoveGroupOwner"
+)
+
+// GroupOwnersServiceClient is the client API for GroupOwnersService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GroupOwnersService manages the owners of groups. Owners are members of the
+// group that can add and remove its members without access to the whole API.
+type GroupOwnersServiceClient interface {
+	AddGroupOwners(ctx context.Context, in *AddGroupOwnersReq, opts ...grpc.CallOption) (*AddGroupOwnersResp, error)
+	RemoveGroupOwner(ctx context.Context, in *RemoveGroupOwnerReq, opts ...grpc.CallOption) (*RemoveGroupOwnerResp, error)
+}
+
+type groupOwnersServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupOwnersServiceClient(cc grpc.ClientConnInterface) GroupOwnersServiceClient {
+	return &groupOwnersServiceClient{cc}
+}
+
+func (c *groupOwnersServiceClient) AddGroupOwners(ctx context.Context, in *AddGroupOwnersReq, opts ...grpc.CallOption) (*AddGroupOwnersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddGroupOwnersResp)
+	err := c.cc.Invoke(ctx, GroupOwnersService_AddGroupOwners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupOwnersServiceClient) RemoveGroupOwner(ctx context.Context, in *RemoveGroupOwnerReq, opts ...grpc.CallOption) (*RemoveGroupOwnerResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveGroupOwnerResp)
+	err := c.cc.Invoke(ctx, GroupOwnersService_RemoveGroupOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupOwnersServiceServer is the server API for GroupOwnersService service.
+// All implementations must embed UnimplementedGroupOwnersServiceServer
+// for forward compatibility.
+//
+// GroupOwnersService manages the owners of groups. Owners are members of the
+// group that can add and remove its members without access to the whole API.
+type GroupOwnersServiceServer interface {
+	AddGroupOwners(context.Context, *AddGroupOwnersReq) (*AddGroupOwnersResp, error)
+	RemoveGroupOwner(context.Context, *RemoveGroupOwnerReq) (*RemoveGroupOwnerResp, error)
+	mustEmbedUnimplementedGroupOwnersServiceServer()
+}
+
+// UnimplementedGroupOwnersServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupOwnersServiceServer struct{}
+
+func (UnimplementedGroupOwnersServiceServer) AddGroupOwners(context.Context, *AddGroupOwnersReq) (*AddGroupOwnersResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddGroupOwners not implemented")
+}
+func (UnimplementedGroupOwnersServiceServer) RemoveGroupOwner(context.Context, *RemoveGroupOwnerReq) (*RemoveGroupOwnerResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveGroupOwner not implemented")
+}
+func (UnimplementedGroupOwnersServiceServer) mustEmbedUnimplementedGroupOwnersServiceServer() {}
+func (UnimplementedGroupOwnersServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeGroupOwnersServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupOwnersServiceServer will
+// result in compilation errors.
+type UnsafeGroupOwnersServiceServer interface {
+	mustEmbedUnimplementedGroupOwnersServiceServer()
+}
+
+func RegisterGroupOwnersServiceServer(s grpc.ServiceRegistrar, srv GroupOwnersServiceServer) {
+	// If the following call panics, it indicates UnimplementedGroupOwnersServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupOwnersService_ServiceDesc, srv)
+}
+
+func _GroupOwnersService_AddGroupOwners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGroupOwnersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupOwnersServiceServer).AddGroupOwners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupOwnersService_AddGroupOwners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupOwnersServiceServer).AddGroupOwners(ctx, req.(*AddGroupOwnersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupOwnersService_RemoveGroupOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupOwnerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupOwnersServiceServer).RemoveGroupOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupOwnersService_RemoveGroupOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupOwnersServiceServer).RemoveGroupOwner(ctx, req.(*RemoveGroupOwnerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupOwnersService_ServiceDesc is the grpc.ServiceDesc for GroupOwnersService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupOwnersService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hook.groups.v1.GroupOwnersService",
+	HandlerType: (*GroupOwnersServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddGroupOwners",
+			Handler:    _GroupOwnersService_AddGroupOwners_Handler,
+		},
+		{
+			MethodName: "RemoveGroupOwner",
+			Handler:    _GroupOwnersService_RemoveGroupOwner_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hook/groups/v1/owners.proto",
+}
@@ -0,0 +1,470 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: hook/history/v1/history.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListGroupHistoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupHistoryReq) Reset() {
+	*x = ListGroupHistoryReq{}
+	mi := &file_hook_history_v1_history_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupHistoryReq) ProtoMessage() {}
+
+func (x *ListGroupHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_history_v1_history_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupHistoryReq.ProtoReflect.Descriptor instead.
+func (*ListGroupHistoryReq) Descriptor() ([]byte, []int) {
+	return file_hook_history_v1_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListGroupHistoryReq) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListGroupHistoryReq) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListGroupHistoryReq) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListGroupHistoryReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListGroupHistoryReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListUserHistoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserHistoryReq) Reset() {
+	*x = ListUserHistoryReq{}
+	mi := &file_hook_history_v1_history_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserHistoryReq) ProtoMessage() {}
+
+func (x *ListUserHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_history_v1_history_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserHistoryReq.ProtoReflect.Descriptor instead.
+func (*ListUserHistoryReq) Descriptor() ([]byte, []int) {
+	return file_hook_history_v1_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListUserHistoryReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserHistoryReq) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListUserHistoryReq) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListUserHistoryReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUserHistoryReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListHistoryResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*HistoryEntry        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHistoryResp) Reset() {
+	*x = ListHistoryResp{}
+	mi := &file_hook_history_v1_history_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryResp) ProtoMessage() {}
+
+func (x *ListHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_history_v1_history_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHistoryResp.ProtoReflect.Descriptor instead.
+func (*ListHistoryResp) Descriptor() ([]byte, []int) {
+	return file_hook_history_v1_history_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListHistoryResp) GetData() []*HistoryEntry {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListHistoryResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListHistoryResp) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ListHistoryResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type HistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	Actor         string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	Source        string                 `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	Before        *structpb.Struct       `protobuf:"bytes,9,opt,name=before,proto3" json:"before,omitempty"`
+	After         *structpb.Struct       `protobuf:"bytes,10,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	mi := &file_hook_history_v1_history_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_hook_history_v1_history_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_hook_history_v1_history_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetBefore() *structpb.Struct {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetAfter() *structpb.Struct {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+var File_hook_history_v1_history_proto protoreflect.FileDescriptor
+
+const file_hook_history_v1_history_proto_rawDesc = "" +
+	"\n" +
+	"\x1dhook/history/v1/history.proto\x12\x0fhook.history.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd0\x01\n" +
+	"\x13ListGroupHistoryReq\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\xcd\x01\n" +
+	"\x12ListUserHistoryReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\xaf\x01\n" +
+	"\x0fListHistoryResp\x121\n" +
+	"\x04data\x18\x01 \x03(\v2\x1d.hook.history.v1.HistoryEntryR\x04data\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageTokenB\n" +
+	"\n" +
+	"\b_message\"\xd0\x02\n" +
+	"\fHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\tR\agroupId\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\x12/\n" +
+	"\x06before\x18\t \x01(\v2\x17.google.protobuf.StructR\x06before\x12-\n" +
+	"\x05after\x18\n" +
+	" \x01(\v2\x17.google.protobuf.StructR\x05after2\xa8\x02\n" +
+	"\x0eHistoryService\x12\x8b\x01\n" +
+	"\x10ListGroupHistory\x12$.hook.history.v1.ListGroupHistoryReq\x1a .hook.history.v1.ListHistoryResp\"/\x82\xd3\xe4\x93\x02)\x12'/api/v0/authz/groups/{group_id}/history\x12\x87\x01\n" +
+	"\x0fListUserHistory\x12#.hook.history.v1.ListUserHistoryReq\x1a .hook.history.v1.ListHistoryResp\"-\x82\xd3\xe4\x93\x02'\x12%/api/v0/authz/users/{user_id}/historyB7Z5github.com/canonical/hook-service/gen/hook/history/v1b\x06proto3"
+
+var (
+	file_hook_history_v1_history_proto_rawDescOnce sync.Once
+	file_hook_history_v1_history_proto_rawDescData []byte
+)
+
+func file_hook_history_v1_history_proto_rawDescGZIP() []byte {
+	file_hook_history_v1_history_proto_rawDescOnce.Do(func() {
+		file_hook_history_v1_history_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hook_history_v1_history_proto_rawDesc), len(file_hook_history_v1_history_proto_rawDesc)))
+	})
+	return file_hook_history_v1_history_proto_rawDescData
+}
+
+var file_hook_history_v1_history_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_hook_history_v1_history_proto_goTypes = []any{
+	(*ListGroupHistoryReq)(nil),   // 0: hook.history.v1.ListGroupHistoryReq
+	(*ListUserHistoryReq)(nil),    // 1: hook.history.v1.ListUserHistoryReq
+	(*ListHistoryResp)(nil),       // 2: hook.history.v1.ListHistoryResp
+	(*HistoryEntry)(nil),          // 3: hook.history.v1.HistoryEntry
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 5: google.protobuf.Struct
+}
+var file_hook_history_v1_history_proto_depIdxs = []int32{
+	4,  // 0: hook.history.v1.ListGroupHistoryReq.since:type_name -> google.protobuf.Timestamp
+	4,  // 1: hook.history.v1.ListGroupHistoryReq.until:type_name -> google.protobuf.Timestamp
+	4,  // 2: hook.history.v1.ListUserHistoryReq.since:type_name -> google.protobuf.Timestamp
+	4,  // 3: hook.history.v1.ListUserHistoryReq.until:type_name -> google.protobuf.Timestamp
+	3,  // 4: hook.history.v1.ListHistoryResp.data:type_name -> hook.history.v1.HistoryEntry
+	4,  // 5: hook.history.v1.HistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: hook.history.v1.HistoryEntry.before:type_name -> google.protobuf.Struct
+	5,  // 7: hook.history.v1.HistoryEntry.after:type_name -> google.protobuf.Struct
+	0,  // 8: hook.history.v1.HistoryService.ListGroupHistory:input_type -> hook.history.v1.ListGroupHistoryReq
+	1,  // 9: hook.history.v1.HistoryService.ListUserHistory:input_type -> hook.history.v1.ListUserHistoryReq
+	2,  // 10: hook.history.v1.HistoryService.ListGroupHistory:output_type -> hook.history.v1.ListHistoryResp
+	2,  // 11: hook.history.v1.HistoryService.ListUserHistory:output_type -> hook.history.v1.ListHistoryResp
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_hook_history_v1_history_proto_init() }
+func file_hook_history_v1_history_proto_init() {
+	if File_hook_history_v1_history_proto != nil {
+		return
+	}
+	file_hook_history_v1_history_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hook_history_v1_history_proto_rawDesc), len(file_hook_history_v1_history_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hook_history_v1_history_proto_goTypes,
+		DependencyIndexes: file_hook_history_v1_history_proto_depIdxs,
+		MessageInfos:      file_hook_history_v1_history_proto_msgTypes,
+	}.Build()
+	File_hook_history_v1_history_proto = out.File
+	file_hook_history_v1_history_proto_goTypes = nil
+	file_hook_history_v1_history_proto_depIdxs = nil
+}
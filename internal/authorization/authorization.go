@@ -74,21 +74,14 @@ func (a *Authorizer) ValidateModel(ctx context.Context) error {
 }
 
 func (a *Authorizer) CanAccess(ctx context.Context, userId, clientId string, groups []string) (bool, error) {
-	ctxTuples := []openfga.Tuple{}
-	for _, group := range groups {
-		ctxTuples = append(ctxTuples, *openfga.NewTuple(UserTuple(userId), MEMBER_RELATION, GroupTuple(group)))
-	}
-	return a.Check(ctx, UserTuple(userId), CAN_ACCESS_RELATION, ClientTuple(clientId), ctxTuples...)
+	return a.Check(ctx, UserTuple(userId), CAN_ACCESS_RELATION, ClientTuple(clientId), MembershipTuples(userId, groups)...)
 }
 
 func (a *Authorizer) BatchCanAccess(ctx context.Context, userId string, clientIds []string, groups []string) (bool, error) {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.BatchCanAccess")
 	defer span.End()
 
-	ctxTuples := []openfga.Tuple{}
-	for _, group := range groups {
-		ctxTuples = append(ctxTuples, *openfga.NewTuple(UserTuple(userId), MEMBER_RELATION, GroupTuple(group)))
-	}
+	ctxTuples := MembershipTuples(userId, groups)
 
 	tuples := []openfga.TupleWithContext{}
 	for _, clientId := range clientIds {
@@ -98,6 +91,18 @@ func (a *Authorizer) BatchCanAccess(ctx context.Context, userId string, clientId
 	return a.client.BatchCheck(ctx, tuples...)
 }
 
+// HasAllowedGroups reports whether at least one group is allowed to access the client.
+func (a *Authorizer) HasAllowedGroups(ctx context.Context, clientID string) (bool, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.HasAllowedGroups")
+	defer span.End()
+
+	r, err := a.client.ReadTuples(ctx, GroupMemberTuple(""), CAN_ACCESS_RELATION, ClientTuple(clientID), "")
+	if err != nil {
+		return false, err
+	}
+	return len(r.Tuples) > 0, nil
+}
+
 func (a *Authorizer) AddAllowedAppToGroup(ctx context.Context, groupID, clientID string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.AddAllowedAppToGroup")
 	defer span.End()
@@ -19,6 +19,7 @@ type AuthorizerInterface interface {
 	ValidateModel(context.Context) error
 	CanAccess(context.Context, string, string, []string) (bool, error)
 	BatchCanAccess(context.Context, string, []string, []string) (bool, error)
+	HasAllowedGroups(context.Context, string) (bool, error)
 
 	AddAllowedAppToGroup(context.Context, string, string) error
 	RemoveAllowedAppFromGroup(context.Context, string, string) error
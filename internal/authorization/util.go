@@ -3,7 +3,11 @@
 
 package authorization
 
-import "encoding/base64"
+import (
+	"encoding/base64"
+
+	"github.com/canonical/hook-service/internal/openfga"
+)
 
 const (
 	CAN_ACCESS_RELATION = "can_access"
@@ -27,3 +31,18 @@ func GroupTuple(groupId string) string {
 func GroupMemberTuple(groupId string) string {
 	return GroupTuple(groupId) + "#" + MEMBER_RELATION
 }
+
+// MembershipTuples returns the contextual tuples declaring the user a member
+// of the groups, as sent along with the can_access checks.
+func MembershipTuples(userId string, groups []string) []openfga.Tuple {
+	tuples := make([]openfga.Tuple, 0, len(groups))
+	for _, group := range groups {
+		tuples = append(tuples, *openfga.NewTuple(UserTuple(userId), MEMBER_RELATION, GroupTuple(group)))
+	}
+	return tuples
+}
+
+// AccessTuple returns the tuple checked to decide whether the user can access the client.
+func AccessTuple(userId, clientId string) openfga.Tuple {
+	return *openfga.NewTuple(UserTuple(userId), CAN_ACCESS_RELATION, ClientTuple(clientId))
+}
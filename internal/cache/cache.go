@@ -0,0 +1,142 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+// MaxTTL bounds the TTL of every cache, entries are never served older than this.
+const MaxTTL = 5 * time.Minute
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is an in-process, size bounded LRU cache whose entries expire after a TTL.
+// It is safe for concurrent use.
+type Cache[V any] struct {
+	name    string
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	lru     *list.List
+
+	mu sync.Mutex
+
+	now func() time.Time
+}
+
+// Get returns the value stored for key if present and not expired.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+
+	el, ok := c.entries[key]
+	if !ok {
+		cacheMisses.WithLabelValues(c.name).Inc()
+		return zero, false
+	}
+
+	e := el.Value.(*entry[V])
+	if !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		cacheMisses.WithLabelValues(c.name).Inc()
+		return zero, false
+	}
+
+	c.lru.MoveToFront(el)
+	cacheHits.WithLabelValues(c.name).Inc()
+	return e.value, true
+}
+
+// Set stores value for key, evicting the least recently used entry when the
+// cache is full.
+func (c *Cache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry[V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		cacheEvictions.WithLabelValues(c.name).Inc()
+	}
+}
+
+// Delete removes the given keys.
+func (c *Cache[V]) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.entries[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+// Purge removes every entry.
+func (c *Cache[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element, c.size)
+	c.lru.Init()
+	cacheEntries.WithLabelValues(c.name).Set(0)
+}
+
+// Len returns the number of entries, including expired ones not yet evicted.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache[V]) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*entry[V]).key)
+	cacheEntries.WithLabelValues(c.name).Set(float64(c.lru.Len()))
+}
+
+// NewCache creates a cache holding at most size entries for ttl, which is
+// capped at MaxTTL. The name labels the cache metrics.
+func NewCache[V any](name string, size int, ttl time.Duration, logger logging.LoggerInterface) *Cache[V] {
+	c := new(Cache[V])
+
+	if ttl > MaxTTL {
+		logger.Warnf("cache %s TTL %s exceeds the maximum, using %s", name, ttl, MaxTTL)
+		ttl = MaxTTL
+	}
+
+	c.name = name
+	c.size = size
+	c.ttl = ttl
+	c.entries = make(map[string]*list.Element, size)
+	c.lru = list.New()
+	c.now = time.Now
+
+	registerMetrics(logger)
+
+	return c
+}
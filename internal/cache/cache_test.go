@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+func TestCacheGetSet(t *testing.T) {
+	c := NewCache[string]("test", 10, time.Minute, logging.NewNoopLogger())
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected missing key not to be found")
+	}
+
+	c.Set("key", "value")
+
+	v, ok := c.Get("key")
+	if !ok || v != "value" {
+		t.Fatalf("expected value to be %q not %q", "value", v)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := NewCache[string]("test", 10, time.Minute, logging.NewNoopLogger())
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set("key", "value")
+
+	now = now.Add(time.Minute)
+
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("expected expired key not to be found")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected expired entry to be removed, got %d entries", c.Len())
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewCache[int]("test", 2, time.Minute, logging.NewNoopLogger())
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected least recently used key to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Fatalf("expected %q to be cached", key)
+		}
+	}
+}
+
+func TestCacheDeleteAndPurge(t *testing.T) {
+	c := NewCache[int]("test", 10, time.Minute, logging.NewNoopLogger())
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Delete("a", "b", "missing")
+	if c.Len() != 1 {
+		t.Fatalf("expected 1 entry after delete, got %d", c.Len())
+	}
+
+	c.Purge()
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("expected purged key not to be found")
+	}
+}
+
+func TestCacheTTLIsCapped(t *testing.T) {
+	c := NewCache[int]("test", 10, time.Hour, logging.NewNoopLogger())
+
+	if c.ttl != MaxTTL {
+		t.Fatalf("expected TTL to be capped to %s not %s", MaxTTL, c.ttl)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+var (
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_cache_hits_total",
+		Help: "Total number of cache hits",
+	}, []string{"cache"})
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_cache_misses_total",
+		Help: "Total number of cache misses",
+	}, []string{"cache"})
+	cacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_cache_evictions_total",
+		Help: "Total number of entries evicted because the cache was full",
+	}, []string{"cache"})
+	cacheEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hook_service_cache_entries",
+		Help: "Current number of cache entries",
+	}, []string{"cache"})
+
+	registerOnce sync.Once
+)
+
+func registerMetrics(logger logging.LoggerInterface) {
+	registerOnce.Do(func() {
+		for _, collector := range []prometheus.Collector{cacheHits, cacheMisses, cacheEvictions, cacheEntries} {
+			err := prometheus.Register(collector)
+			switch err.(type) {
+			case nil:
+				continue
+			case prometheus.AlreadyRegisteredError:
+				logger.Debugf("metric %v already registered", collector)
+			default:
+				logger.Errorf("metric %v could not be registered", collector)
+			}
+		}
+	})
+}
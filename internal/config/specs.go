@@ -24,6 +24,9 @@ type EnvSpec struct {
 
 	ApiToken string `envconfig:"api_token" default:""`
 
+	HookSigningSecrets string        `envconfig:"hook_signing_secrets" default:""`
+	HookSigningWindow  time.Duration `envconfig:"hook_signing_window" default:"5m"`
+
 	OpenfgaApiScheme string `envconfig:"openfga_api_scheme" default:""`
 	OpenfgaApiHost   string `envconfig:"openfga_api_host"`
 	OpenfgaApiToken  string `envconfig:"openfga_api_token"`
@@ -34,8 +37,9 @@ type EnvSpec struct {
 	SalesforceConsumerKey    string `envconfig:"salesforce_consumer_key"`
 	SalesforceConsumerSecret string `envconfig:"salesforce_consumer_secret"`
 
-	AuthorizationEnabled bool `envconfig:"authorization_enabled" default:"false"`
-	OpenFGAWorkersTotal  int  `envconfig:"openfga_workers_total" default:"150"`
+	AuthorizationEnabled bool   `envconfig:"authorization_enabled" default:"false"`
+	AuthorizationPolicy  string `envconfig:"authorization_policy" default:""`
+	OpenFGAWorkersTotal  int    `envconfig:"openfga_workers_total" default:"150"`
 
 	AuthenticationEnabled         bool   `envconfig:"authentication_enabled" default:"true"`
 	AuthenticationIssuer          string `envconfig:"authentication_issuer"`
@@ -50,9 +54,15 @@ type EnvSpec struct {
 	DBMaxConnLifetime time.Duration `envconfig:"db_max_conn_lifetime" default:"1h"`
 	DBMaxConnIdleTime time.Duration `envconfig:"db_max_conn_idle_time" default:"30m"`
 
-	TenantServiceGRPCAddress string        `envconfig:"tenant_service_grpc_address" default:""`
-	TenantServiceGRPCTimeout time.Duration `envconfig:"tenant_service_grpc_timeout" default:"5s"`
-	TenantServiceTLSEnabled  bool          `envconfig:"tenant_service_tls_enabled" default:"false"`
+	TenantServiceGRPCAddress     string        `envconfig:"tenant_service_grpc_address" default:""`
+	TenantServiceGRPCTimeout     time.Duration `envconfig:"tenant_service_grpc_timeout" default:"5s"`
+	TenantServiceTLSEnabled      bool          `envconfig:"tenant_service_tls_enabled" default:"false"`
+	TenantServiceCacheTTL        time.Duration `envconfig:"tenant_service_cache_ttl" default:"30s"`
+	TenantServiceCacheStaleTTL   time.Duration `envconfig:"tenant_service_cache_stale_ttl" default:"0s"`
+	TenantServiceCacheMaxEntries int           `envconfig:"tenant_service_cache_max_entries" default:"10000"`
+
+	TenantSessionKey string `envconfig:"tenant_session_key" default:"_tenant_id"`
+	TenantAutoSelect bool   `envconfig:"tenant_auto_select" default:"false"`
 
 	ReplicaDSN              string        `envconfig:"replica_dsn" default:""`
 	ReplicaDBMaxConns       int32         `envconfig:"replica_db_max_conns" default:"25"`
@@ -65,6 +75,44 @@ type EnvSpec struct {
 	StreamTimeout        time.Duration `envconfig:"stream_timeout" default:"30s"`
 
 	HookMaxConcurrent int `envconfig:"hook_max_concurrent" default:"150"`
+
+	HookCacheTTL        time.Duration `envconfig:"hook_cache_ttl" default:"0s"`
+	HookCacheMaxEntries int           `envconfig:"hook_cache_max_entries" default:"10000"`
+
+	DecisionLogEnabled   bool          `envconfig:"decision_log_enabled" default:"true"`
+	DecisionLogRetention time.Duration `envconfig:"decision_log_retention" default:"720h"`
+
+	GroupDeletionRetention time.Duration `envconfig:"group_deletion_retention" default:"720h"`
+
+	WebhookSubscribers  string        `envconfig:"webhook_subscribers" default:""`
+	WebhookMaxAttempts  int           `envconfig:"webhook_max_attempts" default:"8"`
+	WebhookMinBackoff   time.Duration `envconfig:"webhook_min_backoff" default:"1s"`
+	WebhookMaxBackoff   time.Duration `envconfig:"webhook_max_backoff" default:"5m"`
+	WebhookTimeout      time.Duration `envconfig:"webhook_timeout" default:"10s"`
+	WebhookPollInterval time.Duration `envconfig:"webhook_poll_interval" default:"5s"`
+	OutboxRetention     time.Duration `envconfig:"outbox_retention" default:"168h"`
+
+	TokenClaimMappings string `envconfig:"token_claim_mappings" default:""`
+
+	GroupSources string `envconfig:"group_sources" default:""`
+
+	LDAPURL                string        `envconfig:"ldap_url" default:""`
+	LDAPBindDN             string        `envconfig:"ldap_bind_dn" default:""`
+	LDAPBindPassword       string        `envconfig:"ldap_bind_password" default:""`
+	LDAPStartTLS           bool          `envconfig:"ldap_start_tls" default:"false"`
+	LDAPBaseDN             string        `envconfig:"ldap_base_dn" default:""`
+	LDAPUserBaseDN         string        `envconfig:"ldap_user_base_dn" default:""`
+	LDAPGroupBaseDN        string        `envconfig:"ldap_group_base_dn" default:""`
+	LDAPUserFilter         string        `envconfig:"ldap_user_filter" default:"(mail={user})"`
+	LDAPGroupFilter        string        `envconfig:"ldap_group_filter" default:"(&(objectClass=groupOfNames)(member={dn}))"`
+	LDAPUserAttribute      string        `envconfig:"ldap_user_attribute" default:"email"`
+	LDAPGroupIDAttribute   string        `envconfig:"ldap_group_id_attribute" default:"dn"`
+	LDAPGroupNameAttribute string        `envconfig:"ldap_group_name_attribute" default:"cn"`
+	LDAPTenantID           string        `envconfig:"ldap_tenant_id" default:"default"`
+	LDAPTimeout            time.Duration `envconfig:"ldap_timeout" default:"5s"`
+	LDAPPoolSize           int           `envconfig:"ldap_pool_size" default:"10"`
+	LDAPCacheTTL           time.Duration `envconfig:"ldap_cache_ttl" default:"30s"`
+	LDAPCacheMaxEntries    int           `envconfig:"ldap_cache_max_entries" default:"10000"`
 }
 
 type Flags struct {
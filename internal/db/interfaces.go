@@ -22,3 +22,10 @@ type TxInterface interface {
 	Rollback() error
 	sq.BaseRunner
 }
+
+// NotificationHandlerInterface receives the notifications of a channel, see
+// DBClient.Listen.
+type NotificationHandlerInterface interface {
+	HandleNotification(ctx context.Context, payload string)
+	HandleReconnect(ctx context.Context)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	listenMinBackoff = time.Second
+	listenMaxBackoff = 30 * time.Second
+)
+
+// Listen subscribes to a Postgres notification channel on a dedicated
+// connection taken from the pool and passes every notification to the
+// handler. If the connection is lost it reconnects with a backoff and calls
+// HandleReconnect, as notifications sent in the meantime are lost.
+// It blocks until ctx is canceled.
+func (d *DBClient) Listen(ctx context.Context, channel string, handler NotificationHandlerInterface) error {
+	backoff := listenMinBackoff
+
+	for connected := false; ; {
+		err := d.listen(ctx, channel, handler, func() {
+			if connected {
+				handler.HandleReconnect(ctx)
+			}
+			connected = true
+			backoff = listenMinBackoff
+		})
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		d.logger.Warnf("lost notification channel %s, reconnecting in %s: %v", channel, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, listenMaxBackoff)
+	}
+}
+
+func (d *DBClient) listen(ctx context.Context, channel string, handler NotificationHandlerInterface, onListen func()) error {
+	c, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %v", err)
+	}
+
+	// The connection is removed from the pool so that it is never reused while
+	// still subscribed to the channel.
+	conn := c.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", channel, err)
+	}
+
+	d.logger.Debugf("listening on notification channel %s", channel)
+	onListen()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		handler.HandleNotification(ctx, n.Payload)
+	}
+}
+
+// Notify sends a notification on a Postgres channel. When ctx carries a
+// transaction the notification is only delivered once it commits.
+func Notify(ctx context.Context, c DBClientInterface, channel, payload string) error {
+	_, err := c.Statement(ctx).
+		Select().
+		Column(sq.Expr("pg_notify(?, ?)", channel, payload)).
+		ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type channelHandler struct {
+	payloads   chan string
+	reconnects chan struct{}
+}
+
+func (h *channelHandler) HandleNotification(_ context.Context, payload string) {
+	h.payloads <- payload
+}
+
+func (h *channelHandler) HandleReconnect(context.Context) {
+	h.reconnects <- struct{}{}
+}
+
+func TestIntegration_ListenNotify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	connStr, container := setupTestPostgres(t, "listen")
+	if container == nil {
+		return
+	}
+	defer func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+
+	dbClient, err := NewDBClient(Config{DSN: connStr, MaxConns: 5, MinConns: 1}, &noopTracer{}, &noopMonitor{}, &integrationLogger{t: t})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer dbClient.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := &channelHandler{payloads: make(chan string, 10), reconnects: make(chan struct{}, 10)}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- dbClient.Listen(ctx, "test_channel", handler)
+	}()
+
+	// LISTEN is asynchronous, keep notifying until the listener is subscribed.
+	deadline := time.After(10 * time.Second)
+	received := false
+	for !received {
+		if err := Notify(context.Background(), dbClient, "test_channel", "ready"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		select {
+		case <-handler.payloads:
+			received = true
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("timed out waiting for the listener")
+		}
+	}
+
+	t.Run("Notification is only delivered on commit", func(t *testing.T) {
+		err := dbClient.WithTx(context.Background(), func(txCtx context.Context) error {
+			if err := Notify(txCtx, dbClient, "test_channel", "rolled back"); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+		if err == nil {
+			t.Fatal("expected the transaction to fail")
+		}
+
+		err = dbClient.WithTx(context.Background(), func(txCtx context.Context) error {
+			return Notify(txCtx, dbClient, "test_channel", "committed")
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for {
+			select {
+			case p := <-handler.payloads:
+				if p == "rolled back" {
+					t.Fatal("expected rolled back notification not to be delivered")
+				}
+				if p == "committed" {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the committed notification")
+			}
+		}
+	})
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected Listen to return nil on cancellation, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Listen to return")
+	}
+
+	if len(handler.reconnects) != 0 {
+		t.Fatalf("expected no reconnect, got %d", len(handler.reconnects))
+	}
+}
@@ -171,6 +171,24 @@ func TestStatement_TransactionOverridesReadOnly(t *testing.T) {
 	}
 }
 
+func TestWithTx_JoinsTransaction(t *testing.T) {
+	txRunner := &mockBaseRunner{}
+	ctx := ContextWithTx(context.Background(), &mockTx{runner: txRunner})
+
+	d := &DBClient{logger: &testLogger{}}
+
+	err := d.WithTx(ctx, func(txCtx context.Context) error {
+		_ = d.Statement(txCtx).Select("1").QueryRow()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := txRunner.queryRowCalled.Load(); got != 1 {
+		t.Errorf("tx calls = %d, want 1", got)
+	}
+}
+
 type mockTx struct {
 	runner *mockBaseRunner
 }
@@ -25,11 +25,14 @@ import (
 )
 
 const (
-	defaultPage      uint64 = 1
-	defaultPageSize  uint64 = 100
-	defaultTxTimeout        = time.Second * 60
+	defaultPage     uint64 = 1
+	defaultPageSize uint64 = 100
 )
 
+// TxTimeout bounds the duration of the transactions of WithTx and
+// TransactionMiddleware, they are rolled back once it elapses.
+const TxTimeout = time.Second * 60
+
 type TxContextKey struct{}
 type LazyTxContextKey struct{}
 type ReadOnlyContextKey struct{}
@@ -118,7 +121,7 @@ func (lt *lazyTx) get() (TxInterface, error) {
 	// Use background context to prevent transaction from being auto-rolled back
 	// when the request context is canceled.
 	// We add a timeout to ensure the transaction doesn't hang indefinitely.
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTxTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), TxTimeout)
 	tx, err := lt.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: false})
 	if err != nil {
 		cancel()
@@ -253,7 +256,13 @@ func readOnlyFromContext(ctx context.Context) bool {
 // If the function returns an error, the transaction is rolled back.
 // Otherwise, the transaction is committed.
 // If no database operations occurred, no transaction is created or committed.
+// If ctx already carries a transaction, fn runs in it and the outermost
+// caller commits or rolls back.
 func (d *DBClient) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	if hasTransaction(ctx) {
+		return fn(ctx)
+	}
+
 	lt := &lazyTx{
 		db:     d.db,
 		logger: d.logger,
@@ -0,0 +1,105 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import (
+	"errors"
+
+	v0Types "github.com/canonical/identity-platform-api/v0/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// ListOptions returns the list options of a search request.
+func ListOptions(req *pb.SearchReq) (*types.ListOptions, error) {
+	opts := &types.ListOptions{
+		Search:     req.GetSearch(),
+		TenantID:   req.GetTenantId(),
+		SortBy:     req.GetSortBy(),
+		Descending: req.GetDescending(),
+		PageSize:   int(req.GetPageSize()),
+		PageToken:  req.GetPageToken(),
+	}
+
+	if req.GetType() != "" {
+		t, err := types.ParseGroupType(req.GetType())
+		if err != nil {
+			return nil, err
+		}
+		opts.Type = &t
+	}
+	if len(req.GetAttributes()) > 0 {
+		attributes, err := types.ParseAttributes(req.GetAttributes())
+		if err != nil {
+			return nil, err
+		}
+		opts.Attributes = attributes
+	}
+	if req.GetCreatedAfter() != nil {
+		opts.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+	if req.GetCreatedBefore() != nil {
+		opts.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+	if req.GetUpdatedAfter() != nil {
+		opts.UpdatedAfter = req.GetUpdatedAfter().AsTime()
+	}
+	if req.GetUpdatedBefore() != nil {
+		opts.UpdatedBefore = req.GetUpdatedBefore().AsTime()
+	}
+
+	return opts, nil
+}
+
+// PaginationOptions returns the list options of a v0 API pagination input,
+// requests without pagination list every row.
+func PaginationOptions(p *v0Types.PaginationInput) *types.ListOptions {
+	if p == nil {
+		return nil
+	}
+
+	return &types.ListOptions{
+		PageSize:  int(p.GetSize()),
+		PageToken: p.GetPageToken(),
+	}
+}
+
+// Pagination returns the v0 API pagination metadata of a page of size items
+// listed with opts, nil without pagination.
+func Pagination(opts *types.ListOptions, size int, page *types.PageInfo) *v0Types.Pagination {
+	if opts == nil {
+		return nil
+	}
+
+	meta := &v0Types.Pagination{Size: int32(size)}
+	if opts.PageToken != "" {
+		meta.PageToken = &opts.PageToken
+	}
+	if page != nil && page.NextPageToken != "" {
+		meta.Next = &page.NextPageToken
+	}
+	return meta
+}
+
+// ListErrorStatus maps the errors of invalid list options to an
+// InvalidArgument status, other errors are not mapped and return nil.
+func ListErrorStatus(err error) error {
+	switch {
+	case errors.Is(err, types.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "invalid page token")
+	case errors.Is(err, types.ErrInvalidPageSize), errors.Is(err, types.ErrInvalidSort):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, types.ErrInvalidTimeRange):
+		return status.Errorf(codes.InvalidArgument, "the after times must be before the before times")
+	case errors.Is(err, types.ErrInvalidGroupType):
+		return status.Errorf(codes.InvalidArgument, "invalid group type")
+	case errors.Is(err, types.ErrInvalidAttribute):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	default:
+		return nil
+	}
+}
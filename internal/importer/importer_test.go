@@ -272,7 +272,7 @@ func TestImporterIntegration(t *testing.T) {
 	}
 
 	// Verify: check that groups were created
-	groups, err := s.ListGroups(ctx)
+	groups, _, err := s.ListGroups(ctx, nil)
 	if err != nil {
 		t.Fatalf("Failed to list groups: %v", err)
 	}
@@ -306,7 +306,7 @@ func TestImporterIntegration(t *testing.T) {
 	}
 
 	// Verify the group count hasn't changed
-	groupsAfterSecondRun, err := s.ListGroups(ctx)
+	groupsAfterSecondRun, _, err := s.ListGroups(ctx, nil)
 	if err != nil {
 		t.Fatalf("Failed to list groups after second run: %v", err)
 	}
@@ -564,7 +564,7 @@ func TestImporterSyncIntegration(t *testing.T) {
 		t.Fatalf("Initial import failed: %v", err)
 	}
 
-	groupsAfterImport, err := s.ListGroups(ctx)
+	groupsAfterImport, _, err := s.ListGroups(ctx, nil)
 	if err != nil {
 		t.Fatalf("Failed to list groups after import: %v", err)
 	}
@@ -583,7 +583,7 @@ func TestImporterSyncIntegration(t *testing.T) {
 		t.Fatalf("Sync failed: %v", err)
 	}
 
-	groupsAfterSync, err := s.ListGroups(ctx)
+	groupsAfterSync, _, err := s.ListGroups(ctx, nil)
 	if err != nil {
 		t.Fatalf("Failed to list groups after sync: %v", err)
 	}
@@ -31,6 +31,7 @@ type SecurityLoggerInterface interface {
 	AuthzFailure(string, string, ...Option)
 	AuthzFailureNotEmployee(string, ...Option)
 	AuthzFailureApplicationAccess(string, string, ...Option)
+	AuthzShadowDenial(string, string, ...Option)
 	AuthzFailureNoSession(string, ...Option)
 	AuthzFailureInsufficientPermissions(string, string, string, ...Option)
 	AuthzFailureRoleAssignment(string, string, ...Option)
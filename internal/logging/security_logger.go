@@ -197,6 +197,15 @@ func (a *SecurityLogger) AuthzFailureApplicationAccess(user, clientID string, op
 	a.l.Warn(msg, fields...)
 }
 
+func (a *SecurityLogger) AuthzShadowDenial(user, clientID string, options ...Option) {
+	msg := fmt.Sprintf("User %s would have been denied access to application %s (shadow mode)", user, clientID)
+	fields := []Field{zap.String("event", fmt.Sprintf("authz_shadow_fail:%s,%s", user, clientID))}
+	for _, opt := range options {
+		fields = append(fields, opt...)
+	}
+	a.l.Warn(msg, fields...)
+}
+
 func (a *SecurityLogger) AuthzFailureInsufficientPermissions(user, action, api string, options ...Option) {
 	msg := fmt.Sprintf("User %s tried to perform `%s` on the `%s` API without enough permissions", user, action, api)
 	fields := []Field{zap.String("event", fmt.Sprintf("authz_fail:%s,%s", user, api))}
@@ -0,0 +1,34 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+// Run calls purge every interval until ctx is cancelled, logging how many
+// rows were purged and the failures. what describes the purged rows in the
+// logs, such as "decisions older than 720h0m0s".
+func Run(ctx context.Context, interval time.Duration, what string, purge func(context.Context) (int64, error), logger logging.LoggerInterface) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := purge(ctx)
+		if err != nil {
+			logger.Errorf("failed to purge %s: %v", what, err)
+		} else if n > 0 {
+			logger.Infof("purged %d %s", n, what)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package signing
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignerVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"session":{}}`)
+
+	current, _ := NewSigner([]string{"current"}, 5*time.Minute)
+	previous, _ := NewSigner([]string{"previous"}, 5*time.Minute)
+	other, _ := NewSigner([]string{"other"}, 5*time.Minute)
+
+	s, err := NewSigner([]string{"current", "previous"}, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.now = func() time.Time { return now }
+
+	ts, sig := current.Sign(now, body)
+	prevTs, prevSig := previous.Sign(now, body)
+	_, otherSig := other.Sign(now, body)
+	oldTs, oldSig := current.Sign(now.Add(-6*time.Minute), body)
+	futureTs, futureSig := current.Sign(now.Add(6*time.Minute), body)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		body      []byte
+
+		expectedError error
+	}{
+		{name: "Current secret", timestamp: ts, signature: sig, body: body},
+		{name: "Previous secret during rotation", timestamp: prevTs, signature: prevSig, body: body},
+		{name: "Missing headers", body: body, expectedError: ErrMissingSignature},
+		{name: "Malformed timestamp", timestamp: "yesterday", signature: sig, body: body, expectedError: ErrInvalidTimestamp},
+		{name: "Replayed request", timestamp: oldTs, signature: oldSig, body: body, expectedError: ErrExpiredTimestamp},
+		{name: "Timestamp in the future", timestamp: futureTs, signature: futureSig, body: body, expectedError: ErrExpiredTimestamp},
+		{name: "Unknown secret", timestamp: ts, signature: otherSig, body: body, expectedError: ErrInvalidSignature},
+		{name: "Tampered body", timestamp: ts, signature: sig, body: []byte(`{}`), expectedError: ErrInvalidSignature},
+		{name: "Timestamp not covered by signature", timestamp: strconv.FormatInt(now.Unix()+1, 10), signature: sig, body: body, expectedError: ErrInvalidSignature},
+		{name: "Unknown version", timestamp: ts, signature: "v2=" + sig[3:], body: body, expectedError: ErrInvalidSignature},
+		{name: "Malformed signature", timestamp: ts, signature: "v1=zz", body: body, expectedError: ErrInvalidSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Verify(test.timestamp, test.signature, test.body)
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets []string
+		window  time.Duration
+
+		expectedError error
+	}{
+		{name: "Two secrets", secrets: []string{"a", "b"}, window: time.Minute},
+		{name: "No secret", window: time.Minute, expectedError: ErrInvalidConfig},
+		{name: "Too many secrets", secrets: []string{"a", "b", "c"}, window: time.Minute, expectedError: ErrInvalidConfig},
+		{name: "Empty secret", secrets: []string{"a", ""}, window: time.Minute, expectedError: ErrInvalidConfig},
+		{name: "Non positive window", secrets: []string{"a"}, expectedError: ErrInvalidConfig},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewSigner(test.secrets, test.window)
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// SetGroupAttributes adds attributes to a group, overwriting the values of
+// the existing keys, and returns every attribute of the group.
+func (s *Storage) SetGroupAttributes(ctx context.Context, groupID string, attributes map[string]string) (map[string]string, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.SetGroupAttributes")
+	defer span.End()
+
+	value, err := attributesValue(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateAttributes(ctx, groupID, sq.Expr("attributes || ?::jsonb", value))
+}
+
+// RemoveGroupAttributes removes attributes from a group, missing keys are
+// ignored, and returns the remaining attributes of the group.
+func (s *Storage) RemoveGroupAttributes(ctx context.Context, groupID string, keys []string) (map[string]string, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveGroupAttributes")
+	defer span.End()
+
+	expr := "attributes" + strings.Repeat(" - ?::text", len(keys))
+	args := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		args = append(args, k)
+	}
+
+	return s.updateAttributes(ctx, groupID, sq.Expr(expr, args...))
+}
+
+// updateAttributes sets the attributes of a group to the result of an
+// expression over its current attributes.
+func (s *Storage) updateAttributes(ctx context.Context, groupID string, expr sq.Sqlizer) (map[string]string, error) {
+	var attributes map[string]string
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		current, err := s.lockGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		err = s.db.Statement(ctx).
+			Update("groups").
+			Set("attributes", expr).
+			Set("updated_at", time.Now().UTC()).
+			Where(sq.Eq{"id": groupID}).
+			Suffix("RETURNING attributes").
+			QueryRowContext(ctx).
+			Scan((*attributesColumn)(&attributes))
+		if err != nil {
+			return fmt.Errorf("failed to update group attributes: %v", err)
+		}
+
+		if before, after := changedValues(stringValues(current.Attributes), stringValues(attributes)); before != nil {
+			err := s.record(ctx, &types.HistoryEntry{
+				TenantID: current.TenantId,
+				GroupID:  groupID,
+				Action:   types.HistoryGroupAttributesChanged,
+				Before:   before,
+				After:    after,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindGroup, GroupID: groupID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+// attributesValue encodes attributes for a jsonb column.
+func attributesValue(attributes map[string]string) (string, error) {
+	if len(attributes) == 0 {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(attributes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %v", err)
+	}
+	return string(b), nil
+}
+
+// attributesColumn scans the jsonb attributes column, empty attributes are
+// scanned as nil.
+type attributesColumn map[string]string
+
+func (a *attributesColumn) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported attributes type %T", src)
+	}
+
+	attributes := make(map[string]string)
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		return fmt.Errorf("failed to decode attributes: %v", err)
+	}
+
+	*a = nil
+	if len(attributes) > 0 {
+		*a = attributes
+	}
+	return nil
+}
@@ -10,34 +10,20 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
 )
 
-// GetAllowedApps retrieves all application IDs allowed for a specific group.
-func (s *Storage) GetAllowedApps(ctx context.Context, groupID string) ([]string, error) {
+// GetAllowedApps retrieves a page of the application IDs allowed for a
+// specific group, matching the filter.
+func (s *Storage) GetAllowedApps(ctx context.Context, groupID string, filter *types.ListFilter) ([]string, *types.Page, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetAllowedApps")
 	defer span.End()
 
-	rows, err := s.db.Statement(ctx).
-		Select("application_id").
-		From("application_groups").
-		Where(sq.Eq{"group_id": groupID}).
-		OrderBy("application_id ASC").
-		QueryContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query allowed apps: %v", err)
-	}
-	defer rows.Close()
-
-	appIDs, err := scanApps(rows)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scan allowed apps: %v", err)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating allowed apps: %v", err)
-	}
+	filter = listFilter(filter)
+	where := append(appListing.where(filter), sq.Eq{"group_id": groupID}, tenantFilter(ctx, "tenant_id"))
 
-	return appIDs, nil
+	return s.listGrants(ctx, appListing, where, filter)
 }
 
 // AddAllowedApp adds a single application to the allowed list for a group.
@@ -47,22 +33,28 @@ func (s *Storage) AddAllowedApp(ctx context.Context, groupID string, appID strin
 
 	now := time.Now().UTC()
 
-	_, err := s.db.Statement(ctx).
-		Insert("application_groups").
-		Columns("group_id", "application_id", "tenant_id", "created_at", "updated_at").
-		Values(groupID, appID, "default", now, now).
-		ExecContext(ctx)
-	if err != nil {
-		if IsDuplicateKeyError(err) {
-			return WrapDuplicateKeyError(err, "app already allowed for group")
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := s.db.Statement(ctx).
+			Insert("application_groups").
+			Columns("group_id", "application_id", "tenant_id", "created_at", "updated_at").
+			Values(groupID, appID, memberTenant(ctx, groupID), now, now).
+			ExecContext(ctx)
+		if err != nil {
+			if IsDuplicateKeyError(err) {
+				return WrapDuplicateKeyError(err, "app already allowed for group")
+			}
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "group does not exist")
+			}
+			return fmt.Errorf("failed to insert allowed app: %v", err)
 		}
-		if IsForeignKeyViolation(err) {
-			return WrapForeignKeyError(err, "group does not exist")
+
+		if err := s.record(ctx, grantEntries(types.HistoryAppGranted, []string{groupID}, []string{appID})...); err != nil {
+			return err
 		}
-		return fmt.Errorf("failed to insert allowed app: %v", err)
-	}
 
-	return nil
+		return s.notify(ctx, &Change{Kind: ChangeKindAppGrants, GroupID: groupID, AppIDs: []string{appID}})
+	})
 }
 
 // AddAllowedApps adds multiple applications to the allowed list for a group.
@@ -75,24 +67,40 @@ func (s *Storage) AddAllowedApps(ctx context.Context, groupID string, appIDs []s
 	}
 
 	now := time.Now().UTC()
-	insert := s.db.Statement(ctx).
-		Insert("application_groups").
-		Columns("group_id", "application_id", "tenant_id", "created_at", "updated_at").
-		Suffix("ON CONFLICT DO NOTHING")
 
-	for _, appID := range appIDs {
-		insert = insert.Values(groupID, appID, "default", now, now)
-	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		insert := s.db.Statement(ctx).
+			Insert("application_groups").
+			Columns("group_id", "application_id", "tenant_id", "created_at", "updated_at").
+			Suffix("ON CONFLICT DO NOTHING RETURNING application_id")
 
-	_, err := insert.ExecContext(ctx)
-	if err != nil {
-		if IsForeignKeyViolation(err) {
-			return WrapForeignKeyError(err, "group does not exist")
+		tenantID := memberTenant(ctx, groupID)
+		for _, appID := range appIDs {
+			insert = insert.Values(groupID, appID, tenantID, now, now)
+		}
+
+		rows, err := insert.QueryContext(ctx)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "group does not exist")
+			}
+			return fmt.Errorf("failed to insert allowed apps: %v", err)
+		}
+
+		granted, err := scanIDs(rows)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "group does not exist")
+			}
+			return err
+		}
+
+		if err := s.record(ctx, grantEntries(types.HistoryAppGranted, []string{groupID}, granted)...); err != nil {
+			return err
 		}
-		return fmt.Errorf("failed to insert allowed apps: %v", err)
-	}
 
-	return nil
+		return s.notify(ctx, &Change{Kind: ChangeKindAppGrants, GroupID: groupID, AppIDs: appIDs})
+	})
 }
 
 // RemoveAllowedApp removes a single application from the allowed list for a group.
@@ -100,15 +108,28 @@ func (s *Storage) RemoveAllowedApp(ctx context.Context, groupID string, appID st
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveAllowedApp")
 	defer span.End()
 
-	_, err := s.db.Statement(ctx).
-		Delete("application_groups").
-		Where(sq.Eq{"group_id": groupID, "application_id": appID}).
-		ExecContext(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to remove allowed app: %v", err)
-	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("application_groups").
+			Where(sq.Eq{"group_id": groupID, "application_id": appID}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix("RETURNING application_id").
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove allowed app: %v", err)
+		}
+
+		revoked, err := scanIDs(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := s.record(ctx, grantEntries(types.HistoryAppRevoked, []string{groupID}, revoked)...); err != nil {
+			return err
+		}
 
-	return nil
+		return s.notify(ctx, &Change{Kind: ChangeKindAppGrants, GroupID: groupID, AppIDs: []string{appID}})
+	})
 }
 
 // RemoveAllowedApps removes all applications from the allowed list for a group and returns the removed app IDs.
@@ -116,23 +137,37 @@ func (s *Storage) RemoveAllowedApps(ctx context.Context, groupID string) ([]stri
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveAllowedApps")
 	defer span.End()
 
-	rows, err := s.db.Statement(ctx).
-		Delete("application_groups").
-		Where(sq.Eq{"group_id": groupID}).
-		Suffix("RETURNING application_id").
-		QueryContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to remove allowed apps: %v", err)
-	}
-	defer rows.Close()
+	var appIDs []string
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("application_groups").
+			Where(sq.Eq{"group_id": groupID}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix("RETURNING application_id").
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove allowed apps: %v", err)
+		}
+		defer rows.Close()
 
-	appIDs, err := scanApps(rows)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scan allowed apps: %v", err)
-	}
+		appIDs, err = scanApps(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan allowed apps: %v", err)
+		}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating removed apps: %v", err)
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating removed apps: %v", err)
+		}
+
+		if err := s.record(ctx, grantEntries(types.HistoryAppRevoked, []string{groupID}, appIDs)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindAppGrants, GroupID: groupID, AppIDs: appIDs})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return appIDs, nil
@@ -148,56 +183,119 @@ func (s *Storage) AddAllowedGroupsForApp(ctx context.Context, appID string, grou
 	}
 
 	now := time.Now().UTC()
-	insert := s.db.Statement(ctx).
-		Insert("application_groups").
-		Columns("group_id", "application_id", "tenant_id", "created_at", "updated_at").
-		Suffix("ON CONFLICT DO NOTHING")
 
-	for _, groupID := range groupIDs {
-		insert = insert.Values(groupID, appID, "default", now, now)
-	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		insert := s.db.Statement(ctx).
+			Insert("application_groups").
+			Columns("group_id", "application_id", "tenant_id", "created_at", "updated_at").
+			Suffix("ON CONFLICT DO NOTHING RETURNING group_id")
 
-	_, err := insert.ExecContext(ctx)
-	if err != nil {
-		if IsForeignKeyViolation(err) {
-			return WrapForeignKeyError(err, "one or more groups do not exist")
+		for _, groupID := range groupIDs {
+			insert = insert.Values(groupID, appID, memberTenant(ctx, groupID), now, now)
+		}
+
+		rows, err := insert.QueryContext(ctx)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "one or more groups do not exist")
+			}
+			return fmt.Errorf("failed to insert allowed groups for app: %v", err)
+		}
+
+		granted, err := scanIDs(rows)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "one or more groups do not exist")
+			}
+			return err
 		}
-		return fmt.Errorf("failed to insert allowed groups for app: %v", err)
-	}
 
-	return nil
+		if err := s.record(ctx, grantEntries(types.HistoryAppGranted, granted, []string{appID})...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindAppGrants, AppIDs: []string{appID}})
+	})
 }
 
-// GetAllowedGroupsForApp retrieves all group IDs that are allowed to access a specific application.
-func (s *Storage) GetAllowedGroupsForApp(ctx context.Context, appID string) ([]string, error) {
+// GetAllowedGroupsForApp retrieves a page of the group IDs that are allowed
+// to access a specific application, matching the filter. The grants of the
+// deleted groups are kept for their restore but not listed.
+func (s *Storage) GetAllowedGroupsForApp(ctx context.Context, appID string, filter *types.ListFilter) ([]string, *types.Page, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetAllowedGroupsForApp")
 	defer span.End()
 
-	rows, err := s.db.Statement(ctx).
-		Select("group_id").
-		From("application_groups").
-		Where(sq.Eq{"application_id": appID}).
-		OrderBy("group_id ASC").
-		QueryContext(ctx)
+	filter = listFilter(filter)
+	where := append(
+		grantedListing.where(filter),
+		sq.Eq{"application_id": appID},
+		sq.Expr("group_id NOT IN (SELECT id FROM groups WHERE deleted_at IS NOT NULL)"),
+		tenantFilter(ctx, "tenant_id"),
+	)
+
+	return s.listGrants(ctx, grantedListing, where, filter)
+}
+
+// listGrants retrieves a page of the keys of the application grants
+// matching the conditions, the application or group IDs depending on the
+// listing.
+func (s *Storage) listGrants(ctx context.Context, l *listing, where sq.And, filter *types.ListFilter) ([]string, *types.Page, error) {
+	query, err := l.page(
+		s.db.Statement(ctx).
+			Select(l.key, "created_at", "updated_at").
+			From("application_groups").
+			Where(where),
+		filter,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query allowed groups for app: %v", err)
+		return nil, nil, err
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query application grants: %v", err)
 	}
 	defer rows.Close()
 
-	groupIDs := make([]string, 0)
+	type grant struct {
+		key                  string
+		createdAt, updatedAt time.Time
+	}
+
+	grants := make([]grant, 0)
 	for rows.Next() {
-		var groupID string
-		if err := rows.Scan(&groupID); err != nil {
-			return nil, fmt.Errorf("failed to scan group ID: %v", err)
+		var g grant
+		if err := rows.Scan(&g.key, &g.createdAt, &g.updatedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan application grant: %v", err)
 		}
-		groupIDs = append(groupIDs, groupID)
+		grants = append(grants, g)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating allowed groups: %v", err)
+		return nil, nil, fmt.Errorf("error iterating application grants: %v", err)
 	}
 
-	return groupIDs, nil
+	page := new(types.Page)
+	if hasNext(filter, len(grants)) {
+		grants = grants[:filter.Limit]
+		last := grants[len(grants)-1]
+		page.Next = l.cursor(filter, last.key, last.key, last.createdAt, last.updatedAt)
+	}
+
+	if filter.CountTotal {
+		total, err := s.count(ctx, "application_groups", where)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	}
+
+	keys := make([]string, 0, len(grants))
+	for _, g := range grants {
+		keys = append(keys, g.key)
+	}
+
+	return keys, page, nil
 }
 
 // RemoveAllAllowedGroupsForApp removes all groups from the allowed list for an application and returns the removed group IDs.
@@ -205,27 +303,41 @@ func (s *Storage) RemoveAllAllowedGroupsForApp(ctx context.Context, appID string
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveAllAllowedGroupsForApp")
 	defer span.End()
 
-	rows, err := s.db.Statement(ctx).
-		Delete("application_groups").
-		Where(sq.Eq{"application_id": appID}).
-		Suffix("RETURNING group_id").
-		QueryContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to remove allowed groups for app: %v", err)
-	}
-	defer rows.Close()
+	var groupIDs []string
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("application_groups").
+			Where(sq.Eq{"application_id": appID}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix("RETURNING group_id").
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove allowed groups for app: %v", err)
+		}
+		defer rows.Close()
+
+		groupIDs = make([]string, 0)
+		for rows.Next() {
+			var groupID string
+			if err := rows.Scan(&groupID); err != nil {
+				return fmt.Errorf("failed to scan group ID: %v", err)
+			}
+			groupIDs = append(groupIDs, groupID)
+		}
 
-	groupIDs := make([]string, 0)
-	for rows.Next() {
-		var groupID string
-		if err := rows.Scan(&groupID); err != nil {
-			return nil, fmt.Errorf("failed to scan group ID: %v", err)
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating removed groups: %v", err)
 		}
-		groupIDs = append(groupIDs, groupID)
-	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating removed groups: %v", err)
+		if err := s.record(ctx, grantEntries(types.HistoryAppRevoked, groupIDs, []string{appID})...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindAppGrants, AppIDs: []string{appID}})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return groupIDs, nil
@@ -0,0 +1,105 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/canonical/hook-service/internal/db"
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+// ChangesChannel is the Postgres notification channel on which storage
+// announces writes to groups, memberships and app grants.
+const ChangesChannel = "hook_service_changes"
+
+// maxPayloadSize stays below the 8000 bytes Postgres accepts for a payload.
+const maxPayloadSize = 7900
+
+// ChangeKind identifies what a Change touched.
+type ChangeKind string
+
+const (
+	// ChangeKindMemberships means group memberships changed, UserIDs lists
+	// the affected users or is empty when they are not known.
+	ChangeKindMemberships ChangeKind = "memberships"
+	// ChangeKindGroup means a group was updated, deleted or restored.
+	ChangeKindGroup ChangeKind = "group"
+	// ChangeKindAppGrants means apps were granted to or revoked from groups.
+	ChangeKindAppGrants ChangeKind = "app_grants"
+	// ChangeKindResync is never sent, subscribers receive it after
+	// reconnecting as changes may have been missed.
+	ChangeKindResync ChangeKind = "resync"
+)
+
+// Change describes a committed write.
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	GroupID string     `json:"group_id,omitempty"`
+	UserIDs []string   `json:"user_ids,omitempty"`
+	AppIDs  []string   `json:"app_ids,omitempty"`
+}
+
+// ChangeHandlerInterface reacts to changes made by any instance.
+type ChangeHandlerInterface interface {
+	HandleChange(context.Context, *Change)
+}
+
+// notify announces a change on ChangesChannel. Within a transaction the
+// notification is only delivered on commit.
+func (s *Storage) notify(ctx context.Context, c *Change) error {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode change: %v", err)
+	}
+
+	if len(payload) > maxPayloadSize {
+		// Subscribers treat a change without IDs as affecting everything.
+		payload, _ = json.Marshal(&Change{Kind: c.Kind, GroupID: c.GroupID})
+	}
+
+	if err := db.Notify(ctx, s.db, ChangesChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify change: %v", err)
+	}
+
+	return nil
+}
+
+var _ db.NotificationHandlerInterface = (*ChangeSubscriber)(nil)
+
+// ChangeSubscriber decodes the notifications of ChangesChannel and passes
+// them to a ChangeHandlerInterface.
+type ChangeSubscriber struct {
+	handler ChangeHandlerInterface
+
+	logger logging.LoggerInterface
+}
+
+// HandleNotification decodes a notification payload, malformed payloads are
+// logged and dropped.
+func (s *ChangeSubscriber) HandleNotification(ctx context.Context, payload string) {
+	c := new(Change)
+	if err := json.Unmarshal([]byte(payload), c); err != nil {
+		s.logger.Errorf("failed to decode change notification: %v", err)
+		return
+	}
+
+	s.handler.HandleChange(ctx, c)
+}
+
+// HandleReconnect passes a ChangeKindResync change to the handler.
+func (s *ChangeSubscriber) HandleReconnect(ctx context.Context) {
+	s.handler.HandleChange(ctx, &Change{Kind: ChangeKindResync})
+}
+
+// NewChangeSubscriber creates a ChangeSubscriber, use it with DBClient.Listen
+// on ChangesChannel.
+func NewChangeSubscriber(handler ChangeHandlerInterface, logger logging.LoggerInterface) *ChangeSubscriber {
+	s := new(ChangeSubscriber)
+	s.handler = handler
+	s.logger = logger
+	return s
+}
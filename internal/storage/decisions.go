@@ -0,0 +1,149 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// CreateDecisions persists token hook authorization decisions in a single
+// statement.
+func (s *Storage) CreateDecisions(ctx context.Context, decisions ...*types.Decision) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.CreateDecisions")
+	defer span.End()
+
+	if len(decisions) == 0 {
+		return nil
+	}
+
+	insert := s.db.Statement(ctx).
+		Insert("authz_decisions").
+		Columns("created_at", "user_id", "client_id", "grant_types", "audience", "tenant_id", "groups", "allowed", "shadow", "reason", "latency_us")
+
+	for _, d := range decisions {
+		createdAt := d.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now().UTC()
+		}
+		insert = insert.Values(
+			createdAt,
+			d.UserID,
+			d.ClientID,
+			nonNil(d.GrantTypes),
+			nonNil(d.Audience),
+			d.TenantID,
+			nonNil(d.Groups),
+			d.Allowed,
+			d.Shadow,
+			d.Reason,
+			d.Latency.Microseconds(),
+		)
+	}
+
+	if _, err := insert.ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to insert decisions: %v", err)
+	}
+
+	return nil
+}
+
+// ListDecisions retrieves the decisions matching the filter, newest first.
+func (s *Storage) ListDecisions(ctx context.Context, filter *types.DecisionFilter) ([]*types.Decision, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListDecisions")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select("id", "created_at", "user_id", "client_id", "grant_types", "audience", "tenant_id", "groups", "allowed", "shadow", "reason", "latency_us").
+		From("authz_decisions").
+		OrderBy("id DESC")
+
+	if filter.UserID != "" {
+		query = query.Where(sq.Eq{"user_id": filter.UserID})
+	}
+	if filter.ClientID != "" {
+		query = query.Where(sq.Eq{"client_id": filter.ClientID})
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"created_at": filter.Since})
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where(sq.Lt{"created_at": filter.Until})
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where(sq.Lt{"id": filter.BeforeID})
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query decisions: %v", err)
+	}
+	defer rows.Close()
+
+	m := pgtype.NewMap()
+	decisions := make([]*types.Decision, 0)
+	for rows.Next() {
+		d := new(types.Decision)
+		var latency int64
+		err := rows.Scan(
+			&d.ID,
+			&d.CreatedAt,
+			&d.UserID,
+			&d.ClientID,
+			m.SQLScanner(&d.GrantTypes),
+			m.SQLScanner(&d.Audience),
+			&d.TenantID,
+			m.SQLScanner(&d.Groups),
+			&d.Allowed,
+			&d.Shadow,
+			&d.Reason,
+			&latency,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan decision: %v", err)
+		}
+		d.Latency = time.Duration(latency) * time.Microsecond
+		decisions = append(decisions, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating decisions: %v", err)
+	}
+
+	return decisions, nil
+}
+
+// PurgeDecisions deletes the decisions recorded before the given time and
+// returns how many were deleted.
+func (s *Storage) PurgeDecisions(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.PurgeDecisions")
+	defer span.End()
+
+	result, err := s.db.Statement(ctx).
+		Delete("authz_decisions").
+		Where(sq.Lt{"created_at": before}).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge decisions: %v", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// nonNil avoids inserting NULL into the NOT NULL array columns.
+func nonNil(vs []string) []string {
+	if vs == nil {
+		return []string{}
+	}
+	return vs
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// ListDeletedGroups retrieves the deleted groups of the tenant scope, or of
+// every tenant, most recently deleted first.
+func (s *Storage) ListDeletedGroups(ctx context.Context) ([]*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListDeletedGroups")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at", "deleted_at").
+		From("groups").
+		Where(sq.NotEq{"deleted_at": nil}).
+		Where(tenantFilter(ctx, "tenant_id")).
+		OrderBy("deleted_at DESC", "id").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted groups: %v", err)
+	}
+	defer rows.Close()
+
+	groups := make([]*types.Group, 0)
+	for rows.Next() {
+		group, err := scanDeletedGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %v", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted groups: %v", err)
+	}
+
+	return groups, nil
+}
+
+// RestoreGroup clears the deletion of a group, bringing back its members,
+// subgroups and app grants. It fails with ErrDuplicateKey when another group
+// took its name in the meantime.
+func (s *Storage) RestoreGroup(ctx context.Context, id string) (*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.RestoreGroup")
+	defer span.End()
+
+	var group *types.Group
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		row := s.db.Statement(ctx).
+			Update("groups").
+			Set("deleted_at", nil).
+			Set("updated_at", time.Now().UTC()).
+			Where(sq.Eq{"id": id}).
+			Where(sq.NotEq{"deleted_at": nil}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix("RETURNING id, name, tenant_id, description, type, attributes, created_at, updated_at").
+			QueryRowContext(ctx)
+
+		var err error
+		group, err = scanGroup(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			if IsDuplicateKeyError(err) {
+				return WrapDuplicateKeyError(err, "group name already exists in the tenant")
+			}
+			return fmt.Errorf("failed to restore group: %v", err)
+		}
+
+		err = s.record(ctx, &types.HistoryEntry{
+			TenantID: group.TenantId,
+			GroupID:  id,
+			Action:   types.HistoryGroupRestored,
+			After:    map[string]interface{}{"name": group.Name},
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindGroup, GroupID: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// PurgeGroups permanently deletes the groups deleted before the given time,
+// with their members, subgroups and app grants, and returns how many were
+// purged.
+func (s *Storage) PurgeGroups(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.PurgeGroups")
+	defer span.End()
+
+	var purged int64
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("groups").
+			Where(sq.Lt{"deleted_at": before}).
+			Suffix("RETURNING id, tenant_id, name").
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to purge groups: %v", err)
+		}
+		defer rows.Close()
+
+		entries := make([]*types.HistoryEntry, 0)
+		for rows.Next() {
+			var id, tenantID, name string
+			if err := rows.Scan(&id, &tenantID, &name); err != nil {
+				return fmt.Errorf("failed to scan purged group: %v", err)
+			}
+			entries = append(entries, &types.HistoryEntry{
+				TenantID: tenantID,
+				GroupID:  id,
+				Action:   types.HistoryGroupPurged,
+				Before:   map[string]interface{}{"name": name},
+			})
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating purged groups: %v", err)
+		}
+
+		purged = int64(len(entries))
+		return s.record(ctx, entries...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// scanDeletedGroup scans a database row into a Group struct along with its
+// deletion time.
+func scanDeletedGroup(row sq.RowScanner) (*types.Group, error) {
+	group := &types.Group{}
+	err := row.Scan(
+		&group.ID,
+		&group.Name,
+		&group.TenantId,
+		&group.Description,
+		&group.Type,
+		(*attributesColumn)(&group.Attributes),
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// directoryColumn is a column a SCIM filter compares.
+type directoryColumn struct {
+	expr string
+	// text columns are not present when empty.
+	text bool
+}
+
+var (
+	userDirectory = map[string]directoryColumn{
+		"id":           {expr: "id::text", text: true},
+		"user_name":    {expr: "user_name", text: true},
+		"external_id":  {expr: "external_id", text: true},
+		"display_name": {expr: "display_name", text: true},
+		"given_name":   {expr: "given_name", text: true},
+		"family_name":  {expr: "family_name", text: true},
+		"email":        {expr: "email", text: true},
+		"active":       {expr: "active"},
+		"created_at":   {expr: "created_at"},
+		"updated_at":   {expr: "updated_at"},
+	}
+	groupDirectory = map[string]directoryColumn{
+		"id":         {expr: "groups.id::text", text: true},
+		"name":       {expr: "groups.name", text: true},
+		"created_at": {expr: "groups.created_at"},
+		"updated_at": {expr: "groups.updated_at"},
+	}
+	// memberDirectory describes the members as the SCIM groups list them,
+	// members without a SCIM user are listed by their user ID.
+	memberDirectory = map[string]directoryColumn{
+		types.MemberValue:   {expr: "COALESCE(u.id::text, gm.user_id)", text: true},
+		types.MemberDisplay: {expr: "COALESCE(NULLIF(u.display_name, ''), u.user_name, gm.user_id)", text: true},
+		types.MemberType:    {expr: "CASE WHEN u.id IS NULL THEN '' ELSE 'User' END", text: true},
+	}
+)
+
+// memberExists matches the groups having a member matching the condition.
+const memberExists = "EXISTS (SELECT 1 FROM group_members gm LEFT JOIN users u ON lower(u.user_name) = lower(gm.user_id) WHERE gm.group_id = groups.id AND %s)"
+
+// SearchUsers returns a page of the users matching the filter, ordered by
+// user name, and the number of matching users.
+func (s *Storage) SearchUsers(ctx context.Context, filter *types.DirectoryFilter) ([]*types.User, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.SearchUsers")
+	defer span.End()
+
+	where, err := directoryWhere(filter.Where, userDirectory)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.count(ctx, "users", where)
+	if err != nil || total == 0 || filter.Limit == 0 {
+		return []*types.User{}, total, err
+	}
+
+	users, err := s.queryUsers(ctx, s.db.Statement(ctx).
+		Select(userColumns...).
+		From("users").
+		Where(where).
+		OrderBy("user_name ASC", "id ASC").
+		Offset(filter.Offset).
+		Limit(filter.Limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// SearchGroups returns a page of the groups of the tenant scope matching the
+// filter, ordered by name, and the number of matching groups.
+func (s *Storage) SearchGroups(ctx context.Context, filter *types.DirectoryFilter) ([]*types.Group, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.SearchGroups")
+	defer span.End()
+
+	cond, err := directoryWhere(filter.Where, groupDirectory)
+	if err != nil {
+		return nil, 0, err
+	}
+	where := append(cond, sq.Eq{"deleted_at": nil}, tenantFilter(ctx, "tenant_id"))
+
+	total, err := s.count(ctx, "groups", where)
+	if err != nil || total == 0 || filter.Limit == 0 {
+		return []*types.Group{}, total, err
+	}
+
+	rows, err := s.db.Statement(ctx).
+		Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at").
+		From("groups").
+		Where(where).
+		OrderBy("name ASC", "id ASC").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query groups: %v", err)
+	}
+	defer rows.Close()
+
+	groups := make([]*types.Group, 0, filter.Limit)
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan group: %v", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating groups: %v", err)
+	}
+
+	return groups, total, nil
+}
+
+// ListUsersInGroups returns the user IDs of the members of each group, owners
+// included, ordered by user ID.
+func (s *Storage) ListUsersInGroups(ctx context.Context, groupIDs []string) (map[string][]string, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListUsersInGroups")
+	defer span.End()
+
+	members := make(map[string][]string, len(groupIDs))
+	if len(groupIDs) == 0 {
+		return members, nil
+	}
+
+	rows, err := s.db.Statement(ctx).
+		Select("group_id", "user_id").
+		From("group_members").
+		Where(sq.Eq{"group_id": groupIDs}).
+		Where(tenantFilter(ctx, "tenant_id")).
+		OrderBy("group_id ASC", "user_id ASC").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group members: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID, userID string
+		if err := rows.Scan(&groupID, &userID); err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %v", err)
+		}
+		members[groupID] = append(members[groupID], userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating group members: %v", err)
+	}
+
+	return members, nil
+}
+
+// directoryWhere returns the conditions of a SCIM filter on the columns.
+func directoryWhere(c *types.Condition, columns map[string]directoryColumn) (sq.And, error) {
+	if c == nil {
+		return sq.And{}, nil
+	}
+
+	cond, err := directoryCondition(c, columns)
+	if err != nil {
+		return nil, err
+	}
+	return sq.And{cond}, nil
+}
+
+func directoryCondition(c *types.Condition, columns map[string]directoryColumn) (sq.Sqlizer, error) {
+	switch c.Op {
+	case types.CondAnd, types.CondOr:
+		operands := make([]sq.Sqlizer, 0, len(c.Operands))
+		for _, o := range c.Operands {
+			cond, err := directoryCondition(o, columns)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, cond)
+		}
+		if c.Op == types.CondAnd {
+			return sq.And(operands), nil
+		}
+		return sq.Or(operands), nil
+	case types.CondNot:
+		if len(c.Operands) != 1 {
+			return nil, fmt.Errorf("condition %s needs one operand", c.Op)
+		}
+		return negate(directoryCondition(c.Operands[0], columns))
+	case types.CondMember:
+		if len(c.Operands) != 1 {
+			return nil, fmt.Errorf("condition %s needs one operand", c.Op)
+		}
+		cond, err := directoryCondition(c.Operands[0], memberDirectory)
+		if err != nil {
+			return nil, err
+		}
+		query, args, err := cond.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		return sq.Expr(fmt.Sprintf(memberExists, query), args...), nil
+	}
+
+	column, ok := columns[c.Column]
+	if !ok {
+		return nil, fmt.Errorf("unknown column %q", c.Column)
+	}
+
+	present := sq.Sqlizer(sq.Expr(column.expr + " IS NOT NULL"))
+	if column.text {
+		present = sq.Expr(column.expr + " <> ''")
+	}
+
+	switch c.Op {
+	case types.CondPresent:
+		return present, nil
+	case types.CondNe:
+		eq := *c
+		eq.Op = types.CondEq
+		return negate(directoryCondition(&eq, columns))
+	}
+
+	expr, placeholder := column.expr, "?"
+	if _, ok := c.Value.(string); ok && !c.CaseExact {
+		expr, placeholder = "lower("+expr+")", "lower(?)"
+	}
+
+	switch c.Op {
+	case types.CondEq:
+		return sq.And{present, sq.Expr(expr+" = "+placeholder, c.Value)}, nil
+	case types.CondGt:
+		return sq.And{present, sq.Expr(expr+" > "+placeholder, c.Value)}, nil
+	case types.CondGe:
+		return sq.And{present, sq.Expr(expr+" >= "+placeholder, c.Value)}, nil
+	case types.CondLt:
+		return sq.And{present, sq.Expr(expr+" < "+placeholder, c.Value)}, nil
+	case types.CondLe:
+		return sq.And{present, sq.Expr(expr+" <= "+placeholder, c.Value)}, nil
+	case types.CondContain, types.CondStart, types.CondEnd:
+		value, ok := c.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("condition %s needs a string", c.Op)
+		}
+
+		pattern := likeEscaper.Replace(value)
+		switch c.Op {
+		case types.CondContain:
+			pattern = "%" + pattern + "%"
+		case types.CondStart:
+			pattern = pattern + "%"
+		case types.CondEnd:
+			pattern = "%" + pattern
+		}
+
+		like := "ILIKE"
+		if c.CaseExact {
+			like = "LIKE"
+		}
+		return sq.And{present, sq.Expr(column.expr+" "+like+" ? ESCAPE '\\'", pattern)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown condition %q", c.Op)
+}
+
+func negate(cond sq.Sqlizer, err error) (sq.Sqlizer, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := cond.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	return sq.Expr("NOT ("+query+")", args...), nil
+}
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"maps"
 	"slices"
-	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -21,18 +20,29 @@ import (
 
 const DefaultTenantID = "default"
 
-// ListGroups retrieves all groups from the database.
-func (s *Storage) ListGroups(ctx context.Context) ([]*types.Group, error) {
+// ListGroups retrieves a page of the groups of the tenant scope, or of every
+// tenant, matching the filter.
+func (s *Storage) ListGroups(ctx context.Context, filter *types.ListFilter) ([]*types.Group, *types.Page, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListGroups")
 	defer span.End()
 
-	rows, err := s.db.Statement(ctx).
-		Select("id", "name", "tenant_id", "description", "type", "created_at", "updated_at").
-		From("groups").
-		OrderBy("name ASC").
-		QueryContext(ctx)
+	filter = listFilter(filter)
+
+	where := append(groupListing.where(filter), sq.Eq{"deleted_at": nil}, tenantFilter(ctx, "tenant_id"))
+	query, err := groupListing.page(
+		s.db.Statement(ctx).
+			Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at").
+			From("groups").
+			Where(where),
+		filter,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query groups: %v", err)
+		return nil, nil, fmt.Errorf("failed to query groups: %v", err)
 	}
 	defer rows.Close()
 
@@ -40,19 +50,35 @@ func (s *Storage) ListGroups(ctx context.Context) ([]*types.Group, error) {
 	for rows.Next() {
 		group, err := scanGroup(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan group: %v", err)
+			return nil, nil, fmt.Errorf("failed to scan group: %v", err)
 		}
 		groups = append(groups, group)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating groups: %v", err)
+		return nil, nil, fmt.Errorf("error iterating groups: %v", err)
 	}
 
-	return groups, nil
+	page := new(types.Page)
+	if hasNext(filter, len(groups)) {
+		groups = groups[:filter.Limit]
+		last := groups[len(groups)-1]
+		page.Next = groupListing.cursor(filter, last.ID, last.Name, last.CreatedAt, last.UpdatedAt)
+	}
+
+	if filter.CountTotal {
+		total, err := s.count(ctx, "groups", where)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	}
+
+	return groups, page, nil
 }
 
-// CreateGroup inserts a new group into the database.
+// CreateGroup inserts a new group into the database, in the tenant scope
+// when set.
 func (s *Storage) CreateGroup(ctx context.Context, group *types.Group) (*types.Group, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.CreateGroup")
 	defer span.End()
@@ -62,31 +88,60 @@ func (s *Storage) CreateGroup(ctx context.Context, group *types.Group) (*types.G
 		return nil, fmt.Errorf("failed to generate uuid: %v", err)
 	}
 
-	var createdAt, updatedAt time.Time
+	tenantID := group.TenantId
+	if scoped, ok := TenantFromContext(ctx); ok {
+		tenantID = scoped
+	}
+	if tenantID == "" {
+		tenantID = DefaultTenantID
+	}
 
-	err = s.db.Statement(ctx).
-		Insert("groups").
-		Columns("id", "name", "tenant_id", "description", "type").
-		Values(id, group.Name, group.TenantId, group.Description, group.Type).
-		Suffix("RETURNING created_at, updated_at").
-		QueryRowContext(ctx).
-		Scan(&createdAt, &updatedAt)
+	attributes, err := attributesValue(group.Attributes)
 	if err != nil {
-		if IsDuplicateKeyError(err) {
-			return nil, WrapDuplicateKeyError(err, "group name already exists")
-		}
-		return nil, fmt.Errorf("failed to insert group: %v", err)
+		return nil, err
 	}
 
-	return &types.Group{
+	created := &types.Group{
 		ID:          id.String(),
 		Name:        group.Name,
-		TenantId:    group.TenantId,
+		TenantId:    tenantID,
 		Description: group.Description,
 		Type:        group.Type,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-	}, nil
+		Attributes:  group.Attributes,
+	}
+
+	err = s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.db.Statement(ctx).
+			Insert("groups").
+			Columns("id", "name", "tenant_id", "description", "type", "attributes").
+			Values(id, group.Name, tenantID, group.Description, group.Type, sq.Expr("?::jsonb", attributes)).
+			Suffix("RETURNING created_at, updated_at").
+			QueryRowContext(ctx).
+			Scan(&created.CreatedAt, &created.UpdatedAt)
+		if err != nil {
+			if IsDuplicateKeyError(err) {
+				return WrapDuplicateKeyError(err, "group name already exists in the tenant")
+			}
+			return fmt.Errorf("failed to insert group: %v", err)
+		}
+
+		after := groupValues(created)
+		if len(group.Attributes) > 0 {
+			after["attributes"] = stringValues(group.Attributes)
+		}
+
+		return s.record(ctx, &types.HistoryEntry{
+			TenantID: tenantID,
+			GroupID:  created.ID,
+			Action:   types.HistoryGroupCreated,
+			After:    after,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 // GetGroup retrieves a single group by ID.
@@ -95,9 +150,10 @@ func (s *Storage) GetGroup(ctx context.Context, id string) (*types.Group, error)
 	defer span.End()
 
 	row := s.db.Statement(ctx).
-		Select("id", "name", "tenant_id", "description", "type", "created_at", "updated_at").
+		Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at").
 		From("groups").
-		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		Where(tenantFilter(ctx, "tenant_id")).
 		QueryRowContext(ctx)
 
 	group, err := scanGroup(row)
@@ -122,9 +178,9 @@ func (s *Storage) GetGroupByName(ctx context.Context, name, tenantID string) (*t
 	}
 
 	row := s.db.Statement(ctx).
-		Select("id", "name", "tenant_id", "description", "type", "created_at", "updated_at").
+		Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at").
 		From("groups").
-		Where(sq.Eq{"name": name, "tenant_id": tenantID}).
+		Where(sq.Eq{"name": name, "tenant_id": tenantID, "deleted_at": nil}).
 		QueryRowContext(ctx)
 
 	group, err := scanGroup(row)
@@ -138,32 +194,49 @@ func (s *Storage) GetGroupByName(ctx context.Context, name, tenantID string) (*t
 	return group, nil
 }
 
-// UpdateGroup updates an existing group's mutable fields.
+// UpdateGroup updates an existing group's mutable fields, its attributes are
+// left unchanged.
 func (s *Storage) UpdateGroup(ctx context.Context, id string, group *types.Group) (*types.Group, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.UpdateGroup")
 	defer span.End()
 
 	now := time.Now().UTC()
 
-	result, err := s.db.Statement(ctx).
-		Update("groups").
-		Set("name", group.Name).
-		Set("description", group.Description).
-		Set("type", group.Type).
-		Set("updated_at", now).
-		Where(sq.Eq{"id": id}).
-		ExecContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update group: %v", err)
-	}
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		current, err := s.lockGroup(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rows affected: %v", err)
-	}
+		_, err = s.db.Statement(ctx).
+			Update("groups").
+			Set("name", group.Name).
+			Set("description", group.Description).
+			Set("type", group.Type).
+			Set("updated_at", now).
+			Where(sq.Eq{"id": id}).
+			ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update group: %v", err)
+		}
 
-	if rowsAffected == 0 {
-		return nil, ErrNotFound
+		if before, after := changedValues(groupValues(current), groupValues(group)); after != nil {
+			err := s.record(ctx, &types.HistoryEntry{
+				TenantID: current.TenantId,
+				GroupID:  id,
+				Action:   types.HistoryGroupUpdated,
+				Before:   before,
+				After:    after,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindGroup, GroupID: id})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	updated := *group
@@ -173,20 +246,63 @@ func (s *Storage) UpdateGroup(ctx context.Context, id string, group *types.Group
 	return &updated, nil
 }
 
-// DeleteGroup removes a group from the database.
+// DeleteGroup marks a group as deleted, it is hidden from every read but
+// keeps its members, subgroups and app grants until it is restored or
+// purged.
 func (s *Storage) DeleteGroup(ctx context.Context, id string) error {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.DeleteGroup")
 	defer span.End()
 
-	_, err := s.db.Statement(ctx).
-		Delete("groups").
-		Where(sq.Eq{"id": id}).
-		ExecContext(ctx)
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		var tenantID, name string
+		err := s.db.Statement(ctx).
+			Update("groups").
+			Set("deleted_at", time.Now().UTC()).
+			Where(sq.Eq{"id": id, "deleted_at": nil}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix("RETURNING tenant_id, name").
+			QueryRowContext(ctx).
+			Scan(&tenantID, &name)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to delete group: %v", err)
+		}
+
+		if err == nil {
+			err := s.record(ctx, &types.HistoryEntry{
+				TenantID: tenantID,
+				GroupID:  id,
+				Action:   types.HistoryGroupDeleted,
+				Before:   map[string]interface{}{"name": name},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindGroup, GroupID: id})
+	})
+}
+
+// lockGroup retrieves a group for an update within a transaction, later
+// updates of the group wait for the transaction to end.
+func (s *Storage) lockGroup(ctx context.Context, id string) (*types.Group, error) {
+	row := s.db.Statement(ctx).
+		Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at").
+		From("groups").
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		Where(tenantFilter(ctx, "tenant_id")).
+		Suffix("FOR UPDATE").
+		QueryRowContext(ctx)
+
+	group, err := scanGroup(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("failed to delete group: %v", err)
+		return nil, fmt.Errorf("failed to lock group: %v", err)
 	}
 
-	return nil
+	return group, nil
 }
 
 // AddUsersToGroup adds multiple users to a group.
@@ -211,57 +327,97 @@ func (s *Storage) AddUsersToGroup(ctx context.Context, groupID string, userIDs [
 	}
 
 	now := time.Now().UTC()
+	tenantID := memberTenant(ctx, groupID)
 	insert := s.db.Statement(ctx).
 		Insert("group_members").
 		Columns("group_id", "user_id", "tenant_id", "role", "created_at", "updated_at")
 
 	for _, userID := range unique {
-		insert = insert.Values(groupID, userID, "default", types.RoleMember, now, now)
+		insert = insert.Values(groupID, userID, tenantID, types.RoleMember, now, now)
 	}
 
-	_, err := insert.
-		Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET updated_at = EXCLUDED.updated_at").
-		ExecContext(ctx)
-	if err != nil {
-		if IsForeignKeyViolation(err) {
-			return WrapForeignKeyError(err, "group does not exist")
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := insert.
+			Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET updated_at = EXCLUDED.updated_at " + returningUpsertedMembers).
+			QueryContext(ctx)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "group does not exist")
+			}
+			return fmt.Errorf("failed to insert group members: %v", err)
 		}
-		return fmt.Errorf("failed to insert group members: %v", err)
-	}
 
-	return nil
+		added, err := scanMemberChanges(rows)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "group does not exist")
+			}
+			return err
+		}
+
+		if err := s.record(ctx, addedMembers(added)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, GroupID: groupID, UserIDs: unique})
+	})
 }
 
-// ListUsersInGroup retrieves all user IDs that are members of a group.
-func (s *Storage) ListUsersInGroup(ctx context.Context, groupID string) ([]string, error) {
+// ListUsersInGroup retrieves a page of the members and owners of a group
+// with their role, matching the filter.
+func (s *Storage) ListUsersInGroup(ctx context.Context, groupID string, filter *types.ListFilter) ([]*types.GroupUser, *types.Page, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListUsersInGroup")
 	defer span.End()
 
-	rows, err := s.db.Statement(ctx).
-		Select("user_id").
-		From("group_members").
-		Where(sq.Eq{"group_id": groupID}).
-		OrderBy("user_id ASC").
-		QueryContext(ctx)
+	filter = listFilter(filter)
+
+	where := append(memberListing.where(filter), sq.Eq{"group_id": groupID}, tenantFilter(ctx, "tenant_id"))
+	query, err := memberListing.page(
+		s.db.Statement(ctx).
+			Select("user_id", "role", "tenant_id", "created_at", "updated_at").
+			From("group_members").
+			Where(where),
+		filter,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query group members: %v", err)
+		return nil, nil, fmt.Errorf("failed to query group members: %v", err)
 	}
 	defer rows.Close()
 
-	userIDs := make([]string, 0)
+	users := make([]*types.GroupUser, 0)
 	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
-			return nil, fmt.Errorf("failed to scan user ID: %v", err)
+		user := new(types.GroupUser)
+		if err := rows.Scan(&user.ID, &user.Role, &user.TenantId, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan group member: %v", err)
 		}
-		userIDs = append(userIDs, userID)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating group members: %v", err)
+		return nil, nil, fmt.Errorf("error iterating group members: %v", err)
 	}
 
-	return userIDs, nil
+	page := new(types.Page)
+	if hasNext(filter, len(users)) {
+		users = users[:filter.Limit]
+		last := users[len(users)-1]
+		page.Next = memberListing.cursor(filter, last.ID, last.ID, last.CreatedAt, last.UpdatedAt)
+	}
+
+	if filter.CountTotal {
+		total, err := s.count(ctx, "group_members", where)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	}
+
+	return users, page, nil
 }
 
 // RemoveUsersFromGroup removes specific users from a group.
@@ -269,27 +425,42 @@ func (s *Storage) RemoveUsersFromGroup(ctx context.Context, groupID string, user
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveUsersFromGroup")
 	defer span.End()
 
-	_, err := s.db.Statement(ctx).
-		Delete("group_members").
-		Where(sq.Eq{"group_id": groupID, "user_id": users}).
-		ExecContext(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to remove users from group: %v", err)
-	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("group_members").
+			Where(sq.Eq{"group_id": groupID, "user_id": users}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix(returningChangedMembers).
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove users from group: %v", err)
+		}
 
-	return nil
+		removed, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := s.record(ctx, removedMembers(removed)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, GroupID: groupID, UserIDs: users})
+	})
 }
 
-// GetGroupsForUser retrieves all groups that a user belongs to.
+// GetGroupsForUser retrieves all groups that a user belongs to, directly or
+// through nested groups.
 func (s *Storage) GetGroupsForUser(ctx context.Context, userID string) ([]*types.Group, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetGroupsForUser")
 	defer span.End()
 
 	rows, err := s.db.Statement(ctx).
-		Select("g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.created_at", "g.updated_at").
+		Select("g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.attributes", "g.created_at", "g.updated_at").
+		Prefix(userGroupsCTE, userID).
 		From("groups g").
-		Join("group_members gm ON g.id = gm.group_id").
-		Where(sq.Eq{"gm.user_id": userID}).
+		Join("user_groups ug ON g.id = ug.group_id").
+		Where(tenantFilter(ctx, "g.tenant_id")).
 		OrderBy("g.name ASC").
 		QueryContext(ctx)
 	if err != nil {
@@ -326,43 +497,71 @@ func (s *Storage) UpdateGroupsForUser(ctx context.Context, userID string, groupI
 		uniqueGroupIDsMap[id] = struct{}{}
 	}
 	uniqueGroupIDs := slices.Collect(maps.Keys(uniqueGroupIDsMap))
+	change := &Change{Kind: ChangeKindMemberships, UserIDs: []string{userID}}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		// Remove groups that are not in the provided list
+		delBuilder := s.db.Statement(ctx).
+			Delete("group_members").
+			Where(sq.Eq{"user_id": userID}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix(returningChangedMembers)
+
+		if len(uniqueGroupIDs) > 0 {
+			delBuilder = delBuilder.Where(sq.NotEq{"group_id": uniqueGroupIDs})
+		}
 
-	// Remove groups that are not in the provided list
-	delBuilder := s.db.Statement(ctx).
-		Delete("group_members").
-		Where(sq.Eq{"user_id": userID})
+		rows, err := delBuilder.QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove old group memberships: %v", err)
+		}
 
-	if len(uniqueGroupIDs) > 0 {
-		delBuilder = delBuilder.Where(sq.NotEq{"group_id": uniqueGroupIDs})
-	}
+		removed, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
 
-	if _, err := delBuilder.ExecContext(ctx); err != nil {
-		return fmt.Errorf("failed to remove old group memberships: %v", err)
-	}
+		if err := s.record(ctx, removedMembers(removed)...); err != nil {
+			return err
+		}
 
-	if len(uniqueGroupIDs) == 0 {
-		return nil
-	}
+		if len(uniqueGroupIDs) == 0 {
+			return s.notify(ctx, change)
+		}
 
-	now := time.Now().UTC()
+		now := time.Now().UTC()
 
-	insert := s.db.Statement(ctx).
-		Insert("group_members").
-		Columns("group_id", "user_id", "tenant_id", "role", "created_at", "updated_at").
-		Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET updated_at = EXCLUDED.updated_at")
+		insert := s.db.Statement(ctx).
+			Insert("group_members").
+			Columns("group_id", "user_id", "tenant_id", "role", "created_at", "updated_at").
+			Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET updated_at = EXCLUDED.updated_at " + returningUpsertedMembers)
 
-	for _, groupID := range uniqueGroupIDs {
-		insert = insert.Values(groupID, userID, "default", types.RoleMember, now, now)
-	}
+		for _, groupID := range uniqueGroupIDs {
+			insert = insert.Values(groupID, userID, memberTenant(ctx, groupID), types.RoleMember, now, now)
+		}
 
-	if _, err := insert.ExecContext(ctx); err != nil {
-		if IsForeignKeyViolation(err) {
-			return WrapForeignKeyError(err, "one or more groups do not exist")
+		rows, err = insert.QueryContext(ctx)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "one or more groups do not exist")
+			}
+			return fmt.Errorf("failed to upsert group memberships: %v", err)
 		}
-		return fmt.Errorf("failed to upsert group memberships: %v", err)
-	}
 
-	return nil
+		added, err := scanMemberChanges(rows)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return WrapForeignKeyError(err, "one or more groups do not exist")
+			}
+			return err
+		}
+
+		if err := s.record(ctx, addedMembers(added)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, change)
+	})
 }
 
 
@@ -372,15 +571,28 @@ func (s *Storage) RemoveUserFromAllGroups(ctx context.Context, userID string) er
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveUserFromAllGroups")
 	defer span.End()
 
-	_, err := s.db.Statement(ctx).
-		Delete("group_members").
-		Where(sq.Eq{"user_id": userID}).
-		ExecContext(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to remove user from all groups: %v", err)
-	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("group_members").
+			Where(sq.Eq{"user_id": userID}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix(returningChangedMembers).
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove user from all groups: %v", err)
+		}
 
-	return nil
+		removed, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := s.record(ctx, removedMembers(removed)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, UserIDs: []string{userID}})
+	})
 }
 
 // ListGroupsByPrefix retrieves all groups whose names start with the given prefix for a tenant.
@@ -395,11 +607,11 @@ func (s *Storage) ListGroupsByPrefix(ctx context.Context, prefix, tenantID strin
 		tenantID = DefaultTenantID
 	}
 
-	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	escaped := likeEscaper.Replace(prefix)
 	rows, err := s.db.Statement(ctx).
-		Select("id", "name", "tenant_id", "description", "type", "created_at", "updated_at").
+		Select("id", "name", "tenant_id", "description", "type", "attributes", "created_at", "updated_at").
 		From("groups").
-		Where(sq.Eq{"tenant_id": tenantID}).
+		Where(sq.Eq{"tenant_id": tenantID, "deleted_at": nil}).
 		Where(sq.Expr("name LIKE ? ESCAPE '\\'", escaped+"%")).
 		OrderBy("name ASC").
 		QueryContext(ctx)
@@ -437,65 +649,103 @@ func (s *Storage) SyncGroupMembers(ctx context.Context, groupID string, userIDs
 		uniqueUserIDsMap[id] = struct{}{}
 	}
 	uniqueUserIDs := slices.Collect(maps.Keys(uniqueUserIDsMap))
+	// The removed members are not known, the change affects every user.
+	change := &Change{Kind: ChangeKindMemberships, GroupID: groupID}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		// Remove members that are not in the provided list
+		delBuilder := s.db.Statement(ctx).
+			Delete("group_members").
+			Where(sq.Eq{"group_id": groupID}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix(returningChangedMembers)
+
+		if len(uniqueUserIDs) > 0 {
+			delBuilder = delBuilder.Where(sq.NotEq{"user_id": uniqueUserIDs})
+		}
 
-	// Remove members that are not in the provided list
-	delBuilder := s.db.Statement(ctx).
-		Delete("group_members").
-		Where(sq.Eq{"group_id": groupID})
+		rows, err := delBuilder.QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove stale group members: %v", err)
+		}
 
-	if len(uniqueUserIDs) > 0 {
-		delBuilder = delBuilder.Where(sq.NotEq{"user_id": uniqueUserIDs})
-	}
+		removed, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
 
-	if _, err := delBuilder.ExecContext(ctx); err != nil {
-		return fmt.Errorf("failed to remove stale group members: %v", err)
-	}
+		if err := s.record(ctx, removedMembers(removed)...); err != nil {
+			return err
+		}
 
-	if len(uniqueUserIDs) == 0 {
-		return nil
-	}
+		if len(uniqueUserIDs) == 0 {
+			return s.notify(ctx, change)
+		}
 
-	now := time.Now().UTC()
+		now := time.Now().UTC()
+		tenantID := memberTenant(ctx, groupID)
 
-	insert := s.db.Statement(ctx).
-		Insert("group_members").
-		Columns("group_id", "user_id", "tenant_id", "role", "created_at", "updated_at").
-		Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET updated_at = EXCLUDED.updated_at")
+		insert := s.db.Statement(ctx).
+			Insert("group_members").
+			Columns("group_id", "user_id", "tenant_id", "role", "created_at", "updated_at").
+			Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET updated_at = EXCLUDED.updated_at " + returningUpsertedMembers)
 
-	for _, userID := range uniqueUserIDs {
-		insert = insert.Values(groupID, userID, "default", types.RoleMember, now, now)
-	}
+		for _, userID := range uniqueUserIDs {
+			insert = insert.Values(groupID, userID, tenantID, types.RoleMember, now, now)
+		}
 
-	if _, err := insert.ExecContext(ctx); err != nil {
-		return fmt.Errorf("failed to upsert group members: %v", err)
-	}
+		rows, err = insert.QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to upsert group members: %v", err)
+		}
 
-	return nil
+		added, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := s.record(ctx, addedMembers(added)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, change)
+	})
 }
 
 const streamTimeout = 30 * time.Second
 
-// StreamGroupsForUser streams all groups that a user belongs to within a specific tenant,
-// calling fn for each group. Returns on the first error (including context cancellation).
-func (s *Storage) StreamGroupsForUser(ctx context.Context, tenantID, userID string, fn func(*types.Group) (error)) error {
+// StreamGroupsForUser streams the groups that a user belongs to within a specific tenant,
+// directly or through nested groups, ordered by name and ID, calling fn for each group. The
+// groups after the cursor are streamed, up to limit of them when it is not 0. Returns on the
+// first error (including context cancellation).
+func (s *Storage) StreamGroupsForUser(ctx context.Context, tenantID, userID string, after *types.Cursor, limit uint64, fn func(*types.Group) error) error {
 	ctx, span := s.tracer.Start(ctx, "storage.Storage.StreamGroupsForUser")
 	defer span.End()
 
 	ctx, cancel := context.WithTimeout(ctx, s.streamTimeout)
 	defer cancel()
 
-	whereClause := sq.Eq{"gm.user_id": userID}
+	whereClause := sq.Eq{}
 	if tenantID != "" {
 		whereClause["g.tenant_id"] = tenantID
 	}
 
-	rows, err := s.db.Statement(ctx).
-		Select("g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.created_at", "g.updated_at").
+	query := s.db.Statement(ctx).
+		Select("g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.attributes", "g.created_at", "g.updated_at").
+		Prefix(userGroupsCTE, userID).
 		From("groups g").
-		Join("group_members gm ON g.id = gm.group_id").
+		Join("user_groups ug ON g.id = ug.group_id").
 		Where(whereClause).
-		OrderBy("g.name ASC").
-		QueryContext(ctx)
+		OrderBy("g.name ASC", "g.id ASC")
+
+	if after != nil {
+		query = query.Where(sq.Expr("(g.name, g.id) > (?, ?)", after.Value, after.ID))
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to query groups for user: %v", err)
 	}
@@ -518,26 +768,95 @@ func (s *Storage) StreamGroupsForUser(ctx context.Context, tenantID, userID stri
 	return nil
 }
 
-// StreamUsersInGroup streams all user IDs that are members of a group within a specific tenant,
-// calling fn for each user ID. Returns on the first error (including context cancellation).
-func (s *Storage) StreamUsersInGroup(ctx context.Context, tenantID, groupID string, fn func(string) (error)) error {
-	ctx, span := s.tracer.Start(ctx, "storage.Storage.StreamUsersInGroup")
+// GetGroupsForUsers retrieves the groups of several users within a specific
+// tenant, directly or through nested groups, with a single query. The groups
+// are keyed by user ID and ordered by name, users without groups are left
+// out.
+func (s *Storage) GetGroupsForUsers(ctx context.Context, tenantID string, userIDs []string) (map[string][]*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetGroupsForUsers")
 	defer span.End()
 
 	ctx, cancel := context.WithTimeout(ctx, s.streamTimeout)
 	defer cancel()
 
-	whereClause := sq.Eq{"group_id": groupID}
+	whereClause := sq.Eq{}
 	if tenantID != "" {
-		whereClause["tenant_id"] = tenantID
+		whereClause["g.tenant_id"] = tenantID
 	}
 
 	rows, err := s.db.Statement(ctx).
-		Select("user_id").
-		From("group_members").
+		Select("ug.user_id", "g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.attributes", "g.created_at", "g.updated_at").
+		Prefix(usersGroupsCTE, userIDs).
+		From("groups g").
+		Join("users_groups ug ON g.id = ug.group_id").
 		Where(whereClause).
-		OrderBy("user_id ASC").
+		OrderBy("ug.user_id ASC", "g.name ASC").
 		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups for users: %v", err)
+	}
+	defer rows.Close()
+
+	groups := make(map[string][]*types.Group)
+	for rows.Next() {
+		var userID string
+		group := &types.Group{}
+		err := rows.Scan(
+			&userID,
+			&group.ID,
+			&group.Name,
+			&group.TenantId,
+			&group.Description,
+			&group.Type,
+			(*attributesColumn)(&group.Attributes),
+			&group.CreatedAt,
+			&group.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %v", err)
+		}
+		groups[userID] = append(groups[userID], group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating groups: %v", err)
+	}
+
+	return groups, nil
+}
+
+// StreamUsersInGroup streams the user IDs that are members of a group within a specific tenant,
+// directly or through nested groups, in order, calling fn for each user ID. The user IDs after
+// the cursor are streamed, up to limit of them when it is not 0. Returns on the first error
+// (including context cancellation).
+func (s *Storage) StreamUsersInGroup(ctx context.Context, tenantID, groupID string, after *types.Cursor, limit uint64, fn func(string) error) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.StreamUsersInGroup")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.streamTimeout)
+	defer cancel()
+
+	whereClause := sq.Eq{}
+	if tenantID != "" {
+		whereClause["gm.tenant_id"] = tenantID
+	}
+
+	query := s.db.Statement(ctx).
+		Select("DISTINCT gm.user_id").
+		Prefix(groupTreeCTE, groupID).
+		From("group_members gm").
+		Join("group_tree t ON gm.group_id = t.group_id").
+		Where(whereClause).
+		OrderBy("gm.user_id ASC")
+
+	if after != nil {
+		query = query.Where(sq.Gt{"gm.user_id": after.ID})
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to query group members: %v", err)
 	}
@@ -560,6 +879,59 @@ func (s *Storage) StreamUsersInGroup(ctx context.Context, tenantID, groupID stri
 	return nil
 }
 
+// StreamMemberships streams the direct memberships matching the filter,
+// ordered by group and user, calling fn for each one. Returns on the first
+// error (including context cancellation).
+func (s *Storage) StreamMemberships(ctx context.Context, filter *types.MembershipFilter, fn func(*types.MembershipEvent) error) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.StreamMemberships")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.streamTimeout)
+	defer cancel()
+
+	whereClause := sq.Eq{}
+	if filter.TenantID != "" {
+		whereClause["gm.tenant_id"] = filter.TenantID
+	}
+	if filter.GroupID != "" {
+		whereClause["gm.group_id"] = filter.GroupID
+	}
+	if filter.UserID != "" {
+		whereClause["gm.user_id"] = filter.UserID
+	}
+	if !filter.IncludeDeleted {
+		whereClause["g.deleted_at"] = nil
+	}
+
+	rows, err := s.db.Statement(ctx).
+		Select("gm.tenant_id", "gm.group_id", "gm.user_id", "gm.role", "gm.updated_at").
+		From("group_members gm").
+		Join("groups g ON g.id = gm.group_id").
+		Where(whereClause).
+		OrderBy("gm.group_id ASC", "gm.user_id ASC").
+		QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query memberships: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		m := new(types.MembershipEvent)
+		if err := rows.Scan(&m.TenantID, &m.GroupID, &m.UserID, &m.Role, &m.Time); err != nil {
+			return fmt.Errorf("failed to scan membership: %v", err)
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating memberships: %v", err)
+	}
+
+	return nil
+}
+
 // scanGroup scans a database row into a Group struct.
 func scanGroup(row sq.RowScanner) (*types.Group, error) {
 	group := &types.Group{}
@@ -569,6 +941,7 @@ func scanGroup(row sq.RowScanner) (*types.Group, error) {
 		&group.TenantId,
 		&group.Description,
 		&group.Type,
+		(*attributesColumn)(&group.Attributes),
 		&group.CreatedAt,
 		&group.UpdatedAt,
 	)
@@ -0,0 +1,397 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// Sources of the changes, recorded in the history with their actor.
+const (
+	SourceAPI  = "api"
+	SourceSCIM = "scim"
+	SourceCLI  = "cli"
+	// SourceImport is followed by the name of the import driver, as in
+	// "import:salesforce".
+	SourceImport = "import"
+	// SourceSystem is the source of the background jobs, such as the purge
+	// of the deleted groups.
+	SourceSystem = "system"
+)
+
+// Actor is who made a change and where from.
+type Actor struct {
+	// ID is the subject of the caller or the name of the local user running
+	// a command, it is empty when unknown.
+	ID     string
+	Source string
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a copy of ctx recording actor in the history of the
+// changes made with it.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor of the changes made with ctx.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(Actor)
+	return actor, ok
+}
+
+// ListHistory retrieves the history entries matching the filter, newest
+// first.
+func (s *Storage) ListHistory(ctx context.Context, filter *types.HistoryFilter) ([]*types.HistoryEntry, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListHistory")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select("id", "created_at", "tenant_id", "COALESCE(group_id::text, '')", "COALESCE(user_id, '')", "action", "actor", "source", "before", "after").
+		From("group_history").
+		Where(tenantFilter(ctx, "tenant_id")).
+		OrderBy("id DESC")
+
+	if filter.GroupID != "" {
+		query = query.Where(sq.Eq{"group_id": filter.GroupID})
+	}
+	if filter.UserID != "" {
+		query = query.Where(sq.Eq{"user_id": filter.UserID})
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"created_at": filter.Since})
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where(sq.Lt{"created_at": filter.Until})
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where(sq.Lt{"id": filter.BeforeID})
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %v", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*types.HistoryEntry, 0)
+	for rows.Next() {
+		e := new(types.HistoryEntry)
+		err := rows.Scan(
+			&e.ID,
+			&e.CreatedAt,
+			&e.TenantID,
+			&e.GroupID,
+			&e.UserID,
+			&e.Action,
+			&e.Actor,
+			&e.Source,
+			(*historyValues)(&e.Before),
+			(*historyValues)(&e.After),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan history entry: %v", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating history: %v", err)
+	}
+
+	return entries, nil
+}
+
+// historyBatchSize bounds the entries of an insert, below the limit of bind
+// parameters of a statement.
+const historyBatchSize = 1000
+
+// record appends entries to the history with the actor of ctx, and writes
+// the membership and app grant changes to the outbox. Mutations run in
+// s.db.WithTx so that a change, its history and its events are committed
+// together.
+func (s *Storage) record(ctx context.Context, entries ...*types.HistoryEntry) error {
+	for len(entries) > historyBatchSize {
+		if err := s.record(ctx, entries[:historyBatchSize]...); err != nil {
+			return err
+		}
+		entries = entries[historyBatchSize:]
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	actor, _ := ActorFromContext(ctx)
+
+	insert := s.db.Statement(ctx).
+		Insert("group_history").
+		Columns("tenant_id", "group_id", "user_id", "action", "actor", "source", "before", "after")
+	outbox := s.db.Statement(ctx).
+		Insert("outbox_events").
+		Columns("tenant_id", "group_id", "user_id", "type", "actor", "source", "before", "after")
+	events := 0
+
+	for _, e := range entries {
+		before, err := historyValue(e.Before)
+		if err != nil {
+			return err
+		}
+		after, err := historyValue(e.After)
+		if err != nil {
+			return err
+		}
+
+		values := []interface{}{
+			historyTenant(ctx, e),
+			nullString(e.GroupID),
+			nullString(e.UserID),
+			string(e.Action),
+			actor.ID,
+			actor.Source,
+			before,
+			after,
+		}
+
+		insert = insert.Values(values...)
+		if slices.Contains(types.WebhookEventTypes, e.Action) {
+			outbox = outbox.Values(values...)
+			events++
+		}
+	}
+
+	if _, err := insert.ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to record history: %v", err)
+	}
+
+	if events > 0 {
+		if _, err := outbox.ExecContext(ctx); err != nil {
+			return fmt.Errorf("failed to write outbox events: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// historyTenant is the tenant_id value of a history entry: its own tenant,
+// or else the tenant of its group.
+func historyTenant(ctx context.Context, e *types.HistoryEntry) interface{} {
+	if e.TenantID != "" {
+		return e.TenantID
+	}
+	if e.GroupID != "" {
+		return memberTenant(ctx, e.GroupID)
+	}
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		return tenantID
+	}
+	return DefaultTenantID
+}
+
+// historyValue encodes the values of a history entry for a jsonb column.
+func historyValue(values map[string]interface{}) (interface{}, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode history values: %v", err)
+	}
+	return sq.Expr("?::jsonb", string(b)), nil
+}
+
+func nullString(v string) interface{} {
+	if v == "" {
+		return nil
+	}
+	return v
+}
+
+// groupValues are the recorded values of a group.
+func groupValues(g *types.Group) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        g.Name,
+		"description": g.Description,
+		"type":        g.Type.String(),
+	}
+}
+
+// stringValues converts string values, such as attributes, for a history
+// entry.
+func stringValues(m map[string]string) map[string]interface{} {
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[k] = v
+	}
+	return values
+}
+
+// changedValues keeps the values that differ between before and after,
+// both are nil when nothing changed.
+func changedValues(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	b := make(map[string]interface{})
+	a := make(map[string]interface{})
+	for k, v := range before {
+		if w, ok := after[k]; !ok || !reflect.DeepEqual(v, w) {
+			b[k] = v
+		}
+	}
+	for k, v := range after {
+		if w, ok := before[k]; !ok || !reflect.DeepEqual(v, w) {
+			a[k] = v
+		}
+	}
+	if len(a) == 0 && len(b) == 0 {
+		return nil, nil
+	}
+	return b, a
+}
+
+const (
+	// returningUpsertedMembers returns the memberships of an upsert on
+	// group_members, telling the inserted rows from the updated ones.
+	returningUpsertedMembers = "RETURNING group_id, user_id, role, (xmax = 0)"
+	// returningChangedMembers returns the memberships of a delete or update
+	// on group_members.
+	returningChangedMembers = "RETURNING group_id, user_id, role, false"
+)
+
+// memberChange is a membership returned by a mutation of group_members.
+type memberChange struct {
+	GroupID  string
+	UserID   string
+	Role     types.Role
+	Inserted bool
+}
+
+// scanMemberChanges scans the memberships returned with
+// returningUpsertedMembers or returningChangedMembers.
+func scanMemberChanges(rows *sql.Rows) ([]memberChange, error) {
+	defer rows.Close()
+
+	changes := make([]memberChange, 0)
+	for rows.Next() {
+		var c memberChange
+		if err := rows.Scan(&c.GroupID, &c.UserID, &c.Role, &c.Inserted); err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %v", err)
+		}
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating memberships: %w", err)
+	}
+
+	return changes, nil
+}
+
+// addedMembers are the history entries of the inserted memberships.
+func addedMembers(changes []memberChange) []*types.HistoryEntry {
+	entries := make([]*types.HistoryEntry, 0, len(changes))
+	for _, c := range changes {
+		if c.Inserted {
+			entries = append(entries, &types.HistoryEntry{
+				GroupID: c.GroupID,
+				UserID:  c.UserID,
+				Action:  types.HistoryMemberAdded,
+				After:   roleValues(c.Role),
+			})
+		}
+	}
+	return entries
+}
+
+// removedMembers are the history entries of the deleted memberships.
+func removedMembers(changes []memberChange) []*types.HistoryEntry {
+	entries := make([]*types.HistoryEntry, 0, len(changes))
+	for _, c := range changes {
+		entries = append(entries, &types.HistoryEntry{
+			GroupID: c.GroupID,
+			UserID:  c.UserID,
+			Action:  types.HistoryMemberRemoved,
+			Before:  roleValues(c.Role),
+		})
+	}
+	return entries
+}
+
+func roleValues(role types.Role) map[string]interface{} {
+	return map[string]interface{}{"role": role.String()}
+}
+
+// scanIDs scans the single ID column returned by a mutation.
+func scanIDs(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan ID: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// grantEntries are the history entries of app grants of groups.
+func grantEntries(action types.HistoryAction, groupIDs []string, appIDs []string) []*types.HistoryEntry {
+	entries := make([]*types.HistoryEntry, 0, len(groupIDs)*len(appIDs))
+	for _, groupID := range groupIDs {
+		for _, appID := range appIDs {
+			values := map[string]interface{}{"app_id": appID}
+			e := &types.HistoryEntry{GroupID: groupID, Action: action}
+			if action == types.HistoryAppGranted {
+				e.After = values
+			} else {
+				e.Before = values
+			}
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// historyValues scans the jsonb before and after columns.
+type historyValues map[string]interface{}
+
+func (h *historyValues) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported history values type %T", src)
+	}
+
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("failed to decode history values: %v", err)
+	}
+	*h = values
+	return nil
+}
@@ -5,39 +5,76 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/hook-service/internal/types"
 )
 
 type StorageInterface interface {
 	// Group CRUD operations
-	ListGroups(ctx context.Context) ([]*types.Group, error)
+	ListGroups(ctx context.Context, filter *types.ListFilter) ([]*types.Group, *types.Page, error)
 	CreateGroup(ctx context.Context, group *types.Group) (*types.Group, error)
 	GetGroup(ctx context.Context, id string) (*types.Group, error)
 	GetGroupByName(ctx context.Context, name, tenantID string) (*types.Group, error)
 	UpdateGroup(ctx context.Context, id string, group *types.Group) (*types.Group, error)
 	DeleteGroup(ctx context.Context, id string) error
 
+	// Deleted group operations
+	ListDeletedGroups(ctx context.Context) ([]*types.Group, error)
+	RestoreGroup(ctx context.Context, id string) (*types.Group, error)
+	PurgeGroups(ctx context.Context, before time.Time) (int64, error)
+
 	// Group membership operations
 	AddUsersToGroup(ctx context.Context, groupID string, userIDs []string) error
-	ListUsersInGroup(ctx context.Context, groupID string) ([]string, error)
+	ListUsersInGroup(ctx context.Context, groupID string, filter *types.ListFilter) ([]*types.GroupUser, *types.Page, error)
 	RemoveUsersFromGroup(ctx context.Context, groupID string, users []string) error
 
+	// Group attribute operations
+	SetGroupAttributes(ctx context.Context, groupID string, attributes map[string]string) (map[string]string, error)
+	RemoveGroupAttributes(ctx context.Context, groupID string, keys []string) (map[string]string, error)
+
+	// Group ownership operations
+	AddOwnersToGroup(ctx context.Context, groupID string, userIDs []string) error
+	RemoveOwnersFromGroup(ctx context.Context, groupID string, userIDs []string) error
+	IsGroupOwner(ctx context.Context, groupID string, userIDs []string) (bool, error)
+
 	// User-centric group operations
 	GetGroupsForUser(ctx context.Context, userID string) ([]*types.Group, error)
 	UpdateGroupsForUser(ctx context.Context, userID string, groupIDs []string) error
 	RemoveUserFromAllGroups(ctx context.Context, userID string) error
 
+	// Nested group operations
+	AddSubgroups(ctx context.Context, groupID string, subgroupIDs []string) error
+	ListSubgroups(ctx context.Context, groupID string) ([]*types.Group, error)
+	RemoveSubgroups(ctx context.Context, groupID string, subgroupIDs []string) error
+	ListMembershipsForUser(ctx context.Context, userID string) ([]*types.Membership, error)
+	ListMembersOfGroup(ctx context.Context, groupID string) ([]*types.Membership, error)
+
+	// User operations
+	CreateUser(ctx context.Context, user *types.User) (*types.User, error)
+	GetUser(ctx context.Context, id string) (*types.User, error)
+	GetUsersByIDs(ctx context.Context, ids []string) ([]*types.User, error)
+	GetUsersByUserNames(ctx context.Context, userNames []string) ([]*types.User, error)
+	UpdateUser(ctx context.Context, id string, user *types.User) (*types.User, error)
+	DeleteUser(ctx context.Context, id string) error
+
+	// SCIM directory operations
+	SearchUsers(ctx context.Context, filter *types.DirectoryFilter) ([]*types.User, int64, error)
+	SearchGroups(ctx context.Context, filter *types.DirectoryFilter) ([]*types.Group, int64, error)
+	ListUsersInGroups(ctx context.Context, groupIDs []string) (map[string][]string, error)
+
 	// Prefix-based group operations
 	ListGroupsByPrefix(ctx context.Context, prefix, tenantID string) ([]*types.Group, error)
 	SyncGroupMembers(ctx context.Context, groupID string, userIDs []string) error
 
 	// User-centric group streaming operations
-	StreamGroupsForUser(ctx context.Context, tenantID, userID string, fn func(*types.Group) error) error
-	StreamUsersInGroup(ctx context.Context, tenantID, groupID string, fn func(string) error) error
+	StreamGroupsForUser(ctx context.Context, tenantID, userID string, after *types.Cursor, limit uint64, fn func(*types.Group) error) error
+	StreamUsersInGroup(ctx context.Context, tenantID, groupID string, after *types.Cursor, limit uint64, fn func(string) error) error
+	GetGroupsForUsers(ctx context.Context, tenantID string, userIDs []string) (map[string][]*types.Group, error)
+	StreamMemberships(ctx context.Context, filter *types.MembershipFilter, fn func(*types.MembershipEvent) error) error
 
 	// Application authorization operations
-	GetAllowedApps(ctx context.Context, groupID string) ([]string, error)
+	GetAllowedApps(ctx context.Context, groupID string, filter *types.ListFilter) ([]string, *types.Page, error)
 	AddAllowedApp(ctx context.Context, groupID string, appID string) error
 	AddAllowedApps(ctx context.Context, groupID string, appIDs []string) error
 	RemoveAllowedApp(ctx context.Context, groupID string, appID string) error
@@ -45,6 +82,26 @@ type StorageInterface interface {
 
 	// Group-centric application authorization operations
 	AddAllowedGroupsForApp(ctx context.Context, appID string, groupIDs []string) error
-	GetAllowedGroupsForApp(ctx context.Context, appID string) ([]string, error)
+	GetAllowedGroupsForApp(ctx context.Context, appID string, filter *types.ListFilter) ([]string, *types.Page, error)
 	RemoveAllAllowedGroupsForApp(ctx context.Context, appID string) ([]string, error)
+
+	// Authorization decision log operations
+	CreateDecisions(ctx context.Context, decisions ...*types.Decision) error
+	ListDecisions(ctx context.Context, filter *types.DecisionFilter) ([]*types.Decision, error)
+	PurgeDecisions(ctx context.Context, before time.Time) (int64, error)
+
+	// Group and membership history operations
+	ListHistory(ctx context.Context, filter *types.HistoryFilter) ([]*types.HistoryEntry, error)
+
+	// Webhook outbox and delivery operations
+	ListOutboxEvents(ctx context.Context, afterID int64, settle time.Duration, limit uint64) ([]*types.OutboxEvent, error)
+	GetOutboxEvents(ctx context.Context, ids []int64) ([]*types.OutboxEvent, error)
+	GetSettledOutboxEventID(ctx context.Context, settle time.Duration) (int64, error)
+	PurgeOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+	ClaimWebhookCursor(ctx context.Context, subscriber, owner string, lease time.Duration) (int64, bool, error)
+	AdvanceWebhookCursor(ctx context.Context, subscriber, owner string, eventID int64, lease time.Duration) (bool, error)
+	CreateWebhookDelivery(ctx context.Context, d *types.WebhookDelivery) error
+	UpdateWebhookDelivery(ctx context.Context, id int64, status types.WebhookDeliveryStatus, attempts int, lastError string) error
+	ListWebhookDeliveries(ctx context.Context, filter *types.WebhookDeliveryFilter) ([]*types.WebhookDelivery, error)
+	ReplayWebhookDeliveries(ctx context.Context, subscriber string, ids []int64) (int64, error)
 }
@@ -0,0 +1,163 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// listing describes the columns of a paginated listing.
+type listing struct {
+	// key identifies the rows of the listing.
+	key string
+	// name is searched and sorted by name, it may be the key.
+	name string
+	// kind is filtered by group type, when the rows have one.
+	kind string
+	// attributes is filtered by containment, when the rows have attributes.
+	attributes string
+}
+
+var (
+	groupListing   = &listing{key: "id", name: "name", kind: "type", attributes: "attributes"}
+	memberListing  = &listing{key: "user_id", name: "user_id"}
+	appListing     = &listing{key: "application_id", name: "application_id"}
+	grantedListing = &listing{key: "group_id", name: "group_id"}
+)
+
+// listFilter returns the filter of a listing, a nil filter lists every row
+// by name.
+func listFilter(f *types.ListFilter) *types.ListFilter {
+	if f == nil {
+		return &types.ListFilter{SortBy: types.SortByName}
+	}
+	return f
+}
+
+// where returns the conditions of the filter, regardless of the cursor.
+func (l *listing) where(f *types.ListFilter) sq.And {
+	where := sq.And{}
+
+	if f.Search != "" {
+		where = append(where, sq.Expr(l.name+" ILIKE ? ESCAPE '\\'", "%"+likeEscaper.Replace(f.Search)+"%"))
+	}
+	if f.Type != nil && l.kind != "" {
+		where = append(where, sq.Eq{l.kind: *f.Type})
+	}
+	if f.TenantID != "" {
+		where = append(where, sq.Eq{"tenant_id": f.TenantID})
+	}
+	if len(f.Attributes) > 0 && l.attributes != "" {
+		// Encoding a map of strings cannot fail.
+		value, _ := attributesValue(f.Attributes)
+		where = append(where, sq.Expr(l.attributes+" @> ?::jsonb", value))
+	}
+	if !f.CreatedAfter.IsZero() {
+		where = append(where, sq.GtOrEq{"created_at": f.CreatedAfter})
+	}
+	if !f.CreatedBefore.IsZero() {
+		where = append(where, sq.Lt{"created_at": f.CreatedBefore})
+	}
+	if !f.UpdatedAfter.IsZero() {
+		where = append(where, sq.GtOrEq{"updated_at": f.UpdatedAfter})
+	}
+	if !f.UpdatedBefore.IsZero() {
+		where = append(where, sq.Lt{"updated_at": f.UpdatedBefore})
+	}
+
+	return where
+}
+
+// sortColumn returns the column the listing is sorted by.
+func (l *listing) sortColumn(f *types.ListFilter) string {
+	switch f.SortBy {
+	case types.SortByCreatedAt, types.SortByUpdatedAt:
+		return f.SortBy
+	default:
+		return l.name
+	}
+}
+
+// page orders the query by the sort column then by key, and only selects the
+// rows following the cursor of the filter. One more row than the limit is
+// selected, to know if there is a next page.
+func (l *listing) page(query sq.SelectBuilder, f *types.ListFilter) (sq.SelectBuilder, error) {
+	column := l.sortColumn(f)
+
+	order, cmp := "ASC", ">"
+	if f.Descending {
+		order, cmp = "DESC", "<"
+	}
+
+	if column == l.key {
+		query = query.OrderBy(l.key + " " + order)
+	} else {
+		query = query.OrderBy(column+" "+order, l.key+" "+order)
+	}
+
+	if f.After != nil {
+		var value interface{} = f.After.Value
+		if column != l.name {
+			t, err := time.Parse(time.RFC3339Nano, f.After.Value)
+			if err != nil {
+				return query, types.ErrInvalidPageToken
+			}
+			value = t
+		}
+
+		if column == l.key {
+			query = query.Where(sq.Expr(l.key+" "+cmp+" ?", value))
+		} else {
+			query = query.Where(sq.Expr("("+column+", "+l.key+") "+cmp+" (?, ?)", value, f.After.ID))
+		}
+	}
+
+	if f.Limit > 0 {
+		query = query.Limit(f.Limit + 1)
+	}
+
+	return query, nil
+}
+
+// cursor returns the position of a row in the listing.
+func (l *listing) cursor(f *types.ListFilter, key, name string, createdAt, updatedAt time.Time) *types.Cursor {
+	switch l.sortColumn(f) {
+	case types.SortByCreatedAt:
+		return &types.Cursor{Value: createdAt.Format(time.RFC3339Nano), ID: key}
+	case types.SortByUpdatedAt:
+		return &types.Cursor{Value: updatedAt.Format(time.RFC3339Nano), ID: key}
+	default:
+		return &types.Cursor{Value: name, ID: key}
+	}
+}
+
+// hasNext reports whether a page of n rows has a next page.
+func hasNext(f *types.ListFilter, n int) bool {
+	return f.Limit > 0 && uint64(n) > f.Limit
+}
+
+// count counts the rows of a table matching the conditions.
+func (s *Storage) count(ctx context.Context, table string, where sq.And) (int64, error) {
+	var total int64
+	err := s.db.Statement(ctx).
+		Select("COUNT(*)").
+		From(table).
+		Where(where).
+		QueryRowContext(ctx).
+		Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %v", table, err)
+	}
+	return total, nil
+}
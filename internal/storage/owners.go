@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// AddOwnersToGroup makes users owners of a group, adding them to the group
+// when they are not members yet.
+func (s *Storage) AddOwnersToGroup(ctx context.Context, groupID string, userIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.AddOwnersToGroup")
+	defer span.End()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	group, err := s.GetGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(userIDs))
+	unique := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+
+	now := time.Now().UTC()
+	insert := s.db.Statement(ctx).
+		Insert("group_members").
+		Columns("group_id", "user_id", "tenant_id", "role", "created_at", "updated_at")
+
+	for _, userID := range unique {
+		insert = insert.Values(groupID, userID, group.TenantId, types.RoleOwner, now, now)
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		// Owners are left untouched, only new owners are returned.
+		rows, err := insert.
+			Suffix("ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at " +
+				"WHERE group_members.role <> EXCLUDED.role " + returningUpsertedMembers).
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to insert group owners: %v", err)
+		}
+
+		added, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
+
+		entries := make([]*types.HistoryEntry, 0, len(added))
+		for _, c := range added {
+			e := &types.HistoryEntry{GroupID: c.GroupID, UserID: c.UserID, Action: types.HistoryOwnerAdded, After: roleValues(types.RoleOwner)}
+			if !c.Inserted {
+				e.Before = roleValues(types.RoleMember)
+			}
+			entries = append(entries, e)
+		}
+
+		if err := s.record(ctx, entries...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, GroupID: groupID, UserIDs: unique})
+	})
+}
+
+// RemoveOwnersFromGroup makes owners of a group regular members, they keep
+// their membership.
+func (s *Storage) RemoveOwnersFromGroup(ctx context.Context, groupID string, userIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveOwnersFromGroup")
+	defer span.End()
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Update("group_members").
+			Set("role", types.RoleMember).
+			Set("updated_at", time.Now().UTC()).
+			Where(sq.Eq{"group_id": groupID, "user_id": userIDs, "role": types.RoleOwner}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix(returningChangedMembers).
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove group owners: %v", err)
+		}
+
+		removed, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
+
+		entries := make([]*types.HistoryEntry, 0, len(removed))
+		for _, c := range removed {
+			entries = append(entries, &types.HistoryEntry{
+				GroupID: c.GroupID,
+				UserID:  c.UserID,
+				Action:  types.HistoryOwnerRemoved,
+				Before:  roleValues(types.RoleOwner),
+				After:   roleValues(types.RoleMember),
+			})
+		}
+
+		return s.record(ctx, entries...)
+	})
+}
+
+// IsGroupOwner reports whether one of the user IDs owns a group. A caller can
+// be known under several IDs, such as its subject and its email.
+func (s *Storage) IsGroupOwner(ctx context.Context, groupID string, userIDs []string) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.IsGroupOwner")
+	defer span.End()
+
+	if _, err := uuid.Parse(groupID); err != nil || len(userIDs) == 0 {
+		return false, nil
+	}
+
+	var found int
+	err := s.db.Statement(ctx).
+		Select("1").
+		From("group_members").
+		Where(sq.Eq{"group_id": groupID, "user_id": userIDs, "role": types.RoleOwner}).
+		Where(tenantFilter(ctx, "tenant_id")).
+		Limit(1).
+		QueryRowContext(ctx).
+		Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check group owner: %v", err)
+	}
+
+	return true, nil
+}
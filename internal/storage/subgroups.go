@@ -0,0 +1,373 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// subgroupsLockID is the advisory lock serialising nesting changes made in
+// transactions, so that two concurrent writes cannot each close half of a
+// cycle.
+const subgroupsLockID = 0x686f6f6b_67727073
+
+// userGroupsCTE resolves the groups a user belongs to, directly or through
+// nested groups. Users deactivated through SCIM keep their memberships but
+// no groups, and deleted groups neither count nor pass on their parents.
+// UNION skips the groups already found, so the recursion ends even on a
+// cycle.
+const userGroupsCTE = `WITH RECURSIVE user_groups(group_id) AS (
+	SELECT gm.group_id FROM group_members gm
+	JOIN groups g ON g.id = gm.group_id AND g.deleted_at IS NULL
+	LEFT JOIN users u ON lower(u.user_name) = lower(gm.user_id)
+	WHERE gm.user_id = ? AND u.active IS NOT FALSE
+	UNION
+	SELECT s.parent_id FROM group_subgroups s
+	JOIN user_groups ug ON s.child_id = ug.group_id
+	JOIN groups p ON p.id = s.parent_id AND p.deleted_at IS NULL
+)`
+
+// usersGroupsCTE resolves the groups of several users like userGroupsCTE,
+// keyed by user.
+const usersGroupsCTE = `WITH RECURSIVE users_groups(user_id, group_id) AS (
+	SELECT gm.user_id, gm.group_id FROM group_members gm
+	JOIN groups g ON g.id = gm.group_id AND g.deleted_at IS NULL
+	LEFT JOIN users u ON lower(u.user_name) = lower(gm.user_id)
+	WHERE gm.user_id = ANY(?) AND u.active IS NOT FALSE
+	UNION
+	SELECT ug.user_id, s.parent_id FROM group_subgroups s
+	JOIN users_groups ug ON s.child_id = ug.group_id
+	JOIN groups p ON p.id = s.parent_id AND p.deleted_at IS NULL
+)`
+
+// groupTreeCTE resolves a group and the groups nested in it, skipping the
+// deleted ones.
+const groupTreeCTE = `WITH RECURSIVE group_tree(group_id) AS (
+	SELECT id FROM groups WHERE id = ?::uuid AND deleted_at IS NULL
+	UNION
+	SELECT s.child_id FROM group_subgroups s
+	JOIN group_tree t ON s.parent_id = t.group_id
+	JOIN groups c ON c.id = s.child_id AND c.deleted_at IS NULL
+)`
+
+// descendantsCTE resolves the groups nested in a list of groups, including
+// the groups themselves.
+const descendantsCTE = `WITH RECURSIVE descendants(group_id) AS (
+	SELECT unnest(?::text[])::uuid
+	UNION
+	SELECT s.child_id FROM group_subgroups s
+	JOIN descendants d ON s.parent_id = d.group_id
+)`
+
+// userMembershipsCTE resolves the groups of a user with the chain of group
+// names leading to each of them, skipping the deleted groups.
+const userMembershipsCTE = `WITH RECURSIVE user_groups(group_id, ids, path) AS (
+	SELECT g.id, ARRAY[g.id], ARRAY[g.name::text]
+	FROM group_members gm
+	JOIN groups g ON g.id = gm.group_id
+	WHERE gm.user_id = ? AND g.deleted_at IS NULL
+	UNION ALL
+	SELECT p.id, array_append(ug.ids, p.id), array_append(ug.path, p.name::text)
+	FROM user_groups ug
+	JOIN group_subgroups s ON s.child_id = ug.group_id
+	JOIN groups p ON p.id = s.parent_id
+	WHERE NOT p.id = ANY(ug.ids) AND p.deleted_at IS NULL
+)`
+
+// groupMembersCTE resolves a group and the groups nested in it with the chain
+// of group names leading from each of them to the group, skipping the
+// deleted groups.
+const groupMembersCTE = `WITH RECURSIVE group_tree(group_id, ids, path) AS (
+	SELECT g.id, ARRAY[g.id], ARRAY[g.name::text]
+	FROM groups g
+	WHERE g.id = ? AND g.deleted_at IS NULL
+	UNION ALL
+	SELECT c.id, array_append(t.ids, c.id), array_prepend(c.name::text, t.path)
+	FROM group_tree t
+	JOIN group_subgroups s ON s.parent_id = t.group_id
+	JOIN groups c ON c.id = s.child_id
+	WHERE NOT c.id = ANY(t.ids) AND c.deleted_at IS NULL
+)`
+
+// AddSubgroups nests groups in a group, their members become members of the
+// group. It fails with ErrGroupCycle when the group is nested in one of them.
+func (s *Storage) AddSubgroups(ctx context.Context, groupID string, subgroupIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.AddSubgroups")
+	defer span.End()
+
+	if len(subgroupIDs) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(subgroupIDs))
+	unique := make([]string, 0, len(subgroupIDs))
+	for _, id := range subgroupIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("group %q does not exist: %w", id, ErrForeignKeyViolation)
+		}
+		if id == groupID {
+			return fmt.Errorf("group %s cannot contain itself: %w", groupID, ErrGroupCycle)
+		}
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		return s.addSubgroups(ctx, groupID, unique)
+	})
+}
+
+// addSubgroups nests groups in a group within the transaction of ctx, which
+// holds the nesting lock until it ends.
+func (s *Storage) addSubgroups(ctx context.Context, groupID string, unique []string) error {
+	group, err := s.GetGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.Statement(ctx).
+		Select().
+		Column(sq.Expr("pg_advisory_xact_lock(?)", subgroupsLockID)).
+		ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to lock group nesting: %v", err)
+	}
+
+	var cycle bool
+	err = s.db.Statement(ctx).
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM descendants WHERE group_id = ?)", groupID)).
+		Prefix(descendantsCTE, unique).
+		QueryRowContext(ctx).
+		Scan(&cycle)
+	if err != nil {
+		return fmt.Errorf("failed to check group nesting: %v", err)
+	}
+	if cycle {
+		return fmt.Errorf("group %s is nested in one of the subgroups: %w", groupID, ErrGroupCycle)
+	}
+
+	now := time.Now().UTC()
+	insert := s.db.Statement(ctx).
+		Insert("group_subgroups").
+		Columns("parent_id", "child_id", "tenant_id", "created_at")
+
+	for _, id := range unique {
+		insert = insert.Values(groupID, id, group.TenantId, now)
+	}
+
+	rows, err := insert.
+		Suffix("ON CONFLICT (parent_id, child_id) DO NOTHING RETURNING child_id").
+		QueryContext(ctx)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return WrapForeignKeyError(err, "subgroups must exist in the tenant of the group")
+		}
+		return fmt.Errorf("failed to insert subgroups: %v", err)
+	}
+
+	added, err := scanIDs(rows)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return WrapForeignKeyError(err, "subgroups must exist in the tenant of the group")
+		}
+		return err
+	}
+
+	if err := s.record(ctx, subgroupEntries(types.HistorySubgroupAdded, group.TenantId, groupID, added)...); err != nil {
+		return err
+	}
+
+	// The members of the subgroups are not known, the change affects every user.
+	return s.notify(ctx, &Change{Kind: ChangeKindMemberships, GroupID: groupID})
+}
+
+// ListSubgroups retrieves the groups directly nested in a group.
+func (s *Storage) ListSubgroups(ctx context.Context, groupID string) ([]*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListSubgroups")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.attributes", "g.created_at", "g.updated_at").
+		From("groups g").
+		Join("group_subgroups s ON g.id = s.child_id").
+		Where(sq.Eq{"s.parent_id": groupID, "g.deleted_at": nil}).
+		Where(tenantFilter(ctx, "s.tenant_id")).
+		OrderBy("g.name ASC").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subgroups: %v", err)
+	}
+	defer rows.Close()
+
+	groups := make([]*types.Group, 0)
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %v", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating subgroups: %v", err)
+	}
+
+	return groups, nil
+}
+
+// RemoveSubgroups removes groups nested in a group.
+func (s *Storage) RemoveSubgroups(ctx context.Context, groupID string, subgroupIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.RemoveSubgroups")
+	defer span.End()
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.Statement(ctx).
+			Delete("group_subgroups").
+			Where(sq.Eq{"parent_id": groupID, "child_id": subgroupIDs}).
+			Where(tenantFilter(ctx, "tenant_id")).
+			Suffix("RETURNING child_id, tenant_id").
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove subgroups: %v", err)
+		}
+		defer rows.Close()
+
+		// Subgroups are nested in the tenant of the group.
+		var tenantID string
+		removed := make([]string, 0, len(subgroupIDs))
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id, &tenantID); err != nil {
+				return fmt.Errorf("failed to scan subgroup: %v", err)
+			}
+			removed = append(removed, id)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating subgroups: %v", err)
+		}
+
+		if err := s.record(ctx, subgroupEntries(types.HistorySubgroupRemoved, tenantID, groupID, removed)...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, GroupID: groupID})
+	})
+}
+
+// subgroupEntries are the history entries of the subgroups added to or
+// removed from a group.
+func subgroupEntries(action types.HistoryAction, tenantID, groupID string, subgroupIDs []string) []*types.HistoryEntry {
+	entries := make([]*types.HistoryEntry, 0, len(subgroupIDs))
+	for _, id := range subgroupIDs {
+		values := map[string]interface{}{"subgroup_id": id}
+		e := &types.HistoryEntry{TenantID: tenantID, GroupID: groupID, Action: action}
+		if action == types.HistorySubgroupAdded {
+			e.After = values
+		} else {
+			e.Before = values
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// ListMembershipsForUser retrieves the direct and inherited memberships of a
+// user, ordered by group name.
+func (s *Storage) ListMembershipsForUser(ctx context.Context, userID string) ([]*types.Membership, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListMembershipsForUser")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("g.id", "g.name", "g.tenant_id", "g.description", "g.type", "g.attributes", "g.created_at", "g.updated_at", "ug.path").
+		Options("DISTINCT ON (g.name, g.id)").
+		Prefix(userMembershipsCTE, userID).
+		From("user_groups ug").
+		Join("groups g ON g.id = ug.group_id").
+		Where(tenantFilter(ctx, "g.tenant_id")).
+		OrderBy("g.name ASC", "g.id", "cardinality(ug.path)").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memberships for user: %v", err)
+	}
+	defer rows.Close()
+
+	m := pgtype.NewMap()
+	memberships := make([]*types.Membership, 0)
+	for rows.Next() {
+		membership := &types.Membership{UserID: userID, Group: new(types.Group)}
+		g := membership.Group
+		err := rows.Scan(
+			&g.ID,
+			&g.Name,
+			&g.TenantId,
+			&g.Description,
+			&g.Type,
+			(*attributesColumn)(&g.Attributes),
+			&g.CreatedAt,
+			&g.UpdatedAt,
+			m.SQLScanner(&membership.Path),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %v", err)
+		}
+		memberships = append(memberships, membership)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating memberships: %v", err)
+	}
+
+	return memberships, nil
+}
+
+// ListMembersOfGroup retrieves the direct and inherited members of a group,
+// ordered by user ID. The Group of the memberships is not set.
+func (s *Storage) ListMembersOfGroup(ctx context.Context, groupID string) ([]*types.Membership, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListMembersOfGroup")
+	defer span.End()
+
+	if _, err := uuid.Parse(groupID); err != nil {
+		return []*types.Membership{}, nil
+	}
+
+	rows, err := s.db.Statement(ctx).
+		Select("gm.user_id", "t.path").
+		Options("DISTINCT ON (gm.user_id)").
+		Prefix(groupMembersCTE, groupID).
+		From("group_tree t").
+		Join("group_members gm ON gm.group_id = t.group_id").
+		Where(tenantFilter(ctx, "gm.tenant_id")).
+		OrderBy("gm.user_id ASC", "cardinality(t.path)").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members of group: %v", err)
+	}
+	defer rows.Close()
+
+	m := pgtype.NewMap()
+	memberships := make([]*types.Membership, 0)
+	for rows.Next() {
+		membership := new(types.Membership)
+		if err := rows.Scan(&membership.UserID, m.SQLScanner(&membership.Path)); err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %v", err)
+		}
+		memberships = append(memberships, membership)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating memberships: %v", err)
+	}
+
+	return memberships, nil
+}
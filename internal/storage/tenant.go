@@ -0,0 +1,45 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of ctx scoping the storage operations to a
+// tenant: groups of other tenants, their memberships and their allowed apps
+// are not visible and cannot be changed. Operations without a tenant scope
+// see every tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant the storage operations are scoped to.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// tenantFilter matches the rows of the tenant scope on a tenant_id column,
+// and every row without a scope.
+func tenantFilter(ctx context.Context, column string) sq.Eq {
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		return sq.Eq{column: tenantID}
+	}
+	return sq.Eq{}
+}
+
+// memberTenant is the tenant_id value of a row referencing a group: the
+// tenant scope, or else the tenant of the group. A missing group falls back
+// to the default tenant, so that the insert fails on the foreign key.
+func memberTenant(ctx context.Context, groupID string) interface{} {
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		return tenantID
+	}
+	return sq.Expr("COALESCE((SELECT tenant_id FROM groups WHERE id = ?), ?)", groupID, DefaultTenantID)
+}
@@ -0,0 +1,330 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var userColumns = []string{"id", "user_name", "external_id", "display_name", "given_name", "family_name", "email", "active", "created_at", "updated_at"}
+
+// CreateUser inserts a new user into the database.
+func (s *Storage) CreateUser(ctx context.Context, user *types.User) (*types.User, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.CreateUser")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate uuid: %v", err)
+	}
+
+	var createdAt, updatedAt time.Time
+
+	err = s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.db.Statement(ctx).
+			Insert("users").
+			Columns("id", "user_name", "external_id", "display_name", "given_name", "family_name", "email", "active").
+			Values(id, user.UserName, user.ExternalID, user.DisplayName, user.GivenName, user.FamilyName, user.Email, user.Active).
+			Suffix("RETURNING created_at, updated_at").
+			QueryRowContext(ctx).
+			Scan(&createdAt, &updatedAt)
+		if err != nil {
+			if IsDuplicateKeyError(err) {
+				return WrapDuplicateKeyError(err, "user name already exists")
+			}
+			return fmt.Errorf("failed to insert user: %v", err)
+		}
+
+		return s.record(ctx, &types.HistoryEntry{
+			UserID: user.UserName,
+			Action: types.HistoryUserCreated,
+			After:  userValues(user),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *user
+	created.ID = id.String()
+	created.CreatedAt = createdAt
+	created.UpdatedAt = updatedAt
+
+	return &created, nil
+}
+
+// GetUser retrieves a single user by ID.
+func (s *Storage) GetUser(ctx context.Context, id string) (*types.User, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetUser")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrNotFound
+	}
+
+	row := s.db.Statement(ctx).
+		Select(userColumns...).
+		From("users").
+		Where(sq.Eq{"id": id}).
+		QueryRowContext(ctx)
+
+	user, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user: %v", err)
+	}
+
+	return user, nil
+}
+
+// GetUsersByIDs retrieves the users with the given IDs, unknown and malformed
+// IDs are ignored.
+func (s *Storage) GetUsersByIDs(ctx context.Context, ids []string) ([]*types.User, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetUsersByIDs")
+	defer span.End()
+
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return []*types.User{}, nil
+	}
+
+	return s.queryUsers(ctx, s.db.Statement(ctx).
+		Select(userColumns...).
+		From("users").
+		Where(sq.Eq{"id": valid}).
+		OrderBy("user_name ASC"))
+}
+
+// GetUsersByUserNames retrieves the users with the given user names, compared
+// case-insensitively.
+func (s *Storage) GetUsersByUserNames(ctx context.Context, userNames []string) ([]*types.User, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetUsersByUserNames")
+	defer span.End()
+
+	if len(userNames) == 0 {
+		return []*types.User{}, nil
+	}
+
+	lower := make([]string, 0, len(userNames))
+	for _, name := range userNames {
+		lower = append(lower, strings.ToLower(name))
+	}
+
+	return s.queryUsers(ctx, s.db.Statement(ctx).
+		Select(userColumns...).
+		From("users").
+		Where(sq.Eq{"lower(user_name)": lower}).
+		OrderBy("user_name ASC"))
+}
+
+// UpdateUser updates an existing user's mutable fields. When the user name
+// changes, the memberships are moved to the new name.
+func (s *Storage) UpdateUser(ctx context.Context, id string, user *types.User) (*types.User, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.UpdateUser")
+	defer span.End()
+
+	var current *types.User
+	now := time.Now().UTC()
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		current, err = s.GetUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.db.Statement(ctx).
+			Update("users").
+			Set("user_name", user.UserName).
+			Set("external_id", user.ExternalID).
+			Set("display_name", user.DisplayName).
+			Set("given_name", user.GivenName).
+			Set("family_name", user.FamilyName).
+			Set("email", user.Email).
+			Set("active", user.Active).
+			Set("updated_at", now).
+			Where(sq.Eq{"id": id}).
+			ExecContext(ctx)
+		if err != nil {
+			if IsDuplicateKeyError(err) {
+				return WrapDuplicateKeyError(err, "user name already exists")
+			}
+			return fmt.Errorf("failed to update user: %v", err)
+		}
+
+		affected := []string{current.UserName}
+		if user.UserName != current.UserName {
+			// Drop the memberships the new name already has to avoid conflicts.
+			_, err = s.db.Statement(ctx).
+				Delete("group_members").
+				Where(sq.Eq{"user_id": user.UserName}).
+				Where(sq.Expr("group_id IN (SELECT group_id FROM group_members WHERE user_id = ?)", current.UserName)).
+				ExecContext(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to rename user memberships: %v", err)
+			}
+
+			_, err = s.db.Statement(ctx).
+				Update("group_members").
+				Set("user_id", user.UserName).
+				Set("updated_at", now).
+				Where(sq.Eq{"user_id": current.UserName}).
+				ExecContext(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to rename user memberships: %v", err)
+			}
+			affected = append(affected, user.UserName)
+		}
+
+		if before, after := changedValues(userValues(current), userValues(user)); after != nil {
+			err := s.record(ctx, &types.HistoryEntry{
+				UserID: current.UserName,
+				Action: types.HistoryUserUpdated,
+				Before: before,
+				After:  after,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Deactivating a user hides its groups from the token hook.
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, UserIDs: affected})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *user
+	updated.ID = id
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = now
+
+	return &updated, nil
+}
+
+// DeleteUser removes a user and its memberships from the database.
+func (s *Storage) DeleteUser(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.DeleteUser")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrNotFound
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		var userName string
+		err := s.db.Statement(ctx).
+			Delete("users").
+			Where(sq.Eq{"id": id}).
+			Suffix("RETURNING user_name").
+			QueryRowContext(ctx).
+			Scan(&userName)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %v", err)
+		}
+
+		rows, err := s.db.Statement(ctx).
+			Delete("group_members").
+			Where(sq.Eq{"user_id": userName}).
+			Suffix(returningChangedMembers).
+			QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete user memberships: %v", err)
+		}
+
+		removed, err := scanMemberChanges(rows)
+		if err != nil {
+			return err
+		}
+
+		entries := append(removedMembers(removed), &types.HistoryEntry{
+			UserID: userName,
+			Action: types.HistoryUserDeleted,
+			Before: map[string]interface{}{"user_name": userName},
+		})
+		if err := s.record(ctx, entries...); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, &Change{Kind: ChangeKindMemberships, UserIDs: []string{userName}})
+	})
+}
+
+// userValues are the recorded values of a user.
+func userValues(u *types.User) map[string]interface{} {
+	return map[string]interface{}{
+		"user_name":    u.UserName,
+		"external_id":  u.ExternalID,
+		"display_name": u.DisplayName,
+		"given_name":   u.GivenName,
+		"family_name":  u.FamilyName,
+		"email":        u.Email,
+		"active":       u.Active,
+	}
+}
+
+func (s *Storage) queryUsers(ctx context.Context, query sq.SelectBuilder) ([]*types.User, error) {
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %v", err)
+	}
+	defer rows.Close()
+
+	users := make([]*types.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %v", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %v", err)
+	}
+
+	return users, nil
+}
+
+func scanUser(row sq.RowScanner) (*types.User, error) {
+	user := &types.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.UserName,
+		&user.ExternalID,
+		&user.DisplayName,
+		&user.GivenName,
+		&user.FamilyName,
+		&user.Email,
+		&user.Active,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
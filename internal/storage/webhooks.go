@@ -0,0 +1,348 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var outboxEventColumns = []string{
+	"e.id",
+	"e.created_at",
+	"e.tenant_id",
+	"e.type",
+	"COALESCE(e.group_id::text, '')",
+	"COALESCE(e.user_id, '')",
+	"e.actor",
+	"e.source",
+	"e.before",
+	"e.after",
+}
+
+// ListOutboxEvents retrieves up to limit events newer than afterID, oldest
+// first. Only the events written more than settle ago are returned, by the
+// database clock, so that no transaction still running can commit an event
+// with a lower ID than the returned ones.
+func (s *Storage) ListOutboxEvents(ctx context.Context, afterID int64, settle time.Duration, limit uint64) ([]*types.OutboxEvent, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListOutboxEvents")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select(outboxEventColumns...).
+		From("outbox_events e").
+		Where(sq.Gt{"e.id": afterID}).
+		Where(sq.Expr("e.created_at < clock_timestamp() - make_interval(secs => ?)", settle.Seconds())).
+		OrderBy("e.id").
+		Limit(limit).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox events: %v", err)
+	}
+	defer rows.Close()
+
+	events := make([]*types.OutboxEvent, 0)
+	for rows.Next() {
+		e := new(types.OutboxEvent)
+		if err := rows.Scan(outboxEventFields(e)...); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %v", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox events: %v", err)
+	}
+
+	return events, nil
+}
+
+// GetOutboxEvents retrieves the events with the given IDs, oldest first,
+// the missing IDs are skipped.
+func (s *Storage) GetOutboxEvents(ctx context.Context, ids []int64) ([]*types.OutboxEvent, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetOutboxEvents")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select(outboxEventColumns...).
+		From("outbox_events e").
+		Where(sq.Eq{"e.id": ids}).
+		OrderBy("e.id").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox events: %v", err)
+	}
+	defer rows.Close()
+
+	events := make([]*types.OutboxEvent, 0, len(ids))
+	for rows.Next() {
+		e := new(types.OutboxEvent)
+		if err := rows.Scan(outboxEventFields(e)...); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %v", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox events: %v", err)
+	}
+
+	return events, nil
+}
+
+// GetSettledOutboxEventID returns the ID of the latest event written more
+// than settle ago, or 0 without one. Every event with a lower ID is
+// committed or rolled back once settle exceeds the transaction timeout.
+func (s *Storage) GetSettledOutboxEventID(ctx context.Context, settle time.Duration) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.GetSettledOutboxEventID")
+	defer span.End()
+
+	var id int64
+	err := s.db.Statement(ctx).
+		Select("COALESCE(MAX(id), 0)").
+		From("outbox_events").
+		Where(sq.Expr("created_at < clock_timestamp() - make_interval(secs => ?)", settle.Seconds())).
+		QueryRowContext(ctx).
+		Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get settled outbox event: %v", err)
+	}
+
+	return id, nil
+}
+
+// PurgeOutboxEvents deletes the events written before the given time, except
+// those of failed or pending deliveries, and returns how many were deleted.
+func (s *Storage) PurgeOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.PurgeOutboxEvents")
+	defer span.End()
+
+	result, err := s.db.Statement(ctx).
+		Delete("outbox_events").
+		Where(sq.Lt{"created_at": before}).
+		Where(sq.Expr(
+			"NOT EXISTS (SELECT 1 FROM webhook_deliveries d WHERE d.event_id = outbox_events.id AND d.status <> ?)",
+			string(types.WebhookDeliveryDelivered),
+		)).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %v", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// ClaimWebhookCursor takes or renews the lease of owner on the cursor of a
+// subscriber and returns the ID of the last event handed to it. A new
+// subscriber starts after the latest event. It returns false when another
+// owner holds an unexpired lease.
+func (s *Storage) ClaimWebhookCursor(ctx context.Context, subscriber, owner string, lease time.Duration) (int64, bool, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ClaimWebhookCursor")
+	defer span.End()
+
+	var lastEventID int64
+	err := s.db.Statement(ctx).
+		Insert("webhook_cursors").
+		Columns("subscriber", "last_event_id", "owner", "lease_until").
+		Values(
+			subscriber,
+			sq.Expr("(SELECT COALESCE(MAX(id), 0) FROM outbox_events)"),
+			owner,
+			sq.Expr("clock_timestamp() + make_interval(secs => ?)", lease.Seconds()),
+		).
+		Suffix(
+			"ON CONFLICT (subscriber) DO UPDATE SET owner = EXCLUDED.owner, lease_until = EXCLUDED.lease_until " +
+				"WHERE webhook_cursors.owner = EXCLUDED.owner OR webhook_cursors.lease_until < clock_timestamp() " +
+				"RETURNING last_event_id",
+		).
+		QueryRowContext(ctx).
+		Scan(&lastEventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to claim webhook cursor: %v", err)
+	}
+
+	return lastEventID, true, nil
+}
+
+// AdvanceWebhookCursor moves the cursor of a subscriber to eventID, it never
+// moves backwards, and renews the lease of owner. It returns false when owner
+// no longer holds the lease.
+func (s *Storage) AdvanceWebhookCursor(ctx context.Context, subscriber, owner string, eventID int64, lease time.Duration) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.AdvanceWebhookCursor")
+	defer span.End()
+
+	result, err := s.db.Statement(ctx).
+		Update("webhook_cursors").
+		Set("last_event_id", sq.Expr("GREATEST(last_event_id, ?)", eventID)).
+		Set("lease_until", sq.Expr("clock_timestamp() + make_interval(secs => ?)", lease.Seconds())).
+		Set("updated_at", time.Now().UTC()).
+		Where(sq.Eq{"subscriber": subscriber, "owner": owner}).
+		ExecContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance webhook cursor: %v", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to advance webhook cursor: %v", err)
+	}
+
+	return n > 0, nil
+}
+
+// CreateWebhookDelivery dead-letters the event of a delivery that failed
+// every attempt, a previous delivery of the same event to the subscriber is
+// overwritten.
+func (s *Storage) CreateWebhookDelivery(ctx context.Context, d *types.WebhookDelivery) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.CreateWebhookDelivery")
+	defer span.End()
+
+	now := time.Now().UTC()
+	_, err := s.db.Statement(ctx).
+		Insert("webhook_deliveries").
+		Columns("subscriber", "event_id", "status", "attempts", "last_error", "created_at", "updated_at").
+		Values(d.Subscriber, d.Event.ID, string(d.Status), d.Attempts, d.LastError, now, now).
+		Suffix(
+			"ON CONFLICT (subscriber, event_id) DO UPDATE SET status = EXCLUDED.status, " +
+				"attempts = webhook_deliveries.attempts + EXCLUDED.attempts, " +
+				"last_error = EXCLUDED.last_error, updated_at = EXCLUDED.updated_at",
+		).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhookDelivery records the outcome of the replay of a delivery,
+// attempts are added to the previous ones.
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, id int64, status types.WebhookDeliveryStatus, attempts int, lastError string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.UpdateWebhookDelivery")
+	defer span.End()
+
+	result, err := s.db.Statement(ctx).
+		Update("webhook_deliveries").
+		Set("status", string(status)).
+		Set("attempts", sq.Expr("attempts + ?", attempts)).
+		Set("last_error", lastError).
+		Set("updated_at", time.Now().UTC()).
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %v", err)
+	}
+
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries retrieves the deliveries matching the filter with
+// their events, oldest first.
+func (s *Storage) ListWebhookDeliveries(ctx context.Context, filter *types.WebhookDeliveryFilter) ([]*types.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ListWebhookDeliveries")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select("d.id", "d.subscriber", "d.status", "d.attempts", "d.last_error", "d.created_at", "d.updated_at").
+		Columns(outboxEventColumns...).
+		From("webhook_deliveries d").
+		Join("outbox_events e ON e.id = d.event_id").
+		OrderBy("d.id")
+
+	if filter.Subscriber != "" {
+		query = query.Where(sq.Eq{"d.subscriber": filter.Subscriber})
+	}
+	if filter.Status != "" {
+		query = query.Where(sq.Eq{"d.status": string(filter.Status)})
+	}
+	if filter.AfterID > 0 {
+		query = query.Where(sq.Gt{"d.id": filter.AfterID})
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %v", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*types.WebhookDelivery, 0)
+	for rows.Next() {
+		d := &types.WebhookDelivery{Event: new(types.OutboxEvent)}
+		fields := append(
+			[]interface{}{&d.ID, &d.Subscriber, &d.Status, &d.Attempts, &d.LastError, &d.CreatedAt, &d.UpdatedAt},
+			outboxEventFields(d.Event)...,
+		)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %v", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %v", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayWebhookDeliveries marks failed deliveries as pending so that the
+// dispatcher retries them, and returns how many were marked. Without IDs
+// every failed delivery of the subscriber is replayed.
+func (s *Storage) ReplayWebhookDeliveries(ctx context.Context, subscriber string, ids []int64) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.Storage.ReplayWebhookDeliveries")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Update("webhook_deliveries").
+		Set("status", string(types.WebhookDeliveryPending)).
+		Set("updated_at", time.Now().UTC()).
+		Where(sq.Eq{"status": string(types.WebhookDeliveryFailed)})
+
+	if subscriber != "" {
+		query = query.Where(sq.Eq{"subscriber": subscriber})
+	}
+	if len(ids) > 0 {
+		query = query.Where(sq.Eq{"id": ids})
+	}
+
+	result, err := query.ExecContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay webhook deliveries: %v", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// outboxEventFields are the scan destinations of outboxEventColumns.
+func outboxEventFields(e *types.OutboxEvent) []interface{} {
+	return []interface{}{
+		&e.ID,
+		&e.CreatedAt,
+		&e.TenantID,
+		&e.Type,
+		&e.GroupID,
+		&e.UserID,
+		&e.Actor,
+		&e.Source,
+		(*historyValues)(&e.Before),
+		(*historyValues)(&e.After),
+	}
+}
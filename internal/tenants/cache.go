@@ -0,0 +1,171 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tenants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/canonical/hook-service/internal/cache"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+const (
+	lookupHit   = "hit"
+	lookupMiss  = "miss"
+	lookupStale = "stale"
+)
+
+type lookup struct {
+	tenants   tenantSet
+	fetchedAt time.Time
+}
+
+var _ TenantValidatorInterface = (*CachedClient)(nil)
+
+var ErrInvalidCacheTTL = errors.New("invalid tenant cache TTL")
+
+// CachedClient validates memberships against the tenants of each identity
+// cached for a TTL, so that tenant-service is not called on every token hook.
+// Concurrent lookups of the same identity are coalesced into one call.
+//
+// With a stale TTL, an expired entry is still served for that long while it
+// is refreshed in the background, and while tenant-service is unavailable.
+type CachedClient struct {
+	client   *Client
+	cache    *cache.Cache[*lookup]
+	lookups  singleflight.Group
+	ttl      time.Duration
+	staleTTL time.Duration
+
+	now func() time.Time
+
+	tracer tracing.TracingInterface
+	logger logging.LoggerInterface
+}
+
+// ValidateMembership checks whether the user identified by identityID is an
+// active member of the given tenant, see Client.ValidateMembership.
+func (c *CachedClient) ValidateMembership(ctx context.Context, identityID, tenantID string) error {
+	ctx, span := c.tracer.Start(ctx, "tenants.CachedClient.ValidateMembership")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("identity_id", identityID),
+		attribute.String("tenant_id", tenantID),
+	)
+
+	tenants, result, err := c.tenants(ctx, identityID)
+	span.SetAttributes(attribute.String("cache.result", result))
+	tenantLookups.WithLabelValues(result).Inc()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "cannot look up tenants")
+		return err
+	}
+
+	if !tenants.contains(tenantID) {
+		span.SetStatus(codes.Ok, "membership denied")
+		return ErrNotMember
+	}
+
+	span.SetStatus(codes.Ok, "membership validated")
+	return nil
+}
+
+// ListTenants returns the sorted IDs of the tenants the user identified by
+// identityID is an active member of, see Client.ListTenants.
+func (c *CachedClient) ListTenants(ctx context.Context, identityID string) ([]string, error) {
+	ctx, span := c.tracer.Start(ctx, "tenants.CachedClient.ListTenants")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("identity_id", identityID))
+
+	tenants, result, err := c.tenants(ctx, identityID)
+	span.SetAttributes(attribute.String("cache.result", result))
+	tenantLookups.WithLabelValues(result).Inc()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "cannot look up tenants")
+		return nil, err
+	}
+
+	return tenants.ids(), nil
+}
+
+func (c *CachedClient) tenants(ctx context.Context, identityID string) (tenantSet, string, error) {
+	l, ok := c.cache.Get(identityID)
+	if ok && c.now().Before(l.fetchedAt.Add(c.ttl)) {
+		return l.tenants, lookupHit, nil
+	}
+
+	// Expired entries are only kept in the cache for the stale TTL.
+	if ok && c.staleTTL > 0 {
+		ch := c.lookups.DoChan(identityID, c.refresh(ctx, identityID))
+		go func() {
+			if res := <-ch; res.Err != nil {
+				c.logger.Warnf("failed to refresh the tenants of %s, serving stale: %v", identityID, res.Err)
+			}
+		}()
+		return l.tenants, lookupStale, nil
+	}
+
+	select {
+	case res := <-c.lookups.DoChan(identityID, c.refresh(ctx, identityID)):
+		if res.Err != nil {
+			return nil, lookupMiss, res.Err
+		}
+		return res.Val.(tenantSet), lookupMiss, nil
+	case <-ctx.Done():
+		return nil, lookupMiss, ctx.Err()
+	}
+}
+
+// refresh looks up the tenants of the identity and caches them. The lookup is
+// shared by every waiting caller, so it is not cancelled with the context of
+// the caller that started it, the client timeout still applies.
+func (c *CachedClient) refresh(ctx context.Context, identityID string) func() (interface{}, error) {
+	ctx = context.WithoutCancel(ctx)
+
+	return func() (interface{}, error) {
+		tenants, err := c.client.lookupTenants(ctx, identityID)
+		if err != nil {
+			return nil, err
+		}
+
+		c.cache.Set(identityID, &lookup{tenants: tenants, fetchedAt: c.now()})
+		return tenants, nil
+	}
+}
+
+// NewCachedClient wraps client with a cache holding the tenants of at most
+// size identities for ttl, and serving them for staleTTL more while they are
+// refreshed. ttl and staleTTL together must not exceed cache.MaxTTL.
+func NewCachedClient(client *Client, size int, ttl, staleTTL time.Duration, tracer tracing.TracingInterface, logger logging.LoggerInterface) (*CachedClient, error) {
+	if ttl+staleTTL > cache.MaxTTL {
+		return nil, fmt.Errorf("%w: the TTL %s and the stale TTL %s exceed %s together", ErrInvalidCacheTTL, ttl, staleTTL, cache.MaxTTL)
+	}
+
+	c := new(CachedClient)
+	c.client = client
+	c.cache = cache.NewCache[*lookup]("tenant_lookups", size, ttl+staleTTL, logger)
+	c.ttl = ttl
+	c.staleTTL = staleTTL
+	c.now = time.Now
+	c.tracer = tracer
+	c.logger = logger
+
+	registerMetrics(logger)
+
+	return c, nil
+}
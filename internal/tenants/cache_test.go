@@ -0,0 +1,194 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tenants
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	tenantpb "github.com/canonical/identity-platform-api/v0/tenant"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+
+	"github.com/canonical/hook-service/internal/cache"
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+func newTestCachedClient(t *testing.T, grpcClient TenantServiceClientInterface, staleTTL time.Duration) *CachedClient {
+	t.Helper()
+
+	client := NewClient(grpcClient, 5*time.Second, &noopTracer{}, nil, nil)
+	c, err := NewCachedClient(client, 10, time.Minute, staleTTL, &noopTracer{}, logging.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return c
+}
+
+func tenantsResponse(ids ...string) *tenantpb.LookupTenantsResponse {
+	resp := new(tenantpb.LookupTenantsResponse)
+	for _, id := range ids {
+		resp.Tenants = append(resp.Tenants, &tenantpb.Tenant{Id: id})
+	}
+	return resp
+}
+
+func TestCachedClientValidateMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	grpcClient := NewMockTenantServiceClientInterface(ctrl)
+	grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).Times(1).Return(tenantsResponse("tenant-abc"), nil)
+
+	c := newTestCachedClient(t, grpcClient, 0)
+
+	if err := c.ValidateMembership(context.Background(), "user-123", "tenant-abc"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := c.ValidateMembership(context.Background(), "user-123", "tenant-xyz"); !errors.Is(err, ErrNotMember) {
+		t.Fatalf("expected ErrNotMember, got %v", err)
+	}
+	if ids, err := c.ListTenants(context.Background(), "user-123"); err != nil || !reflect.DeepEqual(ids, []string{"tenant-abc"}) {
+		t.Fatalf("expected tenants [tenant-abc], got %v, %v", ids, err)
+	}
+}
+
+func TestCachedClientDoesNotCacheErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	grpcClient := NewMockTenantServiceClientInterface(ctrl)
+	gomock.InOrder(
+		grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).Return(nil, errors.New("backend unavailable")),
+		grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).Return(tenantsResponse("tenant-abc"), nil),
+	)
+
+	c := newTestCachedClient(t, grpcClient, 0)
+
+	if err := c.ValidateMembership(context.Background(), "user-123", "tenant-abc"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if err := c.ValidateMembership(context.Background(), "user-123", "tenant-abc"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestCachedClientCoalescesLookups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	grpcClient := NewMockTenantServiceClientInterface(ctrl)
+
+	release := make(chan struct{})
+	grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(context.Context, *tenantpb.LookupTenantsRequest, ...grpc.CallOption) (*tenantpb.LookupTenantsResponse, error) {
+			<-release
+			return tenantsResponse("tenant-abc"), nil
+		},
+	)
+
+	c := newTestCachedClient(t, grpcClient, 0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.ValidateMembership(context.Background(), "user-123", "tenant-abc")
+		}()
+	}
+
+	// Callers arriving after the lookup are served from the cache.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+}
+
+func TestCachedClientServesStale(t *testing.T) {
+	tests := []struct {
+		name     string
+		staleTTL time.Duration
+		refresh  error
+
+		expectErr    bool
+		expectResult string
+	}{
+		{
+			name:         "stale entry served while tenant-service is unavailable",
+			staleTTL:     time.Minute,
+			refresh:      errors.New("backend unavailable"),
+			expectResult: lookupStale,
+		},
+		{
+			name:         "stale entry served while refreshed",
+			staleTTL:     time.Minute,
+			expectResult: lookupStale,
+		},
+		{
+			name:         "expired entry not served without stale TTL",
+			refresh:      errors.New("backend unavailable"),
+			expectErr:    true,
+			expectResult: lookupMiss,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			grpcClient := NewMockTenantServiceClientInterface(ctrl)
+
+			refreshed := make(chan struct{})
+			gomock.InOrder(
+				grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).Return(tenantsResponse("tenant-abc"), nil),
+				grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(context.Context, *tenantpb.LookupTenantsRequest, ...grpc.CallOption) (*tenantpb.LookupTenantsResponse, error) {
+						defer close(refreshed)
+						if test.refresh != nil {
+							return nil, test.refresh
+						}
+						return tenantsResponse("tenant-abc"), nil
+					},
+				),
+			)
+
+			c := newTestCachedClient(t, grpcClient, test.staleTTL)
+			now := time.Now()
+			c.now = func() time.Time { return now }
+
+			if err := c.ValidateMembership(context.Background(), "user-123", "tenant-abc"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			now = now.Add(90 * time.Second)
+			counted := testutil.ToFloat64(tenantLookups.WithLabelValues(test.expectResult))
+			err := c.ValidateMembership(context.Background(), "user-123", "tenant-abc")
+			<-refreshed
+
+			if test.expectErr != (err != nil) {
+				t.Fatalf("expected error to be %v, got %v", test.expectErr, err)
+			}
+			if n := testutil.ToFloat64(tenantLookups.WithLabelValues(test.expectResult)); n != counted+1 {
+				t.Fatalf("expected one more %s lookup, got %v", test.expectResult, n-counted)
+			}
+		})
+	}
+}
+
+func TestNewCachedClient(t *testing.T) {
+	client := NewClient(nil, 5*time.Second, &noopTracer{}, nil, nil)
+
+	if _, err := NewCachedClient(client, 10, time.Minute, cache.MaxTTL, &noopTracer{}, logging.NewNoopLogger()); !errors.Is(err, ErrInvalidCacheTTL) {
+		t.Fatalf("expected ErrInvalidCacheTTL, got %v", err)
+	}
+	if _, err := NewCachedClient(client, 10, time.Minute, cache.MaxTTL-time.Minute, &noopTracer{}, logging.NewNoopLogger()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	tenantpb "github.com/canonical/identity-platform-api/v0/tenant"
@@ -54,30 +56,70 @@ func (c *Client) ValidateMembership(ctx context.Context, identityID, tenantID st
 		attribute.String("tenant_id", tenantID),
 	)
 
+	tenants, err := c.lookupTenants(ctx, identityID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "cannot look up tenants")
+		return err
+	}
+
+	if !tenants.contains(tenantID) {
+		span.SetStatus(codes.Ok, "membership denied")
+		return ErrNotMember
+	}
+
+	span.SetStatus(codes.Ok, "membership validated")
+	return nil
+}
+
+// ListTenants returns the sorted IDs of the tenants the user identified by
+// identityID is an active member of.
+func (c *Client) ListTenants(ctx context.Context, identityID string) ([]string, error) {
+	ctx, span := c.tracer.Start(ctx, "tenants.Client.ListTenants")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("identity_id", identityID))
+
+	tenants, err := c.lookupTenants(ctx, identityID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "cannot look up tenants")
+		return nil, err
+	}
+
+	return tenants.ids(), nil
+}
+
+// tenantSet holds the IDs of the tenants an identity is an active member of.
+type tenantSet map[string]struct{}
+
+func (t tenantSet) contains(tenantID string) bool {
+	_, ok := t[tenantID]
+	return ok
+}
+
+func (t tenantSet) ids() []string {
+	return slices.Sorted(maps.Keys(t))
+}
+
+// lookupTenants returns the tenants of the identity from tenant-service.
+func (c *Client) lookupTenants(ctx context.Context, identityID string) (tenantSet, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	resp, err := c.grpcClient.LookupTenants(ctx, &tenantpb.LookupTenantsRequest{IdentityId: identityID})
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "cannot look up tenants")
-		return fmt.Errorf("cannot look up tenants: %v", err)
+		return nil, fmt.Errorf("cannot look up tenants: %v", err)
 	}
 
 	if resp == nil {
-		err := errors.New("empty response")
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "empty tenant-service response")
-		return fmt.Errorf("cannot look up tenants: %v", err)
+		return nil, fmt.Errorf("cannot look up tenants: %v", errors.New("empty response"))
 	}
 
+	tenants := make(tenantSet, len(resp.GetTenants()))
 	for _, tenant := range resp.GetTenants() {
-		if tenant.GetId() == tenantID {
-			span.SetStatus(codes.Ok, "membership validated")
-			return nil
-		}
+		tenants[tenant.GetId()] = struct{}{}
 	}
 
-	span.SetStatus(codes.Ok, "membership denied")
-	return ErrNotMember
+	return tenants, nil
 }
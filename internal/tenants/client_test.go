@@ -6,6 +6,7 @@ package tenants
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -103,6 +104,24 @@ func TestClientValidateMembership(t *testing.T) {
 	}
 }
 
+func TestClientListTenants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	grpcClient := NewMockTenantServiceClientInterface(ctrl)
+	grpcClient.EXPECT().LookupTenants(gomock.Any(), gomock.Any()).Return(&tenantpb.LookupTenantsResponse{
+		Tenants: []*tenantpb.Tenant{{Id: "tenant-def"}, {Id: "tenant-abc"}},
+	}, nil)
+
+	client := NewClient(grpcClient, 5*time.Second, &noopTracer{}, nil, nil)
+	ids, err := client.ListTenants(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"tenant-abc", "tenant-def"}) {
+		t.Fatalf("expected sorted tenants, got %v", ids)
+	}
+}
+
 // noopTracer satisfies TracingInterface without requiring gomock.
 type noopTracer struct{}
 
@@ -21,4 +21,7 @@ type TenantValidatorInterface interface {
 	// active member of the given tenant. Returns nil if valid, ErrNotMember
 	// if not, or an error on failure.
 	ValidateMembership(ctx context.Context, identityID, tenantID string) error
+	// ListTenants returns the sorted IDs of the tenants the user identified
+	// by identityID is an active member of.
+	ListTenants(ctx context.Context, identityID string) ([]string, error)
 }
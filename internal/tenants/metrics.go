@@ -0,0 +1,35 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tenants
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+var (
+	tenantLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_tenant_lookups_total",
+		Help: "Total number of tenant membership lookups by cache result (hit, miss or stale)",
+	}, []string{"result"})
+
+	registerOnce sync.Once
+)
+
+// registerMetrics registers the tenants collectors once per process.
+func registerMetrics(logger logging.LoggerInterface) {
+	registerOnce.Do(func() {
+		err := prometheus.Register(tenantLookups)
+		switch err.(type) {
+		case nil:
+		case prometheus.AlreadyRegisteredError:
+			logger.Debugf("metric %v already registered", tenantLookups)
+		default:
+			logger.Errorf("metric %v could not be registered", tenantLookups)
+		}
+	})
+}
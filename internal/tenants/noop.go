@@ -18,3 +18,8 @@ func NewNoopValidator() *NoopValidator {
 func (n *NoopValidator) ValidateMembership(_ context.Context, _, _ string) error {
 	return nil
 }
+
+// ListTenants always returns no tenants.
+func (n *NoopValidator) ListTenants(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxAttributeKeyLength   = 63
+	MaxAttributeValueLength = 1024
+)
+
+var ErrInvalidAttribute = errors.New("invalid attribute")
+
+// attributeKey matches the attribute keys: letters, digits, `_`, `-` and `.`,
+// starting with a letter.
+var attributeKey = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// ValidateAttributeKey checks that key can name a group attribute.
+func ValidateAttributeKey(key string) error {
+	if len(key) > MaxAttributeKeyLength || !attributeKey.MatchString(key) {
+		return fmt.Errorf("%w: key %q must start with a letter and hold at most %d letters, digits, '_', '-' or '.'", ErrInvalidAttribute, key, MaxAttributeKeyLength)
+	}
+	return nil
+}
+
+// ValidateAttributes checks the keys and values of group attributes.
+func ValidateAttributes(attributes map[string]string) error {
+	for k, v := range attributes {
+		if err := ValidateAttributeKey(k); err != nil {
+			return err
+		}
+		if len(v) > MaxAttributeValueLength {
+			return fmt.Errorf("%w: value of %q is longer than %d bytes", ErrInvalidAttribute, k, MaxAttributeValueLength)
+		}
+	}
+	return nil
+}
+
+// ParseAttributes parses `key=value` pairs into attributes, a later pair
+// overrides an earlier one with the same key.
+func ParseAttributes(pairs []string) (map[string]string, error) {
+	attributes := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not a key=value pair", ErrInvalidAttribute, pair)
+		}
+		attributes[k] = v
+	}
+
+	if err := ValidateAttributes(attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
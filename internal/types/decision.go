@@ -0,0 +1,35 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import "time"
+
+// Decision is a persisted authorization decision of the token hook.
+type Decision struct {
+	ID         int64         `json:"id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UserID     string        `json:"user_id"`
+	ClientID   string        `json:"client_id"`
+	GrantTypes []string      `json:"grant_types"`
+	Audience   []string      `json:"audience"`
+	TenantID   string        `json:"tenant_id"`
+	Groups     []string      `json:"groups"`
+	Allowed    bool          `json:"allowed"`
+	Shadow     bool          `json:"shadow"`
+	Reason     string        `json:"reason"`
+	Latency    time.Duration `json:"latency"`
+}
+
+// DecisionFilter selects decisions, empty fields match every decision.
+// Results are ordered from the newest to the oldest and paginated with
+// the ID of the last decision of the previous page.
+type DecisionFilter struct {
+	UserID   string
+	ClientID string
+	Since    time.Time
+	Until    time.Time
+	// BeforeID only selects decisions older than the given ID, 0 disables it.
+	BeforeID int64
+	Limit    uint64
+}
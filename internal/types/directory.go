@@ -0,0 +1,60 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+// Operators of a Condition.
+const (
+	CondAnd = "and"
+	CondOr  = "or"
+	CondNot = "not"
+	// CondMember matches the groups having a member matching the only
+	// operand, whose columns are those of the members.
+	CondMember = "member"
+
+	CondEq      = "eq"
+	CondNe      = "ne"
+	CondContain = "co"
+	CondStart   = "sw"
+	CondEnd     = "ew"
+	CondGt      = "gt"
+	CondGe      = "ge"
+	CondLt      = "lt"
+	CondLe      = "le"
+	CondPresent = "pr"
+)
+
+// Columns of the members matched by a CondMember condition: the ID of the
+// SCIM user or else the stored user ID, its display name and its type.
+const (
+	MemberValue   = "value"
+	MemberDisplay = "display"
+	MemberType    = "type"
+)
+
+// Condition is a boolean expression over the columns of the users or the
+// groups, translated from a SCIM filter. Without operands, CondAnd is always
+// true and CondOr always false.
+type Condition struct {
+	Op       string
+	Operands []*Condition
+
+	// Column is compared with Value by the comparison operators. Strings are
+	// compared case-insensitively unless CaseExact is set, an empty string is
+	// not present.
+	Column    string
+	Value     any
+	CaseExact bool
+}
+
+// DirectoryFilter selects a page of the SCIM users or groups. Users are
+// ordered by user name, groups by name.
+type DirectoryFilter struct {
+	// Where only selects the rows matching the condition, nil matches every
+	// row.
+	Where *Condition
+	// Offset skips the first rows of the listing.
+	Offset uint64
+	// Limit caps the number of rows of the page, 0 only counts the rows.
+	Limit uint64
+}
@@ -35,15 +35,37 @@ type Group struct {
 	TenantId    string    `json:"tenant" default:"default"`
 	Description string    `json:"description"`
 	Type        GroupType `json:"type" default:"local"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	// Attributes are the custom key/value attributes of the group, such as
+	// its cost centre or Slack channel.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	// DeletedAt is set on the deleted groups awaiting their purge.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // GroupUser represents a user's membership in a group.
 type GroupUser struct {
-	ID       string `json:"id"`
-	Role     Role   `json:"role"`
-	TenantId string `json:"tenant" default:"default"`
+	ID        string    `json:"id"`
+	Role      Role      `json:"role"`
+	TenantId  string    `json:"tenant" default:"default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Membership is the membership of a user in a group, held directly or
+// inherited through nested groups.
+type Membership struct {
+	UserID string `json:"user_id"`
+	Group  *Group `json:"group,omitempty"`
+	// Path lists the names of the groups from the one the user is a direct
+	// member of to the group of the membership, following the shortest chain.
+	Path []string `json:"path"`
+}
+
+// Direct reports whether the user is a direct member of the group.
+func (m *Membership) Direct() bool {
+	return len(m.Path) <= 1
 }
 
 // ParseGroupType converts a string to a GroupType.
@@ -0,0 +1,58 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import "time"
+
+// HistoryAction is the kind of change recorded in a history entry.
+type HistoryAction string
+
+const (
+	HistoryGroupCreated           HistoryAction = "group.created"
+	HistoryGroupUpdated           HistoryAction = "group.updated"
+	HistoryGroupDeleted           HistoryAction = "group.deleted"
+	HistoryGroupRestored          HistoryAction = "group.restored"
+	HistoryGroupPurged            HistoryAction = "group.purged"
+	HistoryGroupAttributesChanged HistoryAction = "group.attributes_changed"
+	HistoryMemberAdded            HistoryAction = "member.added"
+	HistoryMemberRemoved          HistoryAction = "member.removed"
+	HistoryOwnerAdded             HistoryAction = "owner.added"
+	HistoryOwnerRemoved           HistoryAction = "owner.removed"
+	HistorySubgroupAdded          HistoryAction = "subgroup.added"
+	HistorySubgroupRemoved        HistoryAction = "subgroup.removed"
+	HistoryAppGranted             HistoryAction = "app.granted"
+	HistoryAppRevoked             HistoryAction = "app.revoked"
+	HistoryUserCreated            HistoryAction = "user.created"
+	HistoryUserUpdated            HistoryAction = "user.updated"
+	HistoryUserDeleted            HistoryAction = "user.deleted"
+)
+
+// HistoryEntry is an append-only record of a change to a group, one of its
+// members or one of its app grants. Before and After hold the changed values,
+// either is nil when the change creates or removes them.
+type HistoryEntry struct {
+	ID        int64                  `json:"id"`
+	CreatedAt time.Time              `json:"created_at"`
+	TenantID  string                 `json:"tenant_id"`
+	GroupID   string                 `json:"group_id,omitempty"`
+	UserID    string                 `json:"user_id,omitempty"`
+	Action    HistoryAction          `json:"action"`
+	Actor     string                 `json:"actor"`
+	Source    string                 `json:"source"`
+	Before    map[string]interface{} `json:"before,omitempty"`
+	After     map[string]interface{} `json:"after,omitempty"`
+}
+
+// HistoryFilter selects history entries, empty fields match every entry.
+// Results are ordered from the newest to the oldest and paginated with
+// the ID of the last entry of the previous page.
+type HistoryFilter struct {
+	GroupID string
+	UserID  string
+	Since   time.Time
+	Until   time.Time
+	// BeforeID only selects entries older than the given ID, 0 disables it.
+	BeforeID int64
+	Limit    uint64
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Orders of the listings, rows with the same sort value are ordered by ID.
+const (
+	SortByName      = "name"
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+)
+
+// sortByID orders the listings of rows appended in ID order, such as the
+// logs. It is not selectable through ListOptions.
+const sortByID = "id"
+
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+var (
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidPageSize  = errors.New("invalid page size")
+	ErrInvalidSort      = errors.New("invalid sort")
+	ErrInvalidTimeRange = errors.New("invalid time range")
+)
+
+// ListFilter selects, orders and paginates the rows of a listing, empty
+// fields match every row. A nil filter lists every row by name.
+type ListFilter struct {
+	// Search matches a case-insensitive substring of the group name, or of
+	// the user or app ID for the members and app grants of a group.
+	Search string
+	// Type only selects groups of the given type, it is ignored by the
+	// other listings.
+	Type     *GroupType
+	TenantID string
+	// Attributes only selects groups holding every given attribute, it is
+	// ignored by the other listings.
+	Attributes map[string]string
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+
+	SortBy     string
+	Descending bool
+
+	// After only selects the rows following the cursor in the order of the
+	// listing, nil starts from the first row.
+	After *Cursor
+	// Limit caps the number of rows of the page, 0 disables it.
+	Limit uint64
+	// CountTotal counts the rows matching the filter, regardless of the
+	// cursor and limit.
+	CountTotal bool
+}
+
+// Cursor is the position of a row in a listing: its sort value and ID.
+type Cursor struct {
+	Value string `json:"v"`
+	ID    string `json:"i"`
+}
+
+// Page describes the page of a listing returned by the storage.
+type Page struct {
+	// Next is the cursor of the next page, nil on the last page.
+	Next *Cursor
+	// Total is the number of rows matching the filter, nil unless counted.
+	Total *int64
+}
+
+// ListOptions filters, orders and paginates a listing requested through the
+// API, empty fields match every row.
+type ListOptions struct {
+	Search     string
+	Type       *GroupType
+	TenantID   string
+	Attributes map[string]string
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+
+	SortBy     string
+	Descending bool
+
+	PageSize  int
+	PageToken string
+}
+
+// PageInfo describes the page of a listing returned through the API.
+type PageInfo struct {
+	// NextPageToken is the token of the next page, empty on the last page.
+	NextPageToken string
+	// Total is the number of rows matching the filter, only counted for the
+	// first page.
+	Total *int64
+}
+
+// pageToken is the opaque token of a page. It holds the order of the
+// listing, so that a token is not reused with another order.
+type pageToken struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	Cursor
+}
+
+// Filter validates the options and returns the storage filter of the page,
+// the total is only counted for the first page. Nil options return a nil
+// filter, listing every row.
+func (o *ListOptions) Filter() (*ListFilter, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	size, err := PageSize(o.PageSize, DefaultPageSize, MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := o.SortBy
+	switch sortBy {
+	case "":
+		sortBy = SortByName
+	case SortByName, SortByCreatedAt, SortByUpdatedAt:
+	default:
+		return nil, fmt.Errorf("%w: must be one of %s, %s or %s", ErrInvalidSort, SortByName, SortByCreatedAt, SortByUpdatedAt)
+	}
+
+	if !validRange(o.CreatedAfter, o.CreatedBefore) || !validRange(o.UpdatedAfter, o.UpdatedBefore) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	f := &ListFilter{
+		Search:        o.Search,
+		Type:          o.Type,
+		TenantID:      o.TenantID,
+		Attributes:    o.Attributes,
+		CreatedAfter:  o.CreatedAfter,
+		CreatedBefore: o.CreatedBefore,
+		UpdatedAfter:  o.UpdatedAfter,
+		UpdatedBefore: o.UpdatedBefore,
+		SortBy:        sortBy,
+		Descending:    o.Descending,
+		Limit:         uint64(size),
+		CountTotal:    o.PageToken == "",
+	}
+
+	if o.PageToken != "" {
+		c, err := decodePageToken(o.PageToken, sortBy, o.Descending)
+		if err != nil {
+			return nil, err
+		}
+		f.After = c
+	}
+
+	return f, nil
+}
+
+// PageInfo returns the API description of a page listed with the filter.
+func (f *ListFilter) PageInfo(page *Page) *PageInfo {
+	info := new(PageInfo)
+	if f == nil || page == nil {
+		return info
+	}
+
+	info.Total = page.Total
+	if page.Next != nil {
+		info.NextPageToken = encodePageToken(&pageToken{SortBy: f.SortBy, Descending: f.Descending, Cursor: *page.Next})
+	}
+	return info
+}
+
+// StreamToken encodes the cursor of a streamed row as the opaque resume
+// token of a stream ordered by order.
+func StreamToken(order string, c *Cursor) string {
+	return encodePageToken(&pageToken{SortBy: order, Cursor: *c})
+}
+
+// ParseStreamToken decodes the resume token of a stream ordered by order.
+func ParseStreamToken(token, order string) (*Cursor, error) {
+	return decodePageToken(token, order, false)
+}
+
+// IDPageToken encodes the ID of the last row of a page as the token of the
+// next page of a listing in ID order.
+func IDPageToken(id int64) string {
+	return encodePageToken(&pageToken{SortBy: sortByID, Cursor: Cursor{ID: strconv.FormatInt(id, 10)}})
+}
+
+// ParseIDPageToken decodes the page token of a listing in ID order, an
+// empty token returns 0.
+func ParseIDPageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	c, err := decodePageToken(token, sortByID, false)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.ParseInt(c.ID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidPageToken
+	}
+
+	return id, nil
+}
+
+// PageSize validates a requested page size, 0 selects defaultSize.
+func PageSize(size, defaultSize, maxSize int) (int, error) {
+	switch {
+	case size == 0:
+		return defaultSize, nil
+	case size < 0 || size > maxSize:
+		return 0, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPageSize, maxSize)
+	}
+	return size, nil
+}
+
+func validRange(after, before time.Time) bool {
+	return after.IsZero() || before.IsZero() || after.Before(before)
+}
+
+func encodePageToken(t *pageToken) string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token, sortBy string, descending bool) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	t := new(pageToken)
+	if err := json.Unmarshal(b, t); err != nil || t.ID == "" {
+		return nil, ErrInvalidPageToken
+	}
+	if t.SortBy != sortBy || t.Descending != descending {
+		return nil, fmt.Errorf("%w: the token was issued for another order", ErrInvalidPageToken)
+	}
+
+	return &t.Cursor, nil
+}
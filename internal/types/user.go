@@ -0,0 +1,21 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import "time"
+
+// User is a user provisioned through SCIM. Its group memberships are stored
+// under its UserName, which the token hook looks users up by.
+type User struct {
+	ID          string    `json:"id"`
+	UserName    string    `json:"user_name"`
+	ExternalID  string    `json:"external_id"`
+	DisplayName string    `json:"display_name"`
+	GivenName   string    `json:"given_name"`
+	FamilyName  string    `json:"family_name"`
+	Email       string    `json:"email"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
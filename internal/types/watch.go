@@ -0,0 +1,57 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import "time"
+
+// MembershipEventType is the kind of a membership event streamed to the
+// watchers.
+type MembershipEventType string
+
+const (
+	// MembershipSnapshot is a membership held when the watch started.
+	MembershipSnapshot MembershipEventType = "snapshot"
+	// MembershipSnapshotEnd follows the last snapshot event.
+	MembershipSnapshotEnd MembershipEventType = "snapshot_end"
+	MembershipAdded       MembershipEventType = "added"
+	MembershipRemoved     MembershipEventType = "removed"
+	// MembershipUpdated is a change of role.
+	MembershipUpdated MembershipEventType = "updated"
+	// MembershipHeartbeat is sent when nothing else was sent for a while,
+	// with the latest cursor.
+	MembershipHeartbeat MembershipEventType = "heartbeat"
+)
+
+// MembershipFilter selects direct memberships, empty fields match every
+// membership.
+type MembershipFilter struct {
+	TenantID string
+	GroupID  string
+	UserID   string
+	// IncludeDeleted also selects the memberships of the deleted groups.
+	IncludeDeleted bool
+}
+
+// Matches reports whether a membership of a user in a group of a tenant is
+// selected by the filter.
+func (f *MembershipFilter) Matches(tenantID, groupID, userID string) bool {
+	return (f.TenantID == "" || f.TenantID == tenantID) &&
+		(f.GroupID == "" || f.GroupID == groupID) &&
+		(f.UserID == "" || f.UserID == userID)
+}
+
+// MembershipEvent is a direct membership of a user in a group, or a change
+// of one, streamed to the watchers.
+type MembershipEvent struct {
+	Type     MembershipEventType
+	TenantID string
+	GroupID  string
+	UserID   string
+	// Role is the role of the user in the group, before the removal on the
+	// removed events.
+	Role Role
+	Time time.Time
+	// Cursor resumes the watch after the event.
+	Cursor string
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package types
+
+import "time"
+
+// WebhookEventTypes are the history actions written to the outbox and
+// delivered to the webhooks.
+var WebhookEventTypes = []HistoryAction{
+	HistoryMemberAdded,
+	HistoryMemberRemoved,
+	HistoryOwnerAdded,
+	HistoryOwnerRemoved,
+	HistorySubgroupAdded,
+	HistorySubgroupRemoved,
+	HistoryAppGranted,
+	HistoryAppRevoked,
+	HistoryGroupDeleted,
+	HistoryGroupRestored,
+}
+
+// OutboxEvent is a membership or app grant change waiting to be delivered to
+// the webhooks, it is the body of the webhook requests.
+type OutboxEvent struct {
+	ID        int64                  `json:"id"`
+	CreatedAt time.Time              `json:"created_at"`
+	TenantID  string                 `json:"tenant_id"`
+	Type      HistoryAction          `json:"type"`
+	GroupID   string                 `json:"group_id,omitempty"`
+	UserID    string                 `json:"user_id,omitempty"`
+	Actor     string                 `json:"actor"`
+	Source    string                 `json:"source"`
+	Before    map[string]interface{} `json:"before,omitempty"`
+	After     map[string]interface{} `json:"after,omitempty"`
+}
+
+// WebhookDeliveryStatus is the state of a dead-lettered delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryFailed means every attempt failed.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+	// WebhookDeliveryPending means the delivery was replayed and waits for
+	// the dispatcher.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered means a replay succeeded.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+)
+
+// WebhookDelivery is an event a subscriber failed to accept.
+type WebhookDelivery struct {
+	ID         int64                 `json:"id"`
+	Subscriber string                `json:"subscriber"`
+	Status     WebhookDeliveryStatus `json:"status"`
+	Attempts   int                   `json:"attempts"`
+	LastError  string                `json:"last_error"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+	Event      *OutboxEvent          `json:"event"`
+}
+
+// WebhookDeliveryFilter selects dead-lettered deliveries, empty fields match
+// every delivery. Results are ordered from the oldest to the newest and
+// paginated with the ID of the last delivery of the previous page.
+type WebhookDeliveryFilter struct {
+	Subscriber string
+	Status     WebhookDeliveryStatus
+	// AfterID only selects deliveries newer than the given ID, 0 disables it.
+	AfterID int64
+	Limit   uint64
+}
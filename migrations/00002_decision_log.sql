@@ -0,0 +1,40 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS authz_decisions
+(
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    user_id VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    grant_types TEXT[] NOT NULL DEFAULT '{}',
+    audience TEXT[] NOT NULL DEFAULT '{}',
+    tenant_id VARCHAR(255) NOT NULL DEFAULT '',
+    groups TEXT[] NOT NULL DEFAULT '{}',
+
+    allowed BOOLEAN NOT NULL,
+    shadow BOOLEAN NOT NULL DEFAULT false,
+    reason VARCHAR(64) NOT NULL,
+    latency_us BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_authz_decisions_created_at ON authz_decisions(created_at);
+CREATE INDEX IF NOT EXISTS idx_authz_decisions_user_id ON authz_decisions(user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_authz_decisions_client_id ON authz_decisions(client_id, id DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_authz_decisions_client_id;
+DROP INDEX IF EXISTS idx_authz_decisions_user_id;
+DROP INDEX IF EXISTS idx_authz_decisions_created_at;
+
+DROP TABLE IF EXISTS authz_decisions;
+
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Members of the child group are members of the parent group. Both groups
+-- belong to the same tenant, cycles are rejected on write.
+CREATE TABLE IF NOT EXISTS group_subgroups
+(
+    parent_id UUID NOT NULL,
+    child_id UUID NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+
+    PRIMARY KEY (parent_id, child_id),
+
+    CHECK (parent_id <> child_id),
+
+    FOREIGN KEY (parent_id, tenant_id)
+        REFERENCES groups(id, tenant_id)
+        ON DELETE CASCADE,
+
+    FOREIGN KEY (child_id, tenant_id)
+        REFERENCES groups(id, tenant_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_subgroups_child_id ON group_subgroups(child_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_group_subgroups_child_id;
+
+DROP TABLE IF EXISTS group_subgroups;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keyset pagination of the groups of a tenant by creation and update time.
+CREATE INDEX IF NOT EXISTS idx_groups_created_at ON groups(tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_groups_updated_at ON groups(tenant_id, updated_at, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_groups_updated_at;
+DROP INDEX IF EXISTS idx_groups_created_at;
+
+-- +goose StatementEnd
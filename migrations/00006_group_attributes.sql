@@ -0,0 +1,21 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Custom key/value attributes of the groups, searched by containment.
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_groups_attributes ON groups USING GIN (attributes jsonb_path_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_groups_attributes;
+
+ALTER TABLE groups DROP COLUMN IF EXISTS attributes;
+
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deleted groups are kept with their members, nesting and app grants until
+-- they are purged, so that they can be restored.
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- The name of a deleted group can be reused.
+DROP INDEX IF EXISTS idx_groups_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_groups_name ON groups(tenant_id, name) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_groups_deleted_at ON groups(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM groups WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_groups_deleted_at;
+
+DROP INDEX IF EXISTS idx_groups_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_groups_name ON groups(tenant_id, name);
+
+ALTER TABLE groups DROP COLUMN IF EXISTS deleted_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Append-only history of the changes to the groups, their members and their
+-- app grants. Rows do not reference the groups or the users so that they
+-- outlive them.
+CREATE TABLE IF NOT EXISTS group_history
+(
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+
+    group_id UUID,
+    user_id VARCHAR(255),
+    action VARCHAR(64) NOT NULL,
+
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(64) NOT NULL DEFAULT '',
+
+    before JSONB,
+    after JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_history_group_id ON group_history(group_id, id DESC) WHERE group_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_group_history_user_id ON group_history(user_id, id DESC) WHERE user_id IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION group_history_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'group_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER group_history_append_only
+    BEFORE UPDATE OR DELETE ON group_history
+    FOR EACH ROW EXECUTE FUNCTION group_history_append_only();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS group_history_append_only ON group_history;
+DROP FUNCTION IF EXISTS group_history_append_only();
+
+DROP INDEX IF EXISTS idx_group_history_user_id;
+DROP INDEX IF EXISTS idx_group_history_group_id;
+
+DROP TABLE IF EXISTS group_history;
+
+-- +goose StatementEnd
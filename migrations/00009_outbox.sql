@@ -0,0 +1,78 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0-only
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Membership and app grant changes to deliver to the webhooks, written in the
+-- transaction of the change. created_at is the time of the insert rather than
+-- the start of the transaction, the dispatcher only reads the events old
+-- enough for every transaction that wrote an earlier ID to have ended.
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT clock_timestamp(),
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+
+    type VARCHAR(64) NOT NULL,
+    group_id UUID,
+    user_id VARCHAR(255),
+
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(64) NOT NULL DEFAULT '',
+
+    before JSONB,
+    after JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_created_at ON outbox_events(created_at);
+
+-- Last event handed to each subscriber, the instance holding the lease is
+-- the only one delivering to the subscriber.
+CREATE TABLE IF NOT EXISTS webhook_cursors
+(
+    subscriber VARCHAR(255) PRIMARY KEY,
+    last_event_id BIGINT NOT NULL,
+
+    owner VARCHAR(255) NOT NULL,
+    lease_until TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Events a subscriber failed to accept after every attempt, kept until
+-- they are replayed.
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id BIGSERIAL PRIMARY KEY,
+    subscriber VARCHAR(255) NOT NULL,
+    event_id BIGINT NOT NULL REFERENCES outbox_events(id) ON DELETE CASCADE,
+
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    UNIQUE (subscriber, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(subscriber, status, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_status;
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP TABLE IF EXISTS webhook_cursors;
+
+DROP INDEX IF EXISTS idx_outbox_events_created_at;
+DROP TABLE IF EXISTS outbox_events;
+
+-- +goose StatementEnd
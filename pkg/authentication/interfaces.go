@@ -18,4 +18,7 @@ type TokenVerifierInterface interface {
 	// VerifyToken verifies a raw JWT string and validates authorization claims
 	// Returns true if the token is valid and authorized, false otherwise
 	VerifyToken(ctx context.Context, rawToken string) (bool, error)
+	// VerifyPrincipal verifies a raw JWT string and returns its caller,
+	// whether or not it is authorized for the whole API
+	VerifyPrincipal(ctx context.Context, rawToken string) (*Principal, error)
 }
@@ -8,11 +8,20 @@ import (
 	"net/http"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/storage"
 	"github.com/canonical/hook-service/internal/tracing"
 )
 
+// TenantHeader selects the tenant of an API request.
+const TenantHeader = "X-Tenant-ID"
+
+// maxTenantIDLength is the size of the tenant_id columns.
+const maxTenantIDLength = 255
+
 type Middleware struct {
 	verifier TokenVerifierInterface
 
@@ -33,19 +42,47 @@ func (m *Middleware) Authenticate() func(http.Handler) http.Handler {
 				return
 			}
 
-			authorized, err := m.verifier.VerifyToken(ctx, token)
+			principal, err := m.verifier.VerifyPrincipal(ctx, token)
 			if err != nil {
 				m.logger.Debugf("JWT verification failed: %v", err)
 				m.unauthorizedResponse(w, "invalid token")
 				return
 			}
 
-			if !authorized {
+			if !principal.Admin {
+				m.logger.Security().AuthzFailure(principal.Subject, "jwt_api_access")
 				m.unauthorizedResponse(w, "unauthorized")
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(PrincipalToContext(ctx, principal)))
+		})
+	}
+}
+
+// AuthenticateDelegated accepts any valid token. The caller is added to the
+// request context, so that the endpoint can check that a caller without
+// access to the whole API is allowed to use it.
+func (m *Middleware) AuthenticateDelegated() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := m.tracer.Start(r.Context(), "authentication.Middleware.AuthenticateDelegated")
+			defer span.End()
+
+			token, found := m.getBearerToken(r.Header)
+			if !found {
+				m.unauthorizedResponse(w, "missing authorization header")
+				return
+			}
+
+			principal, err := m.verifier.VerifyPrincipal(ctx, token)
+			if err != nil {
+				m.logger.Debugf("JWT verification failed: %v", err)
+				m.unauthorizedResponse(w, "invalid token")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(PrincipalToContext(ctx, principal)))
 		})
 	}
 }
@@ -64,14 +101,69 @@ func (m *Middleware) getBearerToken(headers http.Header) (string, bool) {
 	return strings.TrimPrefix(bearer, "Bearer "), true
 }
 
+// Tenant scopes the storage operations of a request to a tenant: the tenant
+// of the caller's token, or else the one of the X-Tenant-ID header, or else
+// the default tenant. A request selecting another tenant than the one of
+// the token is forbidden. It must run after the authentication middleware.
+func (m *Middleware) Tenant() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := m.tracer.Start(r.Context(), "authentication.Middleware.Tenant")
+			defer span.End()
+
+			tenantID := strings.TrimSpace(r.Header.Get(TenantHeader))
+			if len(tenantID) > maxTenantIDLength {
+				m.errorResponse(w, http.StatusBadRequest, "invalid tenant")
+				return
+			}
+
+			if principal, ok := PrincipalFromContext(ctx); ok && principal.TenantID != "" {
+				if tenantID != "" && tenantID != principal.TenantID {
+					m.logger.Security().AuthzFailure(principal.Subject, "tenant:"+tenantID)
+					m.errorResponse(w, http.StatusForbidden, "token is not valid for the tenant")
+					return
+				}
+				tenantID = principal.TenantID
+			}
+
+			if tenantID == "" {
+				tenantID = storage.DefaultTenantID
+			}
+
+			span.SetAttributes(attribute.String("tenant.id", tenantID))
+			next.ServeHTTP(w, r.WithContext(storage.WithTenant(ctx, tenantID)))
+		})
+	}
+}
+
+// Actor records the caller of a request, the subject of its token, as the
+// actor of the changes it makes in the group history. It must run after the
+// authentication middleware.
+func (m *Middleware) Actor(source string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := storage.Actor{Source: source}
+			if principal, ok := PrincipalFromContext(r.Context()); ok {
+				actor.ID = principal.Subject
+			}
+
+			next.ServeHTTP(w, r.WithContext(storage.WithActor(r.Context(), actor)))
+		})
+	}
+}
+
 func (m *Middleware) unauthorizedResponse(w http.ResponseWriter, message string) {
+	m.errorResponse(w, http.StatusUnauthorized, message)
+}
+
+func (m *Middleware) errorResponse(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
+	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":  http.StatusUnauthorized,
+		"status":  status,
 		"message": message,
 	}); err != nil {
-		m.logger.Errorf("failed to encode unauthorized response: %v", err)
+		m.logger.Errorf("failed to encode error response: %v", err)
 	}
 }
 
@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/storage"
 )
 
 func TestMiddleware_Authenticate(t *testing.T) {
@@ -45,7 +49,7 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			authHeader: "Bearer invalid-token",
 			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
 				mockVerifier := NewMockTokenVerifierInterface(ctrl)
-				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "invalid-token").Return(false, fmt.Errorf("invalid token"))
+				mockVerifier.EXPECT().VerifyPrincipal(gomock.Any(), "invalid-token").Return(nil, fmt.Errorf("invalid token"))
 				return mockVerifier
 			},
 			expectedStatusCode: http.StatusUnauthorized,
@@ -55,7 +59,7 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			authHeader: "Bearer valid-token",
 			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
 				mockVerifier := NewMockTokenVerifierInterface(ctrl)
-				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return(false, nil)
+				mockVerifier.EXPECT().VerifyPrincipal(gomock.Any(), "valid-token").Return(&Principal{Subject: "alice"}, nil)
 				return mockVerifier
 			},
 			expectedStatusCode: http.StatusUnauthorized,
@@ -65,7 +69,7 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			authHeader: "Bearer valid-token",
 			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
 				mockVerifier := NewMockTokenVerifierInterface(ctrl)
-				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return(true, nil)
+				mockVerifier.EXPECT().VerifyPrincipal(gomock.Any(), "valid-token").Return(&Principal{Subject: "admin", Admin: true}, nil)
 				return mockVerifier
 			},
 			expectedStatusCode: http.StatusOK,
@@ -85,11 +89,18 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), "authentication.Middleware.Authenticate").Return(ctx, trace.SpanFromContext(ctx))
 			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
 
+			mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+			mockSecurity.EXPECT().AuthzFailure("alice", "jwt_api_access").AnyTimes()
+			mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+
 			mockVerifier := tt.setupMocks(ctrl)
 
 			middleware := NewMiddleware(mockVerifier, mockTracer, mockMonitor, mockLogger)
 
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, ok := PrincipalFromContext(r.Context()); !ok {
+					t.Errorf("expected the principal in the request context")
+				}
 				w.WriteHeader(http.StatusOK)
 				w.Write([]byte("success"))
 			})
@@ -113,6 +124,232 @@ func TestMiddleware_Authenticate(t *testing.T) {
 	}
 }
 
+func TestMiddleware_AuthenticateDelegated(t *testing.T) {
+	tests := []struct {
+		name               string
+		authHeader         string
+		principal          *Principal
+		verifyErr          error
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing token - rejects request",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Token verification fails - rejects request",
+			authHeader:         "Bearer invalid-token",
+			verifyErr:          fmt.Errorf("invalid token"),
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Valid token without admin access - adds principal",
+			authHeader:         "Bearer valid-token",
+			principal:          &Principal{Subject: "alice", Email: "alice@example.com"},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockVerifier := NewMockTokenVerifierInterface(ctrl)
+
+			ctx := context.Background()
+			mockTracer.EXPECT().Start(gomock.Any(), "authentication.Middleware.AuthenticateDelegated").Return(ctx, trace.SpanFromContext(ctx))
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+			if tt.authHeader != "" {
+				mockVerifier.EXPECT().VerifyPrincipal(gomock.Any(), gomock.Any()).Return(tt.principal, tt.verifyErr)
+			}
+
+			middleware := NewMiddleware(mockVerifier, mockTracer, mockMonitor, mockLogger)
+
+			var principal *Principal
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = PrincipalFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rr := httptest.NewRecorder()
+
+			middleware.AuthenticateDelegated()(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("expected status %d, got %d", tt.expectedStatusCode, rr.Code)
+			}
+			if principal != tt.principal {
+				t.Errorf("expected principal %v, got %v", tt.principal, principal)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Tenant(t *testing.T) {
+	tests := []struct {
+		name               string
+		header             string
+		principal          *Principal
+		expectedStatusCode int
+		expectedTenant     string
+	}{
+		{
+			name:               "No tenant - uses the default tenant",
+			expectedStatusCode: http.StatusOK,
+			expectedTenant:     storage.DefaultTenantID,
+		},
+		{
+			name:               "Tenant header",
+			header:             "acme",
+			principal:          &Principal{Subject: "admin", Admin: true},
+			expectedStatusCode: http.StatusOK,
+			expectedTenant:     "acme",
+		},
+		{
+			name:               "Tenant of the token",
+			principal:          &Principal{Subject: "alice", TenantID: "acme"},
+			expectedStatusCode: http.StatusOK,
+			expectedTenant:     "acme",
+		},
+		{
+			name:               "Tenant header matching the token",
+			header:             "acme",
+			principal:          &Principal{Subject: "alice", TenantID: "acme"},
+			expectedStatusCode: http.StatusOK,
+			expectedTenant:     "acme",
+		},
+		{
+			name:               "Tenant header of another tenant - forbids request",
+			header:             "globex",
+			principal:          &Principal{Subject: "alice", TenantID: "acme"},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Tenant header too long - rejects request",
+			header:             strings.Repeat("a", 256),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authentication.Middleware.Tenant").DoAndReturn(
+				func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+					return ctx, trace.SpanFromContext(ctx)
+				},
+			)
+
+			mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+			mockSecurity.EXPECT().AuthzFailure("alice", "tenant:globex").AnyTimes()
+			mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+
+			middleware := NewMiddleware(NewMockTokenVerifierInterface(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			var tenantID string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tenantID, _ = storage.TenantFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(TenantHeader, tt.header)
+			}
+			if tt.principal != nil {
+				req = req.WithContext(PrincipalToContext(req.Context(), tt.principal))
+			}
+			rr := httptest.NewRecorder()
+
+			middleware.Tenant()(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("expected status %d, got %d", tt.expectedStatusCode, rr.Code)
+			}
+			if tenantID != tt.expectedTenant {
+				t.Errorf("expected tenant %q, got %q", tt.expectedTenant, tenantID)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Actor(t *testing.T) {
+	tests := []struct {
+		name          string
+		principal     *Principal
+		expectedActor storage.Actor
+	}{
+		{
+			name:          "Anonymous caller",
+			expectedActor: storage.Actor{Source: storage.SourceAPI},
+		},
+		{
+			name:          "Subject of the token",
+			principal:     &Principal{Subject: "alice", Email: "alice@example.com"},
+			expectedActor: storage.Actor{ID: "alice", Source: storage.SourceAPI},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			middleware := NewMiddleware(NewMockTokenVerifierInterface(ctrl), NewMockTracingInterface(ctrl), NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+			var actor storage.Actor
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor, _ = storage.ActorFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test", nil)
+			if tt.principal != nil {
+				req = req.WithContext(PrincipalToContext(req.Context(), tt.principal))
+			}
+
+			middleware.Actor(storage.SourceAPI)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+			if actor != tt.expectedActor {
+				t.Errorf("expected actor %+v, got %+v", tt.expectedActor, actor)
+			}
+		})
+	}
+}
+
+func TestPrincipal_IDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		expected  []string
+	}{
+		{"subject and email", &Principal{Subject: "alice", Email: "alice@example.com"}, []string{"alice", "alice@example.com"}},
+		{"email as subject", &Principal{Subject: "alice@example.com", Email: "alice@example.com"}, []string{"alice@example.com"}},
+		{"no email", &Principal{Subject: "alice"}, []string{"alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ids := tt.principal.IDs(); !slices.Equal(ids, tt.expected) {
+				t.Errorf("expected IDs %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
 func TestMiddleware_GetBearerToken(t *testing.T) {
 	tests := []struct {
 		name          string
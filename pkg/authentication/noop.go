@@ -18,3 +18,8 @@ func NewNoopVerifier() *NoopVerifier {
 func (n *NoopVerifier) VerifyToken(ctx context.Context, rawIDToken string) (bool, error) {
 	return true, nil
 }
+
+// VerifyPrincipal always returns an admin principal.
+func (n *NoopVerifier) VerifyPrincipal(ctx context.Context, rawIDToken string) (*Principal, error) {
+	return &Principal{Admin: true}, nil
+}
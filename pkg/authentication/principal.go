@@ -0,0 +1,43 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package authentication
+
+import "context"
+
+type principalKey struct{}
+
+// Principal is the caller of an authenticated API request.
+type Principal struct {
+	Subject string
+	Email   string
+	// TenantID is the tenant the token is issued for, the caller can only
+	// use the API within it. It is empty for tokens valid in every tenant.
+	TenantID string
+	// Admin is set when the caller can use the whole API, through an allowed
+	// subject or the required scope.
+	Admin bool
+}
+
+// IDs returns the IDs the caller can be known under in group memberships.
+func (p *Principal) IDs() []string {
+	ids := make([]string, 0, 2)
+	if p.Subject != "" {
+		ids = append(ids, p.Subject)
+	}
+	if p.Email != "" && p.Email != p.Subject {
+		ids = append(ids, p.Email)
+	}
+	return ids
+}
+
+// PrincipalToContext returns a copy of ctx carrying the principal.
+func PrincipalToContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the caller of an authenticated request.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -29,46 +29,62 @@ func (v *JWTVerifier) VerifyToken(ctx context.Context, rawToken string) (bool, e
 	ctx, span := v.tracer.Start(ctx, "authentication.JWTVerifier.VerifyToken")
 	defer span.End()
 
-	token, err := v.verifier.Verify(ctx, rawToken)
+	principal, err := v.verify(ctx, rawToken)
 	if err != nil {
 		return false, err
 	}
 
+	if principal.Admin {
+		return true, nil
+	}
+
+	if len(v.allowedSubjects) == 0 && v.requiredScope == "" {
+		v.logger.Debugf("No authorization criteria configured")
+	}
+
+	v.logger.Security().AuthzFailure(principal.Subject, "jwt_api_access")
+	return false, nil
+}
+
+func (v *JWTVerifier) VerifyPrincipal(ctx context.Context, rawToken string) (*Principal, error) {
+	ctx, span := v.tracer.Start(ctx, "authentication.JWTVerifier.VerifyPrincipal")
+	defer span.End()
+
+	return v.verify(ctx, rawToken)
+}
+
+func (v *JWTVerifier) verify(ctx context.Context, rawToken string) (*Principal, error) {
+	token, err := v.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+
 	var claims struct {
 		Subject string   `json:"sub"`
+		Email   string   `json:"email"`
+		Tenant  string   `json:"tenant_id"`
 		Scope   string   `json:"scope"`
 		Scopes  []string `json:"scp"`
 	}
 
 	if err := token.Claims(&claims); err != nil {
 		v.logger.Debugf("Failed to extract claims: %v", err)
-		return false, err
+		return nil, err
 	}
 
+	principal := &Principal{Subject: claims.Subject, Email: claims.Email, TenantID: claims.Tenant}
+
 	if len(v.allowedSubjects) > 0 && slices.Contains(v.allowedSubjects, claims.Subject) {
-		return true, nil
+		principal.Admin = true
 	}
 
 	if v.requiredScope != "" {
-		if claims.Scope != "" {
-			scopes := strings.Fields(claims.Scope)
-			if slices.Contains(scopes, v.requiredScope) {
-				return true, nil
-			}
-		}
-		if slices.Contains(claims.Scopes, v.requiredScope) {
-			return true, nil
+		if slices.Contains(strings.Fields(claims.Scope), v.requiredScope) || slices.Contains(claims.Scopes, v.requiredScope) {
+			principal.Admin = true
 		}
 	}
 
-	if len(v.allowedSubjects) == 0 && v.requiredScope == "" {
-		v.logger.Debugf("No authorization criteria configured")
-		v.logger.Security().AuthzFailure(claims.Subject, "jwt_api_access")
-		return false, nil
-	}
-
-	v.logger.Security().AuthzFailure(claims.Subject, "jwt_api_access")
-	return false, nil
+	return principal, nil
 }
 
 func NewJWTVerifier(
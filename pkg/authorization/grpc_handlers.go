@@ -48,7 +48,7 @@ func (g *GrpcServer) GetAllowedAppsInGroup(ctx context.Context, req *v0_authz.Ge
 
 	g.logger.Debugf("GetAllowedAppsInGroup request for group: %s", req.GetGroupId())
 
-	apps, err := g.svc.GetAllowedAppsInGroup(ctx, req.GroupId)
+	apps, _, err := g.svc.GetAllowedAppsInGroup(ctx, req.GroupId, nil)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(otelcodes.Error, "get allowed apps in group failed")
@@ -196,7 +196,7 @@ func (g *GrpcServer) GetAllowedGroupsForApp(ctx context.Context, req *v0_authz.G
 
 	g.logger.Debugf("GetAllowedGroupsForApp request for app: %s", req.GetAppId())
 
-	groups, err := g.svc.GetAllowedGroupsForApp(ctx, req.AppId)
+	groups, _, err := g.svc.GetAllowedGroupsForApp(ctx, req.AppId, nil)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(otelcodes.Error, "get allowed groups for app failed")
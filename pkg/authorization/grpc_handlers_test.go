@@ -197,8 +197,8 @@ func newIntegrationServer(t *testing.T) (*testClient, func()) {
 		}),
 	)
 
-	authzSvc := NewService(s, authz, tracer, monitor, logger)
-	groupSvc := groups_api.NewService(s, authz, tracer, monitor, logger)
+	authzSvc := NewService(s, authz, nil, tracer, monitor, logger)
+	groupSvc := groups_api.NewService(s, authz, nil, tracer, monitor, logger)
 
 	ctx := context.Background()
 	v0_authz.RegisterAppAuthorizationServiceHandlerServer(ctx, gwMux,
@@ -315,7 +315,7 @@ func TestGrpcServer_GetAllowedAppsInGroup(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
 			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-			mockSvc.EXPECT().GetAllowedAppsInGroup(gomock.Any(), tt.groupID).Return(tt.expectResult, tt.expectErr)
+			mockSvc.EXPECT().GetAllowedAppsInGroup(gomock.Any(), tt.groupID, nil).Return(tt.expectResult, nil, tt.expectErr)
 
 			req := &v0_authz.GetAllowedAppsInGroupReq{GroupId: tt.groupID}
 			resp, err := server.GetAllowedAppsInGroup(context.Background(), req)
@@ -677,7 +677,7 @@ func TestGrpcServer_GetAllowedGroupsForApp(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
 			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-			mockSvc.EXPECT().GetAllowedGroupsForApp(gomock.Any(), tt.appID).Return(tt.expectResult, tt.expectErr)
+			mockSvc.EXPECT().GetAllowedGroupsForApp(gomock.Any(), tt.appID, nil).Return(tt.expectResult, nil, tt.expectErr)
 
 			req := &v0_authz.GetAllowedGroupsForAppReq{AppId: tt.appID}
 			resp, err := server.GetAllowedGroupsForApp(context.Background(), req)
@@ -3,28 +3,34 @@
 
 package authorization
 
-import "context"
+import (
+	"context"
+
+	"github.com/canonical/hook-service/internal/types"
+)
 
 type ServiceInterface interface {
-	GetAllowedAppsInGroup(context.Context, string) ([]string, error)
+	GetAllowedAppsInGroup(context.Context, string, *types.ListOptions) ([]string, *types.PageInfo, error)
 	AddAllowedAppToGroup(context.Context, string, string) error
 	RemoveAllAllowedAppsFromGroup(context.Context, string) error
 	RemoveAllowedAppFromGroup(context.Context, string, string) error
 
-	GetAllowedGroupsForApp(context.Context, string) ([]string, error)
+	GetAllowedGroupsForApp(context.Context, string, *types.ListOptions) ([]string, *types.PageInfo, error)
 	RemoveAllAllowedGroupsForApp(context.Context, string) error
 }
 
 type AuthorizationDatabaseInterface interface {
-	GetAllowedApps(context.Context, string) ([]string, error)
+	GetAllowedApps(context.Context, string, *types.ListFilter) ([]string, *types.Page, error)
 	AddAllowedApp(context.Context, string, string) error
 	AddAllowedApps(context.Context, string, []string) error
 	RemoveAllowedApp(context.Context, string, string) error
 	RemoveAllowedApps(context.Context, string) ([]string, error)
 
 	AddAllowedGroupsForApp(context.Context, string, []string) error
-	GetAllowedGroupsForApp(context.Context, string) ([]string, error)
+	GetAllowedGroupsForApp(context.Context, string, *types.ListFilter) ([]string, *types.Page, error)
 	RemoveAllAllowedGroupsForApp(context.Context, string) ([]string, error)
+
+	GetGroup(context.Context, string) (*types.Group, error)
 }
 
 type AuthorizerInterface interface {
@@ -34,3 +40,9 @@ type AuthorizerInterface interface {
 
 	RemoveAllAllowedGroupsForApp(context.Context, string) error
 }
+
+// CacheInvalidatorInterface is notified of app grant changes so that cached
+// decisions are not served after a write.
+type CacheInvalidatorInterface interface {
+	InvalidateDecisions(context.Context)
+}
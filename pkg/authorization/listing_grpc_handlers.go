@@ -0,0 +1,144 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package authorization
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	httptypes "github.com/canonical/hook-service/internal/http/types"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+var _ pb.AppGrantListingServiceServer = (*ListingGrpcServer)(nil)
+
+// ListingGrpcServer is the gRPC server searching the app grants.
+type ListingGrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedAppGrantListingServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// SearchAllowedApps handles the gRPC request to search the apps allowed for a group.
+func (l *ListingGrpcServer) SearchAllowedApps(ctx context.Context, req *pb.SearchReq) (*pb.SearchIDsResp, error) {
+	ctx, span := l.tracer.Start(ctx, "authorization.ListingGrpcServer.SearchAllowedApps")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.String("search", req.GetSearch()),
+		attribute.String("sort_by", req.GetSortBy()),
+	)
+
+	if req.GetId() == "" {
+		err := status.Errorf(codes.InvalidArgument, "group id is empty")
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "invalid argument")
+		return nil, err
+	}
+
+	opts, err := httptypes.ListOptions(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "invalid search")
+		return nil, l.mapErrorToStatus(err, "search allowed apps")
+	}
+
+	apps, page, err := l.svc.GetAllowedAppsInGroup(ctx, req.GetId(), opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "search allowed apps failed")
+		return nil, l.mapErrorToStatus(err, "search allowed apps")
+	}
+
+	span.SetAttributes(attribute.Int("apps.count", len(apps)))
+	span.SetStatus(otelcodes.Ok, "allowed apps searched successfully")
+
+	return &pb.SearchIDsResp{
+		Data:          apps,
+		Status:        http.StatusOK,
+		Message:       proto.String("Allowed apps for group"),
+		NextPageToken: page.NextPageToken,
+		Total:         page.Total,
+	}, nil
+}
+
+// SearchAllowedGroups handles the gRPC request to search the groups allowed for an app.
+func (l *ListingGrpcServer) SearchAllowedGroups(ctx context.Context, req *pb.SearchReq) (*pb.SearchIDsResp, error) {
+	ctx, span := l.tracer.Start(ctx, "authorization.ListingGrpcServer.SearchAllowedGroups")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("app.id", req.GetId()),
+		attribute.String("search", req.GetSearch()),
+		attribute.String("sort_by", req.GetSortBy()),
+	)
+
+	if req.GetId() == "" {
+		err := status.Errorf(codes.InvalidArgument, "app id is empty")
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "invalid argument")
+		return nil, err
+	}
+
+	opts, err := httptypes.ListOptions(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "invalid search")
+		return nil, l.mapErrorToStatus(err, "search allowed groups")
+	}
+
+	groups, page, err := l.svc.GetAllowedGroupsForApp(ctx, req.GetId(), opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "search allowed groups failed")
+		return nil, l.mapErrorToStatus(err, "search allowed groups")
+	}
+
+	span.SetAttributes(attribute.Int("groups.count", len(groups)))
+	span.SetStatus(otelcodes.Ok, "allowed groups searched successfully")
+
+	return &pb.SearchIDsResp{
+		Data:          groups,
+		Status:        http.StatusOK,
+		Message:       proto.String("List of groups allowed for app"),
+		NextPageToken: page.NextPageToken,
+		Total:         page.Total,
+	}, nil
+}
+
+func (l *ListingGrpcServer) mapErrorToStatus(err error, action string) error {
+	if s := httptypes.ListErrorStatus(err); s != nil {
+		return s
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	l.logger.Errorf("Unhandled error in %s: %v", action, err)
+	return status.Errorf(codes.Internal, "%s failed", action)
+}
+
+// NewListingGrpcServer creates a new gRPC server searching the app grants.
+func NewListingGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *ListingGrpcServer {
+	return &ListingGrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/canonical/hook-service/internal/monitoring"
 	"github.com/canonical/hook-service/internal/storage"
 	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
 )
 
 var _ ServiceInterface = (*Service)(nil)
@@ -18,27 +19,41 @@ var _ ServiceInterface = (*Service)(nil)
 type Service struct {
 	db    AuthorizationDatabaseInterface
 	authz AuthorizerInterface
+	cache CacheInvalidatorInterface
 
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
 	logger  logging.LoggerInterface
 }
 
-func (s *Service) GetAllowedAppsInGroup(ctx context.Context, groupID string) ([]string, error) {
+// GetAllowedAppsInGroup returns a page of the apps allowed for a group
+// matching the options, nil options list every app.
+func (s *Service) GetAllowedAppsInGroup(ctx context.Context, groupID string, opts *types.ListOptions) ([]string, *types.PageInfo, error) {
 	ctx, span := s.tracer.Start(ctx, "authorization.Service.GetAllowedAppsInGroup")
 	defer span.End()
 
-	apps, err := s.db.GetAllowedApps(ctx, groupID)
-	if err == nil {
-		s.logger.Infof("Retrieved %d allowed app(s) for group %s", len(apps), groupID)
+	filter, err := opts.Filter()
+	if err != nil {
+		return nil, nil, err
 	}
-	return apps, err
+
+	apps, page, err := s.db.GetAllowedApps(ctx, groupID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.Infof("Retrieved %d allowed app(s) for group %s", len(apps), groupID)
+	return apps, filter.PageInfo(page), nil
 }
 
 func (s *Service) AddAllowedAppToGroup(ctx context.Context, groupID string, app string) error {
 	ctx, span := s.tracer.Start(ctx, "authorization.Service.AddAllowedAppToGroup")
 	defer span.End()
 
+	if err := s.checkTenant(ctx, groupID); err != nil {
+		return err
+	}
+
 	if err := s.db.AddAllowedApp(ctx, groupID, app); err != nil {
 		if errors.Is(err, storage.ErrDuplicateKey) {
 			return ErrAppAlreadyExistsInGroup
@@ -53,6 +68,8 @@ func (s *Service) AddAllowedAppToGroup(ctx context.Context, groupID string, app
 		return err
 	}
 
+	s.cache.InvalidateDecisions(ctx)
+
 	return nil
 }
 
@@ -60,6 +77,10 @@ func (s *Service) RemoveAllAllowedAppsFromGroup(ctx context.Context, groupID str
 	ctx, span := s.tracer.Start(ctx, "authorization.Service.RemoveAllAllowedAppsFromGroup")
 	defer span.End()
 
+	if err := s.checkTenant(ctx, groupID); err != nil {
+		return err
+	}
+
 	_, err := s.db.RemoveAllowedApps(ctx, groupID)
 	if err != nil {
 		return err
@@ -70,6 +91,8 @@ func (s *Service) RemoveAllAllowedAppsFromGroup(ctx context.Context, groupID str
 		return err
 	}
 
+	s.cache.InvalidateDecisions(ctx)
+
 	return nil
 }
 
@@ -77,6 +100,10 @@ func (s *Service) RemoveAllowedAppFromGroup(ctx context.Context, groupID string,
 	ctx, span := s.tracer.Start(ctx, "authorization.Service.RemoveAllowedAppFromGroup")
 	defer span.End()
 
+	if err := s.checkTenant(ctx, groupID); err != nil {
+		return err
+	}
+
 	if err := s.db.RemoveAllowedApp(ctx, groupID, app); err != nil {
 		return err
 	}
@@ -85,31 +112,68 @@ func (s *Service) RemoveAllowedAppFromGroup(ctx context.Context, groupID string,
 		return err
 	}
 
+	s.cache.InvalidateDecisions(ctx)
+
 	return nil
 }
 
-func (s *Service) GetAllowedGroupsForApp(ctx context.Context, app string) ([]string, error) {
+// GetAllowedGroupsForApp returns a page of the groups allowed to access an
+// app matching the options, nil options list every group.
+func (s *Service) GetAllowedGroupsForApp(ctx context.Context, app string, opts *types.ListOptions) ([]string, *types.PageInfo, error) {
 	ctx, span := s.tracer.Start(ctx, "authorization.Service.GetAllowedGroupsForApp")
 	defer span.End()
 
-	groups, err := s.db.GetAllowedGroupsForApp(ctx, app)
+	filter, err := opts.Filter()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return groups, nil
+	groups, page, err := s.db.GetAllowedGroupsForApp(ctx, app, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return groups, filter.PageInfo(page), nil
 }
 
 func (s *Service) RemoveAllAllowedGroupsForApp(ctx context.Context, app string) error {
 	ctx, span := s.tracer.Start(ctx, "authorization.Service.RemoveAllAllowedGroupsForApp")
 	defer span.End()
 
-	_, err := s.db.RemoveAllAllowedGroupsForApp(ctx, app)
+	groupIDs, err := s.db.RemoveAllAllowedGroupsForApp(ctx, app)
 	if err != nil {
 		return err
 	}
 
-	if err := s.authz.RemoveAllAllowedGroupsForApp(ctx, app); err != nil {
+	// Only the grants of the groups of the tenant are revoked, the grants of
+	// other tenants stay in the authorization model.
+	if _, ok := storage.TenantFromContext(ctx); ok {
+		for _, groupID := range groupIDs {
+			if err := s.authz.RemoveAllowedAppFromGroup(ctx, groupID, app); err != nil {
+				return err
+			}
+		}
+	} else if err := s.authz.RemoveAllAllowedGroupsForApp(ctx, app); err != nil {
+		return err
+	}
+
+	s.cache.InvalidateDecisions(ctx)
+
+	return nil
+}
+
+// checkTenant makes sure that a group belongs to the tenant of a request
+// scoped to a tenant, before its grants are changed in the authorization
+// model which is shared by every tenant.
+func (s *Service) checkTenant(ctx context.Context, groupID string) error {
+	if _, ok := storage.TenantFromContext(ctx); !ok {
+		return nil
+	}
+
+	if _, err := s.db.GetGroup(ctx, groupID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrGroupNotFound
+		}
 		return err
 	}
 
@@ -119,6 +183,7 @@ func (s *Service) RemoveAllAllowedGroupsForApp(ctx context.Context, app string)
 func NewService(
 	db AuthorizationDatabaseInterface,
 	authz AuthorizerInterface,
+	cache CacheInvalidatorInterface,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
@@ -127,6 +192,11 @@ func NewService(
 
 	s.db = db
 	s.authz = authz
+	s.cache = cache
+
+	if s.cache == nil {
+		s.cache = noopCacheInvalidator{}
+	}
 
 	s.monitor = monitor
 	s.tracer = tracer
@@ -134,3 +204,7 @@ func NewService(
 
 	return s
 }
+
+type noopCacheInvalidator struct{}
+
+func (noopCacheInvalidator) InvalidateDecisions(context.Context) {}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/types"
 	trace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
@@ -33,7 +34,7 @@ func TestService_GetAllowedAppsInGroup(t *testing.T) {
 			groupID: "group1",
 			mockDB: func(ctrl *gomock.Controller, groupID string) AuthorizationDatabaseInterface {
 				mock := NewMockAuthorizationDatabaseInterface(ctrl)
-				mock.EXPECT().GetAllowedApps(gomock.Any(), groupID).Return([]string{"app1", "app2"}, nil)
+				mock.EXPECT().GetAllowedApps(gomock.Any(), groupID, nil).Return([]string{"app1", "app2"}, nil, nil)
 				return mock
 			},
 			expectedResult: []string{"app1", "app2"},
@@ -44,7 +45,7 @@ func TestService_GetAllowedAppsInGroup(t *testing.T) {
 			groupID: "group2",
 			mockDB: func(ctrl *gomock.Controller, groupID string) AuthorizationDatabaseInterface {
 				mock := NewMockAuthorizationDatabaseInterface(ctrl)
-				mock.EXPECT().GetAllowedApps(gomock.Any(), groupID).Return([]string{}, nil)
+				mock.EXPECT().GetAllowedApps(gomock.Any(), groupID, nil).Return([]string{}, nil, nil)
 				return mock
 			},
 			expectedResult: []string{},
@@ -55,7 +56,7 @@ func TestService_GetAllowedAppsInGroup(t *testing.T) {
 			groupID: "group3",
 			mockDB: func(ctrl *gomock.Controller, groupID string) AuthorizationDatabaseInterface {
 				mock := NewMockAuthorizationDatabaseInterface(ctrl)
-				mock.EXPECT().GetAllowedApps(gomock.Any(), groupID).Return(nil, err)
+				mock.EXPECT().GetAllowedApps(gomock.Any(), groupID, nil).Return(nil, nil, err)
 				return mock
 			},
 			expectedResult: nil,
@@ -79,9 +80,9 @@ func TestService_GetAllowedAppsInGroup(t *testing.T) {
 
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.GetAllowedAppsInGroup").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(mockDB, mockAuthorizer, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockDB, mockAuthorizer, nil, mockTracer, mockMonitor, mockLogger)
 
-			apps, err := s.GetAllowedAppsInGroup(context.TODO(), test.groupID)
+			apps, _, err := s.GetAllowedAppsInGroup(context.TODO(), test.groupID, nil)
 
 			if err != test.expectedError {
 				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
@@ -93,6 +94,64 @@ func TestService_GetAllowedAppsInGroup(t *testing.T) {
 	}
 }
 
+func TestService_GetAllowedAppsInGroupPaginated(t *testing.T) {
+	groupID := "group1"
+	total := int64(3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.GetAllowedAppsInGroup").Times(3).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+	mockDB := NewMockAuthorizationDatabaseInterface(ctrl)
+
+	gomock.InOrder(
+		mockDB.EXPECT().GetAllowedApps(gomock.Any(), groupID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, f *types.ListFilter) ([]string, *types.Page, error) {
+				if f.Limit != 2 || f.After != nil || !f.CountTotal || f.Search != "app" {
+					t.Fatalf("unexpected first page filter %+v", f)
+				}
+				return []string{"app1", "app2"}, &types.Page{Next: &types.Cursor{Value: "app2", ID: "app2"}, Total: &total}, nil
+			},
+		),
+		mockDB.EXPECT().GetAllowedApps(gomock.Any(), groupID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, f *types.ListFilter) ([]string, *types.Page, error) {
+				if f.After == nil || f.After.ID != "app2" || f.CountTotal {
+					t.Fatalf("unexpected next page filter %+v", f)
+				}
+				return []string{"app3"}, new(types.Page), nil
+			},
+		),
+	)
+
+	s := NewService(mockDB, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+	opts := &types.ListOptions{Search: "app", PageSize: 2}
+	apps, page, err := s.GetAllowedAppsInGroup(context.TODO(), groupID, opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(apps, []string{"app1", "app2"}) || page.NextPageToken == "" || page.Total == nil || *page.Total != total {
+		t.Fatalf("unexpected first page %v %+v", apps, page)
+	}
+
+	opts.PageToken = page.NextPageToken
+	apps, page, err = s.GetAllowedAppsInGroup(context.TODO(), groupID, opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(apps, []string{"app3"}) || page.NextPageToken != "" || page.Total != nil {
+		t.Fatalf("unexpected last page %v %+v", apps, page)
+	}
+
+	opts.SortBy = types.SortByCreatedAt
+	if _, _, err := s.GetAllowedAppsInGroup(context.TODO(), groupID, opts); !errors.Is(err, types.ErrInvalidPageToken) {
+		t.Fatalf("expected ErrInvalidPageToken for a token of another sort, got %v", err)
+	}
+}
+
 func TestService_AddAllowedAppToGroup(t *testing.T) {
 	dbErr := errors.New("some db error")
 	authzErr := errors.New("some authz error")
@@ -105,6 +164,8 @@ func TestService_AddAllowedAppToGroup(t *testing.T) {
 		mockDB         func(ctrl *gomock.Controller) AuthorizationDatabaseInterface
 		mockAuthorizer func(ctrl *gomock.Controller) AuthorizerInterface
 
+		invalidated bool
+
 		expectedError error
 	}{
 		{
@@ -119,6 +180,7 @@ func TestService_AddAllowedAppToGroup(t *testing.T) {
 				mock.EXPECT().AddAllowedAppToGroup(gomock.Any(), groupID, app).Return(nil)
 				return mock
 			},
+			invalidated:   true,
 			expectedError: nil,
 		},
 		{
@@ -183,10 +245,14 @@ func TestService_AddAllowedAppToGroup(t *testing.T) {
 			mockMonitor := NewMockMonitorInterface(ctrl)
 			mockAuthorizer := test.mockAuthorizer(ctrl)
 			mockDB := test.mockDB(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
+			if test.invalidated {
+				mockCache.EXPECT().InvalidateDecisions(gomock.Any())
+			}
 
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.AddAllowedAppToGroup").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(mockDB, mockAuthorizer, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockDB, mockAuthorizer, mockCache, mockTracer, mockMonitor, mockLogger)
 
 			err := s.AddAllowedAppToGroup(context.TODO(), groupID, app)
 
@@ -272,7 +338,7 @@ func TestService_RemoveAllAllowedAppsFromGroup(t *testing.T) {
 
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.RemoveAllAllowedAppsFromGroup").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(mockDB, mockAuthorizer, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockDB, mockAuthorizer, nil, mockTracer, mockMonitor, mockLogger)
 
 			err := s.RemoveAllAllowedAppsFromGroup(context.TODO(), groupID)
 
@@ -358,7 +424,7 @@ func TestService_RemoveAllowedAppFromGroup(t *testing.T) {
 
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.RemoveAllowedAppFromGroup").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(mockDB, mockAuthorizer, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockDB, mockAuthorizer, nil, mockTracer, mockMonitor, mockLogger)
 
 			err := s.RemoveAllowedAppFromGroup(context.TODO(), groupID, app)
 
@@ -389,7 +455,7 @@ func TestService_GetAllowedGroupsForApp(t *testing.T) {
 			name: "Success",
 			mockDB: func(ctrl *gomock.Controller) AuthorizationDatabaseInterface {
 				mock := NewMockAuthorizationDatabaseInterface(ctrl)
-				mock.EXPECT().GetAllowedGroupsForApp(gomock.Any(), app).Return([]string{"g1", "g2"}, nil)
+				mock.EXPECT().GetAllowedGroupsForApp(gomock.Any(), app, nil).Return([]string{"g1", "g2"}, nil, nil)
 				return mock
 			},
 			expectedResult: []string{"g1", "g2"},
@@ -399,7 +465,7 @@ func TestService_GetAllowedGroupsForApp(t *testing.T) {
 			name: "DB error",
 			mockDB: func(ctrl *gomock.Controller) AuthorizationDatabaseInterface {
 				mock := NewMockAuthorizationDatabaseInterface(ctrl)
-				mock.EXPECT().GetAllowedGroupsForApp(gomock.Any(), app).Return(nil, dbErr)
+				mock.EXPECT().GetAllowedGroupsForApp(gomock.Any(), app, nil).Return(nil, nil, dbErr)
 				return mock
 			},
 			expectedResult: nil,
@@ -420,9 +486,9 @@ func TestService_GetAllowedGroupsForApp(t *testing.T) {
 
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.GetAllowedGroupsForApp").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(mockDB, mockAuthorizer, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockDB, mockAuthorizer, nil, mockTracer, mockMonitor, mockLogger)
 
-			groups, err := s.GetAllowedGroupsForApp(context.TODO(), app)
+			groups, _, err := s.GetAllowedGroupsForApp(context.TODO(), app, nil)
 
 			if err != test.expectedError {
 				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
@@ -513,7 +579,7 @@ func TestService_RemoveAllAllowedGroupsForApp(t *testing.T) {
 
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.RemoveAllAllowedGroupsForApp").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(mockDB, mockAuthorizer, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockDB, mockAuthorizer, nil, mockTracer, mockMonitor, mockLogger)
 
 			err := s.RemoveAllAllowedGroupsForApp(context.TODO(), app)
 
@@ -529,3 +595,64 @@ func TestService_RemoveAllAllowedGroupsForApp(t *testing.T) {
 		})
 	}
 }
+
+func TestService_TenantScopedGrants(t *testing.T) {
+	app := "app1"
+	ctx := storage.WithTenant(context.Background(), "acme")
+
+	t.Run("Group of another tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTracer := NewMockTracingInterface(ctrl)
+		mockDB := NewMockAuthorizationDatabaseInterface(ctrl)
+		mockAuthorizer := NewMockAuthorizerInterface(ctrl)
+
+		mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.RemoveAllowedAppFromGroup").Return(ctx, trace.SpanFromContext(ctx))
+		mockDB.EXPECT().GetGroup(gomock.Any(), "g1").Return(nil, storage.ErrNotFound)
+
+		s := NewService(mockDB, mockAuthorizer, nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+		if err := s.RemoveAllowedAppFromGroup(ctx, "g1", app); !errors.Is(err, ErrGroupNotFound) {
+			t.Fatalf("expected ErrGroupNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Grant to a group of another tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTracer := NewMockTracingInterface(ctrl)
+		mockDB := NewMockAuthorizationDatabaseInterface(ctrl)
+		mockAuthorizer := NewMockAuthorizerInterface(ctrl)
+
+		mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.AddAllowedAppToGroup").Return(ctx, trace.SpanFromContext(ctx))
+		mockDB.EXPECT().GetGroup(gomock.Any(), "g1").Return(nil, storage.ErrNotFound)
+
+		s := NewService(mockDB, mockAuthorizer, nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+		if err := s.AddAllowedAppToGroup(ctx, "g1", app); !errors.Is(err, ErrGroupNotFound) {
+			t.Fatalf("expected ErrGroupNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Revokes the grants of the tenant only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTracer := NewMockTracingInterface(ctrl)
+		mockDB := NewMockAuthorizationDatabaseInterface(ctrl)
+		mockAuthorizer := NewMockAuthorizerInterface(ctrl)
+
+		mockTracer.EXPECT().Start(gomock.Any(), "authorization.Service.RemoveAllAllowedGroupsForApp").Return(ctx, trace.SpanFromContext(ctx))
+		mockDB.EXPECT().RemoveAllAllowedGroupsForApp(gomock.Any(), app).Return([]string{"g1", "g2"}, nil)
+		mockAuthorizer.EXPECT().RemoveAllowedAppFromGroup(gomock.Any(), "g1", app).Return(nil)
+		mockAuthorizer.EXPECT().RemoveAllowedAppFromGroup(gomock.Any(), "g2", app).Return(nil)
+
+		s := NewService(mockDB, mockAuthorizer, nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+		if err := s.RemoveAllAllowedGroupsForApp(ctx, app); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
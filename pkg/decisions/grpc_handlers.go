@@ -0,0 +1,117 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package decisions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/decisions/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var _ pb.DecisionsServiceServer = (*GrpcServer)(nil)
+
+type GrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedDecisionsServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (g *GrpcServer) ListDecisions(ctx context.Context, req *pb.ListDecisionsReq) (*pb.ListDecisionsResp, error) {
+	ctx, span := g.tracer.Start(ctx, "decisions.GrpcServer.ListDecisions")
+	defer span.End()
+
+	opts := &ListOptions{
+		UserID:    req.GetUserId(),
+		ClientID:  req.GetClientId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.GetSince() != nil {
+		opts.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		opts.Until = req.GetUntil().AsTime()
+	}
+
+	decisions, next, err := g.svc.ListDecisions(ctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "list decisions failed")
+		return nil, g.mapErrorToStatus(err, "list decisions")
+	}
+
+	data := make([]*pb.Decision, 0, len(decisions))
+	for _, d := range decisions {
+		data = append(data, toProto(d))
+	}
+
+	span.SetAttributes(attribute.Int("decisions.count", len(data)))
+	span.SetStatus(otelcodes.Ok, "decisions listed successfully")
+
+	return &pb.ListDecisionsResp{
+		Data:          data,
+		Status:        http.StatusOK,
+		Message:       proto.String("Decision list"),
+		NextPageToken: next,
+	}, nil
+}
+
+func (g *GrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, types.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "invalid page token")
+	case errors.Is(err, types.ErrInvalidPageSize):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, types.ErrInvalidTimeRange):
+		return status.Errorf(codes.InvalidArgument, "since must be before until")
+	default:
+		g.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func toProto(d *types.Decision) *pb.Decision {
+	return &pb.Decision{
+		Id:         d.ID,
+		CreatedAt:  timestamppb.New(d.CreatedAt),
+		UserId:     d.UserID,
+		ClientId:   d.ClientID,
+		GrantTypes: d.GrantTypes,
+		Audience:   d.Audience,
+		TenantId:   d.TenantID,
+		Groups:     d.Groups,
+		Allowed:    d.Allowed,
+		Shadow:     d.Shadow,
+		Reason:     d.Reason,
+		Latency:    durationpb.New(d.Latency),
+	}
+}
+
+func NewGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *GrpcServer {
+	return &GrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
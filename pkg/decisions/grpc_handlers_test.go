@@ -0,0 +1,105 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package decisions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/decisions/v1"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestGrpcHandler_ListDecisions(t *testing.T) {
+	since := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	decision := &types.Decision{
+		ID:         42,
+		CreatedAt:  since.Add(14 * time.Minute),
+		UserID:     "alice",
+		ClientID:   "app",
+		GrantTypes: []string{"authorization_code"},
+		Groups:     []string{"admins"},
+		Reason:     "openfga_check",
+		Latency:    3 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.ListDecisionsReq
+
+		expectedOpts *ListOptions
+		svcResult    []*types.Decision
+		svcNext      string
+		svcErr       error
+
+		wantCode codes.Code
+	}{
+		{
+			name:         "List decisions for user in time range",
+			req:          &pb.ListDecisionsReq{UserId: "alice", Since: timestamppb.New(since), PageSize: 10},
+			expectedOpts: &ListOptions{UserID: "alice", Since: since, PageSize: 10},
+			svcResult:    []*types.Decision{decision},
+			svcNext:      "next",
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "Invalid page token",
+			req:          &pb.ListDecisionsReq{PageToken: "bad"},
+			expectedOpts: &ListOptions{PageToken: "bad"},
+			svcErr:       types.ErrInvalidPageToken,
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name:         "Internal error",
+			req:          &pb.ListDecisionsReq{},
+			expectedOpts: &ListOptions{},
+			svcErr:       errors.New("connection refused"),
+			wantCode:     codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			mockSvc.EXPECT().ListDecisions(gomock.Any(), test.expectedOpts).Return(test.svcResult, test.svcNext, test.svcErr)
+
+			server := NewGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			resp, err := server.ListDecisions(context.TODO(), test.req)
+
+			if code := status.Code(err); code != test.wantCode {
+				t.Fatalf("expected code %v, got %v (%v)", test.wantCode, code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.GetNextPageToken() != test.svcNext {
+				t.Fatalf("expected next page token %q, got %q", test.svcNext, resp.GetNextPageToken())
+			}
+			if len(resp.GetData()) != 1 {
+				t.Fatalf("expected 1 decision, got %d", len(resp.GetData()))
+			}
+			d := resp.GetData()[0]
+			if d.GetId() != 42 || d.GetUserId() != "alice" || d.GetReason() != "openfga_check" || d.GetLatency().AsDuration() != 3*time.Millisecond {
+				t.Fatalf("unexpected decision %v", d)
+			}
+		})
+	}
+}
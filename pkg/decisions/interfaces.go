@@ -0,0 +1,20 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package decisions
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+type ServiceInterface interface {
+	ListDecisions(context.Context, *ListOptions) ([]*types.Decision, string, error)
+}
+
+type DatabaseInterface interface {
+	ListDecisions(context.Context, *types.DecisionFilter) ([]*types.Decision, error)
+	PurgeDecisions(context.Context, time.Time) (int64, error)
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package decisions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/purge"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+var _ ServiceInterface = (*Service)(nil)
+
+// ListOptions filters and paginates the decision log, empty fields match
+// every decision.
+type ListOptions struct {
+	UserID    string
+	ClientID  string
+	Since     time.Time
+	Until     time.Time
+	PageSize  int
+	PageToken string
+}
+
+type Service struct {
+	db DatabaseInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// ListDecisions returns a page of decisions, newest first, and the token of
+// the next page or an empty string on the last page.
+func (s *Service) ListDecisions(ctx context.Context, opts *ListOptions) ([]*types.Decision, string, error) {
+	ctx, span := s.tracer.Start(ctx, "decisions.Service.ListDecisions")
+	defer span.End()
+
+	size, err := types.PageSize(opts.PageSize, DefaultPageSize, MaxPageSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && !opts.Since.Before(opts.Until) {
+		return nil, "", types.ErrInvalidTimeRange
+	}
+
+	beforeID, err := types.ParseIDPageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", opts.UserID),
+		attribute.String("client.id", opts.ClientID),
+		attribute.Int("page.size", size),
+	)
+
+	// Fetch one more decision than requested to know if there is a next page.
+	decisions, err := s.db.ListDecisions(ctx, &types.DecisionFilter{
+		UserID:   opts.UserID,
+		ClientID: opts.ClientID,
+		Since:    opts.Since,
+		Until:    opts.Until,
+		BeforeID: beforeID,
+		Limit:    uint64(size) + 1,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(decisions) > size {
+		decisions = decisions[:size]
+		next = types.IDPageToken(decisions[size-1].ID)
+	}
+
+	return decisions, next, nil
+}
+
+// PurgeDecisions deletes the decisions older than retention.
+func (s *Service) PurgeDecisions(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "decisions.Service.PurgeDecisions")
+	defer span.End()
+
+	return s.db.PurgeDecisions(ctx, time.Now().Add(-retention))
+}
+
+// RunRetention purges the decisions older than retention every interval
+// until ctx is cancelled.
+func (s *Service) RunRetention(ctx context.Context, retention, interval time.Duration) error {
+	return purge.Run(ctx, interval, fmt.Sprintf("decisions older than %s", retention), func(ctx context.Context) (int64, error) {
+		return s.PurgeDecisions(ctx, retention)
+	}, s.logger)
+}
+
+func NewService(db DatabaseInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Service {
+	s := new(Service)
+
+	s.db = db
+
+	s.monitor = monitor
+	s.tracer = tracer
+	s.logger = logger
+
+	return s
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package decisions
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package decisions -destination ./mock_decisions.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package decisions -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package decisions -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package decisions -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestServiceListDecisions(t *testing.T) {
+	since := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+
+	page := []*types.Decision{{ID: 9}, {ID: 8}, {ID: 7}}
+
+	tests := []struct {
+		name string
+		opts *ListOptions
+
+		expectedFilter *types.DecisionFilter
+		dbResult       []*types.Decision
+		dbErr          error
+
+		expected      []*types.Decision
+		expectedNext  string
+		expectedError error
+	}{
+		{
+			name:           "Default page size",
+			opts:           &ListOptions{UserID: "alice"},
+			expectedFilter: &types.DecisionFilter{UserID: "alice", Limit: DefaultPageSize + 1},
+			dbResult:       page,
+			expected:       page,
+		},
+		{
+			name:           "Next page token when more decisions exist",
+			opts:           &ListOptions{ClientID: "app", Since: since, Until: until, PageSize: 2},
+			expectedFilter: &types.DecisionFilter{ClientID: "app", Since: since, Until: until, Limit: 3},
+			dbResult:       page,
+			expected:       page[:2],
+			expectedNext:   types.IDPageToken(8),
+		},
+		{
+			name:           "Page token selects older decisions",
+			opts:           &ListOptions{PageSize: 2, PageToken: types.IDPageToken(8)},
+			expectedFilter: &types.DecisionFilter{BeforeID: 8, Limit: 3},
+			dbResult:       page[2:],
+			expected:       page[2:],
+		},
+		{
+			name:          "Invalid page token",
+			opts:          &ListOptions{PageToken: "not a token"},
+			expectedError: types.ErrInvalidPageToken,
+		},
+		{
+			name:          "Page size too large",
+			opts:          &ListOptions{PageSize: MaxPageSize + 1},
+			expectedError: types.ErrInvalidPageSize,
+		},
+		{
+			name:          "Inverted time range",
+			opts:          &ListOptions{Since: until, Until: since},
+			expectedError: types.ErrInvalidTimeRange,
+		},
+		{
+			name:           "Storage error",
+			opts:           &ListOptions{},
+			expectedFilter: &types.DecisionFilter{Limit: DefaultPageSize + 1},
+			dbErr:          errors.New("connection refused"),
+			expectedError:  errors.New("connection refused"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := NewMockDatabaseInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+			if test.expectedFilter != nil {
+				mockDB.EXPECT().ListDecisions(gomock.Any(), test.expectedFilter).Return(test.dbResult, test.dbErr)
+			}
+
+			s := NewService(mockDB, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+			decisions, next, err := s.ListDecisions(context.TODO(), test.opts)
+
+			if test.expectedError != nil {
+				if err == nil || (!errors.Is(err, test.expectedError) && err.Error() != test.expectedError.Error()) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(decisions, test.expected) {
+				t.Fatalf("expected decisions %v, got %v", test.expected, decisions)
+			}
+			if next != test.expectedNext {
+				t.Fatalf("expected next page token %q, got %q", test.expectedNext, next)
+			}
+		})
+	}
+}
+
+func TestServicePurgeDecisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+	retention := 24 * time.Hour
+	mockDB.EXPECT().PurgeDecisions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, before time.Time) (int64, error) {
+			if d := time.Since(before); d < retention || d > retention+time.Minute {
+				t.Fatalf("expected cutoff %s ago, got %s", retention, d)
+			}
+			return 3, nil
+		},
+	)
+
+	s := NewService(mockDB, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	n, err := s.PurgeDecisions(context.TODO(), retention)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 purged decisions, got %d", n)
+	}
+}
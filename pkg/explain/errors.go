@@ -0,0 +1,11 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import "errors"
+
+var (
+	ErrMissingClientID = errors.New("client ID is required")
+	ErrMissingUserID   = errors.New("user ID is required")
+)
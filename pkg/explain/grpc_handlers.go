@@ -0,0 +1,163 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/canonical/hook-service/gen/hook/explain/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/openfga"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+var _ pb.ExplainServiceServer = (*GrpcServer)(nil)
+
+type GrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedExplainServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (g *GrpcServer) Explain(ctx context.Context, req *pb.ExplainReq) (*pb.ExplainResp, error) {
+	ctx, span := g.tracer.Start(ctx, "explain.GrpcServer.Explain")
+	defer span.End()
+
+	res, err := g.svc.Explain(ctx, &Request{
+		UserID:     req.GetUserId(),
+		Email:      req.GetEmail(),
+		ClientID:   req.GetClientId(),
+		GrantTypes: req.GetGrantTypes(),
+		Audience:   req.GetAudience(),
+		TenantID:   req.GetTenantId(),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "explain failed")
+		return nil, g.mapErrorToStatus(err, "explain")
+	}
+
+	data, err := toProto(res)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "explain failed")
+		return nil, g.mapErrorToStatus(err, "explain")
+	}
+
+	span.SetAttributes(
+		attribute.Bool("authorization.allowed", res.Allowed),
+		attribute.String("authorization.decision", string(res.Reason)),
+	)
+	span.SetStatus(otelcodes.Ok, "request explained successfully")
+
+	return &pb.ExplainResp{
+		Data:    data,
+		Status:  http.StatusOK,
+		Message: proto.String("Explanation"),
+	}, nil
+}
+
+func (g *GrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrMissingClientID), errors.Is(err, ErrMissingUserID):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	default:
+		g.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func toProto(r *Result) (*pb.Explanation, error) {
+	e := &pb.Explanation{
+		UserId:           r.UserID,
+		ClientId:         r.ClientID,
+		Groups:           make([]*pb.Group, 0, len(r.Groups)),
+		DegradedSources:  r.DegradedSources,
+		ContextualTuples: tuplesToProto(r.ContextualTuples),
+		Checks:           tuplesToProto(r.Checks),
+		Tenant:           &pb.TenantCheck{TenantId: r.Tenant.TenantID, Member: r.Tenant.Member, AutoSelected: r.Tenant.AutoSelected},
+		Allowed:          r.Allowed,
+		Shadow:           r.Shadow,
+		Reason:           string(r.Reason),
+		Issued:           r.Response != nil,
+	}
+
+	for _, g := range r.Groups {
+		e.Groups = append(e.Groups, &pb.Group{
+			Id:       g.ID,
+			Name:     g.Name,
+			TenantId: g.TenantId,
+			Type:     g.Type.String(),
+		})
+	}
+	if r.GroupsError != "" {
+		e.GroupsError = proto.String(r.GroupsError)
+	}
+	if r.Tenant.Error != "" {
+		e.Tenant.Error = proto.String(r.Tenant.Error)
+	}
+	if r.Error != "" {
+		e.Error = proto.String(r.Error)
+	}
+
+	if r.Response != nil {
+		var err error
+		if e.AccessToken, err = claimsToProto(r.Response.Session.AccessToken); err != nil {
+			return nil, err
+		}
+		if e.IdToken, err = claimsToProto(r.Response.Session.IDToken); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+func tuplesToProto(tuples []openfga.Tuple) []*pb.Tuple {
+	ret := make([]*pb.Tuple, 0, len(tuples))
+	for _, t := range tuples {
+		ret = append(ret, &pb.Tuple{User: t.User, Relation: t.Relation, Object: t.Object})
+	}
+	return ret
+}
+
+// claimsToProto converts the claims through their JSON encoding so the values
+// match what Hydra receives.
+func claimsToProto(claims map[string]interface{}) (*structpb.Struct, error) {
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(structpb.Struct)
+	if err := s.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func NewGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *GrpcServer {
+	return &GrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
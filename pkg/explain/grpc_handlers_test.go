@@ -0,0 +1,118 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ory/hydra/v2/flow"
+	"github.com/ory/hydra/v2/oauth2"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/canonical/hook-service/gen/hook/explain/v1"
+	"github.com/canonical/hook-service/internal/openfga"
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/hooks"
+)
+
+func TestGrpcHandler_Explain(t *testing.T) {
+	resp := &oauth2.TokenHookResponse{Session: *flow.NewConsentRequestSessionData()}
+	resp.Session.AccessToken["groups"] = []string{"admins"}
+	resp.Session.IDToken["groups"] = []string{"admins"}
+
+	allowed := &Result{
+		Explanation: &hooks.Explanation{
+			Groups:           []*types.Group{{ID: "g1", Name: "admins", TenantId: "default"}},
+			ContextualTuples: []openfga.Tuple{{User: "user:alice", Relation: "member", Object: "group:ZzE="}},
+			Checks:           []openfga.Tuple{{User: "user:alice", Relation: "can_access", Object: "client:app"}},
+			Allowed:          true,
+			Reason:           hooks.DecisionReasonCheck,
+		},
+		UserID:   "alice",
+		ClientID: "app",
+		Response: resp,
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.ExplainReq
+
+		expectedReq *Request
+		svcResult   *Result
+		svcErr      error
+
+		wantCode codes.Code
+	}{
+		{
+			name:        "Explain allowed request",
+			req:         &pb.ExplainReq{UserId: "alice", ClientId: "app", TenantId: "t-1", Audience: []string{"api"}},
+			expectedReq: &Request{UserID: "alice", ClientID: "app", TenantID: "t-1", Audience: []string{"api"}},
+			svcResult:   allowed,
+			wantCode:    codes.OK,
+		},
+		{
+			name:        "Missing client",
+			req:         &pb.ExplainReq{UserId: "alice"},
+			expectedReq: &Request{UserID: "alice"},
+			svcErr:      ErrMissingClientID,
+			wantCode:    codes.InvalidArgument,
+		},
+		{
+			name:        "Unexpected error",
+			req:         &pb.ExplainReq{UserId: "alice", ClientId: "app"},
+			expectedReq: &Request{UserID: "alice", ClientID: "app"},
+			svcErr:      errors.New("boom"),
+			wantCode:    codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockSvc.EXPECT().Explain(gomock.Any(), tt.expectedReq).Return(tt.svcResult, tt.svcErr)
+
+			g := NewGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
+			res, err := g.Explain(context.TODO(), tt.req)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, status.Code(err))
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			data := res.GetData()
+			if !data.GetAllowed() || !data.GetIssued() || data.GetReason() != "openfga_check" {
+				t.Errorf("unexpected decision in %+v", data)
+			}
+			if len(data.GetGroups()) != 1 || data.GetGroups()[0].GetType() != "local" {
+				t.Errorf("unexpected groups %+v", data.GetGroups())
+			}
+			if len(data.GetChecks()) != 1 || data.GetChecks()[0].GetObject() != "client:app" {
+				t.Errorf("unexpected checks %+v", data.GetChecks())
+			}
+			if len(data.GetContextualTuples()) != 1 || data.GetContextualTuples()[0].GetRelation() != "member" {
+				t.Errorf("unexpected contextual tuples %+v", data.GetContextualTuples())
+			}
+
+			groups := data.GetAccessToken().GetFields()["groups"].GetListValue().GetValues()
+			if len(groups) != 1 || groups[0].GetStringValue() != "admins" {
+				t.Errorf("expected groups claim [admins], got %v", groups)
+			}
+		})
+	}
+}
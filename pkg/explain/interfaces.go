@@ -0,0 +1,22 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+
+	"github.com/ory/hydra/v2/oauth2"
+
+	"github.com/canonical/hook-service/pkg/hooks"
+)
+
+type ServiceInterface interface {
+	Explain(context.Context, *Request) (*Result, error)
+}
+
+// HookServiceInterface runs the token hook pipeline, see hooks.Service.Explain.
+type HookServiceInterface interface {
+	Explain(context.Context, hooks.User, oauth2.TokenHookRequest) *hooks.Explanation
+	TenantSessionKey() string
+}
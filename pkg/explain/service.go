@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+
+	"github.com/ory/fosite/handler/openid"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/hydra/v2/oauth2"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/pkg/hooks"
+)
+
+// DefaultGrantType is the grant type of explained requests that do not set one.
+const DefaultGrantType = "authorization_code"
+
+var _ ServiceInterface = (*Service)(nil)
+
+// Request describes the token hook request to simulate.
+type Request struct {
+	// UserID is the subject of the user, ignored for service accounts.
+	UserID string
+	// Email is the email the user's groups are stored under, UserID when empty.
+	Email      string
+	ClientID   string
+	GrantTypes []string
+	Audience   []string
+	TenantID   string
+}
+
+// Result is the explanation of a request and the response the token hook
+// would send to Hydra.
+type Result struct {
+	*hooks.Explanation
+
+	// UserID is the ID the request was authorized for.
+	UserID   string
+	ClientID string
+	// Response holds the session claims the hook would emit, nil when the
+	// request would be denied.
+	Response *oauth2.TokenHookResponse
+}
+
+type Service struct {
+	hooks  HookServiceInterface
+	claims *hooks.ClaimMapper
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Explain simulates a token hook request for a user and a client, nothing is
+// issued nor recorded in the decision log.
+func (s *Service) Explain(ctx context.Context, r *Request) (*Result, error) {
+	ctx, span := s.tracer.Start(ctx, "explain.Service.Explain")
+	defer span.End()
+
+	if r.ClientID == "" {
+		return nil, ErrMissingClientID
+	}
+
+	req := newHookRequest(r, s.hooks.TenantSessionKey())
+	user := hooks.NewUserFromHookRequest(req, s.logger)
+	if user.GetUserId() == "" {
+		return nil, ErrMissingUserID
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.GetUserId()),
+		attribute.String("client.id", r.ClientID),
+	)
+
+	e := s.hooks.Explain(ctx, *user, *req)
+
+	res := &Result{
+		Explanation: e,
+		UserID:      user.GetUserId(),
+		ClientID:    r.ClientID,
+	}
+	if e.HookContext != nil {
+		res.Response = hooks.ComposeTokenResponse(s.claims, req, e.HookContext)
+	}
+
+	return res, nil
+}
+
+// newHookRequest builds the token hook request Hydra would send after the
+// user logged in to the client, the tenant is stored under tenantKey.
+func newHookRequest(r *Request, tenantKey string) *oauth2.TokenHookRequest {
+	grantTypes := r.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = []string{DefaultGrantType}
+	}
+
+	email := r.Email
+	if email == "" {
+		email = r.UserID
+	}
+
+	req := &oauth2.TokenHookRequest{
+		Session: &oauth2.Session{
+			DefaultSession: &openid.DefaultSession{
+				Subject: r.UserID,
+				Claims:  &jwt.IDTokenClaims{Extra: map[string]interface{}{"email": email}},
+			},
+		},
+		Request: oauth2.Request{
+			ClientID:        r.ClientID,
+			GrantTypes:      grantTypes,
+			GrantedAudience: r.Audience,
+		},
+	}
+	if r.TenantID != "" {
+		req.Session.Extra = map[string]interface{}{tenantKey: r.TenantID}
+	}
+
+	return req
+}
+
+// NewService creates an explain service running the pipeline of the hook
+// service, when claims is nil the default claim mappings are used.
+func NewService(
+	hookService HookServiceInterface,
+	claims *hooks.ClaimMapper,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	s := new(Service)
+
+	s.hooks = hookService
+
+	s.claims = claims
+	if s.claims == nil {
+		s.claims, _ = hooks.NewClaimMapper(hooks.DefaultClaimMappings())
+	}
+
+	s.tracer = tracer
+	s.monitor = monitor
+	s.logger = logger
+
+	return s
+}
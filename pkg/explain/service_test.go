@@ -0,0 +1,149 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ory/hydra/v2/oauth2"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/hooks"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package explain -destination ./mock_explain.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package explain -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package explain -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package explain -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestServiceExplain(t *testing.T) {
+	groups := []*types.Group{{ID: "g1", Name: "admins"}}
+
+	tests := []struct {
+		name string
+		req  *Request
+
+		expectedUser hooks.User
+		explanation  *hooks.Explanation
+
+		expectedAccessToken map[string]interface{}
+		expectedIDToken     map[string]interface{}
+		expectedError       error
+	}{
+		{
+			name:         "Allowed user gets the mapped claims",
+			req:          &Request{UserID: "user-123", Email: "alice@example.com", ClientID: "app", TenantID: "t-1"},
+			expectedUser: hooks.User{SubjectId: "user-123", Email: "alice@example.com"},
+			explanation: &hooks.Explanation{
+				Groups:      groups,
+				Allowed:     true,
+				Reason:      hooks.DecisionReasonCheck,
+				HookContext: &hooks.HookContext{Groups: groups, TenantID: "t-1"},
+			},
+			expectedAccessToken: map[string]interface{}{"_tenant_id": "t-1", "groups": []string{"admins"}, "tenant_id": "t-1"},
+			expectedIDToken:     map[string]interface{}{"email": "alice@example.com", "groups": []string{"admins"}, "tenant_id": "t-1"},
+		},
+		{
+			name:         "Email defaults to the user ID",
+			req:          &Request{UserID: "alice@example.com", ClientID: "app"},
+			expectedUser: hooks.User{SubjectId: "alice@example.com", Email: "alice@example.com"},
+			explanation:  &hooks.Explanation{Reason: hooks.DecisionReasonCheck},
+		},
+		{
+			name:         "Service account",
+			req:          &Request{ClientID: "app", GrantTypes: []string{"client_credentials"}, Audience: []string{"api"}},
+			expectedUser: hooks.User{ClientId: "app"},
+			explanation:  &hooks.Explanation{Reason: hooks.DecisionReasonBatchCheck},
+		},
+		{
+			name:          "Missing client",
+			req:           &Request{UserID: "user-123"},
+			expectedError: ErrMissingClientID,
+		},
+		{
+			name:          "Missing user",
+			req:           &Request{ClientID: "app"},
+			expectedError: ErrMissingUserID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockHooks := NewMockHookServiceInterface(ctrl)
+			mockHooks.EXPECT().TenantSessionKey().AnyTimes().Return(hooks.DefaultTenantSessionKey)
+
+			if test.explanation != nil {
+				mockHooks.EXPECT().Explain(gomock.Any(), test.expectedUser, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ hooks.User, req oauth2.TokenHookRequest) *hooks.Explanation {
+						if req.Request.ClientID != test.req.ClientID {
+							t.Errorf("expected client %s, got %s", test.req.ClientID, req.Request.ClientID)
+						}
+						return test.explanation
+					},
+				)
+			}
+
+			s := NewService(mockHooks, nil, mockTracer, mockMonitor, mockLogger)
+			res, err := s.Explain(context.TODO(), test.req)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+			if test.expectedError != nil {
+				return
+			}
+
+			if res.Explanation != test.explanation {
+				t.Errorf("expected the hook explanation to be returned")
+			}
+			if res.UserID != test.expectedUser.GetUserId() {
+				t.Errorf("expected user %s, got %s", test.expectedUser.GetUserId(), res.UserID)
+			}
+
+			if test.explanation.HookContext == nil {
+				if res.Response != nil {
+					t.Fatalf("expected no response for a denied request, got %+v", res.Response)
+				}
+				return
+			}
+			if !reflect.DeepEqual(res.Response.Session.AccessToken, test.expectedAccessToken) {
+				t.Errorf("expected access token claims %v, got %v", test.expectedAccessToken, res.Response.Session.AccessToken)
+			}
+			if !reflect.DeepEqual(res.Response.Session.IDToken, test.expectedIDToken) {
+				t.Errorf("expected ID token claims %v, got %v", test.expectedIDToken, res.Response.Session.IDToken)
+			}
+		})
+	}
+}
+
+func TestNewHookRequestDefaultGrantType(t *testing.T) {
+	req := newHookRequest(&Request{UserID: "user-123", ClientID: "app"}, hooks.DefaultTenantSessionKey)
+
+	if !reflect.DeepEqual(req.Request.GrantTypes, []string{DefaultGrantType}) {
+		t.Errorf("expected grant types %v, got %v", []string{DefaultGrantType}, req.Request.GrantTypes)
+	}
+	if req.Session.Extra != nil {
+		t.Errorf("expected no session extra without a tenant, got %v", req.Session.Extra)
+	}
+}
+
+func TestNewHookRequestTenantSessionKey(t *testing.T) {
+	req := newHookRequest(&Request{UserID: "user-123", ClientID: "app", TenantID: "t-1"}, "tenant")
+
+	if !reflect.DeepEqual(req.Session.Extra, map[string]interface{}{"tenant": "t-1"}) {
+		t.Errorf("expected the tenant under the session key, got %v", req.Session.Extra)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var _ pb.GroupAttributesServiceServer = (*AttributesGrpcServer)(nil)
+
+type AttributesGrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedGroupAttributesServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (a *AttributesGrpcServer) GetGroupAttributes(ctx context.Context, req *pb.GetGroupAttributesReq) (*pb.GroupAttributesResp, error) {
+	ctx, span := a.tracer.Start(ctx, "groups.AttributesGrpcServer.GetGroupAttributes")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("group.id", req.GetId()))
+
+	attributes, err := a.svc.GetGroupAttributes(ctx, req.GetId())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "get group attributes failed")
+		return nil, a.mapErrorToStatus(err, "get group attributes")
+	}
+
+	span.SetStatus(otelcodes.Ok, "group attributes retrieved successfully")
+
+	return &pb.GroupAttributesResp{
+		Data:    attributes,
+		Status:  http.StatusOK,
+		Message: proto.String("Group attributes"),
+	}, nil
+}
+
+func (a *AttributesGrpcServer) SetGroupAttributes(ctx context.Context, req *pb.SetGroupAttributesReq) (*pb.GroupAttributesResp, error) {
+	ctx, span := a.tracer.Start(ctx, "groups.AttributesGrpcServer.SetGroupAttributes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.Int("attributes.count", len(req.GetAttributes())),
+	)
+
+	attributes, err := a.svc.SetGroupAttributes(ctx, req.GetId(), req.GetAttributes())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "set group attributes failed")
+		return nil, a.mapErrorToStatus(err, "set group attributes")
+	}
+
+	span.SetStatus(otelcodes.Ok, "group attributes set successfully")
+
+	return &pb.GroupAttributesResp{
+		Data:    attributes,
+		Status:  http.StatusOK,
+		Message: proto.String("Group attributes set"),
+	}, nil
+}
+
+func (a *AttributesGrpcServer) RemoveGroupAttribute(ctx context.Context, req *pb.RemoveGroupAttributeReq) (*pb.GroupAttributesResp, error) {
+	ctx, span := a.tracer.Start(ctx, "groups.AttributesGrpcServer.RemoveGroupAttribute")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.String("attribute.key", req.GetKey()),
+	)
+
+	attributes, err := a.svc.RemoveGroupAttributes(ctx, req.GetId(), []string{req.GetKey()})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "remove group attribute failed")
+		return nil, a.mapErrorToStatus(err, "remove group attribute")
+	}
+
+	span.SetStatus(otelcodes.Ok, "group attribute removed successfully")
+
+	return &pb.GroupAttributesResp{
+		Data:    attributes,
+		Status:  http.StatusOK,
+		Message: proto.String("Group attribute removed"),
+	}, nil
+}
+
+func (a *AttributesGrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrGroupNotFound):
+		return status.Errorf(codes.NotFound, "group not found")
+	case errors.Is(err, types.ErrInvalidAttribute):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	default:
+		a.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func NewAttributesGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *AttributesGrpcServer {
+	return &AttributesGrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
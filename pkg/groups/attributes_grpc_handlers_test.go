@@ -0,0 +1,183 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestAttributesGrpcHandler_SetGroupAttributes_Unit(t *testing.T) {
+	attributes := map[string]string{"department": "engineering"}
+
+	tests := []struct {
+		name     string
+		svcErr   error
+		wantCode codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"group not found", ErrGroupNotFound, codes.NotFound},
+		{"invalid attribute", fmt.Errorf("%w: key", types.ErrInvalidAttribute), codes.InvalidArgument},
+		{"unknown error", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			server := NewAttributesGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
+
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), "groups.AttributesGrpcServer.SetGroupAttributes").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockSvc.EXPECT().SetGroupAttributes(gomock.Any(), "group-id", attributes).Return(attributes, tt.svcErr)
+
+			resp, err := server.SetGroupAttributes(context.Background(), &pb.SetGroupAttributesReq{Id: "group-id", Attributes: attributes})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && !reflect.DeepEqual(resp.GetData(), attributes) {
+				t.Errorf("expected attributes %v, got %v", attributes, resp.GetData())
+			}
+		})
+	}
+}
+
+func TestAttributesGrpcHandler_RemoveGroupAttribute_Unit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	server := NewAttributesGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	mockTracer.EXPECT().Start(gomock.Any(), "groups.AttributesGrpcServer.RemoveGroupAttribute").Return(context.Background(), trace.SpanFromContext(context.Background()))
+	mockSvc.EXPECT().RemoveGroupAttributes(gomock.Any(), "group-id", []string{"slack"}).Return(nil, nil)
+
+	resp, err := server.RemoveGroupAttribute(context.Background(), &pb.RemoveGroupAttributeReq{Id: "group-id", Key: "slack"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetData()) != 0 {
+		t.Errorf("expected no attributes, got %v", resp.GetData())
+	}
+}
+
+// TestGroupAttributes covers the attributes endpoints and the search of
+// groups by attribute.
+func TestGroupAttributes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, teardown := newIntegrationServer(t)
+	if client == nil {
+		return
+	}
+	defer teardown()
+
+	prefix := fmt.Sprintf("attributes-%d", time.Now().UnixNano())
+	engineering := createTestGroup(t, client, prefix+"-engineering")
+	sales := createTestGroup(t, client, prefix+"-sales")
+
+	attributes := func(method, path string, body interface{}, expectedStatus int) map[string]string {
+		t.Helper()
+
+		statusCode, respBody := client.Request(method, path, body)
+		if statusCode != expectedStatus {
+			t.Fatalf("expected status %d for %s %s, got %d. Body: %s", expectedStatus, method, path, statusCode, string(respBody))
+		}
+
+		var resp struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return resp.Data
+	}
+
+	t.Run("set and get attributes", func(t *testing.T) {
+		path := "/api/v0/authz/groups/" + engineering + "/attributes"
+
+		attributes(http.MethodPost, path, map[string]interface{}{"attributes": map[string]string{"department": "engineering", "slack": "#eng"}}, http.StatusOK)
+		got := attributes(http.MethodPost, path, map[string]interface{}{"attributes": map[string]string{"slack": "#identity"}}, http.StatusOK)
+
+		want := map[string]string{"department": "engineering", "slack": "#identity"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected attributes %v, got %v", want, got)
+		}
+		if got := attributes(http.MethodGet, path, nil, http.StatusOK); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected attributes %v, got %v", want, got)
+		}
+	})
+
+	t.Run("invalid attribute", func(t *testing.T) {
+		statusCode, _ := client.Request(http.MethodPost, "/api/v0/authz/groups/"+sales+"/attributes", map[string]interface{}{"attributes": map[string]string{"cost centre": "42"}})
+		if statusCode != http.StatusBadRequest {
+			t.Errorf("expected status Bad Request, got %d", statusCode)
+		}
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		statusCode, _ := client.Request(http.MethodGet, "/api/v0/authz/groups/00000000-0000-0000-0000-000000000000/attributes", nil)
+		if statusCode != http.StatusNotFound {
+			t.Errorf("expected status Not Found, got %d", statusCode)
+		}
+	})
+
+	t.Run("search by attribute", func(t *testing.T) {
+		attributes(http.MethodPost, "/api/v0/authz/groups/"+sales+"/attributes", map[string]interface{}{"attributes": map[string]string{"department": "sales"}}, http.StatusOK)
+
+		query := url.Values{"search": {prefix}, "attributes": {"department=engineering"}}
+		statusCode, body := client.Request(http.MethodGet, "/api/v0/authz/groups:search?"+query.Encode(), nil)
+		if statusCode != http.StatusOK {
+			t.Fatalf("expected status OK, got %d. Body: %s", statusCode, string(body))
+		}
+
+		var resp struct {
+			Data []struct {
+				ID         string            `json:"id"`
+				Attributes map[string]string `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.Data) != 1 || resp.Data[0].ID != engineering || resp.Data[0].Attributes["slack"] != "#identity" {
+			t.Errorf("expected the engineering group with its attributes, got %+v", resp.Data)
+		}
+	})
+
+	t.Run("remove attribute", func(t *testing.T) {
+		got := attributes(http.MethodDelete, "/api/v0/authz/groups/"+engineering+"/attributes/slack", nil, http.StatusOK)
+
+		want := map[string]string{"department": "engineering"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected attributes %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+var _ pb.GroupDeletionServiceServer = (*DeletionGrpcServer)(nil)
+
+type DeletionGrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedGroupDeletionServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (d *DeletionGrpcServer) ListDeletedGroups(ctx context.Context, req *pb.ListDeletedGroupsReq) (*pb.ListDeletedGroupsResp, error) {
+	ctx, span := d.tracer.Start(ctx, "groups.DeletionGrpcServer.ListDeletedGroups")
+	defer span.End()
+
+	groups, err := d.svc.ListDeletedGroups(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "list deleted groups failed")
+		return nil, d.mapErrorToStatus(err, "list deleted groups")
+	}
+
+	data := make([]*pb.GroupMapping, 0, len(groups))
+	for _, g := range groups {
+		data = append(data, toGroupMapping(g))
+	}
+
+	span.SetAttributes(attribute.Int("groups.count", len(data)))
+	span.SetStatus(otelcodes.Ok, "deleted groups listed successfully")
+
+	return &pb.ListDeletedGroupsResp{
+		Data:    data,
+		Status:  http.StatusOK,
+		Message: proto.String("Deleted groups"),
+	}, nil
+}
+
+func (d *DeletionGrpcServer) RestoreGroup(ctx context.Context, req *pb.RestoreGroupReq) (*pb.RestoreGroupResp, error) {
+	ctx, span := d.tracer.Start(ctx, "groups.DeletionGrpcServer.RestoreGroup")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("group.id", req.GetId()))
+
+	group, err := d.svc.RestoreGroup(ctx, req.GetId())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "restore group failed")
+		return nil, d.mapErrorToStatus(err, "restore group")
+	}
+
+	span.SetStatus(otelcodes.Ok, "group restored successfully")
+
+	return &pb.RestoreGroupResp{
+		Data:    []*pb.GroupMapping{toGroupMapping(group)},
+		Status:  http.StatusOK,
+		Message: proto.String("Group restored"),
+	}, nil
+}
+
+func (d *DeletionGrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrGroupNotFound):
+		return status.Errorf(codes.NotFound, "deleted group not found")
+	case errors.Is(err, ErrDuplicateGroup):
+		return status.Errorf(codes.AlreadyExists, "a group with the same name exists")
+	default:
+		d.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func NewDeletionGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *DeletionGrpcServer {
+	return &DeletionGrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
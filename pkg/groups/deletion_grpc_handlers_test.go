@@ -0,0 +1,196 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestDeletionGrpcHandler_ListDeletedGroups_Unit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	server := NewDeletionGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	deletedAt := time.Now().UTC()
+	mockTracer.EXPECT().Start(gomock.Any(), "groups.DeletionGrpcServer.ListDeletedGroups").Return(context.Background(), trace.SpanFromContext(context.Background()))
+	mockSvc.EXPECT().ListDeletedGroups(gomock.Any()).Return([]*types.Group{{ID: "group-id", Name: "engineering", DeletedAt: &deletedAt}}, nil)
+
+	resp, err := server.ListDeletedGroups(context.Background(), &pb.ListDeletedGroupsReq{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetData()) != 1 || !resp.GetData()[0].GetDeletedAt().AsTime().Equal(deletedAt) {
+		t.Errorf("expected the deleted group with its deletion time, got %v", resp.GetData())
+	}
+}
+
+func TestDeletionGrpcHandler_RestoreGroup_Unit(t *testing.T) {
+	tests := []struct {
+		name     string
+		svcErr   error
+		wantCode codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"not deleted", ErrGroupNotFound, codes.NotFound},
+		{"name taken", ErrDuplicateGroup, codes.AlreadyExists},
+		{"unknown error", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			server := NewDeletionGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			var group *types.Group
+			if tt.svcErr == nil {
+				group = &types.Group{ID: "group-id", Name: "engineering"}
+			}
+
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), "groups.DeletionGrpcServer.RestoreGroup").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockSvc.EXPECT().RestoreGroup(gomock.Any(), "group-id").Return(group, tt.svcErr)
+
+			resp, err := server.RestoreGroup(context.Background(), &pb.RestoreGroupReq{Id: "group-id"})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && (len(resp.GetData()) != 1 || resp.GetData()[0].GetId() != "group-id") {
+				t.Errorf("expected the restored group, got %v", resp.GetData())
+			}
+		})
+	}
+}
+
+// TestGroupDeletion covers the deletion of a group, its removal from the
+// groups of its members and its restore.
+func TestGroupDeletion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, teardown := newIntegrationServer(t)
+	if client == nil {
+		return
+	}
+	defer teardown()
+
+	name := fmt.Sprintf("deletion-%d", time.Now().UnixNano())
+	groupID := createTestGroup(t, client, name)
+	userID := fmt.Sprintf("deletion-user-%d@example.com", time.Now().UnixNano())
+
+	if statusCode, body := client.Request(http.MethodPost, fmt.Sprintf("%s/%s/users", groupsBase, groupID), []string{userID}); statusCode != http.StatusOK {
+		t.Fatalf("failed to add user to group (status %d): %s", statusCode, string(body))
+	}
+
+	userGroups := func(t *testing.T) []string {
+		t.Helper()
+
+		statusCode, body := client.Request(http.MethodGet, fmt.Sprintf("%s/%s/groups", usersBase, userID), nil)
+		if statusCode != http.StatusOK {
+			t.Fatalf("expected status OK listing user groups, got %d. Body: %s", statusCode, string(body))
+		}
+
+		var resp struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		ids := make([]string, 0, len(resp.Data))
+		for _, g := range resp.Data {
+			ids = append(ids, g.ID)
+		}
+		return ids
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		if statusCode, body := client.Request(http.MethodDelete, fmt.Sprintf("%s/%s", groupsBase, groupID), nil); statusCode != http.StatusOK {
+			t.Fatalf("expected status OK deleting the group, got %d. Body: %s", statusCode, string(body))
+		}
+
+		if statusCode, _ := client.Request(http.MethodGet, fmt.Sprintf("%s/%s", groupsBase, groupID), nil); statusCode != http.StatusNotFound {
+			t.Errorf("expected status Not Found for a deleted group, got %d", statusCode)
+		}
+		if ids := userGroups(t); len(ids) != 0 {
+			t.Errorf("expected the deleted group to be dropped from the user groups, got %v", ids)
+		}
+	})
+
+	t.Run("list deleted groups", func(t *testing.T) {
+		statusCode, body := client.Request(http.MethodGet, "/api/v0/authz/deleted-groups", nil)
+		if statusCode != http.StatusOK {
+			t.Fatalf("expected status OK, got %d. Body: %s", statusCode, string(body))
+		}
+
+		var resp struct {
+			Data []struct {
+				ID        string `json:"id"`
+				DeletedAt string `json:"deleted_at"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		for _, g := range resp.Data {
+			if g.ID == groupID && g.DeletedAt != "" {
+				return
+			}
+		}
+		t.Errorf("expected the deleted group in %s", string(body))
+	})
+
+	t.Run("restore with a taken name", func(t *testing.T) {
+		otherID := createTestGroup(t, client, name)
+
+		if statusCode, _ := client.Request(http.MethodPost, fmt.Sprintf("%s/%s:restore", groupsBase, groupID), nil); statusCode != http.StatusConflict {
+			t.Errorf("expected status Conflict, got %d", statusCode)
+		}
+
+		if statusCode, body := client.Request(http.MethodDelete, fmt.Sprintf("%s/%s", groupsBase, otherID), nil); statusCode != http.StatusOK {
+			t.Fatalf("expected status OK deleting the group, got %d. Body: %s", statusCode, string(body))
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		if statusCode, body := client.Request(http.MethodPost, fmt.Sprintf("%s/%s:restore", groupsBase, groupID), nil); statusCode != http.StatusOK {
+			t.Fatalf("expected status OK restoring the group, got %d. Body: %s", statusCode, string(body))
+		}
+
+		if ids := userGroups(t); len(ids) != 1 || ids[0] != groupID {
+			t.Errorf("expected the restored group in the user groups, got %v", ids)
+		}
+		if statusCode, _ := client.Request(http.MethodPost, fmt.Sprintf("%s/%s:restore", groupsBase, groupID), nil); statusCode != http.StatusNotFound {
+			t.Errorf("expected status Not Found restoring a live group, got %d", statusCode)
+		}
+	})
+}
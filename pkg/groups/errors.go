@@ -18,4 +18,10 @@ var (
 	ErrInvalidUserID       = errors.New("invalid user id")
 	ErrStreamInterrupted   = errors.New("stream interrupted")
 	ErrUnauthorizedStream  = errors.New("unauthorized stream access")
+	ErrGroupCycle          = errors.New("group nesting would create a cycle")
+	ErrNotGroupOwner       = errors.New("not an owner of the group")
+	ErrOwnerRemoval        = errors.New("only admins can remove owners of the group")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrExpiredCursor       = errors.New("cursor expired")
+	ErrTooManyUsers        = errors.New("too many users")
 )
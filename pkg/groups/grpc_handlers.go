@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	httptypes "github.com/canonical/hook-service/internal/http/types"
 	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/monitoring"
 	"github.com/canonical/hook-service/internal/storage"
@@ -59,7 +60,7 @@ func (g *GrpcServer) CreateGroup(ctx context.Context, req *v0_groups.CreateGroup
 
 	group := &types.Group{
 		Name:        req.Group.GetName(),
-		TenantId:    storage.DefaultTenantID,
+		TenantId:    requestTenant(ctx),
 		Description: req.Group.GetDescription(),
 		Type:        gType,
 	}
@@ -124,7 +125,9 @@ func (g *GrpcServer) ListGroups(ctx context.Context, req *v0_groups.ListGroupsRe
 	ctx, span := g.tracer.Start(ctx, "groups.GrpcServer.ListGroups")
 	defer span.End()
 
-	groups, err := g.svc.ListGroups(ctx)
+	opts := httptypes.PaginationOptions(req.GetPagination())
+
+	groups, page, err := g.svc.ListGroups(ctx, opts)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(otelcodes.Error, "list groups failed")
@@ -151,6 +154,7 @@ func (g *GrpcServer) ListGroups(ctx context.Context, req *v0_groups.ListGroupsRe
 		Data:    respGroups,
 		Status:  http.StatusOK,
 		Message: proto.String("Group list"),
+		Meta:    httptypes.Pagination(opts, len(respGroups), page),
 	}, nil
 }
 
@@ -205,7 +209,7 @@ func (g *GrpcServer) UpdateGroup(ctx context.Context, req *v0_groups.UpdateGroup
 	group := &types.Group{
 		Description: req.Group.GetDescription(),
 		Type:        gType,
-		TenantId:    storage.DefaultTenantID,
+		TenantId:    requestTenant(ctx),
 	}
 
 	gg, err := g.svc.UpdateGroup(ctx, req.GetId(), group)
@@ -238,7 +242,9 @@ func (g *GrpcServer) ListUsersInGroup(ctx context.Context, req *v0_groups.ListUs
 
 	span.SetAttributes(attribute.String("group.id", req.GetId()))
 
-	users, err := g.svc.ListUsersInGroup(ctx, req.GetId())
+	opts := httptypes.PaginationOptions(req.GetPagination())
+
+	users, page, err := g.svc.ListUsersInGroup(ctx, req.GetId(), opts)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(otelcodes.Error, "list users in group failed")
@@ -247,7 +253,12 @@ func (g *GrpcServer) ListUsersInGroup(ctx context.Context, req *v0_groups.ListUs
 
 	respUsers := make([]*v0_groups.User, len(users))
 	for i, user := range users {
-		respUsers[i] = &v0_groups.User{Id: user}
+		respUsers[i] = &v0_groups.User{
+			Id:        user.ID,
+			Role:      user.Role.String(),
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+		}
 	}
 
 	span.SetAttributes(attribute.Int("users.count", len(users)))
@@ -257,6 +268,7 @@ func (g *GrpcServer) ListUsersInGroup(ctx context.Context, req *v0_groups.ListUs
 		Data:    respUsers,
 		Status:  http.StatusOK,
 		Message: proto.String("Users in group"),
+		Meta:    httptypes.Pagination(opts, len(respUsers), page),
 	}, nil
 }
 
@@ -370,6 +382,10 @@ func (g *GrpcServer) AddUserToGroups(ctx context.Context, req *v0_groups.AddUser
 
 // mapErrorToStatus maps known errors to gRPC status errors
 func (g *GrpcServer) mapErrorToStatus(err error, action string) error {
+	if s := httptypes.ListErrorStatus(err); s != nil {
+		return s
+	}
+
 	switch {
 	case err == nil:
 		return nil
@@ -387,6 +403,10 @@ func (g *GrpcServer) mapErrorToStatus(err error, action string) error {
 		return status.Errorf(codes.InvalidArgument, "invalid tenant")
 	case errors.Is(err, ErrInvalidGroupID):
 		return status.Errorf(codes.InvalidArgument, "invalid group id")
+	case errors.Is(err, ErrNotGroupOwner):
+		return status.Errorf(codes.PermissionDenied, "not an owner of the group")
+	case errors.Is(err, ErrOwnerRemoval):
+		return status.Errorf(codes.PermissionDenied, "only admins can remove owners of the group")
 	case errors.Is(err, ErrInternalServerError):
 		return status.Errorf(codes.Internal, "internal server error")
 	default:
@@ -403,3 +423,12 @@ func NewGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monito
 		logger:  logger,
 	}
 }
+
+// requestTenant returns the tenant the storage operations of a request are
+// scoped to.
+func requestTenant(ctx context.Context) string {
+	if tenantID, ok := storage.TenantFromContext(ctx); ok {
+		return tenantID
+	}
+	return storage.DefaultTenantID
+}
@@ -20,12 +20,16 @@ import (
 
 	"github.com/canonical/hook-service/internal/types"
 	v0_groups "github.com/canonical/identity-platform-api/v0/authz_groups"
+	v0_http "github.com/canonical/identity-platform-api/v0/http"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	historypb "github.com/canonical/hook-service/gen/hook/history/v1"
 	"github.com/canonical/hook-service/internal/authorization"
 	"github.com/canonical/hook-service/internal/db"
 	httptypes "github.com/canonical/hook-service/internal/http/types"
@@ -36,6 +40,7 @@ import (
 	"github.com/canonical/hook-service/internal/tracing"
 	"github.com/canonical/hook-service/migrations"
 	authorization_api "github.com/canonical/hook-service/pkg/authorization"
+	"github.com/canonical/hook-service/pkg/history"
 	v0_authz "github.com/canonical/identity-platform-api/v0/authorization"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v5"
@@ -49,11 +54,15 @@ import (
 const groupsBase = "/api/v0/authz/groups"
 const usersBase = "/api/v0/authz/users"
 
+// integrationActor is the actor of the changes made through the integration server.
+const integrationActor = "integration-test"
+
 func TestGrpcHandler_CreateGroup(t *testing.T) {
 	now := time.Now()
 	strPtr := func(s string) *string { return &s }
 	tests := []struct {
 		name       string
+		tenantID   string
 		input      *v0_groups.CreateGroupReq
 		expectResp *types.Group
 		expectErr  error
@@ -127,6 +136,36 @@ func TestGrpcHandler_CreateGroup(t *testing.T) {
 				Message: func() *string { s := "Group created"; return &s }(),
 			},
 		},
+		{
+			name:     "Should create the group in the tenant of the request",
+			tenantID: "acme",
+			input: &v0_groups.CreateGroupReq{
+				Group: &v0_groups.GroupInput{
+					Name: "test-group",
+					Type: strPtr("local"),
+				},
+			},
+			expectResp: &types.Group{
+				ID:        "group-id",
+				Name:      "test-group",
+				TenantId:  "acme",
+				Type:      types.GroupTypeLocal,
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantResp: &v0_groups.CreateGroupResp{
+				Data: []*v0_groups.Group{{
+					Id:        "group-id",
+					Name:      "test-group",
+					TenantId:  "acme",
+					Type:      "local",
+					CreatedAt: timestamppb.New(now),
+					UpdatedAt: timestamppb.New(now),
+				}},
+				Status:  http.StatusOK,
+				Message: func() *string { s := "Group created"; return &s }(),
+			},
+		},
 		{
 			name: "Service returns error",
 			input: &v0_groups.CreateGroupReq{
@@ -152,19 +191,26 @@ func TestGrpcHandler_CreateGroup(t *testing.T) {
 
 			server := NewGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
 
+			ctx := context.Background()
+			tenantID := storage.DefaultTenantID
+			if tt.tenantID != "" {
+				ctx = storage.WithTenant(ctx, tt.tenantID)
+				tenantID = tt.tenantID
+			}
+
 			gType, _ := types.ParseGroupType(tt.input.Group.GetType())
 			g := &types.Group{
 				Name:        tt.input.Group.GetName(),
-				TenantId:    storage.DefaultTenantID,
+				TenantId:    tenantID,
 				Description: tt.input.Group.GetDescription(),
 				Type:        gType,
 			}
 
 			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-			mockTracer.EXPECT().Start(gomock.Any(), "groups.GrpcServer.CreateGroup").Return(context.Background(), trace.SpanFromContext(context.Background())).Times(1)
+			mockTracer.EXPECT().Start(gomock.Any(), "groups.GrpcServer.CreateGroup").Return(ctx, trace.SpanFromContext(ctx)).Times(1)
 			mockSvc.EXPECT().CreateGroup(gomock.Any(), g).Return(tt.expectResp, tt.expectErr)
 
-			resp, err := server.CreateGroup(context.Background(), tt.input)
+			resp, err := server.CreateGroup(ctx, tt.input)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateGroup() error = %v, wantErr %v", err, tt.wantErr)
@@ -279,7 +325,9 @@ func TestGrpcHandler_ListGroups(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
 		name       string
+		pagination *v0_http.PaginationInput
 		expectResp []*types.Group
+		expectPage *types.PageInfo
 		expectErr  error
 		wantErr    bool
 		wantCode   codes.Code
@@ -309,6 +357,29 @@ func TestGrpcHandler_ListGroups(t *testing.T) {
 			wantErr:    false,
 			wantResp:   &v0_groups.ListGroupsResp{Data: []*v0_groups.Group{}, Status: http.StatusOK, Message: func() *string { s := "Group list"; return &s }()},
 		},
+		{
+			name:       "Success with a page of groups",
+			pagination: &v0_http.PaginationInput{Size: 1, PageToken: proto.String("current")},
+			expectResp: []*types.Group{
+				{ID: "group-1", Name: "Group 1", CreatedAt: now, UpdatedAt: now},
+			},
+			expectPage: &types.PageInfo{NextPageToken: "next"},
+			wantResp: &v0_groups.ListGroupsResp{
+				Data: []*v0_groups.Group{
+					{Id: "group-1", Name: "Group 1", Type: "local", CreatedAt: timestamppb.New(now), UpdatedAt: timestamppb.New(now)},
+				},
+				Status:  http.StatusOK,
+				Message: func() *string { s := "Group list"; return &s }(),
+				Meta:    &v0_http.Pagination{Size: 1, PageToken: proto.String("current"), Next: proto.String("next")},
+			},
+		},
+		{
+			name:       "Invalid page token",
+			pagination: &v0_http.PaginationInput{Size: 1, PageToken: proto.String("invalid")},
+			expectErr:  types.ErrInvalidPageToken,
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
 		{
 			name:       "Service returns error",
 			expectResp: nil,
@@ -332,9 +403,9 @@ func TestGrpcHandler_ListGroups(t *testing.T) {
 
 			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 			mockTracer.EXPECT().Start(gomock.Any(), "groups.GrpcServer.ListGroups").Return(context.Background(), trace.SpanFromContext(context.Background())).Times(1)
-			mockSvc.EXPECT().ListGroups(gomock.Any()).Return(tt.expectResp, tt.expectErr)
+			mockSvc.EXPECT().ListGroups(gomock.Any(), httptypes.PaginationOptions(tt.pagination)).Return(tt.expectResp, tt.expectPage, tt.expectErr)
 
-			resp, err := server.ListGroups(context.Background(), &v0_groups.ListGroupsReq{})
+			resp, err := server.ListGroups(context.Background(), &v0_groups.ListGroupsReq{Pagination: tt.pagination})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ListGroups() error = %v, wantErr %v", err, tt.wantErr)
@@ -505,10 +576,12 @@ func TestGrpcHandler_UpdateGroup(t *testing.T) {
 }
 
 func TestGrpcHandler_ListUsersInGroup(t *testing.T) {
+	now := time.Now()
 	tests := []struct {
 		name       string
 		input      *v0_groups.ListUsersInGroupReq
-		expectResp []string
+		expectResp []*types.GroupUser
+		expectPage *types.PageInfo
 		expectErr  error
 		wantErr    bool
 		wantCode   codes.Code
@@ -517,10 +590,16 @@ func TestGrpcHandler_ListUsersInGroup(t *testing.T) {
 		{
 			name:       "Success with users",
 			input:      &v0_groups.ListUsersInGroupReq{Id: "group-id"},
-			expectResp: []string{"user-1", "user-2"},
-			wantErr:    false,
+			expectResp: []*types.GroupUser{
+				{ID: "user-1", Role: types.RoleOwner, CreatedAt: now, UpdatedAt: now},
+				{ID: "user-2", Role: types.RoleMember, CreatedAt: now, UpdatedAt: now},
+			},
+			wantErr: false,
 			wantResp: &v0_groups.ListUsersInGroupResp{
-				Data:    []*v0_groups.User{{Id: "user-1"}, {Id: "user-2"}},
+				Data: []*v0_groups.User{
+					{Id: "user-1", Role: "owner", CreatedAt: timestamppb.New(now), UpdatedAt: timestamppb.New(now)},
+					{Id: "user-2", Role: "member", CreatedAt: timestamppb.New(now), UpdatedAt: timestamppb.New(now)},
+				},
 				Status:  http.StatusOK,
 				Message: func() *string { s := "Users in group"; return &s }(),
 			},
@@ -528,7 +607,7 @@ func TestGrpcHandler_ListUsersInGroup(t *testing.T) {
 		{
 			name:       "Success with no users",
 			input:      &v0_groups.ListUsersInGroupReq{Id: "group-id"},
-			expectResp: []string{},
+			expectResp: []*types.GroupUser{},
 			wantErr:    false,
 			wantResp: &v0_groups.ListUsersInGroupResp{
 				Data:    []*v0_groups.User{},
@@ -536,6 +615,22 @@ func TestGrpcHandler_ListUsersInGroup(t *testing.T) {
 				Message: func() *string { s := "Users in group"; return &s }(),
 			},
 		},
+		{
+			name:  "Success with a page of users",
+			input: &v0_groups.ListUsersInGroupReq{Id: "group-id", Pagination: &v0_http.PaginationInput{Size: 1}},
+			expectResp: []*types.GroupUser{
+				{ID: "user-1", Role: types.RoleOwner, CreatedAt: now, UpdatedAt: now},
+			},
+			expectPage: &types.PageInfo{NextPageToken: "next"},
+			wantResp: &v0_groups.ListUsersInGroupResp{
+				Data: []*v0_groups.User{
+					{Id: "user-1", Role: "owner", CreatedAt: timestamppb.New(now), UpdatedAt: timestamppb.New(now)},
+				},
+				Status:  http.StatusOK,
+				Message: func() *string { s := "Users in group"; return &s }(),
+				Meta:    &v0_http.Pagination{Size: 1, Next: proto.String("next")},
+			},
+		},
 		{
 			name:      "Group not found",
 			input:     &v0_groups.ListUsersInGroupReq{Id: "not-found"},
@@ -543,6 +638,13 @@ func TestGrpcHandler_ListUsersInGroup(t *testing.T) {
 			wantErr:   true,
 			wantCode:  codes.NotFound,
 		},
+		{
+			name:      "Caller does not own the group",
+			input:     &v0_groups.ListUsersInGroupReq{Id: "group-id"},
+			expectErr: ErrNotGroupOwner,
+			wantErr:   true,
+			wantCode:  codes.PermissionDenied,
+		},
 		{
 			name:      "Service returns error",
 			input:     &v0_groups.ListUsersInGroupReq{Id: "error-id"},
@@ -566,7 +668,7 @@ func TestGrpcHandler_ListUsersInGroup(t *testing.T) {
 
 			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 			mockTracer.EXPECT().Start(gomock.Any(), "groups.GrpcServer.ListUsersInGroup").Return(context.Background(), trace.SpanFromContext(context.Background())).Times(1)
-			mockSvc.EXPECT().ListUsersInGroup(gomock.Any(), tt.input.Id).Return(tt.expectResp, tt.expectErr)
+			mockSvc.EXPECT().ListUsersInGroup(gomock.Any(), tt.input.Id, httptypes.PaginationOptions(tt.input.Pagination)).Return(tt.expectResp, tt.expectPage, tt.expectErr)
 
 			resp, err := server.ListUsersInGroup(context.Background(), tt.input)
 
@@ -675,6 +777,13 @@ func TestGrpcHandler_RemoveUserFromGroup(t *testing.T) {
 			wantErr:   true,
 			wantCode:  codes.NotFound,
 		},
+		{
+			name:      "Caller removes an owner",
+			input:     &v0_groups.RemoveUserFromGroupReq{Id: "group-id", UserId: "lead"},
+			expectErr: ErrOwnerRemoval,
+			wantErr:   true,
+			wantCode:  codes.PermissionDenied,
+		},
 		{
 			name:      "Service returns error",
 			input:     &v0_groups.RemoveUserFromGroupReq{Id: "error-id", UserId: "user-1"},
@@ -1078,8 +1187,8 @@ func newIntegrationServer(t *testing.T) (*testClient, func()) {
 		}),
 	)
 
-	authzSvc := authorization_api.NewService(s, authz, tracer, monitor, logger)
-	groupSvc := NewService(s, authz, tracer, monitor, logger)
+	authzSvc := authorization_api.NewService(s, authz, nil, tracer, monitor, logger)
+	groupSvc := NewService(s, authz, nil, tracer, monitor, logger)
 
 	ctx := context.Background()
 	v0_authz.RegisterAppAuthorizationServiceHandlerServer(ctx, gwMux,
@@ -1088,8 +1197,27 @@ func newIntegrationServer(t *testing.T) (*testClient, func()) {
 	v0_groups.RegisterAuthzGroupsServiceHandlerServer(ctx, gwMux,
 		NewGrpcServer(groupSvc, tracer, monitor, logger),
 	)
+	pb.RegisterGroupAttributesServiceHandlerServer(ctx, gwMux,
+		NewAttributesGrpcServer(groupSvc, tracer, monitor, logger),
+	)
+	pb.RegisterGroupDeletionServiceHandlerServer(ctx, gwMux,
+		NewDeletionGrpcServer(groupSvc, tracer, monitor, logger),
+	)
+	pb.RegisterGroupListingServiceHandlerServer(ctx, gwMux,
+		NewListingGrpcServer(groupSvc, tracer, monitor, logger),
+	)
+	pb.RegisterAppGrantListingServiceHandlerServer(ctx, gwMux,
+		authorization_api.NewListingGrpcServer(authzSvc, tracer, monitor, logger),
+	)
+	historypb.RegisterHistoryServiceHandlerServer(ctx, gwMux,
+		history.NewGrpcServer(history.NewService(s, tracer, monitor, logger), tracer, monitor, logger),
+	)
 
-	srv := httptest.NewServer(gwMux)
+	// Record the changes with a fixed actor, as the authentication middleware would.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := storage.Actor{ID: integrationActor, Source: storage.SourceAPI}
+		gwMux.ServeHTTP(w, r.WithContext(storage.WithActor(r.Context(), actor)))
+	}))
 
 	cleanup := func() {
 		srv.Close()
@@ -1481,3 +1609,102 @@ func TestUserMembershipErrors(t *testing.T) {
 		}
 	})
 }
+
+// TestTenantIsolation covers groups of the same name in two tenants.
+func TestTenantIsolation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	connStr, pgContainer := setupTestPostgres(t)
+	if pgContainer == nil {
+		t.Skip("container runtime not available")
+	}
+	defer pgContainer.Terminate(context.Background())
+	runMigrations(t, connStr)
+
+	logger := logging.NewNoopLogger()
+	monitor := monitoring.NewNoopMonitor("hook-service-test", logger)
+	tracer := tracing.NewNoopTracer()
+
+	dbClient, err := db.NewDBClient(db.Config{DSN: connStr, MaxConns: 5, MinConns: 1}, tracer, monitor, logger)
+	if err != nil {
+		t.Fatalf("Failed to create DB client: %v", err)
+	}
+	defer dbClient.Close()
+
+	authz := authorization.NewAuthorizer(
+		openfga.NewNoopClient(tracer, monitor, logger),
+		tracer, monitor, logger,
+	)
+	svc := NewService(storage.NewStorage(dbClient, tracer, monitor, logger), authz, nil, tracer, monitor, logger)
+
+	acme := storage.WithTenant(context.Background(), "acme")
+	globex := storage.WithTenant(context.Background(), "globex")
+
+	acmeGroup, err := svc.CreateGroup(acme, &types.Group{Name: "engineering", Type: types.GroupTypeLocal})
+	if err != nil {
+		t.Fatalf("failed to create the acme group: %v", err)
+	}
+	globexGroup, err := svc.CreateGroup(globex, &types.Group{Name: "engineering", Type: types.GroupTypeLocal})
+	if err != nil {
+		t.Fatalf("failed to create a group of the same name in globex: %v", err)
+	}
+	if acmeGroup.TenantId != "acme" || globexGroup.TenantId != "globex" {
+		t.Fatalf("expected the groups in their tenants, got %s and %s", acmeGroup.TenantId, globexGroup.TenantId)
+	}
+
+	userID := "tenant-user@example.com"
+	if err := svc.AddUsersToGroup(acme, acmeGroup.ID, []string{userID}); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+
+	t.Run("ListGroups returns the groups of the tenant", func(t *testing.T) {
+		groups, _, err := svc.ListGroups(globex, nil)
+		if err != nil {
+			t.Fatalf("ListGroups failed: %v", err)
+		}
+		if len(groups) != 1 || groups[0].ID != globexGroup.ID {
+			t.Errorf("expected only the globex group, got %v", groups)
+		}
+	})
+
+	t.Run("GetGroup hides the groups of other tenants", func(t *testing.T) {
+		if _, err := svc.GetGroup(globex, acmeGroup.ID); !errors.Is(err, ErrGroupNotFound) {
+			t.Errorf("expected ErrGroupNotFound, got %v", err)
+		}
+	})
+
+	t.Run("AddUsersToGroup rejects the groups of other tenants", func(t *testing.T) {
+		if err := svc.AddUsersToGroup(globex, acmeGroup.ID, []string{userID}); !errors.Is(err, ErrInvalidGroupID) {
+			t.Errorf("expected ErrInvalidGroupID, got %v", err)
+		}
+	})
+
+	t.Run("Memberships are scoped to the tenant", func(t *testing.T) {
+		users, _, err := svc.ListUsersInGroup(acme, acmeGroup.ID, nil)
+		if err != nil {
+			t.Fatalf("ListUsersInGroup failed: %v", err)
+		}
+		if len(users) != 1 || users[0].TenantId != "acme" {
+			t.Errorf("expected one member in acme, got %v", users)
+		}
+
+		groups, err := svc.GetGroupsForUser(globex, userID)
+		if err != nil {
+			t.Fatalf("GetGroupsForUser failed: %v", err)
+		}
+		if len(groups) != 0 {
+			t.Errorf("expected no groups in globex, got %v", groups)
+		}
+	})
+
+	t.Run("DeleteGroup leaves the groups of other tenants", func(t *testing.T) {
+		if err := svc.DeleteGroup(globex, acmeGroup.ID); err != nil {
+			t.Fatalf("DeleteGroup failed: %v", err)
+		}
+		if _, err := svc.GetGroup(acme, acmeGroup.ID); err != nil {
+			t.Errorf("expected the acme group to remain, got %v", err)
+		}
+	})
+}
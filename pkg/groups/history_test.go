@@ -0,0 +1,235 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/canonical/hook-service/internal/db"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/history"
+)
+
+// TestGroupHistory covers the history recorded by the group and membership
+// changes, per group and per user.
+func TestGroupHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, teardown := newIntegrationServer(t)
+	if client == nil {
+		return
+	}
+	defer teardown()
+
+	name := fmt.Sprintf("history-%d", time.Now().UnixNano())
+	groupID := createTestGroup(t, client, name)
+	userID := fmt.Sprintf("history-user-%d@example.com", time.Now().UnixNano())
+
+	type entry struct {
+		Action  string                 `json:"action"`
+		GroupID string                 `json:"group_id"`
+		UserID  string                 `json:"user_id"`
+		Actor   string                 `json:"actor"`
+		Source  string                 `json:"source"`
+		Before  map[string]interface{} `json:"before"`
+		After   map[string]interface{} `json:"after"`
+	}
+
+	listHistory := func(path string) []entry {
+		t.Helper()
+
+		statusCode, body := client.Request(http.MethodGet, path, nil)
+		if statusCode != http.StatusOK {
+			t.Fatalf("expected status OK for %s, got %d. Body: %s", path, statusCode, string(body))
+		}
+
+		var resp struct {
+			Data []entry `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return resp.Data
+	}
+
+	statusCode, body := client.Request(http.MethodPost, fmt.Sprintf("%s/%s/users", groupsBase, groupID), []string{userID, userID})
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status OK adding user, got %d. Body: %s", statusCode, string(body))
+	}
+	// Adding an existing member records nothing.
+	client.Request(http.MethodPost, fmt.Sprintf("%s/%s/users", groupsBase, groupID), []string{userID})
+
+	update := map[string]interface{}{"name": name + "-renamed", "description": "integration test group", "type": "local"}
+	statusCode, body = client.Request(http.MethodPut, fmt.Sprintf("%s/%s", groupsBase, groupID), update)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status OK renaming the group, got %d. Body: %s", statusCode, string(body))
+	}
+
+	t.Run("group history", func(t *testing.T) {
+		entries := listHistory(fmt.Sprintf("%s/%s/history", groupsBase, groupID))
+
+		actions := make([]string, 0, len(entries))
+		for _, e := range entries {
+			actions = append(actions, e.Action)
+		}
+		if fmt.Sprint(actions) != "[group.updated member.added group.created]" {
+			t.Fatalf("unexpected actions %v", actions)
+		}
+
+		renamed := entries[0]
+		if renamed.Before["name"] != name || renamed.After["name"] != name+"-renamed" {
+			t.Errorf("expected the rename to be recorded, got %v -> %v", renamed.Before, renamed.After)
+		}
+		if _, ok := renamed.After["description"]; ok {
+			t.Errorf("expected only the changed values, got %v", renamed.After)
+		}
+		if renamed.Actor != integrationActor || renamed.Source != "api" {
+			t.Errorf("expected the actor of the request, got %q from %q", renamed.Actor, renamed.Source)
+		}
+	})
+
+	t.Run("user history", func(t *testing.T) {
+		statusCode, body := client.Request(http.MethodDelete, fmt.Sprintf("%s/%s/users/%s", groupsBase, groupID, userID), nil)
+		if statusCode != http.StatusOK {
+			t.Fatalf("expected status OK removing user, got %d. Body: %s", statusCode, string(body))
+		}
+
+		entries := listHistory(fmt.Sprintf("%s/%s/history", usersBase, userID))
+		if len(entries) != 2 || entries[0].Action != "member.removed" || entries[1].Action != "member.added" {
+			t.Fatalf("unexpected user history %+v", entries)
+		}
+		if entries[0].GroupID != groupID || entries[0].Before["role"] != "member" {
+			t.Errorf("unexpected removal %+v", entries[0])
+		}
+	})
+
+	t.Run("invalid group ID", func(t *testing.T) {
+		statusCode, _ := client.Request(http.MethodGet, groupsBase+"/engineering/history", nil)
+		if statusCode != http.StatusBadRequest {
+			t.Errorf("expected status Bad Request, got %d", statusCode)
+		}
+	})
+}
+
+// TestGroupHistoryRollback covers the history written in the transaction of
+// the change, a rolled back change leaves no entry.
+func TestGroupHistoryRollback(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	connStr, pgContainer := setupTestPostgres(t)
+	if pgContainer == nil {
+		t.Skip("container runtime not available")
+	}
+	defer pgContainer.Terminate(context.Background())
+	runMigrations(t, connStr)
+
+	logger := logging.NewNoopLogger()
+	monitor := monitoring.NewNoopMonitor("hook-service-test", logger)
+	tracer := tracing.NewNoopTracer()
+
+	dbClient, err := db.NewDBClient(db.Config{DSN: connStr, MaxConns: 5, MinConns: 1}, tracer, monitor, logger)
+	if err != nil {
+		t.Fatalf("Failed to create DB client: %v", err)
+	}
+	defer dbClient.Close()
+
+	s := storage.NewStorage(dbClient, tracer, monitor, logger)
+	svc := history.NewService(s, tracer, monitor, logger)
+	ctx := storage.WithActor(context.Background(), storage.Actor{ID: integrationActor, Source: storage.SourceAPI})
+
+	group, err := s.CreateGroup(ctx, &types.Group{Name: "rollback", Type: types.GroupTypeLocal})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+
+	aborted := errors.New("aborted")
+	err = dbClient.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.AddUsersToGroup(ctx, group.ID, []string{"rollback-user@example.com"}); err != nil {
+			return err
+		}
+		return aborted
+	})
+	if !errors.Is(err, aborted) {
+		t.Fatalf("expected the transaction to abort, got %v", err)
+	}
+
+	entries, _, err := svc.ListHistory(ctx, &history.ListOptions{GroupID: group.ID})
+	if err != nil {
+		t.Fatalf("ListHistory failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Action != types.HistoryGroupCreated {
+		t.Errorf("expected only the creation of the group, got %+v", entries)
+	}
+}
+
+// TestSubgroupHistoryTenant covers the tenant of the subgroup entries, which
+// is the one of the parent group.
+func TestSubgroupHistoryTenant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	connStr, pgContainer := setupTestPostgres(t)
+	if pgContainer == nil {
+		t.Skip("container runtime not available")
+	}
+	defer pgContainer.Terminate(context.Background())
+	runMigrations(t, connStr)
+
+	logger := logging.NewNoopLogger()
+	monitor := monitoring.NewNoopMonitor("hook-service-test", logger)
+	tracer := tracing.NewNoopTracer()
+
+	dbClient, err := db.NewDBClient(db.Config{DSN: connStr, MaxConns: 5, MinConns: 1}, tracer, monitor, logger)
+	if err != nil {
+		t.Fatalf("Failed to create DB client: %v", err)
+	}
+	defer dbClient.Close()
+
+	s := storage.NewStorage(dbClient, tracer, monitor, logger)
+	ctx := storage.WithActor(context.Background(), storage.Actor{ID: integrationActor, Source: storage.SourceAPI})
+
+	parent, err := s.CreateGroup(ctx, &types.Group{Name: "parent", TenantId: "acme", Type: types.GroupTypeLocal})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	child, err := s.CreateGroup(ctx, &types.Group{Name: "child", TenantId: "acme", Type: types.GroupTypeLocal})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+
+	if err := s.AddSubgroups(ctx, parent.ID, []string{child.ID}); err != nil {
+		t.Fatalf("AddSubgroups failed: %v", err)
+	}
+	if err := s.RemoveSubgroups(ctx, parent.ID, []string{child.ID}); err != nil {
+		t.Fatalf("RemoveSubgroups failed: %v", err)
+	}
+
+	entries, err := s.ListHistory(ctx, &types.HistoryFilter{GroupID: parent.ID})
+	if err != nil {
+		t.Fatalf("ListHistory failed: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Action != types.HistorySubgroupRemoved || entries[1].Action != types.HistorySubgroupAdded {
+		t.Fatalf("unexpected history %+v", entries)
+	}
+	for _, e := range entries[:2] {
+		if e.TenantID != "acme" {
+			t.Errorf("expected %s in the tenant of the group, got %q", e.Action, e.TenantID)
+		}
+	}
+}
@@ -6,46 +6,97 @@ package groups
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/hook-service/internal/types"
 )
 
 type ServiceInterface interface {
-	ListGroups(context.Context) ([]*types.Group, error)
+	ListGroups(context.Context, *types.ListOptions) ([]*types.Group, *types.PageInfo, error)
 	CreateGroup(context.Context, *types.Group) (*types.Group, error)
 	GetGroup(context.Context, string) (*types.Group, error)
 	UpdateGroup(context.Context, string, *types.Group) (*types.Group, error)
 	DeleteGroup(context.Context, string) error
 
+	ListDeletedGroups(context.Context) ([]*types.Group, error)
+	RestoreGroup(context.Context, string) (*types.Group, error)
+
 	AddUsersToGroup(context.Context, string, []string) error
-	ListUsersInGroup(context.Context, string) ([]string, error)
+	ListUsersInGroup(context.Context, string, *types.ListOptions) ([]*types.GroupUser, *types.PageInfo, error)
 	RemoveUsersFromGroup(context.Context, string, []string) error
 
+	AddOwnersToGroup(context.Context, string, []string) error
+	RemoveOwnersFromGroup(context.Context, string, []string) error
+
+	GetGroupAttributes(context.Context, string) (map[string]string, error)
+	SetGroupAttributes(context.Context, string, map[string]string) (map[string]string, error)
+	RemoveGroupAttributes(context.Context, string, []string) (map[string]string, error)
+
 	GetGroupsForUser(context.Context, string) ([]*types.Group, error)
 	UpdateGroupsForUser(context.Context, string, []string) error
 
-	StreamGroupsForUser(context.Context, string, string, func(*types.Group) error) error
-	StreamUsersInGroup(context.Context, string, string, func(string) error) error
+	AddSubgroups(context.Context, string, []string) error
+	ListSubgroups(context.Context, string) ([]*types.Group, error)
+	RemoveSubgroups(context.Context, string, []string) error
+	ListMembershipsForUser(context.Context, string) ([]*types.Membership, error)
+	ListMembersOfGroup(context.Context, string) ([]*types.Membership, error)
+
+	StreamGroupsForUser(context.Context, string, string, string, int, func(*types.Group, string) error) error
+	StreamUsersInGroup(context.Context, string, string, string, int, func(string, string) error) error
+	BatchGetGroupsForUsers(context.Context, string, []string, func(string, []*types.Group, error) error) error
+	WatchMemberships(context.Context, *types.MembershipFilter, string, func(*types.MembershipEvent) error) error
 }
 
 type DatabaseInterface interface {
-	ListGroups(context.Context) ([]*types.Group, error)
+	ListGroups(context.Context, *types.ListFilter) ([]*types.Group, *types.Page, error)
 	CreateGroup(context.Context, *types.Group) (*types.Group, error)
 	GetGroup(context.Context, string) (*types.Group, error)
 	UpdateGroup(context.Context, string, *types.Group) (*types.Group, error)
 	DeleteGroup(context.Context, string) error
 
+	ListDeletedGroups(context.Context) ([]*types.Group, error)
+	RestoreGroup(context.Context, string) (*types.Group, error)
+	PurgeGroups(context.Context, time.Time) (int64, error)
+	GetAllowedApps(context.Context, string, *types.ListFilter) ([]string, *types.Page, error)
+
 	AddUsersToGroup(context.Context, string, []string) error
-	ListUsersInGroup(context.Context, string) ([]string, error)
+	ListUsersInGroup(context.Context, string, *types.ListFilter) ([]*types.GroupUser, *types.Page, error)
 	RemoveUsersFromGroup(context.Context, string, []string) error
 
+	AddOwnersToGroup(context.Context, string, []string) error
+	RemoveOwnersFromGroup(context.Context, string, []string) error
+	IsGroupOwner(context.Context, string, []string) (bool, error)
+
+	SetGroupAttributes(context.Context, string, map[string]string) (map[string]string, error)
+	RemoveGroupAttributes(context.Context, string, []string) (map[string]string, error)
+
 	GetGroupsForUser(context.Context, string) ([]*types.Group, error)
 	UpdateGroupsForUser(context.Context, string, []string) error
 
-	StreamGroupsForUser(context.Context, string, string, func(*types.Group) error) error
-	StreamUsersInGroup(context.Context, string, string, func(string) error) error
+	AddSubgroups(context.Context, string, []string) error
+	ListSubgroups(context.Context, string) ([]*types.Group, error)
+	RemoveSubgroups(context.Context, string, []string) error
+	ListMembershipsForUser(context.Context, string) ([]*types.Membership, error)
+	ListMembersOfGroup(context.Context, string) ([]*types.Membership, error)
+
+	StreamGroupsForUser(context.Context, string, string, *types.Cursor, uint64, func(*types.Group) error) error
+	StreamUsersInGroup(context.Context, string, string, *types.Cursor, uint64, func(string) error) error
+	GetGroupsForUsers(context.Context, string, []string) (map[string][]*types.Group, error)
+	StreamMemberships(context.Context, *types.MembershipFilter, func(*types.MembershipEvent) error) error
+
+	ListOutboxEvents(context.Context, int64, time.Duration, uint64) ([]*types.OutboxEvent, error)
+	GetOutboxEvents(context.Context, []int64) ([]*types.OutboxEvent, error)
+	GetSettledOutboxEventID(context.Context, time.Duration) (int64, error)
 }
 
 type AuthorizerInterface interface {
+	AddAllowedAppToGroup(context.Context, string, string) error
 	DeleteGroup(context.Context, string) error
 }
+
+// CacheInvalidatorInterface is notified of membership changes so that cached
+// groups are not served after a write.
+type CacheInvalidatorInterface interface {
+	InvalidateUsers(context.Context, ...string)
+	InvalidateGroups(context.Context)
+}
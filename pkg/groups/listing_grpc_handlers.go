@@ -0,0 +1,147 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	httptypes "github.com/canonical/hook-service/internal/http/types"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+var _ pb.GroupListingServiceServer = (*ListingGrpcServer)(nil)
+
+type ListingGrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedGroupListingServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (l *ListingGrpcServer) SearchGroups(ctx context.Context, req *pb.SearchReq) (*pb.SearchGroupsResp, error) {
+	ctx, span := l.tracer.Start(ctx, "groups.ListingGrpcServer.SearchGroups")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("search", req.GetSearch()),
+		attribute.String("sort_by", req.GetSortBy()),
+	)
+
+	opts, err := httptypes.ListOptions(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "invalid search")
+		return nil, l.mapErrorToStatus(err, "search groups")
+	}
+
+	groups, page, err := l.svc.ListGroups(ctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "search groups failed")
+		return nil, l.mapErrorToStatus(err, "search groups")
+	}
+
+	data := make([]*pb.GroupMapping, 0, len(groups))
+	for _, g := range groups {
+		data = append(data, toGroupMapping(g))
+	}
+
+	span.SetAttributes(attribute.Int("groups.count", len(data)))
+	span.SetStatus(otelcodes.Ok, "groups searched successfully")
+
+	return &pb.SearchGroupsResp{
+		Data:          data,
+		Status:        http.StatusOK,
+		Message:       proto.String("Group list"),
+		NextPageToken: page.NextPageToken,
+		Total:         page.Total,
+	}, nil
+}
+
+func (l *ListingGrpcServer) SearchUsersInGroup(ctx context.Context, req *pb.SearchReq) (*pb.SearchUsersInGroupResp, error) {
+	ctx, span := l.tracer.Start(ctx, "groups.ListingGrpcServer.SearchUsersInGroup")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.String("search", req.GetSearch()),
+		attribute.String("sort_by", req.GetSortBy()),
+	)
+
+	opts, err := httptypes.ListOptions(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "invalid search")
+		return nil, l.mapErrorToStatus(err, "search users in group")
+	}
+
+	users, page, err := l.svc.ListUsersInGroup(ctx, req.GetId(), opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "search users in group failed")
+		return nil, l.mapErrorToStatus(err, "search users in group")
+	}
+
+	data := make([]*pb.GroupMember, 0, len(users))
+	for _, u := range users {
+		data = append(data, &pb.GroupMember{
+			Id:        u.ID,
+			Role:      u.Role.String(),
+			CreatedAt: timestamppb.New(u.CreatedAt),
+			UpdatedAt: timestamppb.New(u.UpdatedAt),
+		})
+	}
+
+	span.SetAttributes(attribute.Int("users.count", len(data)))
+	span.SetStatus(otelcodes.Ok, "users searched successfully")
+
+	return &pb.SearchUsersInGroupResp{
+		Data:          data,
+		Status:        http.StatusOK,
+		Message:       proto.String("Users in group"),
+		NextPageToken: page.NextPageToken,
+		Total:         page.Total,
+	}, nil
+}
+
+func (l *ListingGrpcServer) mapErrorToStatus(err error, action string) error {
+	if s := httptypes.ListErrorStatus(err); s != nil {
+		return s
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrGroupNotFound):
+		return status.Errorf(codes.NotFound, "group not found")
+	case errors.Is(err, ErrNotGroupOwner):
+		return status.Errorf(codes.PermissionDenied, "not an owner of the group")
+	default:
+		l.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func NewListingGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *ListingGrpcServer {
+	return &ListingGrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
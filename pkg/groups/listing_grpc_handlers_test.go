@@ -0,0 +1,265 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestListingGrpcHandler_SearchGroups_Unit(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	total := int64(3)
+
+	tests := []struct {
+		name     string
+		req      *pb.SearchReq
+		opts     *types.ListOptions
+		svcErr   error
+		wantCode codes.Code
+	}{
+		{
+			name: "filters and sort",
+			req: &pb.SearchReq{
+				Search:       "eng",
+				Type:         "external",
+				TenantId:     "acme",
+				CreatedAfter: timestamppb.New(since),
+				SortBy:       types.SortByCreatedAt,
+				Descending:   true,
+				PageSize:     2,
+				PageToken:    "token",
+			},
+			opts: &types.ListOptions{
+				Search:       "eng",
+				Type:         func() *types.GroupType { t := types.GroupTypeExternal; return &t }(),
+				TenantID:     "acme",
+				CreatedAfter: since,
+				SortBy:       types.SortByCreatedAt,
+				Descending:   true,
+				PageSize:     2,
+				PageToken:    "token",
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "invalid group type",
+			req:      &pb.SearchReq{Type: "remote"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid sort",
+			req:      &pb.SearchReq{SortBy: "description"},
+			opts:     &types.ListOptions{SortBy: "description"},
+			svcErr:   types.ErrInvalidSort,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid page token",
+			req:      &pb.SearchReq{PageToken: "token"},
+			opts:     &types.ListOptions{PageToken: "token"},
+			svcErr:   types.ErrInvalidPageToken,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown error",
+			req:      &pb.SearchReq{},
+			opts:     &types.ListOptions{},
+			svcErr:   errors.New("boom"),
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			server := NewListingGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
+
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), "groups.ListingGrpcServer.SearchGroups").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			if tt.opts != nil {
+				mockSvc.EXPECT().ListGroups(gomock.Any(), tt.opts).Return(
+					[]*types.Group{{ID: "g1", Name: "engineering"}},
+					&types.PageInfo{NextPageToken: "next", Total: &total},
+					tt.svcErr,
+				)
+			}
+
+			resp, err := server.SearchGroups(context.Background(), tt.req)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(resp.GetData()) != 1 || resp.GetNextPageToken() != "next" || resp.GetTotal() != total {
+				t.Errorf("unexpected response %v", resp)
+			}
+		})
+	}
+}
+
+func TestListingGrpcHandler_SearchUsersInGroup_Unit(t *testing.T) {
+	tests := []struct {
+		name     string
+		svcErr   error
+		wantCode codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"not an owner", ErrNotGroupOwner, codes.PermissionDenied},
+		{"invalid page size", types.ErrInvalidPageSize, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			server := NewListingGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "groups.ListingGrpcServer.SearchUsersInGroup").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockSvc.EXPECT().ListUsersInGroup(gomock.Any(), "group-id", &types.ListOptions{Search: "alice", PageSize: 10}).Return(
+				[]*types.GroupUser{{ID: "alice@example.com", Role: types.RoleOwner}},
+				&types.PageInfo{},
+				tt.svcErr,
+			)
+
+			resp, err := server.SearchUsersInGroup(context.Background(), &pb.SearchReq{Id: "group-id", Search: "alice", PageSize: 10})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(resp.GetData()) != 1 || resp.GetData()[0].GetRole() != "owner" || resp.GetNextPageToken() != "" || resp.Total != nil {
+				t.Errorf("unexpected response %v", resp)
+			}
+		})
+	}
+}
+
+// TestSearchGroups covers GET /groups:search, paging through the groups
+// matching a search in both orders.
+func TestSearchGroups(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, teardown := newIntegrationServer(t)
+	if client == nil {
+		return
+	}
+	defer teardown()
+
+	prefix := fmt.Sprintf("search-%d", time.Now().UnixNano())
+	for _, name := range []string{"a", "b", "c"} {
+		createTestGroup(t, client, prefix+"-"+name)
+	}
+	createTestGroup(t, client, fmt.Sprintf("other-%d", time.Now().UnixNano()))
+
+	type searchResp struct {
+		Data []struct {
+			Name string `json:"name"`
+		} `json:"data"`
+		NextPageToken string `json:"next_page_token"`
+		Total         *int64 `json:"total,string"`
+	}
+
+	search := func(query url.Values) searchResp {
+		t.Helper()
+
+		statusCode, body := client.Request(http.MethodGet, "/api/v0/authz/groups:search?"+query.Encode(), nil)
+		if statusCode != http.StatusOK {
+			t.Fatalf("expected status OK searching groups, got %d. Body: %s", statusCode, string(body))
+		}
+
+		var resp searchResp
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return resp
+	}
+
+	for _, descending := range []bool{false, true} {
+		t.Run(fmt.Sprintf("descending=%v", descending), func(t *testing.T) {
+			query := url.Values{
+				"search":     {prefix},
+				"page_size":  {"2"},
+				"descending": {fmt.Sprint(descending)},
+			}
+
+			names := make([]string, 0)
+			first := search(query)
+			if first.Total == nil || *first.Total != 3 {
+				t.Errorf("expected a total of 3 on the first page, got %v", first.Total)
+			}
+			for _, g := range first.Data {
+				names = append(names, g.Name)
+			}
+			if first.NextPageToken == "" {
+				t.Fatal("expected a next page")
+			}
+
+			query.Set("page_token", first.NextPageToken)
+			last := search(query)
+			if last.Total != nil || last.NextPageToken != "" {
+				t.Errorf("expected the last page without total, got %+v", last)
+			}
+			for _, g := range last.Data {
+				names = append(names, g.Name)
+			}
+
+			want := []string{prefix + "-a", prefix + "-b", prefix + "-c"}
+			if descending {
+				want = []string{prefix + "-c", prefix + "-b", prefix + "-a"}
+			}
+			if fmt.Sprint(names) != fmt.Sprint(want) {
+				t.Errorf("expected groups %v, got %v", want, names)
+			}
+		})
+	}
+
+	t.Run("page token of another sort", func(t *testing.T) {
+		first := search(url.Values{"search": {prefix}, "page_size": {"1"}})
+
+		statusCode, _ := client.Request(http.MethodGet, "/api/v0/authz/groups:search?"+url.Values{
+			"search":     {prefix},
+			"page_size":  {"1"},
+			"sort_by":    {"created_at"},
+			"page_token": {first.NextPageToken},
+		}.Encode(), nil)
+		if statusCode != http.StatusBadRequest {
+			t.Errorf("expected status Bad Request, got %d", statusCode)
+		}
+	})
+}
@@ -49,16 +49,10 @@ func (m *MappingGrpcServer) GetGroupsForUser(req *pb.GetGroupsForUserReq, stream
 		return mapMappingErrorToStatus(err, "stream groups for user")
 	}
 
-	err := m.svc.StreamGroupsForUser(ctx, req.GetTenantId(), req.GetUserId(), func(g *types.Group) error {
-		if err := stream.Send(&pb.GroupMapping{
-			Id:          g.ID,
-			Name:        g.Name,
-			TenantId:    g.TenantId,
-			Description: g.Description,
-			Type:        g.Type.String(),
-			CreatedAt:   timestamppb.New(g.CreatedAt),
-			UpdatedAt:   timestamppb.New(g.UpdatedAt),
-		}); err != nil {
+	err := m.svc.StreamGroupsForUser(ctx, req.GetTenantId(), req.GetUserId(), req.GetAfter(), int(req.GetPageSize()), func(g *types.Group, token string) error {
+		mapping := toGroupMapping(g)
+		mapping.ResumeToken = token
+		if err := stream.Send(mapping); err != nil {
 			return fmt.Errorf("%w: %v", ErrStreamInterrupted, err)
 		}
 		return nil
@@ -89,8 +83,8 @@ func (m *MappingGrpcServer) GetUsersInGroup(req *pb.GetUsersInGroupReq, stream g
 		return mapMappingErrorToStatus(err, "stream users in group")
 	}
 
-	err := m.svc.StreamUsersInGroup(ctx, req.GetTenantId(), req.GetGroupId(), func(userID string) error {
-		if err := stream.Send(&pb.UserMapping{Id: userID}); err != nil {
+	err := m.svc.StreamUsersInGroup(ctx, req.GetTenantId(), req.GetGroupId(), req.GetAfter(), int(req.GetPageSize()), func(userID, token string) error {
+		if err := stream.Send(&pb.UserMapping{Id: userID, ResumeToken: token}); err != nil {
 			return fmt.Errorf("%w: %v", ErrStreamInterrupted, err)
 		}
 		return nil
@@ -105,6 +99,90 @@ func (m *MappingGrpcServer) GetUsersInGroup(req *pb.GetUsersInGroupReq, stream g
 	return nil
 }
 
+// BatchGetGroupsForUsers streams the groups of each requested user, an
+// invalid user ID gets an error entry.
+func (m *MappingGrpcServer) BatchGetGroupsForUsers(req *pb.BatchGetGroupsForUsersReq, stream grpc.ServerStreamingServer[pb.UserGroups]) error {
+	ctx, span := m.tracer.Start(stream.Context(), "groups.MappingGrpcServer.BatchGetGroupsForUsers")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("users.count", len(req.GetUserIds())),
+		attribute.String("tenant.id", req.GetTenantId()),
+	)
+
+	err := m.svc.BatchGetGroupsForUsers(ctx, req.GetTenantId(), req.GetUserIds(), func(userID string, groups []*types.Group, err error) error {
+		msg := &pb.UserGroups{UserId: userID}
+		if err != nil {
+			msg.Error = err.Error()
+		}
+		for _, g := range groups {
+			msg.Groups = append(msg.Groups, toGroupMapping(g))
+		}
+		if err := stream.Send(msg); err != nil {
+			return fmt.Errorf("%w: %v", ErrStreamInterrupted, err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "batch get groups for users failed")
+		return mapMappingErrorToStatus(err, "batch get groups for users")
+	}
+
+	span.SetStatus(otelcodes.Ok, "groups streamed successfully")
+	return nil
+}
+
+// WatchMemberships streams the direct memberships matching the request and
+// their changes until the client goes away.
+func (m *MappingGrpcServer) WatchMemberships(req *pb.WatchMembershipsReq, stream grpc.ServerStreamingServer[pb.MembershipEvent]) error {
+	ctx, span := m.tracer.Start(stream.Context(), "groups.MappingGrpcServer.WatchMemberships")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant.id", req.GetTenantId()),
+		attribute.String("group.id", req.GetGroupId()),
+		attribute.String("user.id", req.GetUserId()),
+	)
+
+	filter := &types.MembershipFilter{
+		TenantID: req.GetTenantId(),
+		GroupID:  req.GetGroupId(),
+		UserID:   req.GetUserId(),
+	}
+
+	err := m.svc.WatchMemberships(ctx, filter, req.GetCursor(), func(e *types.MembershipEvent) error {
+		if err := stream.Send(toMembershipEvent(e)); err != nil {
+			return fmt.Errorf("%w: %v", ErrStreamInterrupted, err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "watch memberships failed")
+		return mapMappingErrorToStatus(err, "watch memberships")
+	}
+
+	span.SetStatus(otelcodes.Ok, "memberships watched successfully")
+	return nil
+}
+
+func toMembershipEvent(e *types.MembershipEvent) *pb.MembershipEvent {
+	event := &pb.MembershipEvent{
+		Type:     string(e.Type),
+		TenantId: e.TenantID,
+		GroupId:  e.GroupID,
+		UserId:   e.UserID,
+		Cursor:   e.Cursor,
+		Time:     timestamppb.New(e.Time),
+	}
+	// Heartbeats and the end of the snapshot are not memberships.
+	if e.GroupID != "" {
+		event.Role = e.Role.String()
+	}
+	return event
+}
+
 func mapMappingErrorToStatus(err error, action string) error {
 	switch {
 	case err == nil:
@@ -115,6 +193,16 @@ func mapMappingErrorToStatus(err error, action string) error {
 		return status.Errorf(codes.InvalidArgument, "invalid group id")
 	case errors.Is(err, ErrInvalidUserID):
 		return status.Errorf(codes.InvalidArgument, "invalid user id")
+	case errors.Is(err, types.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "invalid resume token")
+	case errors.Is(err, types.ErrInvalidPageSize):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, ErrTooManyUsers):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, ErrInvalidCursor):
+		return status.Errorf(codes.InvalidArgument, "invalid cursor")
+	case errors.Is(err, ErrExpiredCursor):
+		return status.Errorf(codes.FailedPrecondition, "cursor expired, watch again without a cursor")
 	case errors.Is(err, ErrStreamInterrupted):
 		return status.Errorf(codes.Internal, "stream interrupted: %v", err)
 	case errors.Is(err, ErrUnauthorizedStream):
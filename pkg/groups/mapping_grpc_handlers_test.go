@@ -82,12 +82,12 @@ func TestMappingGrpcHandler_GetGroupsForUser_Unit(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
 
 			if tt.userID != "" {
-				mockSvc.EXPECT().StreamGroupsForUser(gomock.Any(), tt.tenantID, tt.userID, gomock.Any()).DoAndReturn(
-					func(ctx context.Context, tenantID, userID string, fn func(*types.Group) error) error {
+				mockSvc.EXPECT().StreamGroupsForUser(gomock.Any(), tt.tenantID, tt.userID, "", 0, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tenantID, userID, after string, pageSize int, fn func(*types.Group, string) error) error {
 						if tt.streamErr != nil {
 							return tt.streamErr
 						}
-						return fn(&types.Group{ID: "g1", Name: "group1", TenantId: tenantID, CreatedAt: now, UpdatedAt: now})
+						return fn(&types.Group{ID: "g1", Name: "group1", TenantId: tenantID, CreatedAt: now, UpdatedAt: now}, "token")
 					},
 				)
 			}
@@ -158,12 +158,12 @@ func TestMappingGrpcHandler_GetUsersInGroup_Unit(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
 
 			if tt.groupID != "" {
-				mockSvc.EXPECT().StreamUsersInGroup(gomock.Any(), tt.tenantID, tt.groupID, gomock.Any()).DoAndReturn(
-					func(ctx context.Context, tenantID, groupID string, fn func(string) error) error {
+				mockSvc.EXPECT().StreamUsersInGroup(gomock.Any(), tt.tenantID, tt.groupID, "", 0, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tenantID, groupID, after string, pageSize int, fn func(string, string) error) error {
 						if tt.streamErr != nil {
 							return tt.streamErr
 						}
-						return fn("user-1")
+						return fn("user-1", "token")
 					},
 				)
 			}
@@ -186,6 +186,107 @@ func TestMappingGrpcHandler_GetUsersInGroup_Unit(t *testing.T) {
 	}
 }
 
+func TestMappingGrpcHandler_BatchGetGroupsForUsers_Unit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	server := NewMappingGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	tenantID := "tenant-a"
+	userIDs := []string{"user-1", ""}
+	mockSvc.EXPECT().BatchGetGroupsForUsers(gomock.Any(), tenantID, userIDs, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ []string, fn func(string, []*types.Group, error) error) error {
+			if err := fn("user-1", []*types.Group{{ID: "g1", Name: "group1", TenantId: tenantID}}, nil); err != nil {
+				return err
+			}
+			return fn("", nil, ErrInvalidUserID)
+		},
+	)
+
+	stream := &mockUserGroupsServerStream{ctx: context.Background()}
+	err := server.BatchGetGroupsForUsers(&pb.BatchGetGroupsForUsersReq{UserIds: userIDs, TenantId: &tenantID}, stream)
+	if err != nil {
+		t.Fatalf("BatchGetGroupsForUsers() error = %v", err)
+	}
+
+	if len(stream.sent) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(stream.sent))
+	}
+	if e := stream.sent[0]; e.GetUserId() != "user-1" || len(e.GetGroups()) != 1 || e.GetGroups()[0].GetId() != "g1" || e.GetError() != "" {
+		t.Errorf("unexpected entry %v", e)
+	}
+	if e := stream.sent[1]; e.GetUserId() != "" || len(e.GetGroups()) != 0 || e.GetError() != ErrInvalidUserID.Error() {
+		t.Errorf("unexpected error entry %v", e)
+	}
+}
+
+type mockUserGroupsServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.UserGroups
+}
+
+func (s *mockUserGroupsServerStream) Context() context.Context { return s.ctx }
+func (s *mockUserGroupsServerStream) Send(m *pb.UserGroups) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestMappingGrpcHandler_WatchMemberships_Unit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	server := NewMappingGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	tenantID, userID, cursor := "tenant-a", "user-1", "MTIuMTcwMDAwMDAwMA"
+	mockSvc.EXPECT().WatchMemberships(gomock.Any(), &types.MembershipFilter{TenantID: tenantID, UserID: userID}, cursor, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.MembershipFilter, _ string, fn func(*types.MembershipEvent) error) error {
+			if err := fn(&types.MembershipEvent{Type: types.MembershipUpdated, TenantID: tenantID, GroupID: "g1", UserID: userID, Role: types.RoleOwner, Cursor: "c1"}); err != nil {
+				return err
+			}
+			return fn(&types.MembershipEvent{Type: types.MembershipHeartbeat, Cursor: "c2"})
+		},
+	)
+
+	stream := &mockMembershipEventServerStream{ctx: context.Background()}
+	err := server.WatchMemberships(&pb.WatchMembershipsReq{TenantId: &tenantID, UserId: &userID, Cursor: &cursor}, stream)
+	if err != nil {
+		t.Fatalf("WatchMemberships() error = %v", err)
+	}
+
+	if len(stream.sent) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(stream.sent))
+	}
+	if e := stream.sent[0]; e.GetType() != "updated" || e.GetGroupId() != "g1" || e.GetRole() != "owner" || e.GetCursor() != "c1" {
+		t.Errorf("unexpected update event %v", e)
+	}
+	if e := stream.sent[1]; e.GetType() != "heartbeat" || e.GetRole() != "" || e.GetCursor() != "c2" {
+		t.Errorf("unexpected heartbeat %v", e)
+	}
+}
+
+type mockMembershipEventServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.MembershipEvent
+}
+
+func (s *mockMembershipEventServerStream) Context() context.Context { return s.ctx }
+func (s *mockMembershipEventServerStream) Send(m *pb.MembershipEvent) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
 type mockGroupMappingServerStream struct {
 	grpc.ServerStream
 	ctx context.Context
@@ -227,7 +328,7 @@ func setupMappingIntegrationServer(t *testing.T) (pb.GroupsMappingServiceClient,
 		tracer, monitor, logger,
 	)
 
-	groupSvc := NewService(s, authz, tracer, monitor, logger)
+	groupSvc := NewService(s, authz, nil, tracer, monitor, logger)
 	mappingSrv := NewMappingGrpcServer(groupSvc, tracer, monitor, logger)
 
 	grpcSrv := grpc.NewServer()
@@ -369,6 +470,11 @@ func TestMapMappingErrorToStatus(t *testing.T) {
 		{"ErrStreamInterrupted", ErrStreamInterrupted, "test", codes.Internal},
 		{"ErrUnauthorizedStream", ErrUnauthorizedStream, "test", codes.Unauthenticated},
 		{"storage ErrNotFound", storage.ErrNotFound, "test", codes.NotFound},
+		{"ErrInvalidPageToken", types.ErrInvalidPageToken, "test", codes.InvalidArgument},
+		{"ErrInvalidPageSize", types.ErrInvalidPageSize, "test", codes.InvalidArgument},
+		{"ErrTooManyUsers", ErrTooManyUsers, "test", codes.InvalidArgument},
+		{"ErrInvalidCursor", ErrInvalidCursor, "test", codes.InvalidArgument},
+		{"ErrExpiredCursor", ErrExpiredCursor, "test", codes.FailedPrecondition},
 		{"unknown error", errors.New("boom"), "test-action", codes.Internal},
 	}
 
@@ -507,6 +613,78 @@ func TestMappingGrpcHandler_TenantFiltering_Integration(t *testing.T) {
 		}
 	})
 
+	t.Run("GetGroupsForUser resumes after a resume token", func(t *testing.T) {
+		pageSize := int32(1)
+		stream, err := client.GetGroupsForUser(ctx, &pb.GetGroupsForUserReq{UserId: userID, PageSize: &pageSize})
+		if err != nil {
+			t.Fatalf("GetGroupsForUser failed: %v", err)
+		}
+		first, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		if first.ResumeToken == "" {
+			t.Fatal("expected a resume token")
+		}
+
+		resumed, err := client.GetGroupsForUser(ctx, &pb.GetGroupsForUserReq{UserId: userID, After: &first.ResumeToken, PageSize: &pageSize})
+		if err != nil {
+			t.Fatalf("GetGroupsForUser failed: %v", err)
+		}
+
+		var groups []*pb.GroupMapping
+		for {
+			g, err := resumed.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Recv failed: %v", err)
+			}
+			groups = append(groups, g)
+		}
+
+		if len(groups) != 1 || groups[0].Id == first.Id {
+			t.Fatalf("expected the other group after %s, got %v", first.Id, groups)
+		}
+	})
+
+	t.Run("BatchGetGroupsForUsers with tenant_id filters correctly", func(t *testing.T) {
+		tenant1 := "tenant-1"
+		stream, err := client.BatchGetGroupsForUsers(ctx, &pb.BatchGetGroupsForUsersReq{
+			UserIds:  []string{userID, "nobody@example.com", ""},
+			TenantId: &tenant1,
+		})
+		if err != nil {
+			t.Fatalf("BatchGetGroupsForUsers failed: %v", err)
+		}
+
+		var entries []*pb.UserGroups
+		for {
+			e, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Recv failed: %v", err)
+			}
+			entries = append(entries, e)
+		}
+
+		if len(entries) != 3 {
+			t.Fatalf("expected 3 entries, got %d", len(entries))
+		}
+		if len(entries[0].Groups) != 1 || entries[0].Groups[0].Id != g1ID {
+			t.Errorf("expected %s to be in group %s, got %v", userID, g1ID, entries[0].Groups)
+		}
+		if len(entries[1].Groups) != 0 || entries[1].Error != "" {
+			t.Errorf("expected no groups for an unknown user, got %v", entries[1])
+		}
+		if entries[2].Error == "" {
+			t.Errorf("expected an error entry for an empty user ID, got %v", entries[2])
+		}
+	})
+
 	// Setup another user in group-tenant-1 under different tenant ID in group_members to test StreamUsersInGroup
 	userTenant1 := "user-tenant-1"
 	userTenant2 := "user-tenant-2"
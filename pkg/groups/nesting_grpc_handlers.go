@@ -0,0 +1,212 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var _ pb.GroupNestingServiceServer = (*NestingGrpcServer)(nil)
+
+type NestingGrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedGroupNestingServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (n *NestingGrpcServer) AddSubgroups(ctx context.Context, req *pb.AddSubgroupsReq) (*pb.AddSubgroupsResp, error) {
+	ctx, span := n.tracer.Start(ctx, "groups.NestingGrpcServer.AddSubgroups")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.Int("subgroups.count", len(req.GetSubgroupIds())),
+	)
+
+	if err := n.svc.AddSubgroups(ctx, req.GetId(), req.GetSubgroupIds()); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "add subgroups failed")
+		return nil, n.mapErrorToStatus(err, "add subgroups")
+	}
+
+	span.SetStatus(otelcodes.Ok, "subgroups added successfully")
+
+	return &pb.AddSubgroupsResp{
+		Status:  http.StatusOK,
+		Message: proto.String("Subgroups added to group"),
+	}, nil
+}
+
+func (n *NestingGrpcServer) ListSubgroups(ctx context.Context, req *pb.ListSubgroupsReq) (*pb.ListSubgroupsResp, error) {
+	ctx, span := n.tracer.Start(ctx, "groups.NestingGrpcServer.ListSubgroups")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("group.id", req.GetId()))
+
+	groups, err := n.svc.ListSubgroups(ctx, req.GetId())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "list subgroups failed")
+		return nil, n.mapErrorToStatus(err, "list subgroups")
+	}
+
+	data := make([]*pb.GroupMapping, 0, len(groups))
+	for _, g := range groups {
+		data = append(data, toGroupMapping(g))
+	}
+
+	span.SetAttributes(attribute.Int("subgroups.count", len(data)))
+	span.SetStatus(otelcodes.Ok, "subgroups listed successfully")
+
+	return &pb.ListSubgroupsResp{
+		Data:    data,
+		Status:  http.StatusOK,
+		Message: proto.String("Subgroup list"),
+	}, nil
+}
+
+func (n *NestingGrpcServer) RemoveSubgroup(ctx context.Context, req *pb.RemoveSubgroupReq) (*pb.RemoveSubgroupResp, error) {
+	ctx, span := n.tracer.Start(ctx, "groups.NestingGrpcServer.RemoveSubgroup")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.String("subgroup.id", req.GetSubgroupId()),
+	)
+
+	if err := n.svc.RemoveSubgroups(ctx, req.GetId(), []string{req.GetSubgroupId()}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "remove subgroup failed")
+		return nil, n.mapErrorToStatus(err, "remove subgroup")
+	}
+
+	span.SetStatus(otelcodes.Ok, "subgroup removed successfully")
+
+	return &pb.RemoveSubgroupResp{
+		Status:  http.StatusOK,
+		Message: proto.String("Subgroup removed from group"),
+	}, nil
+}
+
+func (n *NestingGrpcServer) ListGroupMembers(ctx context.Context, req *pb.ListGroupMembersReq) (*pb.ListGroupMembersResp, error) {
+	ctx, span := n.tracer.Start(ctx, "groups.NestingGrpcServer.ListGroupMembers")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("group.id", req.GetId()))
+
+	memberships, err := n.svc.ListMembersOfGroup(ctx, req.GetId())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "list group members failed")
+		return nil, n.mapErrorToStatus(err, "list group members")
+	}
+
+	span.SetAttributes(attribute.Int("members.count", len(memberships)))
+	span.SetStatus(otelcodes.Ok, "group members listed successfully")
+
+	return &pb.ListGroupMembersResp{
+		Data:    toMemberships(memberships),
+		Status:  http.StatusOK,
+		Message: proto.String("Group member list"),
+	}, nil
+}
+
+func (n *NestingGrpcServer) ListUserMemberships(ctx context.Context, req *pb.ListUserMembershipsReq) (*pb.ListUserMembershipsResp, error) {
+	ctx, span := n.tracer.Start(ctx, "groups.NestingGrpcServer.ListUserMemberships")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetId()))
+
+	memberships, err := n.svc.ListMembershipsForUser(ctx, req.GetId())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "list user memberships failed")
+		return nil, n.mapErrorToStatus(err, "list user memberships")
+	}
+
+	span.SetAttributes(attribute.Int("memberships.count", len(memberships)))
+	span.SetStatus(otelcodes.Ok, "user memberships listed successfully")
+
+	return &pb.ListUserMembershipsResp{
+		Data:    toMemberships(memberships),
+		Status:  http.StatusOK,
+		Message: proto.String("User membership list"),
+	}, nil
+}
+
+func (n *NestingGrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrGroupNotFound):
+		return status.Errorf(codes.NotFound, "group not found")
+	case errors.Is(err, ErrInvalidGroupID):
+		return status.Errorf(codes.InvalidArgument, "invalid group id")
+	case errors.Is(err, ErrGroupCycle):
+		return status.Errorf(codes.FailedPrecondition, "group nesting would create a cycle")
+	default:
+		n.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func toGroupMapping(g *types.Group) *pb.GroupMapping {
+	mapping := &pb.GroupMapping{
+		Id:          g.ID,
+		Name:        g.Name,
+		TenantId:    g.TenantId,
+		Description: g.Description,
+		Type:        g.Type.String(),
+		CreatedAt:   timestamppb.New(g.CreatedAt),
+		UpdatedAt:   timestamppb.New(g.UpdatedAt),
+		Attributes:  g.Attributes,
+	}
+	if g.DeletedAt != nil {
+		mapping.DeletedAt = timestamppb.New(*g.DeletedAt)
+	}
+	return mapping
+}
+
+func toMemberships(memberships []*types.Membership) []*pb.Membership {
+	data := make([]*pb.Membership, 0, len(memberships))
+	for _, m := range memberships {
+		membership := &pb.Membership{
+			UserId: m.UserID,
+			Direct: m.Direct(),
+			Path:   m.Path,
+		}
+		if m.Group != nil {
+			membership.Group = toGroupMapping(m.Group)
+		}
+		data = append(data, membership)
+	}
+	return data
+}
+
+func NewNestingGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *NestingGrpcServer {
+	return &NestingGrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
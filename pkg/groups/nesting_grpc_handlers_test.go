@@ -0,0 +1,223 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/authorization"
+	"github.com/canonical/hook-service/internal/db"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/openfga"
+	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNestingGrpcHandler_AddSubgroups_Unit(t *testing.T) {
+	tests := []struct {
+		name     string
+		svcErr   error
+		wantCode codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"group not found", ErrGroupNotFound, codes.NotFound},
+		{"invalid subgroup", ErrInvalidGroupID, codes.InvalidArgument},
+		{"cycle", ErrGroupCycle, codes.FailedPrecondition},
+		{"unknown error", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			server := NewNestingGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
+
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockSvc.EXPECT().AddSubgroups(gomock.Any(), "parent", []string{"child"}).Return(tt.svcErr)
+
+			resp, err := server.AddSubgroups(context.Background(), &pb.AddSubgroupsReq{Id: "parent", SubgroupIds: []string{"child"}})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && resp.GetStatus() != 200 {
+				t.Errorf("expected status 200, got %d", resp.GetStatus())
+			}
+		})
+	}
+}
+
+func TestNestingGrpcHandler_ListUserMemberships_Unit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	server := NewNestingGrpcServer(mockSvc, mockTracer, mockMonitor, mockLogger)
+
+	now := time.Now()
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+	mockSvc.EXPECT().ListMembershipsForUser(gomock.Any(), "user@example.com").Return([]*types.Membership{
+		{
+			UserID: "user@example.com",
+			Group:  &types.Group{ID: "g1", Name: "engineering", CreatedAt: now, UpdatedAt: now},
+			Path:   []string{"identity", "platform", "engineering"},
+		},
+		{
+			UserID: "user@example.com",
+			Group:  &types.Group{ID: "g2", Name: "identity", CreatedAt: now, UpdatedAt: now},
+			Path:   []string{"identity"},
+		},
+	}, nil)
+
+	resp, err := server.ListUserMemberships(context.Background(), &pb.ListUserMembershipsReq{Id: "user@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.GetData()) != 2 {
+		t.Fatalf("expected 2 memberships, got %d", len(resp.GetData()))
+	}
+	if inherited := resp.GetData()[0]; inherited.GetDirect() || inherited.GetGroup().GetName() != "engineering" {
+		t.Errorf("expected inherited membership of engineering, got %v", inherited)
+	}
+	if direct := resp.GetData()[1]; !direct.GetDirect() || direct.GetGroup().GetName() != "identity" {
+		t.Errorf("expected direct membership of identity, got %v", direct)
+	}
+}
+
+func TestNestingGrpcHandler_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	connStr, pgContainer := setupTestPostgres(t)
+	if pgContainer == nil {
+		t.Skip("container runtime not available")
+	}
+	defer pgContainer.Terminate(context.Background())
+	runMigrations(t, connStr)
+
+	logger := logging.NewNoopLogger()
+	monitor := monitoring.NewNoopMonitor("hook-service-test", logger)
+	tracer := tracing.NewNoopTracer()
+
+	dbClient, err := db.NewDBClient(db.Config{DSN: connStr, MaxConns: 5, MinConns: 1}, tracer, monitor, logger)
+	if err != nil {
+		t.Fatalf("Failed to create DB client: %v", err)
+	}
+	defer dbClient.Close()
+
+	authz := authorization.NewAuthorizer(
+		openfga.NewNoopClient(tracer, monitor, logger),
+		tracer, monitor, logger,
+	)
+	svc := NewService(storage.NewStorage(dbClient, tracer, monitor, logger), authz, nil, tracer, monitor, logger)
+
+	ctx := context.Background()
+	ids := make(map[string]string)
+	for _, name := range []string{"engineering", "platform", "identity"} {
+		group, err := svc.CreateGroup(ctx, &types.Group{Name: name, TenantId: storage.DefaultTenantID, Type: types.GroupTypeLocal})
+		if err != nil {
+			t.Fatalf("failed to create group %s: %v", name, err)
+		}
+		ids[name] = group.ID
+	}
+
+	userID := "nested-user@example.com"
+	if err := svc.AddUsersToGroup(ctx, ids["identity"], []string{userID}); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	if err := svc.AddSubgroups(ctx, ids["engineering"], []string{ids["platform"]}); err != nil {
+		t.Fatalf("failed to nest platform: %v", err)
+	}
+	if err := svc.AddSubgroups(ctx, ids["platform"], []string{ids["identity"]}); err != nil {
+		t.Fatalf("failed to nest identity: %v", err)
+	}
+
+	t.Run("GetGroupsForUser resolves nested groups", func(t *testing.T) {
+		groups, err := svc.GetGroupsForUser(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetGroupsForUser failed: %v", err)
+		}
+
+		names := make([]string, 0, len(groups))
+		for _, g := range groups {
+			names = append(names, g.Name)
+		}
+		if want := []string{"engineering", "identity", "platform"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("expected groups %v, got %v", want, names)
+		}
+	})
+
+	t.Run("ListMembershipsForUser returns the nesting path", func(t *testing.T) {
+		memberships, err := svc.ListMembershipsForUser(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListMembershipsForUser failed: %v", err)
+		}
+		if len(memberships) != 3 {
+			t.Fatalf("expected 3 memberships, got %d", len(memberships))
+		}
+
+		if want := []string{"identity", "platform", "engineering"}; !reflect.DeepEqual(memberships[0].Path, want) {
+			t.Errorf("expected path %v, got %v", want, memberships[0].Path)
+		}
+		if !memberships[1].Direct() {
+			t.Errorf("expected a direct membership of identity, got %v", memberships[1].Path)
+		}
+	})
+
+	t.Run("ListMembersOfGroup includes inherited members", func(t *testing.T) {
+		members, err := svc.ListMembersOfGroup(ctx, ids["engineering"])
+		if err != nil {
+			t.Fatalf("ListMembersOfGroup failed: %v", err)
+		}
+		if len(members) != 1 || members[0].UserID != userID || members[0].Direct() {
+			t.Errorf("expected an inherited membership of %s, got %v", userID, members)
+		}
+	})
+
+	t.Run("AddSubgroups rejects cycles", func(t *testing.T) {
+		err := svc.AddSubgroups(ctx, ids["identity"], []string{ids["engineering"]})
+		if !errors.Is(err, ErrGroupCycle) {
+			t.Fatalf("expected ErrGroupCycle, got %v", err)
+		}
+	})
+
+	t.Run("RemoveSubgroups drops inherited groups", func(t *testing.T) {
+		if err := svc.RemoveSubgroups(ctx, ids["platform"], []string{ids["identity"]}); err != nil {
+			t.Fatalf("RemoveSubgroups failed: %v", err)
+		}
+
+		groups, err := svc.GetGroupsForUser(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetGroupsForUser failed: %v", err)
+		}
+		if len(groups) != 1 || groups[0].Name != "identity" {
+			t.Errorf("expected only identity, got %v", groups)
+		}
+	})
+}
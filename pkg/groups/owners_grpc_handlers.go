@@ -0,0 +1,99 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+var _ pb.GroupOwnersServiceServer = (*OwnersGrpcServer)(nil)
+
+type OwnersGrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedGroupOwnersServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (o *OwnersGrpcServer) AddGroupOwners(ctx context.Context, req *pb.AddGroupOwnersReq) (*pb.AddGroupOwnersResp, error) {
+	ctx, span := o.tracer.Start(ctx, "groups.OwnersGrpcServer.AddGroupOwners")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.Int("owners.count", len(req.GetUserIds())),
+	)
+
+	if err := o.svc.AddOwnersToGroup(ctx, req.GetId(), req.GetUserIds()); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "add group owners failed")
+		return nil, o.mapErrorToStatus(err, "add group owners")
+	}
+
+	span.SetStatus(otelcodes.Ok, "group owners added successfully")
+
+	return &pb.AddGroupOwnersResp{
+		Status:  http.StatusOK,
+		Message: proto.String("Owners added to group"),
+	}, nil
+}
+
+func (o *OwnersGrpcServer) RemoveGroupOwner(ctx context.Context, req *pb.RemoveGroupOwnerReq) (*pb.RemoveGroupOwnerResp, error) {
+	ctx, span := o.tracer.Start(ctx, "groups.OwnersGrpcServer.RemoveGroupOwner")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("group.id", req.GetId()),
+		attribute.String("user.id", req.GetUserId()),
+	)
+
+	if err := o.svc.RemoveOwnersFromGroup(ctx, req.GetId(), []string{req.GetUserId()}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "remove group owner failed")
+		return nil, o.mapErrorToStatus(err, "remove group owner")
+	}
+
+	span.SetStatus(otelcodes.Ok, "group owner removed successfully")
+
+	return &pb.RemoveGroupOwnerResp{
+		Status:  http.StatusOK,
+		Message: proto.String("Owner removed from group"),
+	}, nil
+}
+
+func (o *OwnersGrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrGroupNotFound):
+		return status.Errorf(codes.NotFound, "group not found")
+	default:
+		o.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func NewOwnersGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *OwnersGrpcServer {
+	return &OwnersGrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
@@ -8,12 +8,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/purge"
 	"github.com/canonical/hook-service/internal/storage"
 	"github.com/canonical/hook-service/internal/tracing"
 	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/authentication"
 )
 
 var _ ServiceInterface = (*Service)(nil)
@@ -21,17 +24,37 @@ var _ ServiceInterface = (*Service)(nil)
 type Service struct {
 	db    DatabaseInterface
 	authz AuthorizerInterface
+	cache CacheInvalidatorInterface
+
+	// outboxRetention is how long the outbox events are kept, 0 keeps
+	// them forever.
+	outboxRetention time.Duration
+	// watchSettle is how long the membership watches wait for a missing
+	// outbox event ID, and how old the event a snapshot starts from is.
+	watchSettle            time.Duration
+	watchPollInterval      time.Duration
+	watchHeartbeatInterval time.Duration
 
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
 	logger  logging.LoggerInterface
 }
 
-func (s *Service) ListGroups(ctx context.Context) ([]*types.Group, error) {
+// ListGroups returns a page of the groups matching the options.
+func (s *Service) ListGroups(ctx context.Context, opts *types.ListOptions) ([]*types.Group, *types.PageInfo, error) {
 	ctx, span := s.tracer.Start(ctx, "groups.Service.ListGroups")
 	defer span.End()
 
-	return s.db.ListGroups(ctx)
+	filter, err := opts.Filter()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups, page, err := s.db.ListGroups(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return groups, filter.PageInfo(page), nil
 }
 
 func (s *Service) CreateGroup(ctx context.Context, group *types.Group) (*types.Group, error) {
@@ -77,6 +100,8 @@ func (s *Service) UpdateGroup(ctx context.Context, id string, group *types.Group
 		}
 		return nil, err
 	}
+
+	s.cache.InvalidateGroups(ctx)
 	return updated, nil
 }
 
@@ -84,15 +109,89 @@ func (s *Service) DeleteGroup(ctx context.Context, id string) error {
 	ctx, span := s.tracer.Start(ctx, "groups.Service.DeleteGroup")
 	defer span.End()
 
+	// The authorization model is shared by every tenant, a group of another
+	// tenant must be left alone.
+	if _, ok := storage.TenantFromContext(ctx); ok {
+		if _, err := s.db.GetGroup(ctx, id); errors.Is(err, storage.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get group from db: %v", err)
+		}
+	}
+
 	if err := s.db.DeleteGroup(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete group from db: %v", err)
 	}
 	if err := s.authz.DeleteGroup(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete group from authz: %v", err)
 	}
+
+	s.cache.InvalidateGroups(ctx)
 	return nil
 }
 
+// ListDeletedGroups returns the deleted groups awaiting their purge.
+func (s *Service) ListDeletedGroups(ctx context.Context) ([]*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.ListDeletedGroups")
+	defer span.End()
+
+	groups, err := s.db.ListDeletedGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted groups: %w", err)
+	}
+	return groups, nil
+}
+
+// RestoreGroup brings back a deleted group with its members and subgroups,
+// and grants it its apps again in the authorization model.
+func (s *Service) RestoreGroup(ctx context.Context, id string) (*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.RestoreGroup")
+	defer span.End()
+
+	group, err := s.db.RestoreGroup(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrGroupNotFound
+		}
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			return nil, ErrDuplicateGroup
+		}
+		return nil, fmt.Errorf("failed to restore group in db: %w", err)
+	}
+
+	// The app grants are kept in the database while the tuples are removed
+	// on deletion.
+	apps, _, err := s.db.GetAllowedApps(ctx, id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed apps of group: %w", err)
+	}
+	for _, app := range apps {
+		if err := s.authz.AddAllowedAppToGroup(ctx, id, app); err != nil {
+			return nil, fmt.Errorf("failed to restore group in authz: %w", err)
+		}
+	}
+
+	s.cache.InvalidateGroups(ctx)
+	return group, nil
+}
+
+// PurgeDeletedGroups permanently deletes the groups deleted more than
+// retention ago.
+func (s *Service) PurgeDeletedGroups(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.PurgeDeletedGroups")
+	defer span.End()
+
+	return s.db.PurgeGroups(ctx, time.Now().Add(-retention))
+}
+
+// RunRetention purges the groups deleted more than retention ago every
+// interval until ctx is cancelled.
+func (s *Service) RunRetention(ctx context.Context, retention, interval time.Duration) error {
+	return purge.Run(ctx, interval, fmt.Sprintf("groups deleted more than %s ago", retention), func(ctx context.Context) (int64, error) {
+		return s.PurgeDeletedGroups(ctx, retention)
+	}, s.logger)
+}
+
 func (s *Service) AddUsersToGroup(ctx context.Context, groupID string, userIDs []string) error {
 	ctx, span := s.tracer.Start(ctx, "groups.Service.AddUsersToGroup")
 	defer span.End()
@@ -101,33 +200,185 @@ func (s *Service) AddUsersToGroup(ctx context.Context, groupID string, userIDs [
 		return nil
 	}
 
+	if err := s.checkOwner(ctx, groupID); err != nil {
+		return err
+	}
+
 	if err := s.db.AddUsersToGroup(ctx, groupID, userIDs); err != nil {
 		if errors.Is(err, storage.ErrForeignKeyViolation) {
 			return ErrInvalidGroupID
 		}
 		return fmt.Errorf("failed to add users to group: %v", err)
 	}
+
+	s.cache.InvalidateUsers(ctx, userIDs...)
 	return nil
 }
 
-func (s *Service) ListUsersInGroup(ctx context.Context, groupID string) ([]string, error) {
+// ListUsersInGroup returns a page of the members of a group matching the
+// options.
+func (s *Service) ListUsersInGroup(ctx context.Context, groupID string, opts *types.ListOptions) ([]*types.GroupUser, *types.PageInfo, error) {
 	ctx, span := s.tracer.Start(ctx, "groups.Service.ListUsersInGroup")
 	defer span.End()
 
-	g, err := s.db.ListUsersInGroup(ctx, groupID)
+	if err := s.checkOwner(ctx, groupID); err != nil {
+		return nil, nil, err
+	}
+
+	filter, err := opts.Filter()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list users in group: %w", err)
+		return nil, nil, err
 	}
-	return g, nil
+
+	g, page, err := s.db.ListUsersInGroup(ctx, groupID, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list users in group: %w", err)
+	}
+	return g, filter.PageInfo(page), nil
 }
 
 func (s *Service) RemoveUsersFromGroup(ctx context.Context, groupID string, users []string) error {
 	ctx, span := s.tracer.Start(ctx, "groups.Service.RemoveUsersFromGroup")
 	defer span.End()
 
+	if err := s.checkOwner(ctx, groupID); err != nil {
+		return err
+	}
+	if err := s.checkOwnerRemoval(ctx, groupID, users); err != nil {
+		return err
+	}
+
 	if err := s.db.RemoveUsersFromGroup(ctx, groupID, users); err != nil {
 		return fmt.Errorf("failed to remove users from group: %w", err)
 	}
+
+	s.cache.InvalidateUsers(ctx, users...)
+	return nil
+}
+
+// AddOwnersToGroup makes users owners of a group, owners can manage the
+// members of the group without access to the whole API.
+func (s *Service) AddOwnersToGroup(ctx context.Context, groupID string, userIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.AddOwnersToGroup")
+	defer span.End()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if err := s.db.AddOwnersToGroup(ctx, groupID, userIDs); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrGroupNotFound
+		}
+		return fmt.Errorf("failed to add owners to group: %w", err)
+	}
+
+	// Owners are members of the group.
+	s.cache.InvalidateUsers(ctx, userIDs...)
+	return nil
+}
+
+// RemoveOwnersFromGroup makes owners of a group regular members.
+func (s *Service) RemoveOwnersFromGroup(ctx context.Context, groupID string, userIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.RemoveOwnersFromGroup")
+	defer span.End()
+
+	if err := s.db.RemoveOwnersFromGroup(ctx, groupID, userIDs); err != nil {
+		return fmt.Errorf("failed to remove owners from group: %w", err)
+	}
+	return nil
+}
+
+// GetGroupAttributes returns the custom attributes of a group.
+func (s *Service) GetGroupAttributes(ctx context.Context, groupID string) (map[string]string, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.GetGroupAttributes")
+	defer span.End()
+
+	group, err := s.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return group.Attributes, nil
+}
+
+// SetGroupAttributes adds attributes to a group, overwriting the values of
+// the existing keys, and returns every attribute of the group.
+func (s *Service) SetGroupAttributes(ctx context.Context, groupID string, attributes map[string]string) (map[string]string, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.SetGroupAttributes")
+	defer span.End()
+
+	if err := types.ValidateAttributes(attributes); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.SetGroupAttributes(ctx, groupID, attributes)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to set group attributes: %w", err)
+	}
+
+	// The attributes of the groups are emitted in the tokens of their members.
+	s.cache.InvalidateGroups(ctx)
+	return updated, nil
+}
+
+// RemoveGroupAttributes removes attributes from a group and returns the
+// remaining attributes of the group.
+func (s *Service) RemoveGroupAttributes(ctx context.Context, groupID string, keys []string) (map[string]string, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.RemoveGroupAttributes")
+	defer span.End()
+
+	updated, err := s.db.RemoveGroupAttributes(ctx, groupID, keys)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to remove group attributes: %w", err)
+	}
+
+	s.cache.InvalidateGroups(ctx)
+	return updated, nil
+}
+
+// checkOwner lets the callers of delegated requests manage the members of a
+// group only when they own it. Other requests are authorized for the whole
+// API or do not come from the API.
+func (s *Service) checkOwner(ctx context.Context, groupID string) error {
+	principal, ok := authentication.PrincipalFromContext(ctx)
+	if !ok || principal.Admin {
+		return nil
+	}
+
+	owner, err := s.db.IsGroupOwner(ctx, groupID, principal.IDs())
+	if err != nil {
+		return fmt.Errorf("failed to check group owner: %w", err)
+	}
+	if !owner {
+		s.logger.Security().AuthzFailure(principal.Subject, fmt.Sprintf("group_members:%s", groupID))
+		return ErrNotGroupOwner
+	}
+	return nil
+}
+
+// checkOwnerRemoval keeps the callers of delegated requests from removing
+// the owners of a group, so that owners cannot take the group from each other
+// or leave it without an owner.
+func (s *Service) checkOwnerRemoval(ctx context.Context, groupID string, users []string) error {
+	principal, ok := authentication.PrincipalFromContext(ctx)
+	if !ok || principal.Admin {
+		return nil
+	}
+
+	owner, err := s.db.IsGroupOwner(ctx, groupID, users)
+	if err != nil {
+		return fmt.Errorf("failed to check group owner: %w", err)
+	}
+	if owner {
+		s.logger.Security().AuthzFailure(principal.Subject, fmt.Sprintf("group_owners:%s", groupID))
+		return ErrOwnerRemoval
+	}
 	return nil
 }
 
@@ -152,32 +403,235 @@ func (s *Service) UpdateGroupsForUser(ctx context.Context, userID string, groupI
 		}
 		return err
 	}
+
+	s.cache.InvalidateUsers(ctx, userID)
 	return nil
 }
 
-func (s *Service) StreamGroupsForUser(ctx context.Context, tenantID, userID string, fn func(*types.Group) error) error {
-	ctx, span := s.tracer.Start(ctx, "groups.Service.StreamGroupsForUser")
+// AddSubgroups nests groups in a group, rejecting nestings that would create
+// a cycle.
+func (s *Service) AddSubgroups(ctx context.Context, groupID string, subgroupIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.AddSubgroups")
 	defer span.End()
 
-	if err := s.db.StreamGroupsForUser(ctx, tenantID, userID, fn); err != nil {
-		return fmt.Errorf("failed to stream groups for user: %w", err)
+	if len(subgroupIDs) == 0 {
+		return nil
+	}
+
+	if err := s.db.AddSubgroups(ctx, groupID, subgroupIDs); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return ErrGroupNotFound
+		case errors.Is(err, storage.ErrForeignKeyViolation):
+			return ErrInvalidGroupID
+		case errors.Is(err, storage.ErrGroupCycle):
+			return ErrGroupCycle
+		}
+		return fmt.Errorf("failed to add subgroups: %w", err)
 	}
+
+	// Every member of the subgroups gains the group.
+	s.cache.InvalidateGroups(ctx)
 	return nil
 }
 
-func (s *Service) StreamUsersInGroup(ctx context.Context, tenantID, groupID string, fn func(string) error) error {
+func (s *Service) ListSubgroups(ctx context.Context, groupID string) ([]*types.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.ListSubgroups")
+	defer span.End()
+
+	groups, err := s.db.ListSubgroups(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subgroups: %w", err)
+	}
+	return groups, nil
+}
+
+func (s *Service) RemoveSubgroups(ctx context.Context, groupID string, subgroupIDs []string) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.RemoveSubgroups")
+	defer span.End()
+
+	if err := s.db.RemoveSubgroups(ctx, groupID, subgroupIDs); err != nil {
+		return fmt.Errorf("failed to remove subgroups: %w", err)
+	}
+
+	s.cache.InvalidateGroups(ctx)
+	return nil
+}
+
+// ListMembershipsForUser returns the direct and inherited memberships of a
+// user.
+func (s *Service) ListMembershipsForUser(ctx context.Context, userID string) ([]*types.Membership, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.ListMembershipsForUser")
+	defer span.End()
+
+	memberships, err := s.db.ListMembershipsForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships for user: %w", err)
+	}
+	return memberships, nil
+}
+
+// ListMembersOfGroup returns the direct and inherited members of a group.
+func (s *Service) ListMembersOfGroup(ctx context.Context, groupID string) ([]*types.Membership, error) {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.ListMembersOfGroup")
+	defer span.End()
+
+	if _, err := s.GetGroup(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.db.ListMembersOfGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of group: %w", err)
+	}
+	return memberships, nil
+}
+
+// StreamGroupsForUser calls fn with each group of a user within a tenant,
+// ordered by name, and the resume token following it. The groups are read
+// pageSize at a time, each page with its own query, and the stream resumes
+// after the group of the after token when set.
+func (s *Service) StreamGroupsForUser(ctx context.Context, tenantID, userID, after string, pageSize int, fn func(*types.Group, string) error) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.StreamGroupsForUser")
+	defer span.End()
+
+	cursor, limit, err := streamPage(after, pageSize, groupsStreamOrder)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n := uint64(0)
+		err := s.db.StreamGroupsForUser(ctx, tenantID, userID, cursor, limit, func(g *types.Group) error {
+			n++
+			cursor = &types.Cursor{Value: g.Name, ID: g.ID}
+			return fn(g, types.StreamToken(groupsStreamOrder, cursor))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to stream groups for user: %w", err)
+		}
+		if n < limit {
+			return nil
+		}
+	}
+}
+
+// StreamUsersInGroup calls fn with each user ID of a group within a tenant,
+// in order, and the resume token following it. The users are read pageSize
+// at a time, each page with its own query, and the stream resumes after the
+// user of the after token when set.
+func (s *Service) StreamUsersInGroup(ctx context.Context, tenantID, groupID, after string, pageSize int, fn func(string, string) error) error {
 	ctx, span := s.tracer.Start(ctx, "groups.Service.StreamUsersInGroup")
 	defer span.End()
 
-	if err := s.db.StreamUsersInGroup(ctx, tenantID, groupID, fn); err != nil {
-		return fmt.Errorf("failed to stream users in group: %w", err)
+	cursor, limit, err := streamPage(after, pageSize, usersStreamOrder)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n := uint64(0)
+		err := s.db.StreamUsersInGroup(ctx, tenantID, groupID, cursor, limit, func(userID string) error {
+			n++
+			cursor = &types.Cursor{ID: userID}
+			return fn(userID, types.StreamToken(usersStreamOrder, cursor))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to stream users in group: %w", err)
+		}
+		if n < limit {
+			return nil
+		}
+	}
+}
+
+// Orders of the mapping streams, a resume token only resumes the stream it
+// was issued by.
+const (
+	groupsStreamOrder = types.SortByName
+	usersStreamOrder  = "user_id"
+)
+
+// defaultStreamPageSize is the number of rows read per query by the mapping
+// streams when the request does not set it.
+const defaultStreamPageSize = 500
+
+// streamPage validates the resume token and page size of a mapping stream.
+func streamPage(after string, pageSize int, order string) (*types.Cursor, uint64, error) {
+	pageSize, err := types.PageSize(pageSize, defaultStreamPageSize, types.MaxPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if after == "" {
+		return nil, uint64(pageSize), nil
+	}
+
+	cursor, err := types.ParseStreamToken(after, order)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cursor, uint64(pageSize), nil
+}
+
+// maxBatchUsers is the number of users whose groups can be looked up at once.
+const maxBatchUsers = 1000
+
+// BatchGetGroupsForUsers looks up the groups of several users within a
+// tenant with a single query, and calls fn once per distinct user ID in the
+// order of the request. Invalid user IDs are reported to fn with
+// ErrInvalidUserID instead of failing the batch.
+func (s *Service) BatchGetGroupsForUsers(ctx context.Context, tenantID string, userIDs []string, fn func(string, []*types.Group, error) error) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.BatchGetGroupsForUsers")
+	defer span.End()
+
+	if len(userIDs) > maxBatchUsers {
+		return fmt.Errorf("%w: at most %d users can be looked up at once", ErrTooManyUsers, maxBatchUsers)
+	}
+
+	unique := make([]string, 0, len(userIDs))
+	valid := make([]string, 0, len(userIDs))
+	seen := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+		if validUserID(id) {
+			valid = append(valid, id)
+		}
+	}
+
+	groups := make(map[string][]*types.Group)
+	if len(valid) > 0 {
+		var err error
+		if groups, err = s.db.GetGroupsForUsers(ctx, tenantID, valid); err != nil {
+			return fmt.Errorf("failed to get groups for users: %w", err)
+		}
+	}
+
+	for _, id := range unique {
+		var err error
+		if !validUserID(id) {
+			err = ErrInvalidUserID
+		}
+		if err := fn(id, groups[id], err); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// validUserID reports whether id can be a member of a group.
+func validUserID(id string) bool {
+	return id != "" && len(id) <= 255
+}
+
 func NewService(
 	db DatabaseInterface,
 	authz AuthorizerInterface,
+	cache CacheInvalidatorInterface,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
@@ -186,6 +640,15 @@ func NewService(
 
 	s.db = db
 	s.authz = authz
+	s.cache = cache
+
+	if s.cache == nil {
+		s.cache = noopCacheInvalidator{}
+	}
+
+	s.watchSettle = watchSettle
+	s.watchPollInterval = watchPollInterval
+	s.watchHeartbeatInterval = watchHeartbeatInterval
 
 	s.monitor = monitor
 	s.tracer = tracer
@@ -193,3 +656,8 @@ func NewService(
 
 	return s
 }
+
+type noopCacheInvalidator struct{}
+
+func (noopCacheInvalidator) InvalidateUsers(context.Context, ...string) {}
+func (noopCacheInvalidator) InvalidateGroups(context.Context)           {}
@@ -8,11 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/storage"
 	"github.com/canonical/hook-service/internal/types"
+	"github.com/canonical/hook-service/pkg/authentication"
 	trace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
@@ -100,7 +103,7 @@ func TestService_CreateGroup(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			g := &types.Group{
 				Name:        groupName,
@@ -187,7 +190,7 @@ func TestService_GetGroup(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -216,17 +219,21 @@ func TestService_GetGroup(t *testing.T) {
 func TestService_ListGroups(t *testing.T) {
 	expectedGroups := []*types.Group{{ID: "1", Name: "group1"}, {ID: "2", Name: "group2"}}
 	dbErr := errors.New("db error")
+	total := int64(2)
 
 	testCases := []struct {
 		name           string
+		opts           *types.ListOptions
 		setupMocks     func(mockStorage *MockDatabaseInterface)
 		expectedGroups []*types.Group
+		expectedNext   bool
+		expectedTotal  *int64
 		expectedErr    error
 	}{
 		{
 			name: "success",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListGroups(gomock.Any()).Return(expectedGroups, nil)
+				mockStorage.EXPECT().ListGroups(gomock.Any(), nil).Return(expectedGroups, new(types.Page), nil)
 			},
 			expectedGroups: expectedGroups,
 			expectedErr:    nil,
@@ -234,15 +241,67 @@ func TestService_ListGroups(t *testing.T) {
 		{
 			name: "success empty",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListGroups(gomock.Any()).Return([]*types.Group{}, nil)
+				mockStorage.EXPECT().ListGroups(gomock.Any(), nil).Return([]*types.Group{}, new(types.Page), nil)
 			},
 			expectedGroups: []*types.Group{},
 			expectedErr:    nil,
 		},
+		{
+			name: "first page",
+			opts: &types.ListOptions{Search: "group", PageSize: 1, SortBy: types.SortByCreatedAt},
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().ListGroups(gomock.Any(), &types.ListFilter{
+					Search:     "group",
+					SortBy:     types.SortByCreatedAt,
+					Limit:      1,
+					CountTotal: true,
+				}).Return(expectedGroups[:1], &types.Page{Next: &types.Cursor{Value: "2026-01-01T00:00:00Z", ID: "1"}, Total: &total}, nil)
+			},
+			expectedGroups: expectedGroups[:1],
+			expectedNext:   true,
+			expectedTotal:  &total,
+		},
+		{
+			name: "default page size",
+			opts: &types.ListOptions{},
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().ListGroups(gomock.Any(), &types.ListFilter{
+					SortBy:     types.SortByName,
+					Limit:      types.DefaultPageSize,
+					CountTotal: true,
+				}).Return(expectedGroups, &types.Page{Total: &total}, nil)
+			},
+			expectedGroups: expectedGroups,
+			expectedTotal:  &total,
+		},
+		{
+			name:        "invalid page size",
+			opts:        &types.ListOptions{PageSize: types.MaxPageSize + 1},
+			setupMocks:  func(mockStorage *MockDatabaseInterface) {},
+			expectedErr: types.ErrInvalidPageSize,
+		},
+		{
+			name:        "invalid sort",
+			opts:        &types.ListOptions{SortBy: "description"},
+			setupMocks:  func(mockStorage *MockDatabaseInterface) {},
+			expectedErr: types.ErrInvalidSort,
+		},
+		{
+			name:        "invalid page token",
+			opts:        &types.ListOptions{PageToken: "not-a-token"},
+			setupMocks:  func(mockStorage *MockDatabaseInterface) {},
+			expectedErr: types.ErrInvalidPageToken,
+		},
+		{
+			name:        "invalid time range",
+			opts:        &types.ListOptions{CreatedAfter: time.Now(), CreatedBefore: time.Now().Add(-time.Hour)},
+			setupMocks:  func(mockStorage *MockDatabaseInterface) {},
+			expectedErr: types.ErrInvalidTimeRange,
+		},
 		{
 			name: "db error",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListGroups(gomock.Any()).Return(nil, dbErr)
+				mockStorage.EXPECT().ListGroups(gomock.Any(), nil).Return(nil, nil, dbErr)
 			},
 			expectedGroups: nil,
 			expectedErr:    dbErr,
@@ -260,12 +319,12 @@ func TestService_ListGroups(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
 
-			groups, err := s.ListGroups(context.Background())
+			groups, page, err := s.ListGroups(context.Background(), tc.opts)
 
 			if tc.expectedErr != nil {
 				if !errors.Is(err, tc.expectedErr) {
@@ -281,11 +340,54 @@ func TestService_ListGroups(t *testing.T) {
 				if !reflect.DeepEqual(tc.expectedGroups, groups) {
 					t.Fatalf("expected groups %+v, got %+v", tc.expectedGroups, groups)
 				}
+				if tc.expectedNext != (page.NextPageToken != "") {
+					t.Fatalf("expected next page to be %v, got %q", tc.expectedNext, page.NextPageToken)
+				}
+				if !reflect.DeepEqual(tc.expectedTotal, page.Total) {
+					t.Fatalf("expected total %v, got %v", tc.expectedTotal, page.Total)
+				}
 			}
 		})
 	}
 }
 
+func TestService_ListGroupsNextPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).Times(2)
+
+	cursor := &types.Cursor{Value: "group1", ID: "1"}
+	gomock.InOrder(
+		mockStorage.EXPECT().ListGroups(gomock.Any(), gomock.Any()).Return([]*types.Group{{ID: "1", Name: "group1"}}, &types.Page{Next: cursor}, nil),
+		mockStorage.EXPECT().ListGroups(gomock.Any(), &types.ListFilter{
+			SortBy:     types.SortByName,
+			Descending: true,
+			After:      cursor,
+			Limit:      1,
+		}).Return([]*types.Group{{ID: "2", Name: "group0"}}, new(types.Page), nil),
+	)
+
+	s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	opts := &types.ListOptions{PageSize: 1, Descending: true}
+	_, page, err := s.ListGroups(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts.PageToken = page.NextPageToken
+	groups, page, err := s.ListGroups(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].ID != "2" || page.NextPageToken != "" {
+		t.Fatalf("unexpected last page %+v %+v", groups, page)
+	}
+}
+
 func TestService_UpdateGroup(t *testing.T) {
 	groupID := "test-id"
 	groupToUpdate := &types.Group{Name: "updated-name"}
@@ -343,7 +445,7 @@ func TestService_UpdateGroup(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -377,6 +479,7 @@ func TestService_DeleteGroup(t *testing.T) {
 	testCases := []struct {
 		name        string
 		groupID     string
+		tenantID    string
 		setupMocks  func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface)
 		expectedErr error
 	}{
@@ -389,6 +492,26 @@ func TestService_DeleteGroup(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:     "group of the tenant",
+			groupID:  groupID,
+			tenantID: "acme",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetGroup(gomock.Any(), groupID).Return(&types.Group{ID: groupID, TenantId: "acme"}, nil)
+				mockStorage.EXPECT().DeleteGroup(gomock.Any(), groupID).Return(nil)
+				mockAuthz.EXPECT().DeleteGroup(gomock.Any(), groupID).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "group of another tenant",
+			groupID:  groupID,
+			tenantID: "acme",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetGroup(gomock.Any(), groupID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: nil,
+		},
 		{
 			name:    "db error",
 			groupID: groupID,
@@ -419,12 +542,17 @@ func TestService_DeleteGroup(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
-			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+			ctx := context.Background()
+			if tc.tenantID != "" {
+				ctx = storage.WithTenant(ctx, tc.tenantID)
+			}
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx))
 			tc.setupMocks(mockStorage, mockAuthz)
 
-			err := s.DeleteGroup(context.Background(), tc.groupID)
+			err := s.DeleteGroup(ctx, tc.groupID)
 
 			if tc.expectedErr != nil {
 				if err == nil || err.Error() != tc.expectedErr.Error() {
@@ -439,6 +567,111 @@ func TestService_DeleteGroup(t *testing.T) {
 	}
 }
 
+func TestService_RestoreGroup(t *testing.T) {
+	groupID := "test-id"
+	group := &types.Group{ID: groupID, Name: "engineering"}
+	authzErr := errors.New("authz error")
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().RestoreGroup(gomock.Any(), groupID).Return(group, nil)
+				mockStorage.EXPECT().GetAllowedApps(gomock.Any(), groupID, nil).Return([]string{"app-1", "app-2"}, &types.Page{}, nil)
+				mockAuthz.EXPECT().AddAllowedAppToGroup(gomock.Any(), groupID, "app-1").Return(nil)
+				mockAuthz.EXPECT().AddAllowedAppToGroup(gomock.Any(), groupID, "app-2").Return(nil)
+			},
+		},
+		{
+			name: "not deleted",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().RestoreGroup(gomock.Any(), groupID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: ErrGroupNotFound,
+		},
+		{
+			name: "name taken",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().RestoreGroup(gomock.Any(), groupID).Return(nil, storage.ErrDuplicateKey)
+			},
+			expectedErr: ErrDuplicateGroup,
+		},
+		{
+			name: "authz error",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().RestoreGroup(gomock.Any(), groupID).Return(group, nil)
+				mockStorage.EXPECT().GetAllowedApps(gomock.Any(), groupID, nil).Return([]string{"app-1"}, &types.Page{}, nil)
+				mockAuthz.EXPECT().AddAllowedAppToGroup(gomock.Any(), groupID, "app-1").Return(authzErr)
+			},
+			expectedErr: authzErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			restored, err := s.RestoreGroup(context.TODO(), groupID)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if restored != group {
+				t.Errorf("expected group %v, got %v", group, restored)
+			}
+		})
+	}
+}
+
+func TestService_PurgeDeletedGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	retention := 24 * time.Hour
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+	mockStorage.EXPECT().PurgeGroups(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, before time.Time) (int64, error) {
+			if d := time.Since(before); d < retention || d > retention+time.Minute {
+				t.Fatalf("expected cutoff %s ago, got %s", retention, d)
+			}
+			return 3, nil
+		},
+	)
+
+	n, err := s.PurgeDeletedGroups(context.TODO(), retention)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 purged groups, got %d", n)
+	}
+}
+
 func TestService_AddUsersToGroup(t *testing.T) {
 	groupID := "group-id"
 	userIDs := []string{"user1", "user2"}
@@ -446,26 +679,27 @@ func TestService_AddUsersToGroup(t *testing.T) {
 
 	testCases := []struct {
 		name        string
-		setupMocks  func(mockStorage *MockDatabaseInterface)
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface)
 		expectedErr error
 	}{
 		{
 			name: "success",
-			setupMocks: func(mockStorage *MockDatabaseInterface) {
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
 				mockStorage.EXPECT().AddUsersToGroup(gomock.Any(), groupID, userIDs).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "user1", "user2")
 			},
 			expectedErr: nil,
 		},
 		{
 			name: "invalid group id",
-			setupMocks: func(mockStorage *MockDatabaseInterface) {
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
 				mockStorage.EXPECT().AddUsersToGroup(gomock.Any(), groupID, userIDs).Return(storage.ErrForeignKeyViolation)
 			},
 			expectedErr: ErrInvalidGroupID,
 		},
 		{
 			name: "db error",
-			setupMocks: func(mockStorage *MockDatabaseInterface) {
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
 				mockStorage.EXPECT().AddUsersToGroup(gomock.Any(), groupID, userIDs).Return(dbErr)
 			},
 			expectedErr: fmt.Errorf("failed to add users to group: %v", dbErr),
@@ -482,11 +716,12 @@ func TestService_AddUsersToGroup(t *testing.T) {
 			mockTracer := NewMockTracingInterface(ctrl)
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockCache, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
-			tc.setupMocks(mockStorage)
+			tc.setupMocks(mockStorage, mockCache)
 
 			err := s.AddUsersToGroup(context.Background(), groupID, userIDs)
 
@@ -505,19 +740,19 @@ func TestService_AddUsersToGroup(t *testing.T) {
 
 func TestService_ListUsersInGroup(t *testing.T) {
 	groupID := "group-id"
-	expectedUsers := []string{"user1", "user2"}
+	expectedUsers := []*types.GroupUser{{ID: "user1", Role: types.RoleOwner}, {ID: "user2", Role: types.RoleMember}}
 	dbErr := errors.New("db error")
 
 	testCases := []struct {
 		name          string
 		setupMocks    func(mockStorage *MockDatabaseInterface)
-		expectedUsers []string
+		expectedUsers []*types.GroupUser
 		expectedErr   error
 	}{
 		{
 			name: "success",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID).Return(expectedUsers, nil)
+				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID, nil).Return(expectedUsers, new(types.Page), nil)
 			},
 			expectedUsers: expectedUsers,
 			expectedErr:   nil,
@@ -525,15 +760,15 @@ func TestService_ListUsersInGroup(t *testing.T) {
 		{
 			name: "success empty",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID).Return([]string{}, nil)
+				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID, nil).Return([]*types.GroupUser{}, new(types.Page), nil)
 			},
-			expectedUsers: []string{},
+			expectedUsers: []*types.GroupUser{},
 			expectedErr:   nil,
 		},
 		{
 			name: "not found",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID).Return(nil, ErrGroupNotFound)
+				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID, nil).Return(nil, nil, ErrGroupNotFound)
 			},
 			expectedUsers: nil,
 			expectedErr:   ErrGroupNotFound,
@@ -541,7 +776,7 @@ func TestService_ListUsersInGroup(t *testing.T) {
 		{
 			name: "db error",
 			setupMocks: func(mockStorage *MockDatabaseInterface) {
-				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID).Return(nil, dbErr)
+				mockStorage.EXPECT().ListUsersInGroup(gomock.Any(), groupID, nil).Return(nil, nil, dbErr)
 			},
 			expectedUsers: nil,
 			expectedErr:   dbErr,
@@ -559,12 +794,12 @@ func TestService_ListUsersInGroup(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
 
-			users, err := s.ListUsersInGroup(context.Background(), groupID)
+			users, _, err := s.ListUsersInGroup(context.Background(), groupID, nil)
 
 			if tc.expectedErr != nil {
 				if !errors.Is(err, tc.expectedErr) {
@@ -629,7 +864,7 @@ func TestService_RemoveUsersFromGroup(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -697,7 +932,7 @@ func TestService_GetGroupsForUser(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -723,6 +958,188 @@ func TestService_GetGroupsForUser(t *testing.T) {
 	}
 }
 
+func TestService_StreamGroupsForUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	after := types.StreamToken(groupsStreamOrder, &types.Cursor{Value: "alpha", ID: "g0"})
+	pages := [][]*types.Group{
+		{{ID: "g1", Name: "beta"}, {ID: "g2", Name: "gamma"}},
+		{{ID: "g3", Name: "delta"}},
+	}
+	gomock.InOrder(
+		mockStorage.EXPECT().StreamGroupsForUser(gomock.Any(), "tenant-a", "user-1", &types.Cursor{Value: "alpha", ID: "g0"}, uint64(2), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string, _ *types.Cursor, _ uint64, fn func(*types.Group) error) error {
+				for _, g := range pages[0] {
+					if err := fn(g); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		),
+		mockStorage.EXPECT().StreamGroupsForUser(gomock.Any(), "tenant-a", "user-1", &types.Cursor{Value: "gamma", ID: "g2"}, uint64(2), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string, _ *types.Cursor, _ uint64, fn func(*types.Group) error) error {
+				return fn(pages[1][0])
+			},
+		),
+	)
+
+	tokens := make(map[string]string)
+	err := s.StreamGroupsForUser(context.Background(), "tenant-a", "user-1", after, 2, func(g *types.Group, token string) error {
+		tokens[g.ID] = token
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(tokens))
+	}
+
+	cursor, err := types.ParseStreamToken(tokens["g3"], groupsStreamOrder)
+	if err != nil {
+		t.Fatalf("unexpected error parsing the resume token: %v", err)
+	}
+	if cursor.Value != "delta" || cursor.ID != "g3" {
+		t.Errorf("expected the resume token to follow g3, got %+v", cursor)
+	}
+}
+
+func TestService_StreamUsersInGroupInvalidPage(t *testing.T) {
+	tests := []struct {
+		name          string
+		after         string
+		pageSize      int
+		expectedError error
+	}{
+		{name: "Malformed token", after: "not a token", expectedError: types.ErrInvalidPageToken},
+		{name: "Token of another stream", after: types.StreamToken(groupsStreamOrder, &types.Cursor{Value: "alpha", ID: "g0"}), expectedError: types.ErrInvalidPageToken},
+		{name: "Negative page size", pageSize: -1, expectedError: types.ErrInvalidPageSize},
+		{name: "Page size too large", pageSize: types.MaxPageSize + 1, expectedError: types.ErrInvalidPageSize},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			s := NewService(NewMockDatabaseInterface(ctrl), NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+
+			err := s.StreamUsersInGroup(context.Background(), "tenant-a", "group-1", test.after, test.pageSize, func(string, string) error {
+				t.Fatal("expected no user")
+				return nil
+			})
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestService_BatchGetGroupsForUsers(t *testing.T) {
+	aliceGroups := []*types.Group{{ID: "group1"}, {ID: "group2"}}
+	dbErr := errors.New("db error")
+
+	type result struct {
+		userID string
+		groups []*types.Group
+		err    error
+	}
+
+	testCases := []struct {
+		name            string
+		userIDs         []string
+		setupMocks      func(mockStorage *MockDatabaseInterface)
+		expectedResults []result
+		expectedErr     error
+	}{
+		{
+			name:    "success",
+			userIDs: []string{"alice", "", "bob", "alice"},
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetGroupsForUsers(gomock.Any(), "tenant-a", []string{"alice", "bob"}).Return(map[string][]*types.Group{"alice": aliceGroups}, nil)
+			},
+			expectedResults: []result{
+				{userID: "alice", groups: aliceGroups},
+				{userID: "", err: ErrInvalidUserID},
+				{userID: "bob"},
+			},
+		},
+		{
+			name:    "only invalid users",
+			userIDs: []string{strings.Repeat("x", 256)},
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+			},
+			expectedResults: []result{
+				{userID: strings.Repeat("x", 256), err: ErrInvalidUserID},
+			},
+		},
+		{
+			name:    "too many users",
+			userIDs: make([]string, maxBatchUsers+1),
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+			},
+			expectedErr: ErrTooManyUsers,
+		},
+		{
+			name:    "db error",
+			userIDs: []string{"alice"},
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetGroupsForUsers(gomock.Any(), "tenant-a", []string{"alice"}).Return(nil, dbErr)
+			},
+			expectedErr: dbErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+
+			s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage)
+
+			results := make([]result, 0)
+			err := s.BatchGetGroupsForUsers(context.Background(), "tenant-a", tc.userIDs, func(userID string, groups []*types.Group, err error) error {
+				results = append(results, result{userID: userID, groups: groups, err: err})
+				return nil
+			})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if len(results) != 0 {
+					t.Fatalf("expected no results, got %+v", results)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedResults, results) {
+				t.Fatalf("expected results %+v, got %+v", tc.expectedResults, results)
+			}
+		})
+	}
+}
+
 func TestService_UpdateGroupsForUser(t *testing.T) {
 	userID := "user-id"
 	groupIDs := []string{"group1", "group2"}
@@ -767,7 +1184,7 @@ func TestService_UpdateGroupsForUser(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -786,3 +1203,485 @@ func TestService_UpdateGroupsForUser(t *testing.T) {
 		})
 	}
 }
+
+func TestService_AddSubgroups(t *testing.T) {
+	groupID := "group-id"
+	subgroupIDs := []string{"subgroup1", "subgroup2"}
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddSubgroups(gomock.Any(), groupID, subgroupIDs).Return(nil)
+				mockCache.EXPECT().InvalidateGroups(gomock.Any())
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "group not found",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddSubgroups(gomock.Any(), groupID, subgroupIDs).Return(storage.ErrNotFound)
+			},
+			expectedErr: ErrGroupNotFound,
+		},
+		{
+			name: "invalid subgroup id",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddSubgroups(gomock.Any(), groupID, subgroupIDs).Return(storage.ErrForeignKeyViolation)
+			},
+			expectedErr: ErrInvalidGroupID,
+		},
+		{
+			name: "cycle",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddSubgroups(gomock.Any(), groupID, subgroupIDs).Return(fmt.Errorf("nested: %w", storage.ErrGroupCycle))
+			},
+			expectedErr: ErrGroupCycle,
+		},
+		{
+			name: "db error",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddSubgroups(gomock.Any(), groupID, subgroupIDs).Return(dbErr)
+			},
+			expectedErr: fmt.Errorf("failed to add subgroups: %v", dbErr),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockCache, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockCache)
+
+			err := s.AddSubgroups(context.Background(), groupID, subgroupIDs)
+
+			if tc.expectedErr != nil {
+				if err == nil || err.Error() != tc.expectedErr.Error() {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr.Error(), err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestService_ListMembersOfGroup(t *testing.T) {
+	groupID := "group-id"
+	expectedMembers := []*types.Membership{
+		{UserID: "user1", Path: []string{"engineering"}},
+		{UserID: "user2", Path: []string{"identity", "platform", "engineering"}},
+	}
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		name            string
+		setupMocks      func(mockStorage *MockDatabaseInterface)
+		expectedMembers []*types.Membership
+		expectedErr     error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetGroup(gomock.Any(), groupID).Return(&types.Group{ID: groupID}, nil)
+				mockStorage.EXPECT().ListMembersOfGroup(gomock.Any(), groupID).Return(expectedMembers, nil)
+			},
+			expectedMembers: expectedMembers,
+			expectedErr:     nil,
+		},
+		{
+			name: "group not found",
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetGroup(gomock.Any(), groupID).Return(nil, storage.ErrNotFound)
+			},
+			expectedMembers: nil,
+			expectedErr:     ErrGroupNotFound,
+		},
+		{
+			name: "db error",
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetGroup(gomock.Any(), groupID).Return(&types.Group{ID: groupID}, nil)
+				mockStorage.EXPECT().ListMembersOfGroup(gomock.Any(), groupID).Return(nil, dbErr)
+			},
+			expectedMembers: nil,
+			expectedErr:     dbErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, nil, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+			tc.setupMocks(mockStorage)
+
+			members, err := s.ListMembersOfGroup(context.Background(), groupID)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(members, tc.expectedMembers) {
+				t.Fatalf("expected members %v, got %v", tc.expectedMembers, members)
+			}
+		})
+	}
+}
+
+func TestService_AddOwnersToGroup(t *testing.T) {
+	groupID := "group-id"
+	userIDs := []string{"user1", "user2"}
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddOwnersToGroup(gomock.Any(), groupID, userIDs).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "user1", "user2")
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "group not found",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddOwnersToGroup(gomock.Any(), groupID, userIDs).Return(storage.ErrNotFound)
+			},
+			expectedErr: ErrGroupNotFound,
+		},
+		{
+			name: "db error",
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().AddOwnersToGroup(gomock.Any(), groupID, userIDs).Return(dbErr)
+			},
+			expectedErr: fmt.Errorf("failed to add owners to group: %v", dbErr),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockCache, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockCache)
+
+			err := s.AddOwnersToGroup(context.Background(), groupID, userIDs)
+
+			if tc.expectedErr != nil {
+				if err == nil || err.Error() != tc.expectedErr.Error() {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr.Error(), err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestService_SetGroupAttributes(t *testing.T) {
+	groupID := "group-id"
+	attributes := map[string]string{"department": "engineering"}
+	stored := map[string]string{"department": "engineering", "slack": "#identity"}
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		name        string
+		attributes  map[string]string
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface)
+		expected    map[string]string
+		expectedErr error
+	}{
+		{
+			name:       "success",
+			attributes: attributes,
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().SetGroupAttributes(gomock.Any(), groupID, attributes).Return(stored, nil)
+				mockCache.EXPECT().InvalidateGroups(gomock.Any())
+			},
+			expected: stored,
+		},
+		{
+			name:        "invalid key",
+			attributes:  map[string]string{"cost centre": "42"},
+			setupMocks:  func(*MockDatabaseInterface, *MockCacheInvalidatorInterface) {},
+			expectedErr: types.ErrInvalidAttribute,
+		},
+		{
+			name:        "value too long",
+			attributes:  map[string]string{"department": strings.Repeat("x", types.MaxAttributeValueLength+1)},
+			setupMocks:  func(*MockDatabaseInterface, *MockCacheInvalidatorInterface) {},
+			expectedErr: types.ErrInvalidAttribute,
+		},
+		{
+			name:       "group not found",
+			attributes: attributes,
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().SetGroupAttributes(gomock.Any(), groupID, attributes).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: ErrGroupNotFound,
+		},
+		{
+			name:       "db error",
+			attributes: attributes,
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface) {
+				mockStorage.EXPECT().SetGroupAttributes(gomock.Any(), groupID, attributes).Return(nil, dbErr)
+			},
+			expectedErr: dbErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockCache, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockCache)
+
+			updated, err := s.SetGroupAttributes(context.Background(), groupID, tc.attributes)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(updated, tc.expected) {
+				t.Fatalf("expected attributes %v, got %v", tc.expected, updated)
+			}
+		})
+	}
+}
+
+func TestService_RemoveGroupAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockCache := NewMockCacheInvalidatorInterface(ctrl)
+
+	s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), mockCache, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).Times(2)
+	mockStorage.EXPECT().RemoveGroupAttributes(gomock.Any(), "group-id", []string{"slack"}).Return(map[string]string{"department": "engineering"}, nil)
+	mockCache.EXPECT().InvalidateGroups(gomock.Any())
+	mockStorage.EXPECT().RemoveGroupAttributes(gomock.Any(), "missing", []string{"slack"}).Return(nil, storage.ErrNotFound)
+
+	updated, err := s.RemoveGroupAttributes(context.Background(), "group-id", []string{"slack"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updated, map[string]string{"department": "engineering"}) {
+		t.Fatalf("unexpected attributes %v", updated)
+	}
+
+	if _, err := s.RemoveGroupAttributes(context.Background(), "missing", []string{"slack"}); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrGroupNotFound, err)
+	}
+}
+
+func TestService_DelegatedMemberManagement(t *testing.T) {
+	groupID := "group-id"
+	userIDs := []string{"user1"}
+	owner := &authentication.Principal{Subject: "lead", Email: "lead@example.com"}
+
+	testCases := []struct {
+		name        string
+		principal   *authentication.Principal
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface)
+		expectedErr error
+	}{
+		{
+			name:      "admin",
+			principal: &authentication.Principal{Subject: "admin", Admin: true},
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().AddUsersToGroup(gomock.Any(), groupID, userIDs).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "user1")
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "owner",
+			principal: owner,
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"lead", "lead@example.com"}).Return(true, nil)
+				mockStorage.EXPECT().AddUsersToGroup(gomock.Any(), groupID, userIDs).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "user1")
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "not an owner",
+			principal: owner,
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"lead", "lead@example.com"}).Return(false, nil)
+				mockLogger.EXPECT().Security().Return(logging.NewNoopLogger().Security())
+			},
+			expectedErr: ErrNotGroupOwner,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockCache, mockTracer, mockMonitor, mockLogger)
+
+			ctx := authentication.PrincipalToContext(context.Background(), tc.principal)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx))
+			tc.setupMocks(mockStorage, mockCache, mockLogger)
+
+			err := s.AddUsersToGroup(ctx, groupID, userIDs)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_DelegatedMemberRemoval(t *testing.T) {
+	groupID := "group-id"
+	owner := &authentication.Principal{Subject: "lead", Email: "lead@example.com"}
+
+	testCases := []struct {
+		name        string
+		principal   *authentication.Principal
+		users       []string
+		setupMocks  func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface)
+		expectedErr error
+	}{
+		{
+			name:      "owner removes a member",
+			principal: owner,
+			users:     []string{"user1"},
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"lead", "lead@example.com"}).Return(true, nil)
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"user1"}).Return(false, nil)
+				mockStorage.EXPECT().RemoveUsersFromGroup(gomock.Any(), groupID, []string{"user1"}).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "user1")
+			},
+		},
+		{
+			name:      "owner removes another owner",
+			principal: owner,
+			users:     []string{"user1", "lead2"},
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"lead", "lead@example.com"}).Return(true, nil)
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"user1", "lead2"}).Return(true, nil)
+				mockLogger.EXPECT().Security().Return(logging.NewNoopLogger().Security())
+			},
+			expectedErr: ErrOwnerRemoval,
+		},
+		{
+			name:      "owner removes themselves",
+			principal: owner,
+			users:     []string{"lead@example.com"},
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"lead", "lead@example.com"}).Return(true, nil)
+				mockStorage.EXPECT().IsGroupOwner(gomock.Any(), groupID, []string{"lead@example.com"}).Return(true, nil)
+				mockLogger.EXPECT().Security().Return(logging.NewNoopLogger().Security())
+			},
+			expectedErr: ErrOwnerRemoval,
+		},
+		{
+			name:      "admin removes an owner",
+			principal: &authentication.Principal{Subject: "admin", Admin: true},
+			users:     []string{"lead@example.com"},
+			setupMocks: func(mockStorage *MockDatabaseInterface, mockCache *MockCacheInvalidatorInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().RemoveUsersFromGroup(gomock.Any(), groupID, []string{"lead@example.com"}).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "lead@example.com")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockCache := NewMockCacheInvalidatorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockCache, mockTracer, mockMonitor, mockLogger)
+
+			ctx := authentication.PrincipalToContext(context.Background(), tc.principal)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx))
+			tc.setupMocks(mockStorage, mockCache, mockLogger)
+
+			err := s.RemoveUsersFromGroup(ctx, groupID, tc.users)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
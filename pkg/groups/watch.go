@@ -0,0 +1,374 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/hook-service/internal/db"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const (
+	// watchPollInterval is how often a watch reads the new outbox events.
+	watchPollInterval = time.Second
+	// watchHeartbeatInterval is how long a watch stays silent before
+	// sending a heartbeat.
+	watchHeartbeatInterval = 15 * time.Second
+	// watchSettle is how long a watch waits for a missing outbox event ID
+	// to be committed, the transaction timeout with a margin.
+	watchSettle = db.TxTimeout + 5*time.Second
+	// watchBatchSize is the number of outbox events read at once.
+	watchBatchSize = 500
+)
+
+// WatchMemberships calls fn with the direct memberships matching filter,
+// then with their changes until ctx is cancelled or fn fails. Without a
+// cursor the memberships are first sent as snapshot events followed by a
+// snapshot_end one, with a cursor only the changes after it are sent. A
+// change can be sent more than once, applying the events in order converges
+// to the current memberships.
+func (s *Service) WatchMemberships(ctx context.Context, filter *types.MembershipFilter, cursor string, fn func(*types.MembershipEvent) error) error {
+	ctx, span := s.tracer.Start(ctx, "groups.Service.WatchMemberships")
+	defer span.End()
+
+	if filter.GroupID != "" {
+		if _, err := uuid.Parse(filter.GroupID); err != nil {
+			return ErrInvalidGroupID
+		}
+	}
+
+	w := &membershipWatch{
+		s:       s,
+		filter:  filter,
+		fn:      fn,
+		handled: make(map[int64]struct{}),
+		missing: make(map[int64]time.Time),
+	}
+
+	if cursor != "" {
+		low, err := s.decodeWatchCursor(cursor)
+		if err != nil {
+			return err
+		}
+		if err := s.checkWatchCursor(ctx, low); err != nil {
+			return err
+		}
+		w.low, w.high = low, low
+	} else if err := w.snapshot(ctx); err != nil {
+		return err
+	}
+
+	span.SetAttributes(attribute.Int64("watch.cursor", w.low))
+
+	return w.run(ctx)
+}
+
+// SetOutboxRetention sets how long the outbox events are kept, the watch
+// cursors older than it are rejected. 0 keeps the events forever.
+func (s *Service) SetOutboxRetention(d time.Duration) {
+	s.outboxRetention = d
+}
+
+// membershipWatch follows the outbox events of the membership changes for
+// a watcher. Outbox event IDs are allocated before their transaction
+// commits, so an ID can show up after higher ones. The IDs skipped while
+// reading are looked up again until the transaction timeout has passed,
+// after which their transaction is known to have rolled back.
+type membershipWatch struct {
+	s      *Service
+	filter *types.MembershipFilter
+	fn     func(*types.MembershipEvent) error
+
+	// low is the ID up to which every event was handled or given up on,
+	// it is the cursor of the watch.
+	low int64
+	// high is the ID of the latest event read.
+	high int64
+	// handled are the IDs above low already handled.
+	handled map[int64]struct{}
+	// missing are the IDs between low and high not read yet, with the
+	// time they were noticed.
+	missing map[int64]time.Time
+
+	lastSent time.Time
+}
+
+// snapshot sends the current memberships. The watch starts from the latest
+// settled event, the changes read afterwards may already be part of the
+// snapshot.
+func (w *membershipWatch) snapshot(ctx context.Context) error {
+	start, err := w.s.db.GetSettledOutboxEventID(ctx, w.s.watchSettle)
+	if err != nil {
+		return fmt.Errorf("failed to get watch cursor: %w", err)
+	}
+	w.low, w.high = start, start
+
+	filter := *w.filter
+	filter.IncludeDeleted = false
+
+	err = w.s.db.StreamMemberships(ctx, &filter, func(m *types.MembershipEvent) error {
+		m.Type = types.MembershipSnapshot
+		return w.send(m)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream memberships: %w", err)
+	}
+
+	return w.send(&types.MembershipEvent{Type: types.MembershipSnapshotEnd, Time: time.Now().UTC()})
+}
+
+func (w *membershipWatch) run(ctx context.Context) error {
+	ticker := time.NewTicker(w.s.watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil {
+			return err
+		}
+
+		if time.Since(w.lastSent) >= w.s.watchHeartbeatInterval {
+			if err := w.send(&types.MembershipEvent{Type: types.MembershipHeartbeat, Time: time.Now().UTC()}); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll handles the missing events committed since the previous poll, then
+// the new events.
+func (w *membershipWatch) poll(ctx context.Context) error {
+	if len(w.missing) > 0 {
+		ids := make([]int64, 0, len(w.missing))
+		for id := range w.missing {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		if len(ids) > watchBatchSize {
+			ids = ids[:watchBatchSize]
+		}
+
+		events, err := w.s.db.GetOutboxEvents(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to read outbox events: %w", err)
+		}
+		for _, e := range events {
+			if err := w.handle(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		events, err := w.s.db.ListOutboxEvents(ctx, w.high, 0, watchBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read outbox events: %w", err)
+		}
+		for _, e := range events {
+			if err := w.handle(ctx, e); err != nil {
+				return err
+			}
+		}
+		if len(events) < watchBatchSize {
+			break
+		}
+	}
+
+	w.advance(time.Now())
+	return nil
+}
+
+func (w *membershipWatch) handle(ctx context.Context, e *types.OutboxEvent) error {
+	if _, ok := w.handled[e.ID]; ok || e.ID <= w.low {
+		return nil
+	}
+
+	now := time.Now()
+	for id := w.high + 1; id < e.ID; id++ {
+		w.missing[id] = now
+	}
+	delete(w.missing, e.ID)
+	w.handled[e.ID] = struct{}{}
+	w.high = max(w.high, e.ID)
+
+	changes, err := w.changes(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	w.advance(now)
+
+	for _, c := range changes {
+		if err := w.send(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advance moves low past the handled IDs and the IDs missing for longer
+// than the transaction timeout.
+func (w *membershipWatch) advance(now time.Time) {
+	for w.low < w.high {
+		next := w.low + 1
+		if _, ok := w.handled[next]; ok {
+			delete(w.handled, next)
+		} else if noticed, ok := w.missing[next]; ok && now.Sub(noticed) >= w.s.watchSettle {
+			delete(w.missing, next)
+		} else {
+			return
+		}
+		w.low = next
+	}
+}
+
+// changes maps an outbox event to the changes of the watched memberships.
+func (w *membershipWatch) changes(ctx context.Context, e *types.OutboxEvent) ([]*types.MembershipEvent, error) {
+	change := func(t types.MembershipEventType, role types.Role) []*types.MembershipEvent {
+		if !w.filter.Matches(e.TenantID, e.GroupID, e.UserID) {
+			return nil
+		}
+		return []*types.MembershipEvent{{Type: t, TenantID: e.TenantID, GroupID: e.GroupID, UserID: e.UserID, Role: role, Time: e.CreatedAt}}
+	}
+
+	switch e.Type {
+	case types.HistoryMemberAdded:
+		return change(types.MembershipAdded, eventRole(e.After)), nil
+	case types.HistoryMemberRemoved:
+		return change(types.MembershipRemoved, eventRole(e.Before)), nil
+	case types.HistoryOwnerAdded:
+		// An existing member is promoted.
+		if e.Before != nil {
+			return change(types.MembershipUpdated, types.RoleOwner), nil
+		}
+		return change(types.MembershipAdded, types.RoleOwner), nil
+	case types.HistoryOwnerRemoved:
+		return change(types.MembershipUpdated, types.RoleMember), nil
+	case types.HistoryGroupDeleted, types.HistoryGroupRestored:
+		return w.groupChanges(ctx, e)
+	default:
+		return nil, nil
+	}
+}
+
+// groupChanges removes or adds back the memberships of a deleted or restored
+// group, as they are when the event is read.
+func (w *membershipWatch) groupChanges(ctx context.Context, e *types.OutboxEvent) ([]*types.MembershipEvent, error) {
+	if !w.filter.Matches(e.TenantID, e.GroupID, w.filter.UserID) {
+		return nil, nil
+	}
+
+	t := types.MembershipRemoved
+	if e.Type == types.HistoryGroupRestored {
+		t = types.MembershipAdded
+	}
+
+	filter := &types.MembershipFilter{GroupID: e.GroupID, UserID: w.filter.UserID, IncludeDeleted: true}
+	changes := make([]*types.MembershipEvent, 0)
+	err := w.s.db.StreamMemberships(ctx, filter, func(m *types.MembershipEvent) error {
+		m.Type = t
+		m.Time = e.CreatedAt
+		changes = append(changes, m)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream memberships: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (w *membershipWatch) send(e *types.MembershipEvent) error {
+	e.Cursor = encodeWatchCursor(w.low, time.Now())
+	if err := w.fn(e); err != nil {
+		return err
+	}
+	w.lastSent = time.Now()
+	return nil
+}
+
+// eventRole is the role recorded in the values of an outbox event.
+func eventRole(values map[string]interface{}) types.Role {
+	s, _ := values["role"].(string)
+	role, _ := types.ParseRole(s)
+	return role
+}
+
+// encodeWatchCursor encodes the low ID of a watch with the time it was
+// reached, to reject the cursors whose events may have been purged.
+func encodeWatchCursor(id int64, at time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10) + "." + strconv.FormatInt(at.Unix(), 10)))
+}
+
+func (s *Service) decodeWatchCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	idPart, atPart, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+	at, err := strconv.ParseInt(atPart, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	// The events above the cursor were written at most the settle delay
+	// before it was issued.
+	if s.outboxRetention > 0 && time.Since(time.Unix(at, 0)) > s.outboxRetention-s.watchSettle {
+		return 0, ErrExpiredCursor
+	}
+
+	return id, nil
+}
+
+// checkWatchCursor rejects the cursor IDs a watch cannot have reached: those
+// above the latest event, and those below the events still retained, since
+// the IDs purged after them would all be waited on as missing.
+func (s *Service) checkWatchCursor(ctx context.Context, id int64) error {
+	latest, err := s.db.GetSettledOutboxEventID(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to check watch cursor: %w", err)
+	}
+	if id > latest {
+		return ErrInvalidCursor
+	}
+
+	if s.outboxRetention <= 0 {
+		return nil
+	}
+
+	// Every event written before a cursor was issued, less the settle
+	// delay, is below it, and a cursor is at most retention old.
+	oldest, err := s.db.GetSettledOutboxEventID(ctx, s.outboxRetention+s.watchSettle)
+	if err != nil {
+		return fmt.Errorf("failed to check watch cursor: %w", err)
+	}
+	if id < oldest {
+		return ErrExpiredCursor
+	}
+
+	return nil
+}
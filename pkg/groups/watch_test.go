@@ -0,0 +1,234 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestService_WatchMemberships(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	s.watchPollInterval = time.Millisecond
+
+	groupID := "0198f0a2-7c3e-7d4b-9a1e-3f2b6c8d9e01"
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+			return ctx, trace.SpanFromContext(ctx)
+		},
+	)
+
+	mockStorage.EXPECT().GetSettledOutboxEventID(gomock.Any(), watchSettle).Return(int64(10), nil)
+	mockStorage.EXPECT().StreamMemberships(gomock.Any(), &types.MembershipFilter{TenantID: "acme"}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.MembershipFilter, fn func(*types.MembershipEvent) error) error {
+			return fn(&types.MembershipEvent{TenantID: "acme", GroupID: groupID, UserID: "alice", Role: types.RoleOwner})
+		},
+	)
+
+	// Event 12 commits after 13, event 14 is in another tenant.
+	polls := [][]*types.OutboxEvent{
+		{
+			{ID: 11, TenantID: "acme", Type: types.HistoryMemberAdded, GroupID: groupID, UserID: "bob", After: map[string]interface{}{"role": "member"}},
+			{ID: 13, TenantID: "acme", Type: types.HistoryOwnerAdded, GroupID: groupID, UserID: "bob", Before: map[string]interface{}{"role": "member"}},
+		},
+		{
+			{ID: 14, TenantID: "other", Type: types.HistoryMemberAdded, GroupID: groupID, UserID: "carol"},
+		},
+	}
+	mockStorage.EXPECT().ListOutboxEvents(gomock.Any(), gomock.Any(), time.Duration(0), uint64(watchBatchSize)).DoAndReturn(
+		func(_ context.Context, afterID int64, _ time.Duration, _ uint64) ([]*types.OutboxEvent, error) {
+			if len(polls) == 0 {
+				return nil, nil
+			}
+			events := polls[0]
+			polls = polls[1:]
+			return events, nil
+		},
+	).AnyTimes()
+	mockStorage.EXPECT().GetOutboxEvents(gomock.Any(), []int64{12}).Return([]*types.OutboxEvent{
+		{ID: 12, TenantID: "acme", Type: types.HistoryMemberRemoved, GroupID: groupID, UserID: "alice", Before: map[string]interface{}{"role": "owner"}},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make([]*types.MembershipEvent, 0)
+	err := s.WatchMemberships(ctx, &types.MembershipFilter{TenantID: "acme"}, "", func(e *types.MembershipEvent) error {
+		events = append(events, e)
+		if e.Type == types.MembershipRemoved {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		eventType types.MembershipEventType
+		userID    string
+		role      types.Role
+		cursor    int64
+	}{
+		{types.MembershipSnapshot, "alice", types.RoleOwner, 10},
+		{types.MembershipSnapshotEnd, "", types.RoleMember, 10},
+		{types.MembershipAdded, "bob", types.RoleMember, 11},
+		{types.MembershipUpdated, "bob", types.RoleOwner, 11},
+		{types.MembershipRemoved, "alice", types.RoleOwner, 13},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(events))
+	}
+	for i, e := range expected {
+		got := events[i]
+		cursor, err := s.decodeWatchCursor(got.Cursor)
+		if err != nil {
+			t.Fatalf("event %d: unexpected cursor error: %v", i, err)
+		}
+		if got.Type != e.eventType || got.UserID != e.userID || got.Role != e.role || cursor != e.cursor {
+			t.Errorf("event %d: expected %s %q %s at %d, got %s %q %s at %d", i, e.eventType, e.userID, e.role, e.cursor, got.Type, got.UserID, got.Role, cursor)
+		}
+	}
+}
+
+func TestService_WatchMembershipsGroupDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+
+	s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	s.watchPollInterval = time.Millisecond
+
+	groupID := "0198f0a2-7c3e-7d4b-9a1e-3f2b6c8d9e01"
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+			return ctx, trace.SpanFromContext(ctx)
+		},
+	)
+
+	mockStorage.EXPECT().GetSettledOutboxEventID(gomock.Any(), time.Duration(0)).Return(int64(21), nil)
+	mockStorage.EXPECT().ListOutboxEvents(gomock.Any(), int64(20), time.Duration(0), uint64(watchBatchSize)).Return([]*types.OutboxEvent{
+		{ID: 21, TenantID: "acme", Type: types.HistoryGroupDeleted, GroupID: groupID},
+	}, nil)
+	mockStorage.EXPECT().ListOutboxEvents(gomock.Any(), int64(21), time.Duration(0), uint64(watchBatchSize)).Return(nil, nil).AnyTimes()
+	mockStorage.EXPECT().StreamMemberships(gomock.Any(), &types.MembershipFilter{GroupID: groupID, UserID: "bob", IncludeDeleted: true}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.MembershipFilter, fn func(*types.MembershipEvent) error) error {
+			return fn(&types.MembershipEvent{TenantID: "acme", GroupID: groupID, UserID: "bob"})
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var removed *types.MembershipEvent
+	err := s.WatchMemberships(ctx, &types.MembershipFilter{UserID: "bob"}, encodeWatchCursor(20, time.Now()), func(e *types.MembershipEvent) error {
+		removed = e
+		cancel()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed == nil || removed.Type != types.MembershipRemoved || removed.GroupID != groupID || removed.UserID != "bob" {
+		t.Fatalf("expected bob to be removed from the group, got %+v", removed)
+	}
+}
+
+func TestService_WatchMembershipsInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        *types.MembershipFilter
+		cursor        string
+		setupMocks    func(mockStorage *MockDatabaseInterface)
+		expectedError error
+	}{
+		{name: "Invalid group ID", filter: &types.MembershipFilter{GroupID: "group-1"}, expectedError: ErrInvalidGroupID},
+		{name: "Malformed cursor", filter: &types.MembershipFilter{}, cursor: "not a cursor", expectedError: ErrInvalidCursor},
+		{name: "Cursor without time", filter: &types.MembershipFilter{}, cursor: "MTI", expectedError: ErrInvalidCursor},
+		{name: "Expired cursor", filter: &types.MembershipFilter{}, cursor: encodeWatchCursor(12, time.Now().Add(-48*time.Hour)), expectedError: ErrExpiredCursor},
+		{
+			name:   "Forged cursor below the retained events",
+			filter: &types.MembershipFilter{},
+			cursor: encodeWatchCursor(0, time.Now()),
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetSettledOutboxEventID(gomock.Any(), time.Duration(0)).Return(int64(5000000), nil)
+				mockStorage.EXPECT().GetSettledOutboxEventID(gomock.Any(), 24*time.Hour+watchSettle).Return(int64(4000000), nil)
+			},
+			expectedError: ErrExpiredCursor,
+		},
+		{
+			name:   "Forged cursor above the latest event",
+			filter: &types.MembershipFilter{},
+			cursor: encodeWatchCursor(5000001, time.Now()),
+			setupMocks: func(mockStorage *MockDatabaseInterface) {
+				mockStorage.EXPECT().GetSettledOutboxEventID(gomock.Any(), time.Duration(0)).Return(int64(5000000), nil)
+			},
+			expectedError: ErrInvalidCursor,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockDatabaseInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			s := NewService(mockStorage, NewMockAuthorizerInterface(ctrl), nil, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+			s.SetOutboxRetention(24 * time.Hour)
+
+			if test.setupMocks != nil {
+				test.setupMocks(mockStorage)
+			}
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+			err := s.WatchMemberships(context.TODO(), test.filter, test.cursor, func(*types.MembershipEvent) error {
+				t.Fatal("expected no event")
+				return nil
+			})
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestMembershipWatchAdvance(t *testing.T) {
+	now := time.Now()
+	w := &membershipWatch{
+		s:       &Service{watchSettle: time.Minute},
+		low:     10,
+		high:    15,
+		handled: map[int64]struct{}{11: {}, 13: {}, 15: {}},
+		missing: map[int64]time.Time{12: now.Add(-2 * time.Minute), 14: now},
+	}
+
+	w.advance(now)
+
+	if w.low != 13 {
+		t.Fatalf("expected the cursor to stop before the pending ID 14, got %d", w.low)
+	}
+	if _, ok := w.missing[14]; !ok || len(w.missing) != 1 {
+		t.Fatalf("expected only 14 to be missing, got %v", w.missing)
+	}
+	if _, ok := w.handled[15]; !ok || len(w.handled) != 1 {
+		t.Fatalf("expected only 15 to be handled above the cursor, got %v", w.handled)
+	}
+}
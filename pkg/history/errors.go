@@ -0,0 +1,8 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package history
+
+import "errors"
+
+var ErrInvalidGroupID = errors.New("invalid group ID")
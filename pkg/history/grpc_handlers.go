@@ -0,0 +1,158 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package history
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/history/v1"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var _ pb.HistoryServiceServer = (*GrpcServer)(nil)
+
+type GrpcServer struct {
+	svc ServiceInterface
+	pb.UnimplementedHistoryServiceServer
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (g *GrpcServer) ListGroupHistory(ctx context.Context, req *pb.ListGroupHistoryReq) (*pb.ListHistoryResp, error) {
+	ctx, span := g.tracer.Start(ctx, "history.GrpcServer.ListGroupHistory")
+	defer span.End()
+
+	opts := &ListOptions{
+		GroupID:   req.GetGroupId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.GetSince() != nil {
+		opts.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		opts.Until = req.GetUntil().AsTime()
+	}
+
+	return g.listHistory(ctx, span, opts)
+}
+
+func (g *GrpcServer) ListUserHistory(ctx context.Context, req *pb.ListUserHistoryReq) (*pb.ListHistoryResp, error) {
+	ctx, span := g.tracer.Start(ctx, "history.GrpcServer.ListUserHistory")
+	defer span.End()
+
+	opts := &ListOptions{
+		UserID:    req.GetUserId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.GetSince() != nil {
+		opts.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		opts.Until = req.GetUntil().AsTime()
+	}
+
+	return g.listHistory(ctx, span, opts)
+}
+
+func (g *GrpcServer) listHistory(ctx context.Context, span trace.Span, opts *ListOptions) (*pb.ListHistoryResp, error) {
+	entries, next, err := g.svc.ListHistory(ctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "list history failed")
+		return nil, g.mapErrorToStatus(err, "list history")
+	}
+
+	data := make([]*pb.HistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		entry, err := toProto(e)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, "list history failed")
+			return nil, g.mapErrorToStatus(err, "list history")
+		}
+		data = append(data, entry)
+	}
+
+	span.SetAttributes(attribute.Int("history.count", len(data)))
+	span.SetStatus(otelcodes.Ok, "history listed successfully")
+
+	return &pb.ListHistoryResp{
+		Data:          data,
+		Status:        http.StatusOK,
+		Message:       proto.String("History"),
+		NextPageToken: next,
+	}, nil
+}
+
+func (g *GrpcServer) mapErrorToStatus(err error, action string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrInvalidGroupID):
+		return status.Errorf(codes.InvalidArgument, "invalid group ID")
+	case errors.Is(err, types.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "invalid page token")
+	case errors.Is(err, types.ErrInvalidPageSize):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, types.ErrInvalidTimeRange):
+		return status.Errorf(codes.InvalidArgument, "since must be before until")
+	default:
+		g.logger.Errorf("Unhandled error in %s: %v", action, err)
+		return status.Errorf(codes.Internal, "%s failed", action)
+	}
+}
+
+func toProto(e *types.HistoryEntry) (*pb.HistoryEntry, error) {
+	entry := &pb.HistoryEntry{
+		Id:        e.ID,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		TenantId:  e.TenantID,
+		GroupId:   e.GroupID,
+		UserId:    e.UserID,
+		Action:    string(e.Action),
+		Actor:     e.Actor,
+		Source:    e.Source,
+	}
+
+	var err error
+	if e.Before != nil {
+		if entry.Before, err = structpb.NewStruct(e.Before); err != nil {
+			return nil, err
+		}
+	}
+	if e.After != nil {
+		if entry.After, err = structpb.NewStruct(e.After); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+func NewGrpcServer(svc ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *GrpcServer {
+	return &GrpcServer{
+		svc:     svc,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/canonical/hook-service/gen/hook/history/v1"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestGrpcHandler_ListGroupHistory(t *testing.T) {
+	groupID := "0195c4a6-1f5e-7a1c-9f44-2b8e0d5a6c11"
+	since := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	entry := &types.HistoryEntry{
+		ID:        42,
+		CreatedAt: since.Add(14 * time.Minute),
+		GroupID:   groupID,
+		Action:    types.HistoryGroupUpdated,
+		Actor:     "alice",
+		Source:    "api",
+		Before:    map[string]interface{}{"name": "engineering"},
+		After:     map[string]interface{}{"name": "platform"},
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.ListGroupHistoryReq
+
+		expectedOpts *ListOptions
+		svcResult    []*types.HistoryEntry
+		svcNext      string
+		svcErr       error
+
+		wantCode codes.Code
+	}{
+		{
+			name:         "List the history of a group in a time range",
+			req:          &pb.ListGroupHistoryReq{GroupId: groupID, Since: timestamppb.New(since), PageSize: 10},
+			expectedOpts: &ListOptions{GroupID: groupID, Since: since, PageSize: 10},
+			svcResult:    []*types.HistoryEntry{entry},
+			svcNext:      "next",
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "Invalid group ID",
+			req:          &pb.ListGroupHistoryReq{GroupId: "engineering"},
+			expectedOpts: &ListOptions{GroupID: "engineering"},
+			svcErr:       ErrInvalidGroupID,
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name:         "Internal error",
+			req:          &pb.ListGroupHistoryReq{GroupId: groupID},
+			expectedOpts: &ListOptions{GroupID: groupID},
+			svcErr:       errors.New("connection refused"),
+			wantCode:     codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			mockSvc.EXPECT().ListHistory(gomock.Any(), test.expectedOpts).Return(test.svcResult, test.svcNext, test.svcErr)
+
+			server := NewGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			resp, err := server.ListGroupHistory(context.TODO(), test.req)
+
+			if code := status.Code(err); code != test.wantCode {
+				t.Fatalf("expected code %v, got %v (%v)", test.wantCode, code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.GetNextPageToken() != test.svcNext {
+				t.Fatalf("expected next page token %q, got %q", test.svcNext, resp.GetNextPageToken())
+			}
+			if len(resp.GetData()) != 1 {
+				t.Fatalf("expected 1 entry, got %d", len(resp.GetData()))
+			}
+			e := resp.GetData()[0]
+			if e.GetId() != 42 || e.GetAction() != "group.updated" || e.GetActor() != "alice" {
+				t.Fatalf("unexpected entry %v", e)
+			}
+			if e.GetBefore().AsMap()["name"] != "engineering" || e.GetAfter().AsMap()["name"] != "platform" {
+				t.Fatalf("unexpected values %v -> %v", e.GetBefore(), e.GetAfter())
+			}
+		})
+	}
+}
+
+func TestGrpcHandler_ListUserHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), "history.GrpcServer.ListUserHistory").Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+	entries := []*types.HistoryEntry{{ID: 7, UserID: "alice", Action: types.HistoryMemberAdded, After: map[string]interface{}{"role": "member"}}}
+	mockSvc.EXPECT().ListHistory(gomock.Any(), &ListOptions{UserID: "alice"}).Return(entries, "", nil)
+
+	server := NewGrpcServer(mockSvc, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	resp, err := server.ListUserHistory(context.TODO(), &pb.ListUserHistoryReq{UserId: "alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetData()) != 1 || resp.GetData()[0].GetBefore() != nil || resp.GetData()[0].GetAfter().AsMap()["role"] != "member" {
+		t.Fatalf("unexpected entries %v", resp.GetData())
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package history
+
+import (
+	"context"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+type ServiceInterface interface {
+	ListHistory(context.Context, *ListOptions) ([]*types.HistoryEntry, string, error)
+}
+
+type DatabaseInterface interface {
+	ListHistory(context.Context, *types.HistoryFilter) ([]*types.HistoryEntry, error)
+}
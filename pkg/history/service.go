@@ -0,0 +1,110 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+var _ ServiceInterface = (*Service)(nil)
+
+// ListOptions filters and paginates the history, empty fields match every
+// entry.
+type ListOptions struct {
+	GroupID   string
+	UserID    string
+	Since     time.Time
+	Until     time.Time
+	PageSize  int
+	PageToken string
+}
+
+type Service struct {
+	db DatabaseInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// ListHistory returns a page of the history of a group or a user, newest
+// first, and the token of the next page or an empty string on the last
+// page.
+func (s *Service) ListHistory(ctx context.Context, opts *ListOptions) ([]*types.HistoryEntry, string, error) {
+	ctx, span := s.tracer.Start(ctx, "history.Service.ListHistory")
+	defer span.End()
+
+	if opts.GroupID != "" {
+		if _, err := uuid.Parse(opts.GroupID); err != nil {
+			return nil, "", ErrInvalidGroupID
+		}
+	}
+
+	size, err := types.PageSize(opts.PageSize, DefaultPageSize, MaxPageSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && !opts.Since.Before(opts.Until) {
+		return nil, "", types.ErrInvalidTimeRange
+	}
+
+	beforeID, err := types.ParseIDPageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	span.SetAttributes(
+		attribute.String("group.id", opts.GroupID),
+		attribute.String("user.id", opts.UserID),
+		attribute.Int("page.size", size),
+	)
+
+	// Fetch one more entry than requested to know if there is a next page.
+	entries, err := s.db.ListHistory(ctx, &types.HistoryFilter{
+		GroupID:  opts.GroupID,
+		UserID:   opts.UserID,
+		Since:    opts.Since,
+		Until:    opts.Until,
+		BeforeID: beforeID,
+		Limit:    uint64(size) + 1,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(entries) > size {
+		entries = entries[:size]
+		next = types.IDPageToken(entries[size-1].ID)
+	}
+
+	return entries, next, nil
+}
+
+func NewService(db DatabaseInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Service {
+	s := new(Service)
+
+	s.db = db
+
+	s.monitor = monitor
+	s.tracer = tracer
+	s.logger = logger
+
+	return s
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package history
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package history -destination ./mock_history.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package history -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package history -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package history -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestServiceListHistory(t *testing.T) {
+	groupID := "0195c4a6-1f5e-7a1c-9f44-2b8e0d5a6c11"
+	since := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+
+	page := []*types.HistoryEntry{{ID: 9}, {ID: 8}, {ID: 7}}
+
+	tests := []struct {
+		name string
+		opts *ListOptions
+
+		expectedFilter *types.HistoryFilter
+		dbResult       []*types.HistoryEntry
+
+		expected      []*types.HistoryEntry
+		expectedNext  string
+		expectedError error
+	}{
+		{
+			name:           "Group filter",
+			opts:           &ListOptions{GroupID: groupID},
+			expectedFilter: &types.HistoryFilter{GroupID: groupID, Limit: DefaultPageSize + 1},
+			dbResult:       page,
+			expected:       page,
+		},
+		{
+			name:           "User and time range filters",
+			opts:           &ListOptions{UserID: "alice", Since: since, Until: until, PageSize: 2},
+			expectedFilter: &types.HistoryFilter{UserID: "alice", Since: since, Until: until, Limit: 3},
+			dbResult:       page,
+			expected:       page[:2],
+			expectedNext:   types.IDPageToken(8),
+		},
+		{
+			name:           "Next page holds the older entries",
+			opts:           &ListOptions{UserID: "alice", PageSize: 2, PageToken: types.IDPageToken(8)},
+			expectedFilter: &types.HistoryFilter{UserID: "alice", BeforeID: 8, Limit: 3},
+			dbResult:       page[2:],
+			expected:       page[2:],
+		},
+		{
+			name:          "Invalid group ID",
+			opts:          &ListOptions{GroupID: "engineering"},
+			expectedError: ErrInvalidGroupID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := NewMockDatabaseInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+			if test.expectedFilter != nil {
+				mockDB.EXPECT().ListHistory(gomock.Any(), test.expectedFilter).Return(test.dbResult, nil)
+			}
+
+			s := NewService(mockDB, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+			entries, next, err := s.ListHistory(context.TODO(), test.opts)
+
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(entries, test.expected) {
+				t.Fatalf("expected entries %v, got %v", test.expected, entries)
+			}
+			if next != test.expectedNext {
+				t.Fatalf("expected next page token %q, got %q", test.expectedNext, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/canonical/hook-service/internal/cache"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const keySeparator = "\x00"
+
+// DecisionCache caches user groups and OpenFGA decisions on the token hook hot
+// path. A nil DecisionCache is valid and caches nothing, so callers do not
+// need to check whether caching is enabled.
+type DecisionCache struct {
+	groups    *cache.Cache[[]*types.Group]
+	decisions *cache.Cache[bool]
+
+	logger logging.LoggerInterface
+}
+
+var _ storage.ChangeHandlerInterface = (*DecisionCache)(nil)
+
+// Client wraps a groups client so that its results are cached per user.
+func (c *DecisionCache) Client(client ClientInterface) ClientInterface {
+	if c == nil {
+		return client
+	}
+	return &cachedClient{client: client, cache: c}
+}
+
+// Authorizer wraps an authorizer so that its decisions are cached.
+func (c *DecisionCache) Authorizer(authz AuthorizerInterface) AuthorizerInterface {
+	if c == nil {
+		return authz
+	}
+	return &cachedAuthorizer{authz: authz, cache: c}
+}
+
+// InvalidateUsers drops the cached groups of the given users, it is called
+// when their memberships change.
+func (c *DecisionCache) InvalidateUsers(ctx context.Context, userIDs ...string) {
+	if c == nil {
+		return
+	}
+	c.logger.Debugf("invalidating cached groups for %d users", len(userIDs))
+	c.groups.Delete(userIDs...)
+}
+
+// InvalidateGroups drops every cached group and decision, it is called when
+// a group is updated or deleted as the users it affects are not known.
+func (c *DecisionCache) InvalidateGroups(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.logger.Debug("invalidating all cached groups and decisions")
+	c.groups.Purge()
+	c.decisions.Purge()
+}
+
+// InvalidateDecisions drops every cached decision, it is called when app
+// grants change.
+func (c *DecisionCache) InvalidateDecisions(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.logger.Debug("invalidating all cached decisions")
+	c.decisions.Purge()
+}
+
+// HandleChange invalidates the entries affected by a write made by any
+// instance, see storage.ChangesChannel.
+func (c *DecisionCache) HandleChange(ctx context.Context, change *storage.Change) {
+	switch {
+	case change.Kind == storage.ChangeKindMemberships && len(change.UserIDs) > 0:
+		c.InvalidateUsers(ctx, change.UserIDs...)
+	case change.Kind == storage.ChangeKindAppGrants:
+		c.InvalidateDecisions(ctx)
+	default:
+		// Group changes, memberships of unknown users and missed
+		// notifications may affect any entry.
+		c.InvalidateGroups(ctx)
+	}
+}
+
+// NewDecisionCache creates a cache holding at most size entries per kind for
+// ttl. It returns nil, disabling caching, when ttl or size is not positive.
+func NewDecisionCache(size int, ttl time.Duration, logger logging.LoggerInterface) *DecisionCache {
+	if ttl <= 0 || size <= 0 {
+		return nil
+	}
+
+	c := new(DecisionCache)
+	c.groups = cache.NewCache[[]*types.Group]("hook_groups", size, ttl, logger)
+	c.decisions = cache.NewCache[bool]("hook_decisions", size, ttl, logger)
+	c.logger = logger
+
+	return c
+}
+
+var _ ClientInterface = (*cachedClient)(nil)
+
+type cachedClient struct {
+	client ClientInterface
+	cache  *DecisionCache
+}
+
+func (c *cachedClient) FetchUserGroups(ctx context.Context, user User) ([]*types.Group, error) {
+	userID := user.storageId()
+	if userID == "" {
+		return c.client.FetchUserGroups(ctx, user)
+	}
+
+	if groups, ok := c.cache.groups.Get(userID); ok {
+		return groups, nil
+	}
+
+	groups, err := c.client.FetchUserGroups(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.groups.Set(userID, groups)
+	return groups, nil
+}
+
+var _ AuthorizerInterface = (*cachedAuthorizer)(nil)
+
+type cachedAuthorizer struct {
+	authz AuthorizerInterface
+	cache *DecisionCache
+}
+
+func (a *cachedAuthorizer) CanAccess(ctx context.Context, userID, clientID string, groups []string) (bool, error) {
+	return a.cached(decisionKey("check", userID, []string{clientID}, groups), func() (bool, error) {
+		return a.authz.CanAccess(ctx, userID, clientID, groups)
+	})
+}
+
+func (a *cachedAuthorizer) BatchCanAccess(ctx context.Context, userID string, clientIDs []string, groups []string) (bool, error) {
+	return a.cached(decisionKey("batch", userID, clientIDs, groups), func() (bool, error) {
+		return a.authz.BatchCanAccess(ctx, userID, clientIDs, groups)
+	})
+}
+
+func (a *cachedAuthorizer) HasAllowedGroups(ctx context.Context, clientID string) (bool, error) {
+	return a.cached(decisionKey("bound", "", []string{clientID}, nil), func() (bool, error) {
+		return a.authz.HasAllowedGroups(ctx, clientID)
+	})
+}
+
+// cached only stores successful decisions so that errors are retried and
+// still go through the policy error mode.
+func (a *cachedAuthorizer) cached(key string, fn func() (bool, error)) (bool, error) {
+	if allowed, ok := a.cache.decisions.Get(key); ok {
+		return allowed, nil
+	}
+
+	allowed, err := fn()
+	if err != nil {
+		return false, err
+	}
+
+	a.cache.decisions.Set(key, allowed)
+	return allowed, nil
+}
+
+// decisionKey builds an order independent key for a decision.
+func decisionKey(kind, userID string, clientIDs, groups []string) string {
+	clientIDs = slices.Sorted(slices.Values(clientIDs))
+	groups = slices.Sorted(slices.Values(groups))
+
+	return strings.Join(
+		[]string{kind, userID, strings.Join(clientIDs, ","), strings.Join(groups, ",")},
+		keySeparator,
+	)
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewDecisionCacheDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := NewDecisionCache(100, 0, logging.NewNoopLogger())
+	if c != nil {
+		t.Fatal("expected a zero TTL to disable the cache")
+	}
+
+	client := NewMockClientInterface(ctrl)
+	authz := NewMockAuthorizerInterface(ctrl)
+
+	if c.Client(client) != client {
+		t.Fatal("expected a disabled cache to return the client unchanged")
+	}
+	if c.Authorizer(authz) != authz {
+		t.Fatal("expected a disabled cache to return the authorizer unchanged")
+	}
+
+	// Invalidating a disabled cache is a noop.
+	c.InvalidateUsers(context.TODO(), "user")
+	c.InvalidateGroups(context.TODO())
+	c.InvalidateDecisions(context.TODO())
+}
+
+func TestDecisionCacheClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := User{SubjectId: "123", Email: "a@a.com"}
+	groups := []*types.Group{{ID: "group-1", Name: "Engineering"}}
+
+	client := NewMockClientInterface(ctrl)
+	client.EXPECT().FetchUserGroups(gomock.Any(), user).Times(1).Return(nil, errors.New("database error"))
+	client.EXPECT().FetchUserGroups(gomock.Any(), user).Times(2).Return(groups, nil)
+
+	c := NewDecisionCache(100, time.Minute, logging.NewNoopLogger())
+	cached := c.Client(client)
+
+	if _, err := cached.FetchUserGroups(context.TODO(), user); err == nil {
+		t.Fatal("expected error not to be cached")
+	}
+
+	for i := 0; i < 2; i++ {
+		r, err := cached.FetchUserGroups(context.TODO(), user)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(r, groups) {
+			t.Fatalf("expected groups to be %v not %v", groups, r)
+		}
+	}
+
+	c.InvalidateUsers(context.TODO(), user.Email)
+
+	if _, err := cached.FetchUserGroups(context.TODO(), user); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDecisionCacheAuthorizer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authz := NewMockAuthorizerInterface(ctrl)
+	authz.EXPECT().CanAccess(gomock.Any(), "user", "client", []string{"b", "a"}).Times(1).Return(true, nil)
+	authz.EXPECT().BatchCanAccess(gomock.Any(), "user", []string{"c1", "c2"}, nil).Times(1).Return(false, nil)
+	authz.EXPECT().HasAllowedGroups(gomock.Any(), "client").Times(1).Return(false, errors.New("openfga error"))
+	authz.EXPECT().HasAllowedGroups(gomock.Any(), "client").Times(2).Return(true, nil)
+
+	c := NewDecisionCache(100, time.Minute, logging.NewNoopLogger())
+	cached := c.Authorizer(authz)
+
+	for _, groups := range [][]string{{"b", "a"}, {"a", "b"}} {
+		allowed, err := cached.CanAccess(context.TODO(), "user", "client", groups)
+		if err != nil || !allowed {
+			t.Fatalf("expected cached check to allow, got %v, %v", allowed, err)
+		}
+	}
+
+	for _, clientIDs := range [][]string{{"c1", "c2"}, {"c2", "c1"}} {
+		allowed, err := cached.BatchCanAccess(context.TODO(), "user", clientIDs, nil)
+		if err != nil || allowed {
+			t.Fatalf("expected cached batch check to deny, got %v, %v", allowed, err)
+		}
+	}
+
+	if _, err := cached.HasAllowedGroups(context.TODO(), "client"); err == nil {
+		t.Fatal("expected error not to be cached")
+	}
+	if _, err := cached.HasAllowedGroups(context.TODO(), "client"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c.InvalidateDecisions(context.TODO())
+
+	if _, err := cached.HasAllowedGroups(context.TODO(), "client"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDecisionCacheHandleChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change *storage.Change
+
+		groupsCached    bool
+		decisionsCached bool
+	}{
+		{
+			name:            "Memberships of known users",
+			change:          &storage.Change{Kind: storage.ChangeKindMemberships, UserIDs: []string{"user"}},
+			decisionsCached: true,
+		},
+		{
+			name:            "Memberships of unknown users",
+			change:          &storage.Change{Kind: storage.ChangeKindMemberships, GroupID: "group"},
+			decisionsCached: false,
+		},
+		{
+			name:         "App grants",
+			change:       &storage.Change{Kind: storage.ChangeKindAppGrants, GroupID: "group"},
+			groupsCached: true,
+		},
+		{
+			name:   "Group",
+			change: &storage.Change{Kind: storage.ChangeKindGroup, GroupID: "group"},
+		},
+		{
+			name:   "Resync",
+			change: &storage.Change{Kind: storage.ChangeKindResync},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewDecisionCache(100, time.Minute, logging.NewNoopLogger())
+			c.groups.Set("user", nil)
+			c.decisions.Set("decision", true)
+
+			c.HandleChange(context.TODO(), test.change)
+
+			if _, ok := c.groups.Get("user"); ok != test.groupsCached {
+				t.Fatalf("expected groups cached to be %v not %v", test.groupsCached, ok)
+			}
+			if _, ok := c.decisions.Get("decision"); ok != test.decisionsCached {
+				t.Fatalf("expected decisions cached to be %v not %v", test.decisionsCached, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/ory/hydra/v2/oauth2"
+)
+
+// ClaimSource identifies which value of the hook context a claim is built from.
+type ClaimSource string
+
+const (
+	// ClaimSourceGroupNames emits the deduplicated list of group names.
+	ClaimSourceGroupNames ClaimSource = "group_names"
+	// ClaimSourceGroupIDs emits the deduplicated list of group IDs.
+	ClaimSourceGroupIDs ClaimSource = "group_ids"
+	// ClaimSourceTenantGroupNames emits the deduplicated list of `tenant/name` pairs.
+	ClaimSourceTenantGroupNames ClaimSource = "tenant_group_names"
+	// ClaimSourceTenantID emits the tenant the request is scoped to, if any.
+	ClaimSourceTenantID ClaimSource = "tenant_id"
+	// ClaimSourceTenants emits the tenants the user is an active member of.
+	ClaimSourceTenants ClaimSource = "tenants"
+	// ClaimSourceGroupAttribute emits the deduplicated list of the values of
+	// an attribute of the groups, groups without the attribute are skipped.
+	ClaimSourceGroupAttribute ClaimSource = "group_attribute"
+)
+
+// ClaimToken identifies the token a claim is written to.
+type ClaimToken string
+
+const (
+	ClaimTokenAccess ClaimToken = "access_token"
+	ClaimTokenID     ClaimToken = "id_token"
+)
+
+// registeredClaims are set by the authorization server, a mapping would
+// overwrite them.
+var registeredClaims = []string{"sub", "iss", "aud", "exp", "iat", "nbf", "jti"}
+
+var (
+	ErrInvalidClaimMapping = errors.New("invalid claim mapping")
+)
+
+// ClaimMapping declares a single claim emitted by the token hook.
+type ClaimMapping struct {
+	// Name is the claim name, it may be a namespaced URI (e.g. https://example.com/groups).
+	Name string `json:"name"`
+	// Source is the hook context value the claim holds.
+	Source ClaimSource `json:"source"`
+	// Attribute is the group attribute of the group_attribute source.
+	Attribute string `json:"attribute,omitempty"`
+	// Tokens lists the tokens the claim is written to, both when empty.
+	Tokens []ClaimToken `json:"tokens,omitempty"`
+}
+
+// DefaultClaimMappings returns the mappings matching the historical behaviour
+// of the hook: group names under `groups` and the tenant under `tenant_id`,
+// both written to the access token and the ID token.
+func DefaultClaimMappings() []ClaimMapping {
+	return []ClaimMapping{
+		{Name: "groups", Source: ClaimSourceGroupNames},
+		{Name: "tenant_id", Source: ClaimSourceTenantID},
+	}
+}
+
+// ParseClaimMappings decodes a JSON array of claim mappings, an empty string
+// yields the default mappings.
+func ParseClaimMappings(raw string) ([]ClaimMapping, error) {
+	if raw == "" {
+		return DefaultClaimMappings(), nil
+	}
+
+	mappings := make([]ClaimMapping, 0)
+	if err := json.Unmarshal([]byte(raw), &mappings); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidClaimMapping, err)
+	}
+
+	return mappings, nil
+}
+
+// ClaimMapper projects a HookContext onto the access and ID token claims.
+type ClaimMapper struct {
+	mappings []ClaimMapping
+}
+
+// Apply writes the mapped claims into the hook response session, overwriting
+// any existing claim with the same name.
+func (m *ClaimMapper) Apply(resp *oauth2.TokenHookResponse, hctx *HookContext) {
+	for _, mapping := range m.mappings {
+		value, ok := claimValue(mapping, hctx)
+		if !ok {
+			continue
+		}
+
+		for _, t := range mapping.targets() {
+			switch t {
+			case ClaimTokenAccess:
+				resp.Session.AccessToken[mapping.Name] = value
+			case ClaimTokenID:
+				resp.Session.IDToken[mapping.Name] = value
+			}
+		}
+	}
+}
+
+// Uses reports whether a claim is built from the given source.
+func (m *ClaimMapper) Uses(source ClaimSource) bool {
+	if m == nil {
+		return false
+	}
+	for _, mapping := range m.mappings {
+		if mapping.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
+func (c ClaimMapping) targets() []ClaimToken {
+	if len(c.Tokens) == 0 {
+		return []ClaimToken{ClaimTokenAccess, ClaimTokenID}
+	}
+	return c.Tokens
+}
+
+// claimValue computes the value of a claim, the boolean is false when the
+// claim must not be emitted.
+func claimValue(mapping ClaimMapping, hctx *HookContext) (any, bool) {
+	switch mapping.Source {
+	case ClaimSourceTenantID:
+		return hctx.TenantID, hctx.TenantID != ""
+	case ClaimSourceTenants:
+		return hctx.Tenants, len(hctx.Tenants) > 0
+	case ClaimSourceGroupNames:
+		return uniqueGroupValues(hctx.Groups, func(g *types.Group) string { return g.Name })
+	case ClaimSourceGroupIDs:
+		return uniqueGroupValues(hctx.Groups, func(g *types.Group) string { return g.ID })
+	case ClaimSourceTenantGroupNames:
+		return uniqueGroupValues(hctx.Groups, func(g *types.Group) string { return g.TenantId + "/" + g.Name })
+	case ClaimSourceGroupAttribute:
+		groups := make([]*types.Group, 0, len(hctx.Groups))
+		for _, g := range hctx.Groups {
+			if _, ok := g.Attributes[mapping.Attribute]; ok {
+				groups = append(groups, g)
+			}
+		}
+		return uniqueGroupValues(groups, func(g *types.Group) string { return g.Attributes[mapping.Attribute] })
+	default:
+		return nil, false
+	}
+}
+
+// uniqueGroupValues returns the deduplicated values in the order they were first seen.
+func uniqueGroupValues(groups []*types.Group, fn func(*types.Group) string) ([]string, bool) {
+	if len(groups) == 0 {
+		return nil, false
+	}
+
+	seen := make(map[string]struct{}, len(groups))
+	values := make([]string, 0, len(groups))
+	for _, g := range groups {
+		v := fn(g)
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+
+	return values, true
+}
+
+// NewClaimMapper validates the mappings and creates a ClaimMapper.
+func NewClaimMapper(mappings []ClaimMapping) (*ClaimMapper, error) {
+	seen := make(map[ClaimToken]map[string]struct{})
+
+	for _, mapping := range mappings {
+		if mapping.Name == "" {
+			return nil, fmt.Errorf("%w: claim name is empty", ErrInvalidClaimMapping)
+		}
+
+		switch mapping.Source {
+		case ClaimSourceGroupNames, ClaimSourceGroupIDs, ClaimSourceTenantGroupNames, ClaimSourceTenantID, ClaimSourceTenants:
+		case ClaimSourceGroupAttribute:
+			if err := types.ValidateAttributeKey(mapping.Attribute); err != nil {
+				return nil, fmt.Errorf("%w: claim %q needs a valid attribute: %v", ErrInvalidClaimMapping, mapping.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown source %q for claim %q", ErrInvalidClaimMapping, mapping.Source, mapping.Name)
+		}
+
+		if slices.Contains(registeredClaims, mapping.Name) {
+			return nil, fmt.Errorf("%w: claim %q is a registered claim set by the authorization server", ErrInvalidClaimMapping, mapping.Name)
+		}
+
+		for _, t := range mapping.targets() {
+			if t != ClaimTokenAccess && t != ClaimTokenID {
+				return nil, fmt.Errorf("%w: unknown token %q for claim %q", ErrInvalidClaimMapping, t, mapping.Name)
+			}
+
+			if seen[t] == nil {
+				seen[t] = make(map[string]struct{})
+			}
+			if _, ok := seen[t][mapping.Name]; ok {
+				return nil, fmt.Errorf("%w: claim %q is mapped twice in %s", ErrInvalidClaimMapping, mapping.Name, t)
+			}
+			seen[t][mapping.Name] = struct{}{}
+		}
+	}
+
+	m := new(ClaimMapper)
+	m.mappings = mappings
+
+	return m, nil
+}
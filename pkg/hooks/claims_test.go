@@ -0,0 +1,245 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/ory/hydra/v2/flow"
+	"github.com/ory/hydra/v2/oauth2"
+)
+
+func TestParseClaimMappings(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+
+		expected      []ClaimMapping
+		expectedError error
+	}{
+		{
+			name:     "Empty config yields defaults",
+			raw:      "",
+			expected: DefaultClaimMappings(),
+		},
+		{
+			name: "Custom mappings",
+			raw:  `[{"name":"roles","source":"group_names","tokens":["access_token"]},{"name":"https://example.com/groups","source":"group_ids"},{"name":"departments","source":"group_attribute","attribute":"department"}]`,
+			expected: []ClaimMapping{
+				{Name: "roles", Source: ClaimSourceGroupNames, Tokens: []ClaimToken{ClaimTokenAccess}},
+				{Name: "https://example.com/groups", Source: ClaimSourceGroupIDs},
+				{Name: "departments", Source: ClaimSourceGroupAttribute, Attribute: "department"},
+			},
+		},
+		{
+			name:          "Invalid JSON",
+			raw:           `{"name":`,
+			expectedError: ErrInvalidClaimMapping,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mappings, err := ParseClaimMappings(test.raw)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+			if !reflect.DeepEqual(mappings, test.expected) {
+				t.Fatalf("expected mappings to be %v not %v", test.expected, mappings)
+			}
+		})
+	}
+}
+
+func TestNewClaimMapper(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []ClaimMapping
+
+		expectedError error
+	}{
+		{
+			name:     "Default mappings",
+			mappings: DefaultClaimMappings(),
+		},
+		{
+			name:     "Same name in different tokens",
+			mappings: []ClaimMapping{{Name: "groups", Source: ClaimSourceGroupNames, Tokens: []ClaimToken{ClaimTokenAccess}}, {Name: "groups", Source: ClaimSourceGroupIDs, Tokens: []ClaimToken{ClaimTokenID}}},
+		},
+		{
+			name:          "Empty name",
+			mappings:      []ClaimMapping{{Source: ClaimSourceGroupNames}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+		{
+			name:          "Unknown source",
+			mappings:      []ClaimMapping{{Name: "groups", Source: "emails"}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+		{
+			name:          "Unknown token",
+			mappings:      []ClaimMapping{{Name: "groups", Source: ClaimSourceGroupNames, Tokens: []ClaimToken{"refresh_token"}}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+		{
+			name:     "Group attribute",
+			mappings: []ClaimMapping{{Name: "departments", Source: ClaimSourceGroupAttribute, Attribute: "department"}},
+		},
+		{
+			name:          "Group attribute without attribute",
+			mappings:      []ClaimMapping{{Name: "departments", Source: ClaimSourceGroupAttribute}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+		{
+			name:          "Duplicated claim in the same token",
+			mappings:      []ClaimMapping{{Name: "groups", Source: ClaimSourceGroupNames}, {Name: "groups", Source: ClaimSourceGroupIDs, Tokens: []ClaimToken{ClaimTokenID}}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+		{
+			name:          "Registered claim",
+			mappings:      []ClaimMapping{{Name: "sub", Source: ClaimSourceTenantID}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+		{
+			name:          "Registered claim in the ID token only",
+			mappings:      []ClaimMapping{{Name: "aud", Source: ClaimSourceTenants, Tokens: []ClaimToken{ClaimTokenID}}},
+			expectedError: ErrInvalidClaimMapping,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewClaimMapper(test.mappings)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestClaimMapperApply(t *testing.T) {
+	groups := []*types.Group{
+		{ID: "id1", Name: "g1", TenantId: "default"},
+		{ID: "id2", Name: "g2", TenantId: "acme"},
+		{ID: "id1", Name: "g1", TenantId: "default"},
+	}
+
+	tests := []struct {
+		name     string
+		mappings []ClaimMapping
+		hctx     *HookContext
+
+		expectedAccessToken map[string]interface{}
+		expectedIDToken     map[string]interface{}
+	}{
+		{
+			name:     "Default mappings",
+			mappings: DefaultClaimMappings(),
+			hctx:     &HookContext{Groups: groups, TenantID: "acme"},
+			expectedAccessToken: map[string]interface{}{
+				"groups":    []string{"g1", "g2"},
+				"tenant_id": "acme",
+			},
+			expectedIDToken: map[string]interface{}{
+				"groups":    []string{"g1", "g2"},
+				"tenant_id": "acme",
+			},
+		},
+		{
+			name:                "Default mappings without groups nor tenant",
+			mappings:            DefaultClaimMappings(),
+			hctx:                &HookContext{},
+			expectedAccessToken: map[string]interface{}{},
+			expectedIDToken:     map[string]interface{}{},
+		},
+		{
+			name: "Custom names and tokens",
+			mappings: []ClaimMapping{
+				{Name: "roles", Source: ClaimSourceGroupNames, Tokens: []ClaimToken{ClaimTokenAccess}},
+				{Name: "https://example.com/groups", Source: ClaimSourceGroupIDs, Tokens: []ClaimToken{ClaimTokenID}},
+				{Name: "tenant_groups", Source: ClaimSourceTenantGroupNames},
+			},
+			hctx: &HookContext{Groups: groups},
+			expectedAccessToken: map[string]interface{}{
+				"roles":         []string{"g1", "g2"},
+				"tenant_groups": []string{"default/g1", "acme/g2"},
+			},
+			expectedIDToken: map[string]interface{}{
+				"https://example.com/groups": []string{"id1", "id2"},
+				"tenant_groups":              []string{"default/g1", "acme/g2"},
+			},
+		},
+		{
+			name:     "Group attribute claim",
+			mappings: []ClaimMapping{{Name: "departments", Source: ClaimSourceGroupAttribute, Attribute: "department", Tokens: []ClaimToken{ClaimTokenAccess}}},
+			hctx: &HookContext{Groups: []*types.Group{
+				{ID: "id1", Name: "g1", Attributes: map[string]string{"department": "engineering"}},
+				{ID: "id2", Name: "g2", Attributes: map[string]string{"cost_centre": "42"}},
+				{ID: "id3", Name: "g3", Attributes: map[string]string{"department": "sales"}},
+				{ID: "id4", Name: "g4", Attributes: map[string]string{"department": "engineering"}},
+			}},
+			expectedAccessToken: map[string]interface{}{
+				"departments": []string{"engineering", "sales"},
+			},
+			expectedIDToken: map[string]interface{}{},
+		},
+		{
+			name:                "Group attribute claim without the attribute",
+			mappings:            []ClaimMapping{{Name: "departments", Source: ClaimSourceGroupAttribute, Attribute: "department"}},
+			hctx:                &HookContext{Groups: groups},
+			expectedAccessToken: map[string]interface{}{},
+			expectedIDToken:     map[string]interface{}{},
+		},
+		{
+			name:     "Tenants claim",
+			mappings: append(DefaultClaimMappings(), ClaimMapping{Name: "tenants", Source: ClaimSourceTenants, Tokens: []ClaimToken{ClaimTokenID}}),
+			hctx:     &HookContext{TenantID: "acme", Tenants: []string{"acme", "globex"}},
+			expectedAccessToken: map[string]interface{}{
+				"tenant_id": "acme",
+			},
+			expectedIDToken: map[string]interface{}{
+				"tenant_id": "acme",
+				"tenants":   []string{"acme", "globex"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := NewClaimMapper(test.mappings)
+			if err != nil {
+				t.Fatalf("expected error to be nil got %v", err)
+			}
+
+			resp := &oauth2.TokenHookResponse{Session: *flow.NewConsentRequestSessionData()}
+			m.Apply(resp, test.hctx)
+
+			if !reflect.DeepEqual(resp.Session.AccessToken, test.expectedAccessToken) {
+				t.Fatalf("expected access token to be %v not %v", test.expectedAccessToken, resp.Session.AccessToken)
+			}
+			if !reflect.DeepEqual(resp.Session.IDToken, test.expectedIDToken) {
+				t.Fatalf("expected id token to be %v not %v", test.expectedIDToken, resp.Session.IDToken)
+			}
+		})
+	}
+}
+
+func TestClaimMapperUses(t *testing.T) {
+	m, err := NewClaimMapper([]ClaimMapping{{Name: "tenants", Source: ClaimSourceTenants}})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	if !m.Uses(ClaimSourceTenants) {
+		t.Fatal("expected the tenants source to be used")
+	}
+	if m.Uses(ClaimSourceGroupNames) {
+		t.Fatal("expected the group names source not to be used")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const (
+	decisionLogQueueSize     = 4096
+	decisionLogBatchSize     = 100
+	decisionLogFlushInterval = time.Second
+)
+
+// DecisionLog persists the authorization decisions of the token hook. Writes
+// are queued and flushed in batches by Run so the token hook never waits on
+// the database; decisions are dropped when the queue is full. A nil
+// DecisionLog is valid and records nothing.
+type DecisionLog struct {
+	db    DecisionStorageInterface
+	queue chan *types.Decision
+
+	logger logging.LoggerInterface
+}
+
+var _ DecisionRecorderInterface = (*DecisionLog)(nil)
+
+// RecordDecision queues a decision to be persisted.
+func (l *DecisionLog) RecordDecision(ctx context.Context, d *types.Decision) {
+	if l == nil {
+		return
+	}
+
+	select {
+	case l.queue <- d:
+	default:
+		droppedDecisions.Inc()
+		l.logger.Warnf("decision log queue is full, dropping decision for user %s to client %s", d.UserID, d.ClientID)
+	}
+}
+
+// Run flushes the queued decisions until ctx is cancelled, the remaining
+// decisions are flushed before returning.
+func (l *DecisionLog) Run(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(decisionLogFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*types.Decision, 0, decisionLogBatchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := l.db.CreateDecisions(ctx, batch...); err != nil {
+			droppedDecisions.Add(float64(len(batch)))
+			l.logger.Errorf("failed to persist %d decisions: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case d := <-l.queue:
+			batch = append(batch, d)
+			if len(batch) >= decisionLogBatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		case <-ctx.Done():
+			// ctx is done, flush what is already queued with a fresh context.
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			for len(l.queue) > 0 {
+				batch = append(batch, <-l.queue)
+				if len(batch) >= decisionLogBatchSize {
+					flush(flushCtx)
+				}
+			}
+			flush(flushCtx)
+			cancel()
+			return nil
+		}
+	}
+}
+
+// NewDecisionLog returns a DecisionLog writing to db.
+func NewDecisionLog(db DecisionStorageInterface, logger logging.LoggerInterface) *DecisionLog {
+	l := new(DecisionLog)
+
+	l.db = db
+	l.queue = make(chan *types.Decision, decisionLogQueueSize)
+
+	registerMetrics(logger)
+
+	l.logger = logger
+
+	return l
+}
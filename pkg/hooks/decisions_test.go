@@ -0,0 +1,91 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestDecisionLogFlushesOnShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockStorage := NewMockDecisionStorageInterface(ctrl)
+
+	decisions := []*types.Decision{
+		{UserID: "alice", ClientID: "app", Reason: string(DecisionReasonCheck)},
+		{UserID: "bob", ClientID: "app", Reason: string(DecisionReasonCheck), Allowed: true},
+	}
+
+	mockStorage.EXPECT().CreateDecisions(gomock.Any(), decisions[0], decisions[1]).Return(nil)
+
+	l := NewDecisionLog(mockStorage, mockLogger)
+	for _, d := range decisions {
+		l.RecordDecision(context.TODO(), d)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	if err := l.Run(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDecisionLogDropsWhenFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), "alice", "app").Times(1)
+	mockStorage := NewMockDecisionStorageInterface(ctrl)
+
+	l := NewDecisionLog(mockStorage, mockLogger)
+	for i := 0; i < decisionLogQueueSize+1; i++ {
+		l.RecordDecision(context.TODO(), &types.Decision{UserID: "alice", ClientID: "app"})
+	}
+
+	if len(l.queue) != decisionLogQueueSize {
+		t.Fatalf("expected %d queued decisions, got %d", decisionLogQueueSize, len(l.queue))
+	}
+}
+
+func TestDecisionLogStorageError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), 1, gomock.Any()).Times(1)
+	mockStorage := NewMockDecisionStorageInterface(ctrl)
+	mockStorage.EXPECT().CreateDecisions(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+	l := NewDecisionLog(mockStorage, mockLogger)
+	l.RecordDecision(context.TODO(), &types.Decision{UserID: "alice", ClientID: "app"})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	if err := l.Run(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestNilDecisionLog(t *testing.T) {
+	var l *DecisionLog
+
+	l.RecordDecision(context.TODO(), &types.Decision{UserID: "alice"})
+	if err := l.Run(context.TODO()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
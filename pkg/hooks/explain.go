@@ -0,0 +1,164 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/canonical/hook-service/internal/authorization"
+	"github.com/canonical/hook-service/internal/openfga"
+	"github.com/canonical/hook-service/internal/tenants"
+	"github.com/canonical/hook-service/internal/types"
+	"github.com/ory/hydra/v2/oauth2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TenantCheck is the outcome of the tenant membership validation of an
+// explained request.
+type TenantCheck struct {
+	// TenantID is the tenant the request is scoped to, or empty if none.
+	TenantID string
+	// AutoSelected is true when the tenant is the only tenant of a user who
+	// did not select one.
+	AutoSelected bool
+	// Member is true when the user is an active member of the tenant.
+	Member bool
+	// Error is set when the tenant service could not be queried.
+	Error string
+}
+
+// Explanation describes how the token hook would handle a request, see
+// Service.Explain.
+type Explanation struct {
+	// Groups is the list of groups the user belongs to.
+	Groups []*types.Group
+	// GroupsError is set when the groups could not be fetched.
+	GroupsError string
+	// DegradedSources are the optional group sources that failed.
+	DegradedSources []string
+	// ContextualTuples are the group memberships sent along with the OpenFGA checks.
+	ContextualTuples []openfga.Tuple
+	// Checks are the can_access tuples checked, empty when the policy decided
+	// without querying OpenFGA.
+	Checks []openfga.Tuple
+	// Tenant is the outcome of the tenant membership validation.
+	Tenant TenantCheck
+	// Allowed is the authorization decision.
+	Allowed bool
+	// Shadow is true when the client is in shadow mode and tokens are issued
+	// regardless of the decision.
+	Shadow bool
+	// Reason names the branch that decided the request.
+	Reason DecisionReason
+	// Error is set when the authorization check failed.
+	Error string
+	// HookContext is the context the claims are built from, nil when the hook
+	// would deny the request.
+	HookContext *HookContext
+}
+
+// Explain runs the steps of ProcessRequest for a request without recording
+// the decision: the groups are fetched, the tenant membership is validated
+// and the request is authorized. Failures are reported in the explanation
+// rather than returned. The steps run sequentially outside the worker pool.
+func (s *Service) Explain(ctx context.Context, user User, req oauth2.TokenHookRequest) *Explanation {
+	ctx, span := s.tracer.Start(ctx, "hooks.Service.Explain")
+	defer span.End()
+
+	policy := s.policy.ForClient(req.Request.ClientID)
+
+	e := new(Explanation)
+	e.Shadow = policy.IsShadow()
+	e.Tenant.TenantID = s.extractTenantID(&req)
+
+	groups, degraded, err := s.fetchUserGroups(ctx, user)
+	e.DegradedSources = degraded
+	if err != nil {
+		e.GroupsError = err.Error()
+		groups = nil
+	}
+
+	var tenantIDs []string
+	if e.Tenant.TenantID != "" || s.tenants.lookups(e.Tenant.TenantID) {
+		t := s.resolveTenant(ctx, user, e.Tenant.TenantID)
+		e.Tenant.TenantID, e.Tenant.AutoSelected, tenantIDs = t.tenantID, t.autoSelected, t.tenants
+		switch {
+		case e.Tenant.TenantID == "":
+		case t.err == nil:
+			e.Tenant.Member = true
+		case !errors.Is(t.err, tenants.ErrNotMember):
+			e.Tenant.Error = t.err.Error()
+		}
+	}
+
+	groups = tenantGroups(groups, e.Tenant.TenantID, policy.IncludesGlobalGroups())
+	e.Groups = groups
+
+	issued := false
+	switch {
+	case e.GroupsError != "" && policy.OnError == ErrorModeFailOpen:
+		// ProcessRequest allows the request without authorizing it.
+		e.Allowed, e.Reason = true, DecisionReasonFailOpen
+		issued = true
+	case e.GroupsError != "":
+		// ProcessRequest stops before authorizing the request.
+		e.Reason = DecisionReasonFailClosed
+		issued = e.Shadow
+	default:
+		allowed, reason, err := s.authorize(ctx, user, req, groups)
+		e.Allowed, e.Reason = allowed && err == nil, reason
+		if err != nil {
+			e.Error = err.Error()
+		}
+		issued = e.Allowed || e.Shadow
+		e.ContextualTuples, e.Checks = accessTuples(user, req, groups, reason)
+	}
+
+	if e.Tenant.TenantID != "" && !e.Tenant.Member {
+		e.Allowed, issued = false, false
+		e.Reason = DecisionReasonTenantDenied
+		if e.Tenant.Error != "" {
+			e.Reason = DecisionReasonTenantError
+		}
+	}
+
+	if issued {
+		e.HookContext = &HookContext{Groups: groups, TenantID: e.Tenant.TenantID, Tenants: tenantIDs}
+	}
+
+	span.SetAttributes(
+		attribute.Bool("authorization.allowed", e.Allowed),
+		attribute.String("authorization.decision", string(e.Reason)),
+	)
+
+	return e
+}
+
+// accessTuples returns the contextual tuples and the can_access tuples sent
+// to OpenFGA by authorize, both empty when the policy decided on its own.
+func accessTuples(user User, req oauth2.TokenHookRequest, groups []*types.Group, reason DecisionReason) ([]openfga.Tuple, []openfga.Tuple) {
+	switch reason {
+	case DecisionReasonCheck, DecisionReasonBatchCheck, DecisionReasonFailOpen, DecisionReasonFailClosed:
+	default:
+		return nil, nil
+	}
+
+	groupIDs := make([]string, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+
+	clientIDs := []string{req.Request.ClientID}
+	if isServiceAccount(req.Request.GrantTypes) {
+		clientIDs = req.Request.GrantedAudience
+	}
+
+	checks := make([]openfga.Tuple, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		checks = append(checks, authorization.AccessTuple(user.GetUserId(), clientID))
+	}
+
+	return authorization.MembershipTuples(user.GetUserId(), groupIDs), checks
+}
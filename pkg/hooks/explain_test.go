@@ -0,0 +1,354 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ory/hydra/v2/oauth2"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/openfga"
+	"github.com/canonical/hook-service/internal/tenants"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestServiceExplain(t *testing.T) {
+	someErr := errors.New("some error")
+	user := User{SubjectId: "user-123", Email: "a@a.com"}
+	serviceAccount := User{ClientId: "client"}
+
+	groups := []*types.Group{{ID: "g1", Name: "g1", TenantId: "t-1"}}
+	memberTuples := []openfga.Tuple{{User: "user:user-123", Relation: "member", Object: "group:ZzE="}}
+	checkTuples := []openfga.Tuple{{User: "user:user-123", Relation: "can_access", Object: "client:client"}}
+
+	shadow := true
+	shadowPolicy := &PolicyConfig{
+		Policy:  Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailClosed},
+		Clients: map[string]Policy{"client": {Shadow: &shadow}},
+	}
+
+	tests := []struct {
+		name string
+
+		user User
+		req  oauth2.TokenHookRequest
+
+		mockClient func(*gomock.Controller) ClientInterface
+		mockAuthz  func(*gomock.Controller) AuthorizerInterface
+		mockTV     func(*gomock.Controller) TenantValidatorInterface
+		policy     *PolicyConfig
+		tenants    *TenantConfig
+
+		expected *Explanation
+	}{
+		{
+			name: "Allowed by OpenFGA",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Allowed:          true,
+				Reason:           DecisionReasonCheck,
+				HookContext:      &HookContext{Groups: groups},
+			},
+		},
+		{
+			name: "Denied by OpenFGA",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(false, nil)
+				return m
+			},
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Reason:           DecisionReasonCheck,
+			},
+		},
+		{
+			name: "Service account checks every audience",
+			user: serviceAccount,
+			req:  createHookRequest("client", "", []string{"client_credentials"}, []string{"app1", "app2"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), serviceAccount).Return(nil, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().BatchCanAccess(gomock.Any(), "client", []string{"app1", "app2"}, []string{}).Return(true, nil)
+				return m
+			},
+			expected: &Explanation{
+				Groups:           []*types.Group{},
+				ContextualTuples: []openfga.Tuple{},
+				Checks: []openfga.Tuple{
+					{User: "user:client", Relation: "can_access", Object: "client:app1"},
+					{User: "user:client", Relation: "can_access", Object: "client:app2"},
+				},
+				Allowed:     true,
+				Reason:      DecisionReasonBatchCheck,
+				HookContext: &HookContext{Groups: []*types.Group{}},
+			},
+		},
+		{
+			name: "Default allow policy does not query OpenFGA",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			policy: &PolicyConfig{Policy: Policy{Default: DefaultDecisionAllow, OnError: ErrorModeFailClosed}},
+			expected: &Explanation{
+				Groups:      groups,
+				Allowed:     true,
+				Reason:      DecisionReasonDefaultAllow,
+				HookContext: &HookContext{Groups: groups},
+			},
+		},
+		{
+			name: "Groups fetch fails, fail closed",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(nil, someErr)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			expected: &Explanation{
+				GroupsError: "group source source-0: " + someErr.Error(),
+				Reason:      DecisionReasonFailClosed,
+			},
+		},
+		{
+			name: "Groups fetch fails, fail open",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(nil, someErr)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			policy: &PolicyConfig{Policy: Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailOpen}},
+			expected: &Explanation{
+				GroupsError: "group source source-0: " + someErr.Error(),
+				Allowed:     true,
+				Reason:      DecisionReasonFailOpen,
+				HookContext: &HookContext{},
+			},
+		},
+		{
+			name: "Shadow client is issued a token when denied",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(false, nil)
+				return m
+			},
+			policy: shadowPolicy,
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Shadow:           true,
+				Reason:           DecisionReasonCheck,
+				HookContext:      &HookContext{Groups: groups},
+			},
+		},
+		{
+			name: "Authorized but not a member of the tenant",
+			user: user,
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(tenants.ErrNotMember)
+				return m
+			},
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Tenant:           TenantCheck{TenantID: "t-1"},
+				Reason:           DecisionReasonTenantDenied,
+			},
+		},
+		{
+			name: "Tenant service unavailable",
+			user: user,
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(someErr)
+				return m
+			},
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Tenant:           TenantCheck{TenantID: "t-1", Error: someErr.Error()},
+				Reason:           DecisionReasonTenantError,
+			},
+		},
+		{
+			name: "Tenant member is issued the tenant claim",
+			user: user,
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(nil)
+				return m
+			},
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Tenant:           TenantCheck{TenantID: "t-1", Member: true},
+				Allowed:          true,
+				Reason:           DecisionReasonCheck,
+				HookContext:      &HookContext{Groups: groups, TenantID: "t-1"},
+			},
+		},
+		{
+			name: "Only tenant of the user selected",
+			user: user,
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(append(groups, &types.Group{ID: "g2", Name: "g2", TenantId: "t-2"}), nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ListTenants(gomock.Any(), user.SubjectId).Return([]string{"t-1"}, nil)
+				return m
+			},
+			tenants: &TenantConfig{SessionKey: DefaultTenantSessionKey, AutoSelect: true, ListTenants: true},
+			expected: &Explanation{
+				Groups:           groups,
+				ContextualTuples: memberTuples,
+				Checks:           checkTuples,
+				Tenant:           TenantCheck{TenantID: "t-1", AutoSelected: true, Member: true},
+				Allowed:          true,
+				Reason:           DecisionReasonCheck,
+				HookContext:      &HookContext{Groups: groups, TenantID: "t-1", Tenants: []string{"t-1"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+			// The decision recorder is never called.
+			mockRecorder := NewMockDecisionRecorderInterface(ctrl)
+
+			var mockTV TenantValidatorInterface = NewMockTenantValidatorInterface(ctrl)
+			if test.mockTV != nil {
+				mockTV = test.mockTV(ctrl)
+			}
+
+			s := NewService(
+				requiredSources(test.mockClient(ctrl)),
+				test.mockAuthz(ctrl),
+				mockTV,
+				test.policy,
+				test.tenants,
+				mockRecorder,
+				NewMockWorkerPoolInterface(ctrl),
+				mockTracer,
+				mockMonitor,
+				mockLogger,
+			)
+
+			e := s.Explain(context.TODO(), test.user, test.req)
+
+			if !reflect.DeepEqual(e, test.expected) {
+				t.Errorf("expected explanation %+v, got %+v", test.expected, e)
+			}
+		})
+	}
+}
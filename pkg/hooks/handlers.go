@@ -7,15 +7,12 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
-	"maps"
 	"net/http"
-	"slices"
 
 	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/monitoring"
 	"github.com/canonical/hook-service/internal/tenants"
 	"github.com/canonical/hook-service/internal/tracing"
-	"github.com/canonical/hook-service/internal/types"
 	"github.com/go-chi/chi/v5"
 	"github.com/ory/hydra/v2/flow"
 	"github.com/ory/hydra/v2/oauth2"
@@ -31,6 +28,7 @@ const (
 type API struct {
 	service    ServiceInterface
 	middleware *AuthMiddleware
+	claims     *ClaimMapper
 
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
@@ -132,8 +130,14 @@ func (a *API) handleHydraHook(w http.ResponseWriter, r *http.Request) {
 }
 
 // composeTokenResponse builds the final TokenHookResponse from a processed hook
-// context, including group names and the tenant_id when present.
+// context, projecting it onto the claims declared by the claim mappings.
 func (a *API) composeTokenResponse(req *oauth2.TokenHookRequest, hctx *HookContext) *oauth2.TokenHookResponse {
+	return ComposeTokenResponse(a.claims, req, hctx)
+}
+
+// ComposeTokenResponse builds the TokenHookResponse returned to Hydra for a
+// processed hook context, keeping the existing session claims.
+func ComposeTokenResponse(claims *ClaimMapper, req *oauth2.TokenHookRequest, hctx *HookContext) *oauth2.TokenHookResponse {
 	var existingAccessToken map[string]interface{}
 	var existingIDToken map[string]interface{}
 	// Preserve existing Hydra session claims and only overwrite hook-managed claims.
@@ -148,17 +152,14 @@ func (a *API) composeTokenResponse(req *oauth2.TokenHookRequest, hctx *HookConte
 		}
 	}
 
-	resp := a.newHookResponse(hctx.Groups, existingAccessToken, existingIDToken)
-	if hctx.TenantID != "" {
-		resp.Session.AccessToken["tenant_id"] = hctx.TenantID
-		resp.Session.IDToken["tenant_id"] = hctx.TenantID
-	}
+	resp := newHookResponse(existingAccessToken, existingIDToken)
+	claims.Apply(resp, hctx)
 	return resp
 }
 
-// newHookResponse creates a TokenHookResponse with the group names added to both
-// the access token and ID token session data. Duplicate group names are removed.
-func (a *API) newHookResponse(groups []*types.Group, existingAccessToken, existingIDToken map[string]interface{}) *oauth2.TokenHookResponse {
+// newHookResponse creates a TokenHookResponse seeded with the existing access
+// token and ID token session data.
+func newHookResponse(existingAccessToken, existingIDToken map[string]interface{}) *oauth2.TokenHookResponse {
 	resp := oauth2.TokenHookResponse{
 		Session: *flow.NewConsentRequestSessionData(),
 	}
@@ -171,25 +172,15 @@ func (a *API) newHookResponse(groups []*types.Group, existingAccessToken, existi
 		resp.Session.IDToken[k] = v
 	}
 
-	if len(groups) == 0 {
-		return &resp
-	}
-
-	groupNames := make(map[string]struct{}, len(groups))
-	for _, g := range groups {
-		groupNames[g.Name] = struct{}{}
-	}
-
-	gg := slices.Collect(maps.Keys(groupNames))
-	resp.Session.AccessToken["groups"] = gg
-	resp.Session.IDToken["groups"] = gg
 	return &resp
 }
 
-// NewAPI creates a new API handler for the Hydra token hook endpoint.
+// NewAPI creates a new API handler for the Hydra token hook endpoint. When
+// claims is nil the default claim mappings are used.
 func NewAPI(
 	service ServiceInterface,
 	middleware *AuthMiddleware,
+	claims *ClaimMapper,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
@@ -201,6 +192,11 @@ func NewAPI(
 		a.middleware = middleware
 	}
 
+	a.claims = claims
+	if a.claims == nil {
+		a.claims, _ = NewClaimMapper(DefaultClaimMappings())
+	}
+
 	a.monitor = monitor
 	a.tracer = tracer
 	a.logger = logger
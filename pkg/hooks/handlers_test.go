@@ -356,7 +356,7 @@ func TestHandleHydraHook(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/api/v0/hook/hydra", bytes.NewBuffer(body))
 
 			mux := chi.NewMux()
-			NewAPI(mockService, nil, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
+			NewAPI(mockService, nil, nil, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
 			w := httptest.NewRecorder()
 
 			mux.ServeHTTP(w, req)
@@ -471,7 +471,7 @@ func TestHandleHydraHookTenantValidation(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/api/v0/hook/hydra", bytes.NewBuffer(body))
 
 			mux := chi.NewMux()
-			NewAPI(mockService, nil, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
+			NewAPI(mockService, nil, nil, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
 			w := httptest.NewRecorder()
 
 			mux.ServeHTTP(w, req)
@@ -533,9 +533,10 @@ func TestExtractTenantID(t *testing.T) {
 			expected: "",
 		},
 	}
+	s := &Service{tenants: DefaultTenantConfig()}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := extractTenantID(test.req)
+			got := s.extractTenantID(test.req)
 			if got != test.expected {
 				t.Fatalf("expected %q, got %q", test.expected, got)
 			}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/canonical/hook-service/internal/types"
+	"github.com/go-ldap/ldap/v3"
 	"github.com/ory/hydra/v2/oauth2"
 )
 
@@ -18,9 +19,18 @@ type ClientInterface interface {
 	FetchUserGroups(context.Context, User) ([]*types.Group, error)
 }
 
+// LDAPConnInterface is the subset of *ldap.Conn used by LDAPHookGroupsClient.
+type LDAPConnInterface interface {
+	Bind(username, password string) error
+	Search(*ldap.SearchRequest) (*ldap.SearchResult, error)
+	IsClosing() bool
+	Close() error
+}
+
 type AuthorizerInterface interface {
 	CanAccess(context.Context, string, string, []string) (bool, error)
 	BatchCanAccess(context.Context, string, []string, []string) (bool, error)
+	HasAllowedGroups(context.Context, string) (bool, error)
 }
 
 type DatabaseInterface interface {
@@ -31,4 +41,15 @@ type DatabaseInterface interface {
 // tenant. See internal/tenants for the real and noop implementations.
 type TenantValidatorInterface interface {
 	ValidateMembership(ctx context.Context, identityID, tenantID string) error
+	ListTenants(ctx context.Context, identityID string) ([]string, error)
+}
+
+// DecisionRecorderInterface records the authorization decisions of the token
+// hook, see DecisionLog.
+type DecisionRecorderInterface interface {
+	RecordDecision(context.Context, *types.Decision)
+}
+
+type DecisionStorageInterface interface {
+	CreateDecisions(context.Context, ...*types.Decision) error
 }
@@ -0,0 +1,436 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/canonical/hook-service/internal/cache"
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// GroupSourceLDAP is the name of the group source backed by an LDAP directory.
+const GroupSourceLDAP = "ldap"
+
+const (
+	// LDAPUserAttributeEmail looks the user up by email, or client ID for
+	// service accounts.
+	LDAPUserAttributeEmail = "email"
+	// LDAPUserAttributeSubject looks the user up by subject, or client ID for
+	// service accounts.
+	LDAPUserAttributeSubject = "subject"
+
+	// ldapDNAttribute selects the DN of the entry instead of an attribute.
+	ldapDNAttribute = "dn"
+
+	ldapUserPlaceholder = "{user}"
+	ldapDNPlaceholder   = "{dn}"
+)
+
+var (
+	ErrInvalidLDAPConfig = errors.New("invalid LDAP configuration")
+	ErrLDAPAmbiguousUser = errors.New("LDAP user filter matched more than one entry")
+)
+
+// LDAPConfig configures the LDAP group source. Filters may reference the
+// mapped user value as `{user}` and, in the group filter, the DN of the user
+// entry as `{dn}`; both are escaped.
+type LDAPConfig struct {
+	// URL of the directory, ldap:// or ldaps://.
+	URL string
+	// BindDN and BindPassword authenticate the pooled connections, they are
+	// anonymous when BindDN is empty.
+	BindDN       string
+	BindPassword string
+	// StartTLS upgrades ldap:// connections to TLS.
+	StartTLS bool
+
+	// BaseDN is the default base of the user and group searches.
+	BaseDN      string
+	UserBaseDN  string
+	GroupBaseDN string
+
+	// UserFilter finds the user entry, it is only used when GroupFilter
+	// references `{dn}`.
+	UserFilter string
+	// GroupFilter finds the groups of the user.
+	GroupFilter string
+	// UserAttribute selects the hook user field substituted for `{user}`,
+	// see LDAPUserAttributeEmail and LDAPUserAttributeSubject.
+	UserAttribute string
+	// GroupIDAttribute and GroupNameAttribute map the group entries, `dn`
+	// selects the entry DN.
+	GroupIDAttribute   string
+	GroupNameAttribute string
+	// TenantID is the tenant of the returned groups.
+	TenantID string
+
+	// Timeout bounds dialing and each request.
+	Timeout time.Duration
+	// PoolSize is the number of idle connections kept open.
+	PoolSize int
+	// CacheTTL and CacheSize configure the groups cache, disabled when the
+	// TTL is not positive.
+	CacheTTL  time.Duration
+	CacheSize int
+}
+
+// withDefaults returns the config with the empty fields set to their defaults.
+func (c LDAPConfig) withDefaults() LDAPConfig {
+	if c.UserBaseDN == "" {
+		c.UserBaseDN = c.BaseDN
+	}
+	if c.GroupBaseDN == "" {
+		c.GroupBaseDN = c.BaseDN
+	}
+	if c.UserFilter == "" {
+		c.UserFilter = "(mail={user})"
+	}
+	if c.GroupFilter == "" {
+		c.GroupFilter = "(&(objectClass=groupOfNames)(member={dn}))"
+	}
+	if c.UserAttribute == "" {
+		c.UserAttribute = LDAPUserAttributeEmail
+	}
+	if c.GroupIDAttribute == "" {
+		c.GroupIDAttribute = ldapDNAttribute
+	}
+	if c.GroupNameAttribute == "" {
+		c.GroupNameAttribute = "cn"
+	}
+	if c.TenantID == "" {
+		c.TenantID = "default"
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 5 * time.Second
+	}
+	if c.PoolSize <= 0 {
+		c.PoolSize = 10
+	}
+	if c.CacheSize <= 0 {
+		c.CacheSize = 10000
+	}
+	return c
+}
+
+func (c LDAPConfig) validate() error {
+	u, err := url.Parse(c.URL)
+	switch {
+	case c.URL == "":
+		return fmt.Errorf("%w: URL is empty", ErrInvalidLDAPConfig)
+	case err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps"):
+		return fmt.Errorf("%w: URL %q must use the ldap or ldaps scheme", ErrInvalidLDAPConfig, c.URL)
+	case c.StartTLS && u.Scheme == "ldaps":
+		return fmt.Errorf("%w: StartTLS cannot be used with ldaps", ErrInvalidLDAPConfig)
+	case c.GroupBaseDN == "":
+		return fmt.Errorf("%w: base DN is empty", ErrInvalidLDAPConfig)
+	case strings.Contains(c.GroupFilter, ldapDNPlaceholder) && c.UserBaseDN == "":
+		return fmt.Errorf("%w: user base DN is empty", ErrInvalidLDAPConfig)
+	case c.UserAttribute != LDAPUserAttributeEmail && c.UserAttribute != LDAPUserAttributeSubject:
+		return fmt.Errorf("%w: unknown user attribute %q", ErrInvalidLDAPConfig, c.UserAttribute)
+	}
+	return nil
+}
+
+var _ ClientInterface = (*LDAPHookGroupsClient)(nil)
+
+// LDAPHookGroupsClient fetches the groups of a user from an LDAP directory,
+// the groups are returned as external groups.
+type LDAPHookGroupsClient struct {
+	config LDAPConfig
+	pool   *ldapPool
+	cache  *cache.Cache[[]*types.Group]
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// FetchUserGroups retrieves the user groups from the directory, results are
+// cached for the configured TTL.
+func (c *LDAPHookGroupsClient) FetchUserGroups(ctx context.Context, user User) ([]*types.Group, error) {
+	ctx, span := c.tracer.Start(ctx, "hooks.LDAPHookGroupsClient.FetchUserGroups")
+	defer span.End()
+
+	value := c.userValue(user)
+	if value == "" {
+		c.logger.Warnf("LDAP user value is empty for user: %#v", user)
+		return nil, nil
+	}
+
+	if c.cache != nil {
+		if groups, ok := c.cache.Get(value); ok {
+			span.SetAttributes(attribute.Bool("cache.hit", true))
+			return groups, nil
+		}
+	}
+
+	var groups []*types.Group
+	err := c.pool.do(ctx, func(conn LDAPConnInterface) error {
+		var err error
+		groups, err = c.searchGroups(conn, value)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to fetch user groups from LDAP")
+		return nil, err
+	}
+
+	if c.cache != nil {
+		c.cache.Set(value, groups)
+	}
+
+	span.SetAttributes(attribute.Int("groups.count", len(groups)))
+	return groups, nil
+}
+
+// Close closes the idle connections of the pool.
+func (c *LDAPHookGroupsClient) Close() {
+	c.pool.close()
+}
+
+func (c *LDAPHookGroupsClient) userValue(user User) string {
+	if c.config.UserAttribute == LDAPUserAttributeSubject {
+		return user.GetUserId()
+	}
+	return user.storageId()
+}
+
+func (c *LDAPHookGroupsClient) searchGroups(conn LDAPConnInterface, value string) ([]*types.Group, error) {
+	filter := strings.ReplaceAll(c.config.GroupFilter, ldapUserPlaceholder, ldap.EscapeFilter(value))
+
+	if strings.Contains(filter, ldapDNPlaceholder) {
+		dn, err := c.searchUserDN(conn, value)
+		if err != nil {
+			return nil, err
+		}
+		if dn == "" {
+			// The user is not in the directory.
+			return []*types.Group{}, nil
+		}
+		filter = strings.ReplaceAll(filter, ldapDNPlaceholder, ldap.EscapeFilter(dn))
+	}
+
+	attributes := []string{c.config.GroupNameAttribute}
+	if c.config.GroupIDAttribute != ldapDNAttribute {
+		attributes = append(attributes, c.config.GroupIDAttribute)
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		c.config.GroupBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, c.timeLimit(), false,
+		filter,
+		attributes,
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search LDAP groups: %w", err)
+	}
+
+	groups := make([]*types.Group, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		id := e.DN
+		if c.config.GroupIDAttribute != ldapDNAttribute {
+			id = e.GetAttributeValue(c.config.GroupIDAttribute)
+		}
+		name := e.GetAttributeValue(c.config.GroupNameAttribute)
+		if id == "" || name == "" {
+			c.logger.Debugf("skipping LDAP group %s without ID or name", e.DN)
+			continue
+		}
+
+		groups = append(groups, &types.Group{
+			ID:       id,
+			Name:     name,
+			TenantId: c.config.TenantID,
+			Type:     types.GroupTypeExternal,
+		})
+	}
+
+	return groups, nil
+}
+
+// searchUserDN returns the DN of the user entry, or an empty string when the
+// user is not in the directory.
+func (c *LDAPHookGroupsClient) searchUserDN(conn LDAPConnInterface, value string) (string, error) {
+	res, err := conn.Search(ldap.NewSearchRequest(
+		c.config.UserBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, c.timeLimit(), false,
+		strings.ReplaceAll(c.config.UserFilter, ldapUserPlaceholder, ldap.EscapeFilter(value)),
+		// 1.1 requests no attributes, only the DN is needed.
+		[]string{"1.1"},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return "", fmt.Errorf("failed to search LDAP user: %w", err)
+	}
+
+	switch {
+	case res == nil || len(res.Entries) == 0:
+		return "", nil
+	case len(res.Entries) > 1:
+		return "", ErrLDAPAmbiguousUser
+	default:
+		return res.Entries[0].DN, nil
+	}
+}
+
+// timeLimit is the time limit of the searches in seconds, rounded up since
+// the server takes 0 as no limit.
+func (c *LDAPHookGroupsClient) timeLimit() int {
+	return max(int(math.Ceil(c.config.Timeout.Seconds())), 1)
+}
+
+// dial opens and binds a new connection to the directory.
+func (c *LDAPHookGroupsClient) dial() (LDAPConnInterface, error) {
+	conn, err := ldap.DialURL(c.config.URL, ldap.DialWithDialer(&net.Dialer{Timeout: c.config.Timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP: %w", err)
+	}
+	conn.SetTimeout(c.config.Timeout)
+
+	if c.config.StartTLS {
+		u, _ := url.Parse(c.config.URL)
+		if err := conn.StartTLS(&tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to start TLS with LDAP: %w", err)
+		}
+	}
+
+	if c.config.BindDN != "" {
+		if err := conn.Bind(c.config.BindDN, c.config.BindPassword); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to bind to LDAP: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// ldapPool keeps up to size bound connections open for reuse.
+type ldapPool struct {
+	dial func() (LDAPConnInterface, error)
+	idle chan LDAPConnInterface
+}
+
+// do runs fn on a pooled connection. A network error discards the connection
+// and fn is retried once on a new one, as idle connections may have been
+// closed by the server. The connection is closed when ctx is done, which
+// aborts the pending request.
+func (p *ldapPool) do(ctx context.Context, fn func(LDAPConnInterface) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	conn, pooled, err := p.get()
+	if err != nil {
+		return err
+	}
+
+	err = p.run(ctx, conn, fn)
+	if err != nil && pooled && ctx.Err() == nil && ldap.IsErrorWithCode(err, ldap.ErrorNetwork) {
+		_ = conn.Close()
+		if conn, err = p.dial(); err != nil {
+			return err
+		}
+		err = p.run(ctx, conn, fn)
+	}
+
+	p.put(conn, ctx.Err() == nil && (err == nil || !ldap.IsErrorWithCode(err, ldap.ErrorNetwork)))
+	return err
+}
+
+// run runs fn on conn, closing conn if ctx is done first.
+func (p *ldapPool) run(ctx context.Context, conn LDAPConnInterface, fn func(LDAPConnInterface) error) error {
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+
+	err := fn(conn)
+	if !stop() {
+		return ctx.Err()
+	}
+	return err
+}
+
+// get returns an idle connection, or a new one. The boolean is true for
+// connections taken from the pool.
+func (p *ldapPool) get() (LDAPConnInterface, bool, error) {
+	for {
+		select {
+		case conn := <-p.idle:
+			if conn.IsClosing() {
+				_ = conn.Close()
+				continue
+			}
+			return conn, true, nil
+		default:
+			conn, err := p.dial()
+			return conn, false, err
+		}
+	}
+}
+
+func (p *ldapPool) put(conn LDAPConnInterface, healthy bool) {
+	if !healthy || conn.IsClosing() {
+		_ = conn.Close()
+		return
+	}
+
+	select {
+	case p.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+func (p *ldapPool) close() {
+	for {
+		select {
+		case conn := <-p.idle:
+			_ = conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// NewLDAPClient validates the config and creates an LDAPHookGroupsClient,
+// connections are opened lazily.
+func NewLDAPClient(config LDAPConfig, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) (*LDAPHookGroupsClient, error) {
+	config = config.withDefaults()
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	c := new(LDAPHookGroupsClient)
+	c.config = config
+	c.pool = &ldapPool{dial: c.dial, idle: make(chan LDAPConnInterface, config.PoolSize)}
+
+	if config.CacheTTL > 0 {
+		c.cache = cache.NewCache[[]*types.Group]("ldap_groups", config.CacheSize, config.CacheTTL, logger)
+	}
+
+	c.tracer = tracer
+	c.monitor = monitor
+	c.logger = logger
+
+	return c, nil
+}
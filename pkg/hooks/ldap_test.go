@@ -0,0 +1,469 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	trace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func newTestLDAPClient(t *testing.T, ctrl *gomock.Controller, config LDAPConfig, conns ...LDAPConnInterface) *LDAPHookGroupsClient {
+	t.Helper()
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), "hooks.LDAPHookGroupsClient.FetchUserGroups").AnyTimes().Return(t.Context(), trace.SpanFromContext(t.Context()))
+
+	c, err := NewLDAPClient(config, mockTracer, NewMockMonitorInterface(ctrl), logging.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	c.pool.dial = func() (LDAPConnInterface, error) {
+		if len(conns) == 0 {
+			t.Fatalf("unexpected dial")
+		}
+		conn := conns[0]
+		conns = conns[1:]
+		return conn, nil
+	}
+
+	return c
+}
+
+func TestNewLDAPClientValidation(t *testing.T) {
+	for name, config := range map[string]LDAPConfig{
+		"empty URL":           {BaseDN: "dc=example,dc=org"},
+		"unknown scheme":      {URL: "http://ldap", BaseDN: "dc=example,dc=org"},
+		"StartTLS over ldaps": {URL: "ldaps://ldap", BaseDN: "dc=example,dc=org", StartTLS: true},
+		"empty base DN":       {URL: "ldap://ldap"},
+		"unknown attribute":   {URL: "ldap://ldap", BaseDN: "dc=example,dc=org", UserAttribute: "uid"},
+	} {
+		if _, err := NewLDAPClient(config, nil, nil, logging.NewNoopLogger()); !errors.Is(err, ErrInvalidLDAPConfig) {
+			t.Errorf("%s: expected ErrInvalidLDAPConfig, got %v", name, err)
+		}
+	}
+}
+
+func TestLDAPHookGroupsClient_FetchUserGroups(t *testing.T) {
+	user := User{SubjectId: "123", Email: "a*@example.org"}
+	userDN := "uid=a,ou=people,dc=example,dc=org"
+	config := LDAPConfig{URL: "ldap://ldap", BaseDN: "dc=example,dc=org", GroupBaseDN: "ou=groups,dc=example,dc=org"}
+
+	userEntry := &ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(userDN, nil)}}
+	groupEntries := &ldap.SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry("cn=admins,ou=groups,dc=example,dc=org", map[string][]string{"cn": {"admins"}}),
+		ldap.NewEntry("cn=devs,ou=groups,dc=example,dc=org", map[string][]string{"cn": {"devs"}}),
+		ldap.NewEntry("cn=nameless,ou=groups,dc=example,dc=org", nil),
+	}}
+	expectedGroups := []*types.Group{
+		{ID: "cn=admins,ou=groups,dc=example,dc=org", Name: "admins", TenantId: "default", Type: types.GroupTypeExternal},
+		{ID: "cn=devs,ou=groups,dc=example,dc=org", Name: "devs", TenantId: "default", Type: types.GroupTypeExternal},
+	}
+
+	tests := []struct {
+		name   string
+		config LDAPConfig
+		user   User
+		setup  func(*MockLDAPConnInterface)
+
+		expectedResult []*types.Group
+		expectedError  bool
+	}{
+		{
+			name:   "Groups of the user DN",
+			config: config,
+			user:   user,
+			setup: func(conn *MockLDAPConnInterface) {
+				conn.EXPECT().Search(gomock.Any()).DoAndReturn(func(r *ldap.SearchRequest) (*ldap.SearchResult, error) {
+					if r.BaseDN != "dc=example,dc=org" || r.Filter != `(mail=a\2a@example.org)` {
+						t.Errorf("unexpected user search %s %s", r.BaseDN, r.Filter)
+					}
+					return userEntry, nil
+				})
+				conn.EXPECT().Search(gomock.Any()).DoAndReturn(func(r *ldap.SearchRequest) (*ldap.SearchResult, error) {
+					if r.BaseDN != "ou=groups,dc=example,dc=org" || r.Filter != "(&(objectClass=groupOfNames)(member="+userDN+"))" {
+						t.Errorf("unexpected group search %s %s", r.BaseDN, r.Filter)
+					}
+					return groupEntries, nil
+				})
+				conn.EXPECT().IsClosing().Return(false)
+			},
+			expectedResult: expectedGroups,
+		},
+		{
+			name: "Group filter on the user value, mapped attributes",
+			config: LDAPConfig{
+				URL:                "ldap://ldap",
+				BaseDN:             "dc=example,dc=org",
+				GroupFilter:        "(memberUid={user})",
+				UserAttribute:      LDAPUserAttributeSubject,
+				GroupIDAttribute:   "gidNumber",
+				GroupNameAttribute: "description",
+				TenantID:           "t-1",
+			},
+			user: user,
+			setup: func(conn *MockLDAPConnInterface) {
+				conn.EXPECT().Search(gomock.Any()).DoAndReturn(func(r *ldap.SearchRequest) (*ldap.SearchResult, error) {
+					if r.Filter != "(memberUid=123)" || !reflect.DeepEqual(r.Attributes, []string{"description", "gidNumber"}) {
+						t.Errorf("unexpected group search %s %v", r.Filter, r.Attributes)
+					}
+					return &ldap.SearchResult{Entries: []*ldap.Entry{
+						ldap.NewEntry("cn=admins,dc=example,dc=org", map[string][]string{"gidNumber": {"500"}, "description": {"Admins"}}),
+					}}, nil
+				})
+				conn.EXPECT().IsClosing().Return(false)
+			},
+			expectedResult: []*types.Group{{ID: "500", Name: "Admins", TenantId: "t-1", Type: types.GroupTypeExternal}},
+		},
+		{
+			name:   "User not in the directory",
+			config: config,
+			user:   user,
+			setup: func(conn *MockLDAPConnInterface) {
+				conn.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil)
+				conn.EXPECT().IsClosing().Return(false)
+			},
+			expectedResult: []*types.Group{},
+		},
+		{
+			name:   "Ambiguous user",
+			config: config,
+			user:   user,
+			setup: func(conn *MockLDAPConnInterface) {
+				conn.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry("uid=a", nil), ldap.NewEntry("uid=b", nil)}}, nil)
+				conn.EXPECT().IsClosing().Return(false)
+			},
+			expectedError: true,
+		},
+		{
+			name:   "User without email",
+			config: config,
+			user:   User{SubjectId: "123"},
+			setup:  func(*MockLDAPConnInterface) {},
+		},
+		{
+			name:   "Search error",
+			config: config,
+			user:   user,
+			setup: func(conn *MockLDAPConnInterface) {
+				conn.EXPECT().Search(gomock.Any()).Return(nil, fmt.Errorf("no such object"))
+				conn.EXPECT().IsClosing().Return(false)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conn := NewMockLDAPConnInterface(ctrl)
+			test.setup(conn)
+
+			c := newTestLDAPClient(t, ctrl, test.config, conn)
+
+			result, err := c.FetchUserGroups(t.Context(), test.user)
+
+			if (err != nil) != test.expectedError {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+			if !reflect.DeepEqual(result, test.expectedResult) {
+				t.Fatalf("expected return value to be %v not %v", test.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestLDAPHookGroupsClient_FetchUserGroupsCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := User{Email: "a@example.org"}
+	conn := NewMockLDAPConnInterface(ctrl)
+	conn.EXPECT().Search(gomock.Any()).Times(1).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry("cn=admins,dc=example,dc=org", map[string][]string{"cn": {"admins"}}),
+	}}, nil)
+	conn.EXPECT().IsClosing().Return(false)
+
+	c := newTestLDAPClient(t, ctrl, LDAPConfig{
+		URL:         "ldap://ldap",
+		BaseDN:      "dc=example,dc=org",
+		GroupFilter: "(member={user})",
+		CacheTTL:    time.Minute,
+	}, conn)
+
+	for i := 0; i < 2; i++ {
+		groups, err := c.FetchUserGroups(t.Context(), user)
+		if err != nil || len(groups) != 1 {
+			t.Fatalf("expected one group, got %v %v", groups, err)
+		}
+	}
+}
+
+func TestLDAPPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stale := NewMockLDAPConnInterface(ctrl)
+	fresh := NewMockLDAPConnInterface(ctrl)
+	dialed := 0
+	p := &ldapPool{
+		dial: func() (LDAPConnInterface, error) {
+			dialed++
+			return fresh, nil
+		},
+		idle: make(chan LDAPConnInterface, 1),
+	}
+	p.idle <- stale
+
+	// The idle connection was closed by the server, the call is retried on a
+	// new connection which goes back to the pool.
+	gomock.InOrder(
+		stale.EXPECT().IsClosing().Return(false),
+		stale.EXPECT().Search(gomock.Any()).Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset"))),
+		stale.EXPECT().Close().Return(nil),
+		fresh.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil),
+		fresh.EXPECT().IsClosing().Return(false),
+	)
+
+	err := p.do(t.Context(), func(conn LDAPConnInterface) error {
+		_, err := conn.Search(&ldap.SearchRequest{})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dialed != 1 || len(p.idle) != 1 {
+		t.Fatalf("expected one dial and one idle connection, got %d and %d", dialed, len(p.idle))
+	}
+
+	fresh.EXPECT().Close().Return(nil)
+	p.close()
+	if len(p.idle) != 0 {
+		t.Fatalf("expected the pool to be empty")
+	}
+}
+
+func TestLDAPPoolContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := NewMockLDAPConnInterface(ctrl)
+	p := &ldapPool{
+		dial: func() (LDAPConnInterface, error) {
+			return conn, nil
+		},
+		idle: make(chan LDAPConnInterface, 1),
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if err := p.do(ctx, func(LDAPConnInterface) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error %v, got %v", context.Canceled, err)
+	}
+
+	// The connection is closed when the context is done during a request,
+	// and is not pooled.
+	ctx, cancel = context.WithCancel(t.Context())
+	closed := make(chan struct{})
+	conn.EXPECT().Search(gomock.Any()).DoAndReturn(func(*ldap.SearchRequest) (*ldap.SearchResult, error) {
+		cancel()
+		<-closed
+		return nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection closed"))
+	})
+	conn.EXPECT().Close().DoAndReturn(func() error {
+		close(closed)
+		return nil
+	})
+	conn.EXPECT().Close().Return(nil)
+
+	err := p.do(ctx, func(conn LDAPConnInterface) error {
+		_, err := conn.Search(&ldap.SearchRequest{})
+		return err
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error %v, got %v", context.Canceled, err)
+	}
+	if len(p.idle) != 0 {
+		t.Fatalf("expected the pool to be empty")
+	}
+}
+
+func TestLDAPTimeLimit(t *testing.T) {
+	for timeout, expected := range map[time.Duration]int{
+		500 * time.Millisecond:  1,
+		time.Second:             1,
+		1500 * time.Millisecond: 2,
+		5 * time.Second:         5,
+	} {
+		c, err := NewLDAPClient(LDAPConfig{URL: "ldap://ldap", BaseDN: "dc=example,dc=org", Timeout: timeout}, nil, nil, logging.NewNoopLogger())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got := c.timeLimit(); got != expected {
+			t.Errorf("%s: expected a time limit of %d, got %d", timeout, expected, got)
+		}
+	}
+}
+
+func setupTestOpenLDAP(t *testing.T) string {
+	t.Helper()
+	ctx := t.Context()
+
+	var container testcontainers.Container
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Skipf("Skipping: Docker not available (%v)", r)
+			}
+		}()
+
+		var err error
+		container, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        "osixia/openldap:1.5.0",
+				ExposedPorts: []string{"389/tcp"},
+				Env: map[string]string{
+					"LDAP_ORGANISATION":   "Example",
+					"LDAP_DOMAIN":         "example.org",
+					"LDAP_ADMIN_PASSWORD": "admin",
+					"LDAP_TLS":            "false",
+				},
+				WaitingFor: wait.ForListeningPort("389/tcp"),
+			},
+			Started: true,
+		})
+		if err != nil {
+			t.Skipf("Skipping: Docker/OpenLDAP container failed to start: %v", err)
+		}
+	}()
+
+	if container == nil {
+		return ""
+	}
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get container host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "389")
+	if err != nil {
+		t.Fatalf("Failed to get mapped port: %v", err)
+	}
+	url := fmt.Sprintf("ldap://%s:%s", host, port.Port())
+
+	// slapd accepts connections before the admin entry is ready.
+	var conn *ldap.Conn
+	for i := 0; i < 30; i++ {
+		if conn, err = ldap.DialURL(url); err == nil {
+			if err = conn.Bind("cn=admin,dc=example,dc=org", "admin"); err == nil {
+				break
+			}
+			conn.Close()
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		t.Fatalf("Failed to bind to OpenLDAP: %v", err)
+	}
+	defer conn.Close()
+
+	entries := []struct {
+		dn    string
+		attrs map[string][]string
+	}{
+		{"ou=people,dc=example,dc=org", map[string][]string{"objectClass": {"organizationalUnit"}, "ou": {"people"}}},
+		{"ou=groups,dc=example,dc=org", map[string][]string{"objectClass": {"organizationalUnit"}, "ou": {"groups"}}},
+		{"uid=alice,ou=people,dc=example,dc=org", map[string][]string{"objectClass": {"inetOrgPerson"}, "uid": {"alice"}, "cn": {"Alice"}, "sn": {"Alice"}, "mail": {"alice@example.org"}}},
+		{"uid=bob,ou=people,dc=example,dc=org", map[string][]string{"objectClass": {"inetOrgPerson"}, "uid": {"bob"}, "cn": {"Bob"}, "sn": {"Bob"}, "mail": {"bob@example.org"}}},
+		{"cn=admins,ou=groups,dc=example,dc=org", map[string][]string{"objectClass": {"groupOfNames"}, "cn": {"admins"}, "member": {"uid=alice,ou=people,dc=example,dc=org"}}},
+		{"cn=devs,ou=groups,dc=example,dc=org", map[string][]string{"objectClass": {"groupOfNames"}, "cn": {"devs"}, "member": {"uid=alice,ou=people,dc=example,dc=org", "uid=bob,ou=people,dc=example,dc=org"}}},
+	}
+	for _, e := range entries {
+		req := ldap.NewAddRequest(e.dn, nil)
+		for k, v := range e.attrs {
+			req.Attribute(k, v)
+		}
+		if err := conn.Add(req); err != nil {
+			t.Fatalf("Failed to add %s: %v", e.dn, err)
+		}
+	}
+
+	return url
+}
+
+func TestIntegration_LDAPHookGroupsClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	url := setupTestOpenLDAP(t)
+	if url == "" {
+		return
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(t.Context(), trace.SpanFromContext(t.Context()))
+
+	c, err := NewLDAPClient(LDAPConfig{
+		URL:          url,
+		BindDN:       "cn=admin,dc=example,dc=org",
+		BindPassword: "admin",
+		BaseDN:       "dc=example,dc=org",
+		UserBaseDN:   "ou=people,dc=example,dc=org",
+		GroupBaseDN:  "ou=groups,dc=example,dc=org",
+		PoolSize:     2,
+	}, mockTracer, NewMockMonitorInterface(ctrl), logging.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer c.Close()
+
+	tests := []struct {
+		email    string
+		expected []string
+	}{
+		{"alice@example.org", []string{"admins", "devs"}},
+		{"bob@example.org", []string{"devs"}},
+		{"carol@example.org", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.email, func(t *testing.T) {
+			groups, err := c.FetchUserGroups(t.Context(), User{Email: test.email})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			names := make([]string, 0, len(groups))
+			for _, g := range groups {
+				if g.Type != types.GroupTypeExternal || g.TenantId != "default" {
+					t.Errorf("unexpected group %+v", g)
+				}
+				names = append(names, g.Name)
+			}
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("expected groups %v, got %v", test.expected, names)
+			}
+		})
+	}
+}
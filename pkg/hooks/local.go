@@ -27,10 +27,7 @@ func (c *StorageHookGroupsClient) FetchUserGroups(ctx context.Context, user User
 	ctx, span := c.tracer.Start(ctx, "hooks.StorageHookGroupsClient.FetchUserGroups")
 	defer span.End()
 
-	userId := user.Email
-	if user.ClientId != "" {
-		userId = user.ClientId
-	}
+	userId := user.storageId()
 	if userId == "" {
 		c.logger.Warnf("User ID is empty for user: %#v", user)
 		return nil, nil
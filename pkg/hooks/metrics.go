@@ -0,0 +1,51 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+var (
+	shadowDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_shadow_denials_total",
+		Help: "Total number of token hook requests that would have been denied in shadow mode",
+	}, []string{"client_id"})
+
+	droppedDecisions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hook_service_decision_log_dropped_total",
+		Help: "Total number of token hook decisions that could not be persisted",
+	})
+
+	degradedSources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_group_source_degraded_total",
+		Help: "Total number of token hook requests served without the groups of a failing optional source",
+	}, []string{"source"})
+
+	registerOnce sync.Once
+)
+
+// registerMetrics registers the hook collectors once per process, services
+// are built more than once in tests.
+func registerMetrics(logger logging.LoggerInterface) {
+	registerOnce.Do(func() { register(logger) })
+}
+
+func register(logger logging.LoggerInterface) {
+	for _, collector := range []prometheus.Collector{shadowDenials, droppedDecisions, degradedSources} {
+		err := prometheus.Register(collector)
+		switch err.(type) {
+		case nil:
+			continue
+		case prometheus.AlreadyRegisteredError:
+			logger.Debugf("metric %v already registered", collector)
+		default:
+			logger.Errorf("metric %v could not be registered", collector)
+		}
+	}
+}
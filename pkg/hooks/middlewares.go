@@ -4,14 +4,21 @@
 package hooks
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"io"
 	"net/http"
 
 	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/tracing"
 )
 
+// maxSignedBodySize bounds the body read to verify a request signature.
+const maxSignedBodySize = 1 << 20
+
 type AuthMiddleware struct {
-	token string
+	token    string
+	verifier *SignatureVerifier
 
 	tracer tracing.TracingInterface
 	logger logging.LoggerInterface
@@ -19,22 +26,44 @@ type AuthMiddleware struct {
 
 func (m *AuthMiddleware) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := r.Header.Get("Authorization")
+		if m.token != "" {
+			token := r.Header.Get("Authorization")
+
+			if subtle.ConstantTimeCompare([]byte(m.token), []byte(token)) != 1 {
+				m.logger.Error("Got invalid authorization header, rejecting request")
+				http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if m.verifier != nil {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBodySize))
+			if err != nil {
+				m.logger.Errorf("Failed to read request body: %v", err)
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+
+			if err := m.verifier.Verify(r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body); err != nil {
+				m.logger.Errorf("Got invalid request signature, rejecting request: %v", err)
+				http.Error(w, "Invalid request signature", http.StatusUnauthorized)
+				return
+			}
 
-		if m.token != token {
-			m.logger.Error("Got invalid authorization header, rejecting request")
-			http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
-			return
+			r.Body = io.NopCloser(bytes.NewReader(body))
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
 
-func NewAuthMiddleware(token string, tracer tracing.TracingInterface, logger logging.LoggerInterface) *AuthMiddleware {
+// NewAuthMiddleware creates a middleware checking the static token and, when
+// verifier is not nil, the request signature.
+func NewAuthMiddleware(token string, verifier *SignatureVerifier, tracer tracing.TracingInterface, logger logging.LoggerInterface) *AuthMiddleware {
 	m := new(AuthMiddleware)
 
 	m.token = token
+	m.verifier = verifier
 
 	m.tracer = tracer
 	m.logger = logger
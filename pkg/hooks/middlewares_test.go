@@ -4,9 +4,12 @@
 package hooks
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 )
@@ -50,7 +53,7 @@ func TestMiddleware_Auth(t *testing.T) {
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 			}
 
-			middleware := NewAuthMiddleware(test.apiToken, mockTracer, mockLogger)
+			middleware := NewAuthMiddleware(test.apiToken, nil, mockTracer, mockLogger)
 
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_, _ = w.Write([]byte("handler\n"))
@@ -76,6 +79,79 @@ func TestMiddleware_Auth(t *testing.T) {
 	}
 }
 
+func TestMiddleware_Signature(t *testing.T) {
+	body := `{"session":{}}`
+	verifier, _ := NewSignatureVerifier([]string{"secret"}, time.Minute)
+	timestamp, signature := verifier.Sign(time.Now(), []byte(body))
+
+	tests := []struct {
+		name      string
+		body      string
+		timestamp string
+		signature string
+
+		expectedStatus int
+	}{
+		{
+			name:           "Should fail because no signature provided",
+			body:           body,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Should fail because the body was tampered with",
+			body:           `{"session":{"tampered":true}}`,
+			timestamp:      timestamp,
+			signature:      signature,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Should pass and forward the body",
+			body:           body,
+			timestamp:      timestamp,
+			signature:      signature,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			if test.expectedStatus != http.StatusOK {
+				mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+			}
+
+			middleware := NewAuthMiddleware("", verifier, mockTracer, mockLogger)
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				if string(b) != test.body {
+					t.Fatalf("expected body %q to be forwarded, got %q", test.body, b)
+				}
+			})
+			m := applyMiddlewares(handler, middleware.AuthMiddleware)
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v0/hook/hydra", strings.NewReader(test.body))
+			if test.signature != "" {
+				r.Header.Add(TimestampHeader, test.timestamp)
+				r.Header.Add(SignatureHeader, test.signature)
+			}
+
+			mockResponse := httptest.NewRecorder()
+
+			m.ServeHTTP(mockResponse, r)
+
+			if status := mockResponse.Result().StatusCode; status != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, status)
+			}
+		})
+	}
+}
+
 func applyMiddlewares(handler http.Handler, ms ...func(http.Handler) http.Handler) http.Handler {
 	for i := len(ms) - 1; i >= 0; i-- {
 		handler = ms[i](handler)
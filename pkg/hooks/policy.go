@@ -0,0 +1,185 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultDecision selects how a request is decided when the token hook
+// authorizes a client.
+type DefaultDecision string
+
+const (
+	// DefaultDecisionDeny only allows requests that OpenFGA explicitly allows.
+	DefaultDecisionDeny DefaultDecision = "deny"
+	// DefaultDecisionAllow allows every request without consulting OpenFGA.
+	DefaultDecisionAllow DefaultDecision = "allow"
+	// DefaultDecisionAllowUnbound allows requests to clients that have no
+	// groups bound to them and consults OpenFGA otherwise.
+	DefaultDecisionAllowUnbound DefaultDecision = "allow_unbound"
+)
+
+// ErrorMode selects how OpenFGA or storage errors are treated.
+type ErrorMode string
+
+const (
+	ErrorModeFailClosed ErrorMode = "fail_closed"
+	ErrorModeFailOpen   ErrorMode = "fail_open"
+)
+
+// DecisionReason names the branch that decided an authorization request, it is
+// reported in spans, logs and the decision log.
+type DecisionReason string
+
+const (
+	DecisionReasonCheck              DecisionReason = "openfga_check"
+	DecisionReasonBatchCheck         DecisionReason = "openfga_batch_check"
+	DecisionReasonDefaultAllow       DecisionReason = "default_allow"
+	DecisionReasonUnboundClient      DecisionReason = "unbound_client"
+	DecisionReasonEmptyAudienceAllow DecisionReason = "empty_audience_allow"
+	DecisionReasonEmptyAudienceDeny  DecisionReason = "empty_audience_deny"
+	DecisionReasonFailOpen           DecisionReason = "fail_open"
+	DecisionReasonFailClosed         DecisionReason = "fail_closed"
+	DecisionReasonTenantDenied       DecisionReason = "tenant_denied"
+	DecisionReasonTenantError        DecisionReason = "tenant_error"
+	DecisionReasonTooBusy            DecisionReason = "too_busy"
+)
+
+var (
+	ErrInvalidPolicy = errors.New("invalid authorization policy")
+)
+
+// Policy controls the authorization decision of the token hook.
+type Policy struct {
+	// Default is the default decision, see DefaultDecision.
+	Default DefaultDecision `json:"default,omitempty"`
+	// OnError selects whether OpenFGA or storage errors deny or allow the request.
+	OnError ErrorMode `json:"on_error,omitempty"`
+	// EmptyAudience decides service account requests without a granted
+	// audience, only `allow` and `deny` are accepted.
+	EmptyAudience DefaultDecision `json:"empty_audience,omitempty"`
+	// Shadow evaluates the authorization but always lets the token through,
+	// recording the requests that would have been denied. It does not cover
+	// the tenant membership validation, whose failures still deny the token.
+	Shadow *bool `json:"shadow,omitempty"`
+	// GlobalGroups adds the groups of the default tenant to the groups of
+	// the tenant a request is scoped to.
+	GlobalGroups *bool `json:"global_groups,omitempty"`
+}
+
+// IsShadow reports whether the policy runs in shadow mode.
+func (p Policy) IsShadow() bool {
+	return p.Shadow != nil && *p.Shadow
+}
+
+// IncludesGlobalGroups reports whether the groups of the default tenant are
+// kept in requests scoped to another tenant.
+func (p Policy) IncludesGlobalGroups() bool {
+	return p.GlobalGroups != nil && *p.GlobalGroups
+}
+
+// PolicyConfig holds the global policy and the per client ID overrides.
+// Empty fields of an override inherit the global value.
+type PolicyConfig struct {
+	Policy
+	Clients map[string]Policy `json:"clients,omitempty"`
+}
+
+// DefaultPolicyConfig returns the historical behaviour of the hook: deny by
+// default, fail closed and allow service accounts with no granted audience.
+func DefaultPolicyConfig() *PolicyConfig {
+	c := new(PolicyConfig)
+	c.Default = DefaultDecisionDeny
+	c.OnError = ErrorModeFailClosed
+	c.EmptyAudience = DefaultDecisionAllow
+	c.Clients = make(map[string]Policy)
+	return c
+}
+
+// ParsePolicyConfig decodes a JSON policy configuration on top of the
+// defaults and validates it, an empty string yields the defaults.
+func ParsePolicyConfig(raw string) (*PolicyConfig, error) {
+	c := DefaultPolicyConfig()
+	if raw == "" {
+		return c, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPolicy, err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Validate checks the global policy and every client override.
+func (c *PolicyConfig) Validate() error {
+	if err := c.Policy.validate(); err != nil {
+		return err
+	}
+
+	for clientID, p := range c.Clients {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("client %q: %w", clientID, err)
+		}
+	}
+
+	return nil
+}
+
+// ForClient returns the effective policy for a client ID.
+func (c *PolicyConfig) ForClient(clientID string) Policy {
+	p := c.Policy
+
+	o, ok := c.Clients[clientID]
+	if !ok {
+		return p
+	}
+
+	if o.Default != "" {
+		p.Default = o.Default
+	}
+	if o.OnError != "" {
+		p.OnError = o.OnError
+	}
+	if o.EmptyAudience != "" {
+		p.EmptyAudience = o.EmptyAudience
+	}
+	if o.Shadow != nil {
+		p.Shadow = o.Shadow
+	}
+	if o.GlobalGroups != nil {
+		p.GlobalGroups = o.GlobalGroups
+	}
+
+	return p
+}
+
+func (p Policy) validate() error {
+	switch p.Default {
+	case "", DefaultDecisionDeny, DefaultDecisionAllow, DefaultDecisionAllowUnbound:
+	default:
+		return fmt.Errorf("%w: unknown default decision %q", ErrInvalidPolicy, p.Default)
+	}
+
+	switch p.OnError {
+	case "", ErrorModeFailClosed, ErrorModeFailOpen:
+	default:
+		return fmt.Errorf("%w: unknown error mode %q", ErrInvalidPolicy, p.OnError)
+	}
+
+	switch p.EmptyAudience {
+	case "", DefaultDecisionDeny, DefaultDecisionAllow:
+	default:
+		return fmt.Errorf("%w: unknown empty audience decision %q", ErrInvalidPolicy, p.EmptyAudience)
+	}
+
+	return nil
+}
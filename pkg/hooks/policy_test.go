@@ -0,0 +1,117 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicyConfig(t *testing.T) {
+	global := true
+	tests := []struct {
+		name string
+		raw  string
+
+		expected      *PolicyConfig
+		expectedError error
+	}{
+		{
+			name:     "Empty config yields defaults",
+			raw:      "",
+			expected: DefaultPolicyConfig(),
+		},
+		{
+			name: "Global and client policies",
+			raw:  `{"default":"allow_unbound","on_error":"fail_open","clients":{"app":{"default":"deny"}}}`,
+			expected: &PolicyConfig{
+				Policy:  Policy{Default: DefaultDecisionAllowUnbound, OnError: ErrorModeFailOpen, EmptyAudience: DefaultDecisionAllow},
+				Clients: map[string]Policy{"app": {Default: DefaultDecisionDeny}},
+			},
+		},
+		{
+			name: "Client including global groups",
+			raw:  `{"clients":{"app":{"global_groups":true}}}`,
+			expected: &PolicyConfig{
+				Policy:  Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailClosed, EmptyAudience: DefaultDecisionAllow},
+				Clients: map[string]Policy{"app": {GlobalGroups: &global}},
+			},
+		},
+		{
+			name:          "Unknown default decision",
+			raw:           `{"default":"maybe"}`,
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name:          "Unknown client error mode",
+			raw:           `{"clients":{"app":{"on_error":"retry"}}}`,
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name:          "Empty audience cannot allow unbound",
+			raw:           `{"empty_audience":"allow_unbound"}`,
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name:          "Invalid JSON",
+			raw:           `{"default":`,
+			expectedError: ErrInvalidPolicy,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := ParsePolicyConfig(test.raw)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+			if !reflect.DeepEqual(c, test.expected) {
+				t.Fatalf("expected config to be %v not %v", test.expected, c)
+			}
+		})
+	}
+}
+
+func TestPolicyConfigForClient(t *testing.T) {
+	global := true
+	c := &PolicyConfig{
+		Policy: Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailClosed, EmptyAudience: DefaultDecisionAllow},
+		Clients: map[string]Policy{
+			"app":    {Default: DefaultDecisionAllowUnbound},
+			"portal": {GlobalGroups: &global},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		clientID string
+		expected Policy
+	}{
+		{
+			name:     "Client without override",
+			clientID: "other",
+			expected: c.Policy,
+		},
+		{
+			name:     "Client override inherits unset fields",
+			clientID: "app",
+			expected: Policy{Default: DefaultDecisionAllowUnbound, OnError: ErrorModeFailClosed, EmptyAudience: DefaultDecisionAllow},
+		},
+		{
+			name:     "Client override includes global groups",
+			clientID: "portal",
+			expected: Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailClosed, EmptyAudience: DefaultDecisionAllow, GlobalGroups: &global},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if p := c.ForClient(test.clientID); p != test.expected {
+				t.Fatalf("expected policy to be %v not %v", test.expected, p)
+			}
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/canonical/hook-service/internal/logging"
 	"github.com/canonical/hook-service/internal/monitoring"
 	"github.com/canonical/hook-service/internal/pool"
+	"github.com/canonical/hook-service/internal/storage"
 	"github.com/canonical/hook-service/internal/tenants"
 	"github.com/canonical/hook-service/internal/tracing"
 	"github.com/canonical/hook-service/internal/types"
@@ -27,6 +30,9 @@ type HookContext struct {
 	Groups []*types.Group
 	// TenantID is the tenant the request is scoped to, or empty if none.
 	TenantID string
+	// Tenants are the tenants the user is an active member of, only looked
+	// up when the tenants claim is emitted.
+	Tenants []string
 }
 
 // ErrTooBusy is returned by ProcessRequest when the worker pool queue is full.
@@ -43,15 +49,21 @@ type groupFetchResult struct {
 	err    error
 }
 
-// tenantValidateResult carries the outcome of a pool-dispatched ValidateMembership call.
+// tenantValidateResult carries the outcome of a pool-dispatched resolveTenant call.
 type tenantValidateResult struct {
-	err error
+	tenantID     string
+	autoSelected bool
+	tenants      []string
+	err          error
 }
 
 type Service struct {
-	clients         []ClientInterface
+	sources         []GroupSource
 	authz           AuthorizerInterface
 	tenantValidator TenantValidatorInterface
+	policy          *PolicyConfig
+	tenants         *TenantConfig
+	decisions       DecisionRecorderInterface
 	wpool           pool.WorkerPoolInterface
 
 	tracer  tracing.TracingInterface
@@ -60,15 +72,33 @@ type Service struct {
 }
 
 // ProcessRequest orchestrates an OAuth token hook request. FetchUserGroups and
-// (when a tenant is present) ValidateMembership are dispatched to the worker
-// pool concurrently. AuthorizeRequest is gated only on FetchUserGroups, so
-// tenant validation proceeds in parallel with authorization. Returns ErrTooBusy
+// (when a tenant is present or looked up) resolveTenant are dispatched to the
+// worker pool concurrently. AuthorizeRequest is gated only on FetchUserGroups,
+// so tenant validation proceeds in parallel with authorization, unless the
+// tenant may be selected automatically. Returns ErrTooBusy
 // when the pool queue is full; all other errors indicate an authorization failure.
+// The outcome is handed to the decision recorder.
 func (s *Service) ProcessRequest(ctx context.Context, user User, req oauth2.TokenHookRequest) (*HookContext, error) {
 	ctx, span := s.tracer.Start(ctx, "hooks.Service.ProcessRequest")
 	defer span.End()
 
-	tenantID := extractTenantID(&req)
+	tenantID := s.extractTenantID(&req)
+	resolve := tenantID != "" || s.tenants.lookups(tenantID)
+
+	start := time.Now()
+	decision := &types.Decision{
+		CreatedAt:  start.UTC(),
+		UserID:     user.GetUserId(),
+		ClientID:   req.Request.ClientID,
+		GrantTypes: req.Request.GrantTypes,
+		Audience:   req.Request.GrantedAudience,
+		TenantID:   tenantID,
+		Reason:     string(DecisionReasonTooBusy),
+	}
+	defer func() {
+		decision.Latency = time.Since(start)
+		s.decisions.RecordDecision(ctx, decision)
+	}()
 
 	groupsCh := make(chan *pool.Result[any], 1)
 	tenantCh := make(chan *pool.Result[any], 1)
@@ -76,20 +106,20 @@ func (s *Service) ProcessRequest(ctx context.Context, user User, req oauth2.Toke
 
 	var (
 		tenantOnce sync.Once
-		tenantErr  error
+		tenantRes  = tenantValidateResult{tenantID: tenantID}
 	)
 	// waitTenant drains the in-flight tenant validation job and stores its
-	// error in tenantErr. Idempotent via sync.Once; safe when tenantID is empty.
+	// outcome in tenantRes. Idempotent via sync.Once; safe when no job runs.
 	// Deferred below so error-path returns drain automatically without explicit calls.
 	waitTenant := func() {
 		tenantOnce.Do(func() {
-			if tenantID == "" {
+			if !resolve {
 				return
 			}
 			tenantWg.Wait()
 			close(tenantCh)
 			if r, ok := <-tenantCh; ok {
-				tenantErr = r.Value.(tenantValidateResult).err
+				tenantRes = r.Value.(tenantValidateResult)
 			}
 		})
 	}
@@ -104,10 +134,10 @@ func (s *Service) ProcessRequest(ctx context.Context, user User, req oauth2.Toke
 		return nil, ErrTooBusy
 	}
 
-	if tenantID != "" {
+	if resolve {
 		tenantWg.Add(1)
 		if _, err := s.wpool.Submit(func() any {
-			return tenantValidateResult{err: s.tenantValidator.ValidateMembership(ctx, user.SubjectId, tenantID)}
+			return s.resolveTenant(ctx, user, tenantID)
 		}, tenantCh, &tenantWg); err != nil {
 			tenantWg.Done()
 			groupsWg.Wait()
@@ -125,73 +155,177 @@ func (s *Service) ProcessRequest(ctx context.Context, user User, req oauth2.Toke
 		return nil, ErrTooBusy
 	}
 	gResult := r.Value.(groupFetchResult)
+
+	// The groups are filtered by tenant, so an automatically selected tenant
+	// is needed before authorizing the request.
+	if tenantID == "" && s.tenants.AutoSelect {
+		waitTenant()
+		tenantID = tenantRes.tenantID
+		decision.TenantID = tenantID
+		span.SetAttributes(attribute.Bool("tenant.auto_selected", tenantRes.autoSelected))
+	}
+
+	policy := s.policy.ForClient(req.Request.ClientID)
+	gResult.groups = tenantGroups(gResult.groups, tenantID, policy.IncludesGlobalGroups())
+	shadow := policy.IsShadow()
+	span.SetAttributes(attribute.Bool("authorization.shadow", shadow))
+	decision.Shadow = shadow
+
+	authorized := false
 	if gResult.err != nil {
-		return nil, fmt.Errorf("cannot fetch user groups: %v", gResult.err)
+		switch {
+		case policy.OnError == ErrorModeFailOpen:
+			s.logger.Warnf("failed to fetch groups for user %s, allowing without groups because of fail-open policy: %v", user.GetUserId(), gResult.err)
+			span.SetAttributes(attribute.String("authorization.decision", string(DecisionReasonFailOpen)))
+			decision.Allowed, decision.Reason = true, string(DecisionReasonFailOpen)
+			authorized = true
+		case shadow:
+			s.recordShadowDenial(ctx, user, req, DecisionReasonFailClosed, gResult.err)
+			decision.Reason = string(DecisionReasonFailClosed)
+			authorized = true
+		default:
+			span.SetAttributes(attribute.String("authorization.decision", string(DecisionReasonFailClosed)))
+			decision.Reason = string(DecisionReasonFailClosed)
+			return nil, fmt.Errorf("cannot fetch user groups: %v", gResult.err)
+		}
+		gResult.groups = nil
 	}
 
 	span.SetAttributes(attribute.Int("groups.count", len(gResult.groups)))
-
-	// AuthorizeRequest runs while tenant validation may still be in flight.
-	allowed, err := s.AuthorizeRequest(ctx, user, req, gResult.groups)
-	if err != nil {
-		return nil, fmt.Errorf("cannot authorize request: %v", err)
+	decision.Groups = make([]string, 0, len(gResult.groups))
+	for _, g := range gResult.groups {
+		decision.Groups = append(decision.Groups, g.Name)
 	}
 
-	if !allowed {
-		return nil, fmt.Errorf("access denied for user %s to client %s", user.GetUserId(), req.Request.ClientID)
+	// AuthorizeRequest runs while tenant validation may still be in flight.
+	if !authorized {
+		allowed, reason, err := s.authorize(ctx, user, req, gResult.groups)
+		decision.Allowed, decision.Reason = allowed && err == nil, string(reason)
+		switch {
+		case shadow && (err != nil || !allowed):
+			// Shadow mode: the decision is only recorded, the token is always issued.
+			s.recordShadowDenial(ctx, user, req, reason, err)
+		case err != nil:
+			return nil, fmt.Errorf("cannot authorize request: %v", err)
+		case !allowed:
+			return nil, fmt.Errorf("access denied for user %s to client %s", user.GetUserId(), req.Request.ClientID)
+		}
 	}
 
 	// Explicitly wait here so we can inspect tenantErr before returning.
 	// The deferred call is a no-op after this point.
 	waitTenant()
-	if tenantErr != nil {
+	if tenantErr := tenantRes.err; tenantErr != nil {
+		decision.Allowed = false
 		if errors.Is(tenantErr, tenants.ErrNotMember) {
+			decision.Reason = string(DecisionReasonTenantDenied)
 			return nil, fmt.Errorf("user %s is not a member of tenant %s: %w", user.SubjectId, tenantID, tenants.ErrNotMember)
 		}
+		decision.Reason = string(DecisionReasonTenantError)
 		return nil, fmt.Errorf("cannot validate tenant membership: %w", errTenantInternal)
 	}
 
 	return &HookContext{
 		Groups:   gResult.groups,
 		TenantID: tenantID,
+		Tenants:  tenantRes.tenants,
 	}, nil
 }
 
+// FetchUserGroups queries the group sources concurrently and merges their
+// groups in precedence order. A failing required source fails the fetch, a
+// failing optional source is skipped and counted as degraded.
 func (s *Service) FetchUserGroups(ctx context.Context, user User) ([]*types.Group, error) {
+	groups, _, err := s.fetchUserGroups(ctx, user)
+	return groups, err
+}
+
+// fetchUserGroups implements FetchUserGroups and also returns the names of
+// the optional sources that failed.
+func (s *Service) fetchUserGroups(ctx context.Context, user User) ([]*types.Group, []string, error) {
 	ctx, span := s.tracer.Start(ctx, "hooks.Service.FetchUserGroups")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user.id", user.GetUserId()),
-		attribute.Int("clients.count", len(s.clients)),
+		attribute.Int("sources.count", len(s.sources)),
 	)
 
+	results := make([]sourceResult, len(s.sources))
+	var wg sync.WaitGroup
+	for i, src := range s.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = src.fetch(ctx, user)
+		}()
+	}
+	wg.Wait()
+
 	ret := make([]*types.Group, 0)
+	var degraded []string
+	// seen holds the groups of the sources already merged, so a source with a
+	// lower precedence cannot override them.
+	seen := make(map[string]struct{})
+
+	for i, src := range s.sources {
+		r := results[i]
+		if r.err != nil {
+			span.RecordError(r.err)
+			if src.Required {
+				span.SetStatus(codes.Error, "failed to fetch user groups from required source")
+				return nil, degraded, fmt.Errorf("group source %s: %w", src.Name, r.err)
+			}
 
-	for _, c := range s.clients {
-		groups, err := c.FetchUserGroups(ctx, user)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "failed to fetch user groups from client")
-			return nil, err
+			degradedSources.WithLabelValues(src.Name).Inc()
+			s.logger.Warnf("group source %s failed for user %s, continuing without its groups: %v", src.Name, user.GetUserId(), r.err)
+			degraded = append(degraded, src.Name)
+			continue
+		}
+
+		keys := make(map[string]struct{}, len(r.groups))
+		for _, g := range r.groups {
+			key := g.TenantId + keySeparator + g.Name
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			keys[key] = struct{}{}
+			ret = append(ret, g)
+		}
+		for key := range keys {
+			seen[key] = struct{}{}
 		}
-		ret = append(ret, groups...)
 	}
 
-	span.SetAttributes(attribute.Int("groups.total_count", len(ret)))
+	span.SetAttributes(
+		attribute.Int("groups.total_count", len(ret)),
+		attribute.StringSlice("sources.degraded", degraded),
+	)
 	span.SetStatus(codes.Ok, "user groups fetched successfully")
 
-	return ret, nil
+	return ret, degraded, nil
 }
 
-// This implements deny by default
-// TODO: we should make this configurable
+// AuthorizeRequest decides whether the user may obtain a token for the client
+// according to the policy configured for the client ID.
 func (s *Service) AuthorizeRequest(
 	ctx context.Context,
 	user User,
 	req oauth2.TokenHookRequest,
 	groups []*types.Group,
 ) (bool, error) {
+	allowed, _, err := s.authorize(ctx, user, req, groups)
+	return allowed, err
+}
+
+// authorize implements AuthorizeRequest and also returns the branch that
+// decided the request.
+func (s *Service) authorize(
+	ctx context.Context,
+	user User,
+	req oauth2.TokenHookRequest,
+	groups []*types.Group,
+) (bool, DecisionReason, error) {
 	ctx, span := s.tracer.Start(ctx, "hooks.Service.AuthorizeRequest")
 	defer span.End()
 
@@ -200,61 +334,148 @@ func (s *Service) AuthorizeRequest(
 		groupIDs = append(groupIDs, g.ID)
 	}
 
+	policy := s.policy.ForClient(req.Request.ClientID)
 	isServiceAcct := isServiceAccount(req.Request.GrantTypes)
 	span.SetAttributes(
 		attribute.String("user.id", user.GetUserId()),
 		attribute.String("client.id", req.Request.ClientID),
 		attribute.Int("groups.count", len(groupIDs)),
 		attribute.Bool("is_service_account", isServiceAcct),
+		attribute.String("authorization.policy.default", string(policy.Default)),
+		attribute.String("authorization.policy.on_error", string(policy.OnError)),
 	)
 
-	var allowed bool
-	var err error
-
-	if !isServiceAcct {
-		allowed, err = s.authz.CanAccess(ctx, user.GetUserId(), req.Request.ClientID, groupIDs)
-		span.SetAttributes(attribute.String("authorization.type", "user_access"))
-	} else if len(req.Request.GrantedAudience) > 0 {
-		allowed, err = s.authz.BatchCanAccess(ctx, user.GetUserId(), req.Request.GrantedAudience, groupIDs)
-		span.SetAttributes(
-			attribute.String("authorization.type", "batch_access"),
-			attribute.StringSlice("granted_audience", req.Request.GrantedAudience),
-		)
-	} else {
-		s.logger.Debugf("Allowed, because empty granted audience in request for service account: %#v", user)
-		allowed = true
+	var (
+		allowed bool
+		reason  DecisionReason
+		err     error
+	)
+
+	switch {
+	case isServiceAcct && len(req.Request.GrantedAudience) == 0:
+		allowed = policy.EmptyAudience == DefaultDecisionAllow
+		reason = DecisionReasonEmptyAudienceDeny
+		if allowed {
+			reason = DecisionReasonEmptyAudienceAllow
+		}
+	case policy.Default == DefaultDecisionAllow:
+		allowed, reason = true, DecisionReasonDefaultAllow
+	default:
+		clientIDs := []string{req.Request.ClientID}
+		if isServiceAcct {
+			clientIDs = req.Request.GrantedAudience
+		}
+
+		unbound := false
+		if policy.Default == DefaultDecisionAllowUnbound {
+			unbound, err = s.unboundClients(ctx, clientIDs)
+		}
+
+		switch {
+		case err != nil:
+		case unbound:
+			allowed, reason = true, DecisionReasonUnboundClient
+		case !isServiceAcct:
+			allowed, err = s.authz.CanAccess(ctx, user.GetUserId(), req.Request.ClientID, groupIDs)
+			reason = DecisionReasonCheck
+			span.SetAttributes(attribute.String("authorization.type", "user_access"))
+		default:
+			allowed, err = s.authz.BatchCanAccess(ctx, user.GetUserId(), req.Request.GrantedAudience, groupIDs)
+			reason = DecisionReasonBatchCheck
+			span.SetAttributes(
+				attribute.String("authorization.type", "batch_access"),
+				attribute.StringSlice("granted_audience", req.Request.GrantedAudience),
+			)
+		}
 	}
 
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "authorization check failed")
-		return false, err
+		if policy.OnError != ErrorModeFailOpen {
+			span.SetAttributes(attribute.String("authorization.decision", string(DecisionReasonFailClosed)))
+			span.SetStatus(codes.Error, "authorization check failed")
+			return false, DecisionReasonFailClosed, err
+		}
+
+		s.logger.Warnf("authorization check failed for user %s to client %s, allowing because of fail-open policy: %v", user.GetUserId(), req.Request.ClientID, err)
+		allowed, reason = true, DecisionReasonFailOpen
 	}
 
-	span.SetAttributes(attribute.Bool("authorization.allowed", allowed))
+	s.logger.Debugf("authorization decision for user %s to client %s: allowed=%v reason=%s", user.GetUserId(), req.Request.ClientID, allowed, reason)
+
+	span.SetAttributes(
+		attribute.Bool("authorization.allowed", allowed),
+		attribute.String("authorization.decision", string(reason)),
+	)
 	if allowed {
 		span.SetStatus(codes.Ok, "authorization successful")
 	} else {
 		span.SetStatus(codes.Ok, "authorization denied")
 	}
 
-	return allowed, nil
+	return allowed, reason, nil
+}
+
+// recordShadowDenial reports a request that would have been denied if the
+// client was not in shadow mode.
+func (s *Service) recordShadowDenial(ctx context.Context, user User, req oauth2.TokenHookRequest, reason DecisionReason, err error) {
+	shadowDenials.WithLabelValues(req.Request.ClientID).Inc()
+
+	options := []logging.Option{
+		logging.WithContext(ctx),
+		logging.WithLabel("reason", string(reason)),
+		logging.WithLabel("grant_types", strings.Join(req.Request.GrantTypes, " ")),
+	}
+	if err != nil {
+		options = append(options, logging.WithLabel("error", err.Error()))
+	}
+
+	s.logger.Security().AuthzShadowDenial(user.GetUserId(), req.Request.ClientID, options...)
 }
 
-// extractTenantID returns the tenant ID from the session extra data, or
-// an empty string if none was set at login time.
-func extractTenantID(req *oauth2.TokenHookRequest) string {
-	if req.Session == nil || req.Session.Extra == nil {
-		return ""
+// unboundClients reports whether none of the clients have groups bound to them.
+func (s *Service) unboundClients(ctx context.Context, clientIDs []string) (bool, error) {
+	for _, clientID := range clientIDs {
+		bound, err := s.authz.HasAllowedGroups(ctx, clientID)
+		if err != nil {
+			return false, err
+		}
+		if bound {
+			return false, nil
+		}
 	}
-	tid, _ := req.Session.Extra["_tenant_id"].(string)
-	return tid
+	return true, nil
+}
+
+// tenantGroups keeps the groups of the tenant a request is scoped to, and the
+// groups of the default tenant when global groups are included, so that the
+// claims and the OpenFGA checks of a tenant never see the groups of another
+// one. Requests without a tenant keep every group.
+func tenantGroups(groups []*types.Group, tenantID string, global bool) []*types.Group {
+	if tenantID == "" {
+		return groups
+	}
+
+	ret := make([]*types.Group, 0, len(groups))
+	for _, g := range groups {
+		switch {
+		case g.TenantId == tenantID:
+		case global && (g.TenantId == storage.DefaultTenantID || g.TenantId == ""):
+		default:
+			continue
+		}
+		ret = append(ret, g)
+	}
+	return ret
 }
 
 func NewService(
-	clients []ClientInterface,
+	sources []GroupSource,
 	authz AuthorizerInterface,
 	tenantValidator TenantValidatorInterface,
+	policy *PolicyConfig,
+	tenantConfig *TenantConfig,
+	decisions DecisionRecorderInterface,
 	wpool pool.WorkerPoolInterface,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
@@ -262,11 +483,28 @@ func NewService(
 ) *Service {
 	s := new(Service)
 
-	s.clients = clients
+	s.sources = sources
 	s.authz = authz
 	s.tenantValidator = tenantValidator
 	s.wpool = wpool
 
+	s.policy = policy
+	if s.policy == nil {
+		s.policy = DefaultPolicyConfig()
+	}
+
+	s.tenants = tenantConfig
+	if s.tenants == nil {
+		s.tenants = DefaultTenantConfig()
+	}
+
+	s.decisions = decisions
+	if s.decisions == nil {
+		s.decisions = (*DecisionLog)(nil)
+	}
+
+	registerMetrics(logger)
+
 	s.monitor = monitor
 	s.tracer = tracer
 	s.logger = logger
@@ -6,6 +6,7 @@ package hooks
 import (
 	"context"
 	"errors"
+	"fmt"
 	reflect "reflect"
 	"sync"
 	"testing"
@@ -45,6 +46,18 @@ func setupMockSubmit(wp *MockWorkerPoolInterface) {
 	).Return(key.String(), nil)
 }
 
+// requiredSources wraps the clients in required group sources, in order.
+func requiredSources(clients ...ClientInterface) []GroupSource {
+	sources := make([]GroupSource, 0, len(clients))
+	for i, c := range clients {
+		sources = append(sources, GroupSource{
+			GroupSourceConfig: GroupSourceConfig{Name: fmt.Sprintf("source-%d", i), Required: true, Precedence: i},
+			Client:            c,
+		})
+	}
+	return sources
+}
+
 func TestServiceFetchUserGroups(t *testing.T) {
 	err := errors.New("some error")
 	u := User{SubjectId: "123", Email: "a@a.com"}
@@ -87,7 +100,7 @@ func TestServiceFetchUserGroups(t *testing.T) {
 				mockClient2.EXPECT().FetchUserGroups(gomock.Any(), u).Return([]*types.Group{{Name: "g3"}, {Name: "g1"}}, nil)
 				return []ClientInterface{mockClient1, mockClient2}
 			},
-			expectedResult: []*types.Group{{Name: "g1"}, {Name: "g2"}, {Name: "g3"}, {Name: "g1"}},
+			expectedResult: []*types.Group{{Name: "g1"}, {Name: "g2"}, {Name: "g3"}},
 		},
 		{
 			name:  "Multiple services with empty result",
@@ -112,6 +125,7 @@ func TestServiceFetchUserGroups(t *testing.T) {
 				mockClient2 := NewMockClientInterface(ctrl)
 				mockClient2.EXPECT().FetchUserGroups(gomock.Any(), u).Return(nil, err)
 				mockClient3 := NewMockClientInterface(ctrl)
+				mockClient3.EXPECT().FetchUserGroups(gomock.Any(), u).Return(nil, nil)
 				return []ClientInterface{mockClient1, mockClient2, mockClient3}
 			},
 			expectedError: err,
@@ -130,11 +144,11 @@ func TestServiceFetchUserGroups(t *testing.T) {
 
 			mockTracer.EXPECT().Start(gomock.Any(), "hooks.Service.FetchUserGroups").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 
-			s := NewService(test.mockedClients(ctrl), mockAuthorizer, nil, nil, mockTracer, mockMonitor, mockLogger)
+			s := NewService(requiredSources(test.mockedClients(ctrl)...), mockAuthorizer, nil, nil, nil, nil, nil, mockTracer, mockMonitor, mockLogger)
 
 			groups, err := s.FetchUserGroups(context.TODO(), test.input)
 
-			if err != test.expectedError {
+			if !errors.Is(err, test.expectedError) {
 				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
 			}
 			if !reflect.DeepEqual(groups, test.expectedResult) {
@@ -157,6 +171,7 @@ func TestServiceAuthorizeRequest(t *testing.T) {
 		grantTypes []string
 		grantedAud []string
 		groups     []*types.Group
+		policy     *PolicyConfig
 
 		mockedCanAccess func(*gomock.Controller) AuthorizerInterface
 
@@ -277,6 +292,81 @@ func TestServiceAuthorizeRequest(t *testing.T) {
 			expectedResult: false,
 			expectedError:  err,
 		},
+		{
+			name:       "Service account with empty audience is allowed by default",
+			user:       serviceAccount,
+			clientId:   "client_id",
+			grantTypes: []string{"client_credentials"},
+			mockedCanAccess: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			expectedResult: true,
+		},
+		{
+			name:       "Service account with empty audience is denied by policy",
+			user:       serviceAccount,
+			clientId:   "client_id",
+			grantTypes: []string{"client_credentials"},
+			policy:     &PolicyConfig{Policy: Policy{Default: DefaultDecisionDeny, EmptyAudience: DefaultDecisionDeny}},
+			mockedCanAccess: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			expectedResult: false,
+		},
+		{
+			name:       "Allow by default skips the check",
+			user:       user,
+			clientId:   "client_id",
+			grantTypes: []string{"authorization_code"},
+			policy:     &PolicyConfig{Policy: Policy{Default: DefaultDecisionDeny}, Clients: map[string]Policy{"client_id": {Default: DefaultDecisionAllow}}},
+			mockedCanAccess: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			expectedResult: true,
+		},
+		{
+			name:       "Allow unbound client",
+			user:       user,
+			clientId:   "client_id",
+			grantTypes: []string{"authorization_code"},
+			groups:     []*types.Group{{ID: "g1", Name: "g1"}},
+			policy:     &PolicyConfig{Policy: Policy{Default: DefaultDecisionAllowUnbound}},
+			mockedCanAccess: func(ctrl *gomock.Controller) AuthorizerInterface {
+				mockAuthorizer := NewMockAuthorizerInterface(ctrl)
+				mockAuthorizer.EXPECT().HasAllowedGroups(gomock.Any(), "client_id").Return(false, nil)
+				return mockAuthorizer
+			},
+			expectedResult: true,
+		},
+		{
+			name:       "Check bound client when allowing unbound clients",
+			user:       user,
+			clientId:   "client_id",
+			grantTypes: []string{"authorization_code"},
+			groups:     []*types.Group{{ID: "g1", Name: "g1"}},
+			policy:     &PolicyConfig{Policy: Policy{Default: DefaultDecisionAllowUnbound}},
+			mockedCanAccess: func(ctrl *gomock.Controller) AuthorizerInterface {
+				mockAuthorizer := NewMockAuthorizerInterface(ctrl)
+				mockAuthorizer.EXPECT().HasAllowedGroups(gomock.Any(), "client_id").Return(true, nil)
+				mockAuthorizer.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client_id", []string{"g1"}).Return(false, nil)
+				return mockAuthorizer
+			},
+			expectedResult: false,
+		},
+		{
+			name:       "Authorization check fails with fail-open policy",
+			user:       user,
+			clientId:   "client_id",
+			grantTypes: []string{"authorization_code"},
+			groups:     []*types.Group{{ID: "g1", Name: "g1"}},
+			policy:     &PolicyConfig{Policy: Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailOpen}},
+			mockedCanAccess: func(ctrl *gomock.Controller) AuthorizerInterface {
+				mockAuthorizer := NewMockAuthorizerInterface(ctrl)
+				mockAuthorizer.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client_id", []string{"g1"}).Return(false, err)
+				return mockAuthorizer
+			},
+			expectedResult: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -295,8 +385,10 @@ func TestServiceAuthorizeRequest(t *testing.T) {
 			mockClient := NewMockClientInterface(ctrl)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "hooks.Service.AuthorizeRequest").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
 
-			s := NewService([]ClientInterface{mockClient}, test.mockedCanAccess(ctrl), nil, nil, mockTracer, mockMonitor, mockLogger)
+			s := NewService(requiredSources(mockClient), test.mockedCanAccess(ctrl), nil, test.policy, nil, nil, nil, mockTracer, mockMonitor, mockLogger)
 
 			req := createHookRequest(test.clientId, test.user.SubjectId, test.grantTypes, test.grantedAud)
 
@@ -317,17 +409,42 @@ func TestServiceProcessRequest(t *testing.T) {
 	someErr := errors.New("some error")
 	user := User{SubjectId: "user-123", Email: "a@a.com"}
 
-	groups := []*types.Group{{ID: "g1", Name: "g1"}}
+	groups := []*types.Group{{ID: "g1", Name: "g1", TenantId: "t-1"}}
 
-	newService := func(ctrl *gomock.Controller, mockClient ClientInterface, mockAuthz AuthorizerInterface, mockTV TenantValidatorInterface, mockPool pool.WorkerPoolInterface) *Service {
+	newService := func(ctrl *gomock.Controller, mockClient ClientInterface, mockAuthz AuthorizerInterface, mockTV TenantValidatorInterface, mockPool pool.WorkerPoolInterface, policy *PolicyConfig, tenantConfig *TenantConfig, shadowDenials int, recorded *types.Decision) *Service {
 		mockTracer := NewMockTracingInterface(ctrl)
 		mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
 		mockMonitor := NewMockMonitorInterface(ctrl)
 		mockLogger := NewMockLoggerInterface(ctrl)
 		mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
-		return NewService([]ClientInterface{mockClient}, mockAuthz, mockTV, mockPool, mockTracer, mockMonitor, mockLogger)
+		mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+		mockSecurityLogger := NewMockSecurityLoggerInterface(ctrl)
+		mockSecurityLogger.EXPECT().AuthzShadowDenial(user.GetUserId(), "client", gomock.Any()).Times(shadowDenials)
+		mockLogger.EXPECT().Security().Return(mockSecurityLogger).Times(shadowDenials)
+		mockRecorder := NewMockDecisionRecorderInterface(ctrl)
+		mockRecorder.EXPECT().RecordDecision(gomock.Any(), gomock.Any()).Do(func(_ context.Context, d *types.Decision) { *recorded = *d })
+		return NewService(requiredSources(mockClient), mockAuthz, mockTV, policy, tenantConfig, mockRecorder, mockPool, mockTracer, mockMonitor, mockLogger)
+	}
+
+	shadow := true
+	shadowPolicy := &PolicyConfig{
+		Policy:  Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailClosed},
+		Clients: map[string]Policy{"client": {Shadow: &shadow}},
 	}
 
+	tenantsGroups := []*types.Group{
+		{ID: "g1", Name: "g1", TenantId: "t-1"},
+		{ID: "g2", Name: "g2", TenantId: "t-2"},
+		{ID: "g3", Name: "g3", TenantId: "default"},
+	}
+	global := true
+	globalGroupsPolicy := &PolicyConfig{
+		Policy:  Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailClosed},
+		Clients: map[string]Policy{"client": {GlobalGroups: &global}},
+	}
+
+	autoSelect := &TenantConfig{SessionKey: DefaultTenantSessionKey, AutoSelect: true}
+
 	tests := []struct {
 		name string
 
@@ -337,10 +454,15 @@ func TestServiceProcessRequest(t *testing.T) {
 		mockAuthz  func(*gomock.Controller) AuthorizerInterface
 		mockTV     func(*gomock.Controller) TenantValidatorInterface
 		mockPool   func(*gomock.Controller) pool.WorkerPoolInterface
+		policy     *PolicyConfig
+		tenants    *TenantConfig
 
-		expectedResult *HookContext
-		expectedError  error
-		expectedErrIs  error
+		expectedShadowDenials int
+		expectedDecision      DecisionReason
+		expectedAllowed       bool
+		expectedResult        *HookContext
+		expectedError         error
+		expectedErrIs         error
 	}{
 		{
 			name: "groups fetched, no tenant, authorized",
@@ -363,7 +485,9 @@ func TestServiceProcessRequest(t *testing.T) {
 				setupMockSubmit(m)
 				return m
 			},
-			expectedResult: &HookContext{Groups: groups},
+			expectedDecision: DecisionReasonCheck,
+			expectedAllowed:  true,
+			expectedResult:   &HookContext{Groups: groups},
 		},
 		{
 			name: "groups fetched, tenant member, authorized",
@@ -413,7 +537,8 @@ func TestServiceProcessRequest(t *testing.T) {
 				setupMockSubmit(m)
 				return m
 			},
-			expectedErrIs: tenants.ErrNotMember,
+			expectedDecision: DecisionReasonTenantDenied,
+			expectedErrIs:    tenants.ErrNotMember,
 		},
 		{
 			name: "tenant service error — errTenantInternal returned",
@@ -438,7 +563,8 @@ func TestServiceProcessRequest(t *testing.T) {
 				setupMockSubmit(m)
 				return m
 			},
-			expectedErrIs: errTenantInternal,
+			expectedDecision: DecisionReasonTenantError,
+			expectedErrIs:    errTenantInternal,
 		},
 		{
 			name: "groups fetch error — error returned",
@@ -459,7 +585,32 @@ func TestServiceProcessRequest(t *testing.T) {
 				setupMockSubmit(m)
 				return m
 			},
-			expectedError: errors.New("cannot fetch user groups: some error"),
+			expectedDecision: DecisionReasonFailClosed,
+			expectedError:    errors.New("cannot fetch user groups: group source source-0: some error"),
+		},
+		{
+			name: "groups fetch error with fail-open policy — token issued without groups",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(nil, someErr)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				return NewMockTenantValidatorInterface(ctrl)
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:           &PolicyConfig{Policy: Policy{Default: DefaultDecisionDeny, OnError: ErrorModeFailOpen}},
+			expectedDecision: DecisionReasonFailOpen,
+			expectedAllowed:  true,
+			expectedResult:   &HookContext{},
 		},
 		{
 			name: "access denied — error returned",
@@ -482,7 +633,342 @@ func TestServiceProcessRequest(t *testing.T) {
 				setupMockSubmit(m)
 				return m
 			},
-			expectedError: errors.New("access denied for user user-123 to client client"),
+			expectedDecision: DecisionReasonCheck,
+			expectedError:    errors.New("access denied for user user-123 to client client"),
+		},
+		{
+			name: "tenant selected — groups of other tenants dropped",
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(nil)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			expectedResult: &HookContext{Groups: tenantsGroups[:1], TenantID: "t-1"},
+		},
+		{
+			name: "tenant selected with global groups — default tenant groups kept",
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1", "g3"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(nil)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:         globalGroupsPolicy,
+			expectedResult: &HookContext{Groups: []*types.Group{tenantsGroups[0], tenantsGroups[2]}, TenantID: "t-1"},
+		},
+		{
+			name: "no tenant, auto selection — only tenant selected",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ListTenants(gomock.Any(), user.SubjectId).Return([]string{"t-1"}, nil)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			tenants:        autoSelect,
+			expectedResult: &HookContext{Groups: tenantsGroups[:1], TenantID: "t-1"},
+		},
+		{
+			name: "no tenant, auto selection — user of several tenants not scoped",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1", "g2", "g3"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ListTenants(gomock.Any(), user.SubjectId).Return([]string{"t-1", "t-2"}, nil)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			tenants:        autoSelect,
+			expectedResult: &HookContext{Groups: tenantsGroups},
+		},
+		{
+			name: "no tenant, auto selection — lookup error ignored",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1", "g2", "g3"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ListTenants(gomock.Any(), user.SubjectId).Return(nil, someErr)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			tenants:        autoSelect,
+			expectedResult: &HookContext{Groups: tenantsGroups},
+		},
+		{
+			name: "tenant selected, tenants listed for the claim",
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(nil)
+				m.EXPECT().ListTenants(gomock.Any(), user.SubjectId).Return([]string{"t-1", "t-2"}, nil)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			tenants:        &TenantConfig{SessionKey: DefaultTenantSessionKey, ListTenants: true},
+			expectedResult: &HookContext{Groups: tenantsGroups[:1], TenantID: "t-1", Tenants: []string{"t-1", "t-2"}},
+		},
+		{
+			name: "tenant selected under a custom session key",
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"tenant": "t-1", "_tenant_id": "t-2"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(tenantsGroups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(nil)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			tenants:        &TenantConfig{SessionKey: "tenant"},
+			expectedResult: &HookContext{Groups: tenantsGroups[:1], TenantID: "t-1"},
+		},
+		{
+			name: "access denied in shadow mode — token issued",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(false, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				return NewMockTenantValidatorInterface(ctrl)
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:                shadowPolicy,
+			expectedShadowDenials: 1,
+			expectedResult:        &HookContext{Groups: groups},
+		},
+		{
+			name: "authorization error in shadow mode — token issued",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(false, someErr)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				return NewMockTenantValidatorInterface(ctrl)
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:                shadowPolicy,
+			expectedShadowDenials: 1,
+			expectedResult:        &HookContext{Groups: groups},
+		},
+		{
+			name: "groups fetch error in shadow mode — token issued without groups",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(nil, someErr)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				return NewMockAuthorizerInterface(ctrl)
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				return NewMockTenantValidatorInterface(ctrl)
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:                shadowPolicy,
+			expectedShadowDenials: 1,
+			expectedResult:        &HookContext{},
+		},
+		{
+			name: "tenant denied in shadow mode — token denied",
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(tenants.ErrNotMember)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:           shadowPolicy,
+			expectedDecision: DecisionReasonTenantDenied,
+			expectedErrIs:    tenants.ErrNotMember,
+		},
+		{
+			name: "tenant error in shadow mode — token denied",
+			req:  createHookRequestWithExtra("client", user.SubjectId, []string{"authorization_code"}, nil, map[string]interface{}{"_tenant_id": "t-1"}),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(false, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				m := NewMockTenantValidatorInterface(ctrl)
+				m.EXPECT().ValidateMembership(gomock.Any(), user.SubjectId, "t-1").Return(someErr)
+				return m
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:                shadowPolicy,
+			expectedShadowDenials: 1,
+			expectedDecision:      DecisionReasonTenantError,
+			expectedErrIs:         errTenantInternal,
+		},
+		{
+			name: "authorized in shadow mode — nothing recorded",
+			req:  createHookRequest("client", user.SubjectId, []string{"authorization_code"}, nil),
+			mockClient: func(ctrl *gomock.Controller) ClientInterface {
+				m := NewMockClientInterface(ctrl)
+				m.EXPECT().FetchUserGroups(gomock.Any(), user).Return(groups, nil)
+				return m
+			},
+			mockAuthz: func(ctrl *gomock.Controller) AuthorizerInterface {
+				m := NewMockAuthorizerInterface(ctrl)
+				m.EXPECT().CanAccess(gomock.Any(), user.GetUserId(), "client", []string{"g1"}).Return(true, nil)
+				return m
+			},
+			mockTV: func(ctrl *gomock.Controller) TenantValidatorInterface {
+				return NewMockTenantValidatorInterface(ctrl)
+			},
+			mockPool: func(ctrl *gomock.Controller) pool.WorkerPoolInterface {
+				m := NewMockWorkerPoolInterface(ctrl)
+				setupMockSubmit(m)
+				return m
+			},
+			policy:         shadowPolicy,
+			expectedResult: &HookContext{Groups: groups},
 		},
 	}
 
@@ -491,10 +977,20 @@ func TestServiceProcessRequest(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := newService(ctrl, test.mockClient(ctrl), test.mockAuthz(ctrl), test.mockTV(ctrl), test.mockPool(ctrl))
+			recorded := new(types.Decision)
+			s := newService(ctrl, test.mockClient(ctrl), test.mockAuthz(ctrl), test.mockTV(ctrl), test.mockPool(ctrl), test.policy, test.tenants, test.expectedShadowDenials, recorded)
 
 			result, err := s.ProcessRequest(context.TODO(), user, test.req)
 
+			if test.expectedDecision != "" {
+				if recorded.Reason != string(test.expectedDecision) || recorded.Allowed != test.expectedAllowed {
+					t.Fatalf("expected decision %s allowed=%v, got %s allowed=%v", test.expectedDecision, test.expectedAllowed, recorded.Reason, recorded.Allowed)
+				}
+				if recorded.UserID != user.GetUserId() || recorded.ClientID != "client" {
+					t.Fatalf("expected decision for %s to client, got %s to %s", user.GetUserId(), recorded.UserID, recorded.ClientID)
+				}
+			}
+
 			if test.expectedError != nil {
 				if err == nil {
 					t.Fatalf("expected error %q, got nil", test.expectedError)
@@ -522,6 +1018,12 @@ func TestServiceProcessRequest(t *testing.T) {
 			if result.TenantID != test.expectedResult.TenantID {
 				t.Fatalf("expected TenantID %q, got %q", test.expectedResult.TenantID, result.TenantID)
 			}
+			if recorded.TenantID != test.expectedResult.TenantID {
+				t.Fatalf("expected recorded TenantID %q, got %q", test.expectedResult.TenantID, recorded.TenantID)
+			}
+			if !reflect.DeepEqual(result.Tenants, test.expectedResult.Tenants) {
+				t.Fatalf("expected tenants %v, got %v", test.expectedResult.Tenants, result.Tenants)
+			}
 		})
 	}
 }
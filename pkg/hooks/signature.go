@@ -0,0 +1,30 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"time"
+
+	"github.com/canonical/hook-service/internal/signing"
+)
+
+const (
+	// TimestampHeader carries the Unix time in seconds at which the request was signed.
+	TimestampHeader = "X-Hook-Timestamp"
+	// SignatureHeader carries the request signature as `v1=<hex HMAC-SHA256>`.
+	SignatureHeader = "X-Hook-Signature"
+)
+
+// SignatureVerifier checks the signature of the hook requests.
+type SignatureVerifier = signing.Signer
+
+// NewSignatureVerifier creates a verifier accepting up to two secrets.
+// It returns nil when no secret is configured, disabling request signing.
+func NewSignatureVerifier(secrets []string, window time.Duration) (*SignatureVerifier, error) {
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	return signing.NewSigner(secrets, window)
+}
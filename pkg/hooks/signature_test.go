@@ -0,0 +1,39 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/canonical/hook-service/internal/signing"
+)
+
+func TestNewSignatureVerifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets []string
+		window  time.Duration
+
+		disabled      bool
+		expectedError error
+	}{
+		{name: "No secret disables signing", disabled: true},
+		{name: "Two secrets", secrets: []string{"a", "b"}, window: time.Minute},
+		{name: "Too many secrets", secrets: []string{"a", "b", "c"}, window: time.Minute, expectedError: signing.ErrInvalidConfig},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := NewSignatureVerifier(test.secrets, test.window)
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+			if err == nil && (v == nil) != test.disabled {
+				t.Fatalf("expected disabled to be %v", test.disabled)
+			}
+		})
+	}
+}
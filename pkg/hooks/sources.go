@@ -0,0 +1,152 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// GroupSourceLocal is the name of the group source backed by the local database.
+const GroupSourceLocal = "local"
+
+var (
+	ErrInvalidGroupSource = errors.New("invalid group source")
+)
+
+// GroupSourceConfig declares how the groups of a source are fetched and merged.
+type GroupSourceConfig struct {
+	// Name identifies the source, it must match a known group client.
+	Name string `json:"name"`
+	// Timeout bounds each fetch from the source, no timeout when zero.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Required sources fail the token hook when they fail, optional sources
+	// are skipped and reported as degraded.
+	Required bool `json:"required"`
+	// Precedence orders the sources, lower first. When several sources return
+	// a group with the same name in the same tenant, the first one wins.
+	Precedence int `json:"precedence"`
+}
+
+// UnmarshalJSON decodes a source config, the timeout is a duration string
+// such as `500ms`.
+func (c *GroupSourceConfig) UnmarshalJSON(data []byte) error {
+	type alias GroupSourceConfig
+	aux := struct {
+		*alias
+		Timeout string `json:"timeout,omitempty"`
+	}{alias: (*alias)(c)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.Timeout = 0
+	if aux.Timeout != "" {
+		d, err := time.ParseDuration(aux.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %v", aux.Timeout, err)
+		}
+		c.Timeout = d
+	}
+	return nil
+}
+
+// GroupSource is a group client and the config it is queried with.
+type GroupSource struct {
+	GroupSourceConfig
+	Client ClientInterface
+}
+
+// DefaultGroupSourceConfigs returns the historical setup of the hook: the
+// local database as the only, required, source.
+func DefaultGroupSourceConfigs() []GroupSourceConfig {
+	return []GroupSourceConfig{
+		{Name: GroupSourceLocal, Required: true},
+	}
+}
+
+// ParseGroupSourceConfigs decodes a JSON array of group source configs, an
+// empty string yields the default configs.
+func ParseGroupSourceConfigs(raw string) ([]GroupSourceConfig, error) {
+	if raw == "" {
+		return DefaultGroupSourceConfigs(), nil
+	}
+
+	configs := make([]GroupSourceConfig, 0)
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGroupSource, err)
+	}
+
+	return configs, nil
+}
+
+// NewGroupSources validates the configs and binds each of them to the client
+// of the same name, the sources are returned in precedence order.
+func NewGroupSources(configs []GroupSourceConfig, clients map[string]ClientInterface) ([]GroupSource, error) {
+	seen := make(map[string]struct{}, len(configs))
+	sources := make([]GroupSource, 0, len(configs))
+
+	for _, c := range configs {
+		if c.Name == "" {
+			return nil, fmt.Errorf("%w: source name is empty", ErrInvalidGroupSource)
+		}
+		if _, ok := seen[c.Name]; ok {
+			return nil, fmt.Errorf("%w: source %q is declared twice", ErrInvalidGroupSource, c.Name)
+		}
+		seen[c.Name] = struct{}{}
+
+		if c.Timeout < 0 {
+			return nil, fmt.Errorf("%w: negative timeout for source %q", ErrInvalidGroupSource, c.Name)
+		}
+
+		client, ok := clients[c.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown source %q", ErrInvalidGroupSource, c.Name)
+		}
+
+		sources = append(sources, GroupSource{GroupSourceConfig: c, Client: client})
+	}
+
+	slices.SortStableFunc(sources, func(a, b GroupSource) int {
+		return a.Precedence - b.Precedence
+	})
+
+	return sources, nil
+}
+
+// sourceResult carries the outcome of fetching the groups of a single source.
+type sourceResult struct {
+	groups []*types.Group
+	err    error
+}
+
+// fetch returns the user's groups from the source, giving up after the
+// source timeout even if the client ignores the context.
+func (src GroupSource) fetch(ctx context.Context, user User) sourceResult {
+	if src.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, src.Timeout)
+		defer cancel()
+	}
+
+	ch := make(chan sourceResult, 1)
+	go func() {
+		groups, err := src.Client.FetchUserGroups(ctx, user)
+		ch <- sourceResult{groups: groups, err: err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r
+	case <-ctx.Done():
+		return sourceResult{err: ctx.Err()}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestParseGroupSourceConfigs(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []GroupSourceConfig
+		wantErr  bool
+	}{
+		{
+			name:     "Empty yields the defaults",
+			raw:      "",
+			expected: DefaultGroupSourceConfigs(),
+		},
+		{
+			name: "Timeout as a duration",
+			raw:  `[{"name": "local", "required": true}, {"name": "ldap", "timeout": "500ms", "precedence": 1}]`,
+			expected: []GroupSourceConfig{
+				{Name: "local", Required: true},
+				{Name: "ldap", Timeout: 500 * time.Millisecond, Precedence: 1},
+			},
+		},
+		{
+			name:    "Invalid timeout",
+			raw:     `[{"name": "local", "timeout": "soon"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			raw:     `{"name": "local"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs, err := ParseGroupSourceConfigs(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidGroupSource) {
+				t.Fatalf("expected ErrInvalidGroupSource, got %v", err)
+			}
+			if !reflect.DeepEqual(configs, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, configs)
+			}
+		})
+	}
+}
+
+func TestNewGroupSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	local := NewMockClientInterface(ctrl)
+	ldap := NewMockClientInterface(ctrl)
+	clients := map[string]ClientInterface{"local": local, "ldap": ldap}
+
+	sources, err := NewGroupSources([]GroupSourceConfig{
+		{Name: "local", Precedence: 10},
+		{Name: "ldap", Precedence: 1},
+	}, clients)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sources) != 2 || sources[0].Name != "ldap" || sources[0].Client != ldap || sources[1].Name != "local" {
+		t.Fatalf("expected sources in precedence order, got %+v", sources)
+	}
+
+	for name, configs := range map[string][]GroupSourceConfig{
+		"unknown source":   {{Name: "scim"}},
+		"empty name":       {{Name: ""}},
+		"duplicate source": {{Name: "local"}, {Name: "local"}},
+		"negative timeout": {{Name: "local", Timeout: -time.Second}},
+	} {
+		if _, err := NewGroupSources(configs, clients); !errors.Is(err, ErrInvalidGroupSource) {
+			t.Errorf("%s: expected ErrInvalidGroupSource, got %v", name, err)
+		}
+	}
+}
+
+func TestServiceFetchUserGroupsSources(t *testing.T) {
+	someErr := errors.New("some error")
+	u := User{SubjectId: "123", Email: "a@a.com"}
+
+	tests := []struct {
+		name string
+
+		sources func(*gomock.Controller) []GroupSource
+
+		expectedResult   []*types.Group
+		expectedDegraded []string
+		expectedError    error
+	}{
+		{
+			name: "Duplicate names resolved by precedence",
+			sources: func(ctrl *gomock.Controller) []GroupSource {
+				primary := NewMockClientInterface(ctrl)
+				primary.EXPECT().FetchUserGroups(gomock.Any(), u).Return([]*types.Group{{ID: "p1", Name: "admins"}}, nil)
+				secondary := NewMockClientInterface(ctrl)
+				secondary.EXPECT().FetchUserGroups(gomock.Any(), u).Return([]*types.Group{
+					{ID: "s1", Name: "admins"},
+					{ID: "s2", Name: "admins", TenantId: "t-1"},
+					{ID: "s3", Name: "devs"},
+				}, nil)
+				return []GroupSource{
+					{GroupSourceConfig: GroupSourceConfig{Name: "primary", Required: true}, Client: primary},
+					{GroupSourceConfig: GroupSourceConfig{Name: "secondary", Precedence: 1}, Client: secondary},
+				}
+			},
+			expectedResult: []*types.Group{
+				{ID: "p1", Name: "admins"},
+				{ID: "s2", Name: "admins", TenantId: "t-1"},
+				{ID: "s3", Name: "devs"},
+			},
+		},
+		{
+			name: "Optional source fails",
+			sources: func(ctrl *gomock.Controller) []GroupSource {
+				local := NewMockClientInterface(ctrl)
+				local.EXPECT().FetchUserGroups(gomock.Any(), u).Return([]*types.Group{{ID: "g1", Name: "g1"}}, nil)
+				ldap := NewMockClientInterface(ctrl)
+				ldap.EXPECT().FetchUserGroups(gomock.Any(), u).Return(nil, someErr)
+				return []GroupSource{
+					{GroupSourceConfig: GroupSourceConfig{Name: "local", Required: true}, Client: local},
+					{GroupSourceConfig: GroupSourceConfig{Name: "ldap", Precedence: 1}, Client: ldap},
+				}
+			},
+			expectedResult:   []*types.Group{{ID: "g1", Name: "g1"}},
+			expectedDegraded: []string{"ldap"},
+		},
+		{
+			name: "Optional source times out",
+			sources: func(ctrl *gomock.Controller) []GroupSource {
+				local := NewMockClientInterface(ctrl)
+				local.EXPECT().FetchUserGroups(gomock.Any(), u).Return([]*types.Group{{ID: "g1", Name: "g1"}}, nil)
+				slow := NewMockClientInterface(ctrl)
+				// The client ignores the context, the source gives up anyway.
+				slow.EXPECT().FetchUserGroups(gomock.Any(), u).DoAndReturn(func(context.Context, User) ([]*types.Group, error) {
+					time.Sleep(100 * time.Millisecond)
+					return []*types.Group{{ID: "s1", Name: "s1"}}, nil
+				})
+				return []GroupSource{
+					{GroupSourceConfig: GroupSourceConfig{Name: "local", Required: true}, Client: local},
+					{GroupSourceConfig: GroupSourceConfig{Name: "slow", Timeout: 10 * time.Millisecond}, Client: slow},
+				}
+			},
+			expectedResult:   []*types.Group{{ID: "g1", Name: "g1"}},
+			expectedDegraded: []string{"slow"},
+		},
+		{
+			name: "Required source times out",
+			sources: func(ctrl *gomock.Controller) []GroupSource {
+				slow := NewMockClientInterface(ctrl)
+				slow.EXPECT().FetchUserGroups(gomock.Any(), u).DoAndReturn(func(ctx context.Context, _ User) ([]*types.Group, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				})
+				return []GroupSource{
+					{GroupSourceConfig: GroupSourceConfig{Name: "slow", Required: true, Timeout: 10 * time.Millisecond}, Client: slow},
+				}
+			},
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(len(test.expectedDegraded))
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), "hooks.Service.FetchUserGroups").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(test.sources(ctrl), NewMockAuthorizerInterface(ctrl), nil, nil, nil, nil, nil, mockTracer, mockMonitor, mockLogger)
+
+			groups, degraded, err := s.fetchUserGroups(context.TODO(), u)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error to be %v not %v", test.expectedError, err)
+			}
+			if !reflect.DeepEqual(groups, test.expectedResult) {
+				t.Errorf("expected groups %v, got %v", test.expectedResult, groups)
+			}
+			if !reflect.DeepEqual(degraded, test.expectedDegraded) {
+				t.Errorf("expected degraded sources %v, got %v", test.expectedDegraded, degraded)
+			}
+		})
+	}
+}
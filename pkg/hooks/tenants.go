@@ -0,0 +1,89 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+
+	"github.com/ory/hydra/v2/oauth2"
+)
+
+// DefaultTenantSessionKey is the session extra key the login UI stores the
+// selected tenant under.
+const DefaultTenantSessionKey = "_tenant_id"
+
+// TenantConfig configures how the token hook finds the tenant of a request.
+type TenantConfig struct {
+	// SessionKey is the session extra key holding the selected tenant.
+	SessionKey string
+	// AutoSelect scopes the requests of users who did not select a tenant
+	// to their tenant when they are a member of exactly one.
+	AutoSelect bool
+	// ListTenants looks up every tenant of the user, for the tenants claim.
+	ListTenants bool
+}
+
+// DefaultTenantConfig returns the historical behaviour of the hook: the
+// tenant is only read from the `_tenant_id` session extra key.
+func DefaultTenantConfig() *TenantConfig {
+	c := new(TenantConfig)
+	c.SessionKey = DefaultTenantSessionKey
+	return c
+}
+
+// lookups reports whether the tenants of the user are looked up for a
+// request selecting tenantID.
+func (c *TenantConfig) lookups(tenantID string) bool {
+	return c.ListTenants || (tenantID == "" && c.AutoSelect)
+}
+
+// TenantSessionKey returns the session extra key holding the selected tenant.
+func (s *Service) TenantSessionKey() string {
+	return s.tenants.SessionKey
+}
+
+// extractTenantID returns the tenant ID from the session extra data, or
+// an empty string if none was set at login time.
+func (s *Service) extractTenantID(req *oauth2.TokenHookRequest) string {
+	if req.Session == nil || req.Session.Extra == nil {
+		return ""
+	}
+	tid, _ := req.Session.Extra[s.tenants.SessionKey].(string)
+	return tid
+}
+
+// resolveTenant validates the membership of the user in the selected tenant,
+// and looks up the tenants of the user when the configuration needs them. A
+// user who did not select a tenant is scoped to their only tenant when auto
+// selection is enabled. Lookup failures only drop the tenants claim and the
+// auto selection, as the request did not depend on them.
+func (s *Service) resolveTenant(ctx context.Context, user User, tenantID string) tenantValidateResult {
+	r := tenantValidateResult{tenantID: tenantID}
+
+	if tenantID != "" {
+		if r.err = s.tenantValidator.ValidateMembership(ctx, user.SubjectId, tenantID); r.err != nil {
+			return r
+		}
+	}
+
+	if !s.tenants.lookups(tenantID) {
+		return r
+	}
+
+	tenantIDs, err := s.tenantValidator.ListTenants(ctx, user.SubjectId)
+	if err != nil {
+		s.logger.Warnf("failed to list the tenants of user %s, continuing without them: %v", user.SubjectId, err)
+		return r
+	}
+
+	if tenantID == "" && s.tenants.AutoSelect && len(tenantIDs) == 1 {
+		r.tenantID = tenantIDs[0]
+		r.autoSelected = true
+	}
+	if s.tenants.ListTenants {
+		r.tenants = tenantIDs
+	}
+
+	return r
+}
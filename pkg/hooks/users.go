@@ -24,6 +24,15 @@ func (u *User) GetUserId() string {
 	return ""
 }
 
+// storageId returns the ID under which the user's memberships are stored:
+// the client ID for service accounts and the email otherwise.
+func (u *User) storageId() string {
+	if u.ClientId != "" {
+		return u.ClientId
+	}
+	return u.Email
+}
+
 func NewUserFromHookRequest(r *oauth2.TokenHookRequest, logger logging.LoggerInterface) *User {
 	u := new(User)
 	if isServiceAccount(r.Request.GrantTypes) {
@@ -0,0 +1,187 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+// supported is a feature flag of the ServiceProviderConfig.
+type supported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  *int `json:"maxOperations,omitempty"`
+	MaxPayloadSize *int `json:"maxPayloadSize,omitempty"`
+	MaxResults     *int `json:"maxResults,omitempty"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+// ServiceProviderConfig describes the SCIM features of the server.
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  supported              `json:"bulk"`
+	Filter                supported              `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta"`
+}
+
+// ResourceType describes a resource endpoint.
+type ResourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta"`
+}
+
+// Attribute describes an attribute of a Schema.
+type Attribute struct {
+	Name           string      `json:"name"`
+	Type           string      `json:"type"`
+	MultiValued    bool        `json:"multiValued"`
+	Description    string      `json:"description"`
+	Required       bool        `json:"required"`
+	CaseExact      bool        `json:"caseExact"`
+	Mutability     string      `json:"mutability"`
+	Returned       string      `json:"returned"`
+	Uniqueness     string      `json:"uniqueness"`
+	ReferenceTypes []string    `json:"referenceTypes,omitempty"`
+	SubAttributes  []Attribute `json:"subAttributes,omitempty"`
+}
+
+// Schema describes the attributes supported for a resource type.
+type Schema struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Attributes  []Attribute `json:"attributes"`
+	Meta        *Meta       `json:"meta"`
+}
+
+// attribute returns a single-valued, optional, read-write attribute.
+func attribute(name, typ, description string) Attribute {
+	return Attribute{
+		Name:        name,
+		Type:        typ,
+		Description: description,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+	}
+}
+
+func newServiceProviderConfig(baseURL string) *ServiceProviderConfig {
+	zero, maxResults := 0, maxCount
+	return &ServiceProviderConfig{
+		Schemas:        []string{SchemaServiceProviderConfig},
+		Patch:          supported{Supported: true},
+		Bulk:           supported{MaxOperations: &zero, MaxPayloadSize: &zero},
+		Filter:         supported{Supported: true, MaxResults: &maxResults},
+		ChangePassword: supported{},
+		Sort:           supported{},
+		ETag:           supported{Supported: true},
+		AuthenticationSchemes: []authenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication with a JWT issued by the configured issuer",
+				Primary:     true,
+			},
+		},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig", Location: baseURL + "/ServiceProviderConfig"},
+	}
+}
+
+func newResourceTypes(baseURL string) []*ResourceType {
+	return []*ResourceType{
+		{
+			Schemas:     []string{SchemaResourceType},
+			ID:          ResourceTypeUser,
+			Name:        ResourceTypeUser,
+			Endpoint:    "/Users",
+			Description: "User account",
+			Schema:      SchemaUser,
+			Meta:        &Meta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/" + ResourceTypeUser},
+		},
+		{
+			Schemas:     []string{SchemaResourceType},
+			ID:          ResourceTypeGroup,
+			Name:        ResourceTypeGroup,
+			Endpoint:    "/Groups",
+			Description: "Group of the default tenant",
+			Schema:      SchemaGroup,
+			Meta:        &Meta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/" + ResourceTypeGroup},
+		},
+	}
+}
+
+func newSchemas(baseURL string) []*Schema {
+	id := attribute("id", "string", "Unique identifier assigned by the service provider.")
+	id.CaseExact, id.Mutability, id.Returned, id.Uniqueness = true, "readOnly", "always", "server"
+
+	externalID := attribute("externalId", "string", "Identifier assigned by the provisioning client.")
+	externalID.CaseExact = true
+
+	userName := attribute("userName", "string", "Unique user name, group memberships are stored under it.")
+	userName.Required, userName.Uniqueness = true, "server"
+
+	name := attribute("name", "complex", "Components of the user's name.")
+	name.SubAttributes = []Attribute{
+		attribute("givenName", "string", "Given name of the user."),
+		attribute("familyName", "string", "Family name of the user."),
+	}
+
+	email := attribute("emails", "complex", "Email addresses of the user, only the primary one is stored.")
+	email.MultiValued = true
+	email.SubAttributes = []Attribute{
+		attribute("value", "string", "Email address."),
+		attribute("type", "string", "Label of the address."),
+		attribute("primary", "boolean", "Whether this is the primary address."),
+	}
+
+	active := attribute("active", "boolean", "Inactive users keep their memberships but receive no groups in tokens.")
+
+	displayName := attribute("displayName", "string", "Name of the group, unique in the tenant.")
+	displayName.Required, displayName.Uniqueness = true, "server"
+
+	memberValue := attribute("value", "string", "Identifier of the member.")
+	memberValue.CaseExact, memberValue.Mutability = true, "immutable"
+	memberRef := attribute("$ref", "reference", "URI of the member.")
+	memberRef.ReferenceTypes, memberRef.Mutability = []string{ResourceTypeUser}, "immutable"
+	memberType := attribute("type", "string", "Type of the member.")
+	memberType.Mutability = "immutable"
+	memberDisplay := attribute("display", "string", "Display name of the member.")
+	memberDisplay.Mutability = "readOnly"
+
+	members := attribute("members", "complex", "Members of the group.")
+	members.MultiValued = true
+	members.SubAttributes = []Attribute{memberValue, memberRef, memberType, memberDisplay}
+
+	return []*Schema{
+		{
+			Schemas:     []string{SchemaSchema},
+			ID:          SchemaUser,
+			Name:        ResourceTypeUser,
+			Description: "User account",
+			Attributes:  []Attribute{id, externalID, userName, name, attribute("displayName", "string", "Name of the user."), email, active},
+			Meta:        &Meta{ResourceType: "Schema", Location: baseURL + "/Schemas/" + SchemaUser},
+		},
+		{
+			Schemas:     []string{SchemaSchema},
+			ID:          SchemaGroup,
+			Name:        ResourceTypeGroup,
+			Description: "Group",
+			Attributes:  []Attribute{id, displayName, members},
+			Meta:        &Meta{ResourceType: "Schema", Location: baseURL + "/Schemas/" + SchemaGroup},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrGroupNotFound   = errors.New("group not found")
+	ErrDuplicateUser   = errors.New("user name already exists")
+	ErrDuplicateGroup  = errors.New("group display name already exists")
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrInvalidPath     = errors.New("invalid path")
+	ErrInvalidValue    = errors.New("invalid value")
+	ErrInvalidSyntax   = errors.New("invalid syntax")
+	ErrNoTarget        = errors.New("no target")
+	ErrVersionMismatch = errors.New("version mismatch")
+)
+
+// errorStatus maps an error to its HTTP status and SCIM error type.
+func errorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrGroupNotFound):
+		return http.StatusNotFound, ""
+	case errors.Is(err, ErrDuplicateUser), errors.Is(err, ErrDuplicateGroup):
+		return http.StatusConflict, "uniqueness"
+	case errors.Is(err, ErrInvalidFilter):
+		return http.StatusBadRequest, "invalidFilter"
+	case errors.Is(err, ErrInvalidPath):
+		return http.StatusBadRequest, "invalidPath"
+	case errors.Is(err, ErrInvalidValue):
+		return http.StatusBadRequest, "invalidValue"
+	case errors.Is(err, ErrInvalidSyntax):
+		return http.StatusBadRequest, "invalidSyntax"
+	case errors.Is(err, ErrNoTarget):
+		return http.StatusBadRequest, "noTarget"
+	case errors.Is(err, ErrVersionMismatch):
+		return http.StatusPreconditionFailed, ""
+	default:
+		return http.StatusInternalServerError, ""
+	}
+}
@@ -0,0 +1,483 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// filter is a parsed SCIM filter, see RFC 7644 section 3.4.2.2. Filters are
+// evaluated against the JSON representation of a resource.
+type filter interface {
+	match(map[string]any) bool
+}
+
+// logicalFilter combines two filters with `and` or `or`.
+type logicalFilter struct {
+	and         bool
+	left, right filter
+}
+
+func (f *logicalFilter) match(r map[string]any) bool {
+	if f.and {
+		return f.left.match(r) && f.right.match(r)
+	}
+	return f.left.match(r) || f.right.match(r)
+}
+
+type notFilter struct {
+	filter filter
+}
+
+func (f *notFilter) match(r map[string]any) bool {
+	return !f.filter.match(r)
+}
+
+// attrFilter compares an attribute with a value, or tests its presence.
+type attrFilter struct {
+	path  []string
+	op    string
+	value any
+}
+
+func (f *attrFilter) match(r map[string]any) bool {
+	values := resolve(r, f.path)
+
+	switch f.op {
+	case "pr":
+		return len(values) > 0
+	case "eq", "ne":
+		if f.value == nil {
+			return (len(values) == 0) == (f.op == "eq")
+		}
+	}
+
+	caseExact := isCaseExact(f.path)
+	for _, v := range values {
+		if compare(v, f.op, f.value, caseExact) {
+			return true
+		}
+	}
+	// ne matches resources where no value equals the operand.
+	return f.op == "ne" && len(values) == 0
+}
+
+// valuePathFilter matches when an element of a multi-valued attribute
+// matches the sub-filter, e.g. `emails[type eq "work"]`.
+type valuePathFilter struct {
+	path   []string
+	filter filter
+}
+
+func (f *valuePathFilter) match(r map[string]any) bool {
+	for _, e := range elements(r, f.path) {
+		if f.filter.match(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the values at path, flattening multi-valued attributes.
+// Complex values are compared through their `value` sub-attribute.
+func resolve(v any, path []string) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		values := make([]any, 0, len(v))
+		for _, e := range v {
+			values = append(values, resolve(e, path)...)
+		}
+		return values
+	case map[string]any:
+		if len(path) == 0 {
+			return resolve(v[lookupKey(v, "value")], nil)
+		}
+		return resolve(v[lookupKey(v, path[0])], path[1:])
+	case string:
+		if len(path) > 0 || v == "" {
+			return nil
+		}
+		return []any{v}
+	default:
+		if len(path) > 0 {
+			return nil
+		}
+		return []any{v}
+	}
+}
+
+// elements returns the complex values at path.
+func elements(v any, path []string) []map[string]any {
+	switch v := v.(type) {
+	case []any:
+		values := make([]map[string]any, 0, len(v))
+		for _, e := range v {
+			values = append(values, elements(e, path)...)
+		}
+		return values
+	case map[string]any:
+		if len(path) == 0 {
+			return []map[string]any{v}
+		}
+		return elements(v[lookupKey(v, path[0])], path[1:])
+	}
+	return nil
+}
+
+// lookupKey returns the key of m matching name case-insensitively, or name.
+func lookupKey(m map[string]any, name string) string {
+	if _, ok := m[name]; ok {
+		return name
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+	return name
+}
+
+func isCaseExact(path []string) bool {
+	switch strings.ToLower(strings.Join(path, ".")) {
+	case "id", "externalid", "meta.version", "members.value", "members.$ref":
+		return true
+	}
+	return false
+}
+
+func compare(v any, op string, operand any, caseExact bool) bool {
+	switch a := v.(type) {
+	case string:
+		b, ok := operand.(string)
+		if !ok {
+			return op == "ne"
+		}
+		if !caseExact {
+			a, b = strings.ToLower(a), strings.ToLower(b)
+		}
+		switch op {
+		case "eq":
+			return a == b
+		case "ne":
+			return a != b
+		case "co":
+			return strings.Contains(a, b)
+		case "sw":
+			return strings.HasPrefix(a, b)
+		case "ew":
+			return strings.HasSuffix(a, b)
+		case "gt":
+			return a > b
+		case "ge":
+			return a >= b
+		case "lt":
+			return a < b
+		case "le":
+			return a <= b
+		}
+	case bool:
+		b, ok := operand.(bool)
+		if !ok {
+			return op == "ne"
+		}
+		switch op {
+		case "eq":
+			return a == b
+		case "ne":
+			return a != b
+		}
+	case float64:
+		b, ok := operand.(float64)
+		if !ok {
+			return op == "ne"
+		}
+		switch op {
+		case "eq":
+			return a == b
+		case "ne":
+			return a != b
+		case "gt":
+			return a > b
+		case "ge":
+			return a >= b
+		case "lt":
+			return a < b
+		case "le":
+			return a <= b
+		}
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func tokenize(s string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '(':
+			tokens = append(tokens, token{kind: tokenLParen})
+			i++
+		case ')':
+			tokens = append(tokens, token{kind: tokenRParen})
+			i++
+		case '[':
+			tokens = append(tokens, token{kind: tokenLBracket})
+			i++
+		case ']':
+			tokens = append(tokens, token{kind: tokenRBracket})
+			i++
+		case '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			var v string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &v); err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s", ErrInvalidFilter, s[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, value: v})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[end])); end++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[i:end]})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+	schema string
+}
+
+// parseFilter parses a filter, attribute paths may be prefixed by the core
+// schema of the resource.
+func parseFilter(s, schema string) (filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty filter", ErrInvalidFilter)
+	}
+
+	p := &filterParser{tokens: tokens, schema: schema}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, p.tokens[p.pos].value)
+	}
+	return f, nil
+}
+
+func (p *filterParser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) keyword(k string) bool {
+	t := p.peek()
+	if t != nil && t.kind == tokenWord && strings.EqualFold(t.value, k) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(kind tokenKind, name string) error {
+	t := p.peek()
+	if t == nil || t.kind != kind {
+		return fmt.Errorf("%w: expected %s", ErrInvalidFilter, name)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filter, error) {
+	if p.keyword("not") {
+		if err := p.expect(tokenLParen, "("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+
+	if t := p.peek(); t != nil && t.kind == tokenLParen {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	return p.parseAttr()
+}
+
+func (p *filterParser) parseAttr() (filter, error) {
+	t := p.peek()
+	if t == nil || t.kind != tokenWord {
+		return nil, fmt.Errorf("%w: expected an attribute", ErrInvalidFilter)
+	}
+	p.pos++
+
+	path, err := parseAttrPath(t.value, p.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t != nil && t.kind == tokenLBracket {
+		p.pos++
+		sub, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: path, filter: sub}, nil
+	}
+
+	op := p.peek()
+	if op == nil || op.kind != tokenWord {
+		return nil, fmt.Errorf("%w: expected an operator after %s", ErrInvalidFilter, t.value)
+	}
+	p.pos++
+
+	f := &attrFilter{path: path, op: strings.ToLower(op.value)}
+	switch f.op {
+	case "pr":
+		return f, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, op.value)
+	}
+
+	v := p.peek()
+	if v == nil || (v.kind != tokenWord && v.kind != tokenString) {
+		return nil, fmt.Errorf("%w: expected a value after %s", ErrInvalidFilter, op.value)
+	}
+	p.pos++
+
+	if v.kind == tokenString {
+		f.value = v.value
+		return f, nil
+	}
+
+	switch strings.ToLower(v.value) {
+	case "true":
+		f.value = true
+	case "false":
+		f.value = false
+	case "null":
+		f.value = nil
+	default:
+		n, err := strconv.ParseFloat(v.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid value %q", ErrInvalidFilter, v.value)
+		}
+		f.value = n
+	}
+
+	// Booleans and null only support equality, numbers have no substrings.
+	if _, ok := f.value.(float64); !ok && f.op != "eq" && f.op != "ne" {
+		return nil, fmt.Errorf("%w: %s does not apply to %s", ErrInvalidFilter, op.value, v.value)
+	}
+	if _, ok := f.value.(float64); ok && (f.op == "co" || f.op == "sw" || f.op == "ew") {
+		return nil, fmt.Errorf("%w: %s does not apply to %s", ErrInvalidFilter, op.value, v.value)
+	}
+
+	return f, nil
+}
+
+// parseAttrPath splits an attribute path, stripping the schema URN prefix.
+func parseAttrPath(s, schema string) ([]string, error) {
+	if len(s) > len(schema) && strings.EqualFold(s[:len(schema)+1], schema+":") {
+		s = s[len(schema)+1:]
+	}
+
+	path := strings.Split(s, ".")
+	for _, p := range path {
+		if p == "" || strings.Contains(p, ":") {
+			return nil, fmt.Errorf("%w: invalid attribute %q", ErrInvalidFilter, s)
+		}
+	}
+	return path, nil
+}
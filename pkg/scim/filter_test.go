@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	user := map[string]any{
+		"schemas":    []any{SchemaUser},
+		"id":         "2819c223-7f76-453a-919d-413861904646",
+		"userName":   "Bjensen@example.com",
+		"externalId": "bjensen",
+		"active":     true,
+		"name":       map[string]any{"givenName": "Barbara", "familyName": "Jensen"},
+		"emails": []any{
+			map[string]any{"value": "bjensen@example.com", "type": "work", "primary": true},
+			map[string]any{"value": "babs@jensen.org", "type": "home"},
+		},
+		"meta": map[string]any{"lastModified": "2026-03-01T10:00:00Z"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected bool
+	}{
+		{name: "Equal is case insensitive", filter: `userName eq "bjensen@example.com"`, expected: true},
+		{name: "Attribute names are case insensitive", filter: `USERNAME eq "bjensen@example.com"`, expected: true},
+		{name: "Case exact attribute", filter: `externalId eq "BJENSEN"`, expected: false},
+		{name: "Not equal", filter: `userName ne "alice"`, expected: true},
+		{name: "Contains", filter: `userName co "jensen"`, expected: true},
+		{name: "Starts with", filter: `userName sw "bj"`, expected: true},
+		{name: "Ends with", filter: `userName ew "example.org"`, expected: false},
+		{name: "Present", filter: `name.givenName pr`, expected: true},
+		{name: "Not present", filter: `displayName pr`, expected: false},
+		{name: "Boolean", filter: `active eq true`, expected: true},
+		{name: "Sub-attribute", filter: `name.familyName eq "Jensen"`, expected: true},
+		{name: "Multi-valued sub-attribute", filter: `emails.value eq "babs@jensen.org"`, expected: true},
+		{name: "Value path", filter: `emails[type eq "work" and value co "example.com"]`, expected: true},
+		{name: "Value path without match", filter: `emails[type eq "home" and primary eq true]`, expected: false},
+		{name: "Greater than date", filter: `meta.lastModified gt "2026-02-01T00:00:00Z"`, expected: true},
+		{name: "Less than date", filter: `meta.lastModified lt "2026-02-01T00:00:00Z"`, expected: false},
+		{name: "Schema URN prefix", filter: SchemaUser + `:userName eq "bjensen@example.com"`, expected: true},
+		{name: "Or", filter: `userName eq "alice" or externalId eq "bjensen"`, expected: true},
+		{name: "And binds tighter than or", filter: `userName eq "alice" and active eq true or externalId eq "nobody"`, expected: false},
+		{name: "Parentheses", filter: `(userName eq "alice" or active eq true) and externalId eq "bjensen"`, expected: true},
+		{name: "Not", filter: `not (userName eq "alice")`, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := parseFilter(test.filter, SchemaUser)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := f.match(user); got != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{name: "Unknown operator", filter: `userName is "alice"`},
+		{name: "Missing value", filter: `userName eq`},
+		{name: "Unterminated string", filter: `userName eq "alice`},
+		{name: "Unbalanced parentheses", filter: `(userName eq "alice"`},
+		{name: "Trailing tokens", filter: `userName eq "alice" "bob"`},
+		{name: "Dangling logical operator", filter: `userName eq "alice" and`},
+		{name: "Ordering on a boolean", filter: `active gt true`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parseFilter(test.filter, SchemaUser); !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("expected error %v, got %v", ErrInvalidFilter, err)
+			}
+		})
+	}
+}
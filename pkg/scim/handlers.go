@@ -0,0 +1,610 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+)
+
+// BasePath is where the SCIM endpoints are mounted.
+const BasePath = "/scim/v2"
+
+const (
+	contentType  = "application/scim+json"
+	defaultCount = 100
+	maxCount     = 200
+	maxBodySize  = 1 << 20
+)
+
+type API struct {
+	service ServiceInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// RegisterEndpoints registers the SCIM endpoints on a router mounted on
+// BasePath.
+func (a *API) RegisterEndpoints(mux *chi.Mux) {
+	mux.Get("/ServiceProviderConfig", a.handleServiceProviderConfig)
+	mux.Get("/ResourceTypes", a.handleListResourceTypes)
+	mux.Get("/ResourceTypes/{id}", a.handleGetResourceType)
+	mux.Get("/Schemas", a.handleListSchemas)
+	mux.Get("/Schemas/{id}", a.handleGetSchema)
+
+	mux.Get("/Users", a.handleListUsers)
+	mux.Post("/Users", a.handleCreateUser)
+	mux.Get("/Users/{id}", a.handleGetUser)
+	mux.Put("/Users/{id}", a.handleReplaceUser)
+	mux.Patch("/Users/{id}", a.handlePatchUser)
+	mux.Delete("/Users/{id}", a.handleDeleteUser)
+
+	mux.Get("/Groups", a.handleListGroups)
+	mux.Post("/Groups", a.handleCreateGroup)
+	mux.Get("/Groups/{id}", a.handleGetGroup)
+	mux.Put("/Groups/{id}", a.handleReplaceGroup)
+	mux.Patch("/Groups/{id}", a.handlePatchGroup)
+	mux.Delete("/Groups/{id}", a.handleDeleteGroup)
+}
+
+func (a *API) handleServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	a.writeJSON(w, http.StatusOK, newServiceProviderConfig(baseURL(r)))
+}
+
+func (a *API) handleListResourceTypes(w http.ResponseWriter, r *http.Request) {
+	resourceTypes := newResourceTypes(baseURL(r))
+	resources := make([]any, 0, len(resourceTypes))
+	for _, rt := range resourceTypes {
+		resources = append(resources, rt)
+	}
+	a.writeJSON(w, http.StatusOK, newListResponse(resources, len(resources), 1))
+}
+
+func (a *API) handleGetResourceType(w http.ResponseWriter, r *http.Request) {
+	for _, rt := range newResourceTypes(baseURL(r)) {
+		if rt.ID == chi.URLParam(r, "id") {
+			a.writeJSON(w, http.StatusOK, rt)
+			return
+		}
+	}
+	a.writeError(w, fmt.Errorf("resource type %q not found", chi.URLParam(r, "id")), http.StatusNotFound)
+}
+
+func (a *API) handleListSchemas(w http.ResponseWriter, r *http.Request) {
+	schemas := newSchemas(baseURL(r))
+	resources := make([]any, 0, len(schemas))
+	for _, s := range schemas {
+		resources = append(resources, s)
+	}
+	a.writeJSON(w, http.StatusOK, newListResponse(resources, len(resources), 1))
+}
+
+func (a *API) handleGetSchema(w http.ResponseWriter, r *http.Request) {
+	for _, s := range newSchemas(baseURL(r)) {
+		if s.ID == chi.URLParam(r, "id") {
+			a.writeJSON(w, http.StatusOK, s)
+			return
+		}
+	}
+	a.writeError(w, fmt.Errorf("schema %q not found", chi.URLParam(r, "id")), http.StatusNotFound)
+}
+
+func (a *API) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleListUsers")
+	defer span.End()
+
+	req := parseListRequest(r)
+	users, total, err := a.service.ListUsers(ctx, req)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, u := range users {
+		resource, err := a.render(r, u, u.Meta, nil)
+		if err != nil {
+			a.writeError(w, err, 0)
+			return
+		}
+		resources = append(resources, resource)
+	}
+	a.writeJSON(w, http.StatusOK, newListResponse(resources, total, req.StartIndex))
+}
+
+func (a *API) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleGetUser")
+	defer span.End()
+
+	u, err := a.service.GetUser(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusOK, u, u.Meta, nil)
+}
+
+func (a *API) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleCreateUser")
+	defer span.End()
+
+	u := &User{Active: true}
+	if err := decodeBody(w, r, u); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	created, err := a.service.CreateUser(ctx, u)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusCreated, created, created.Meta, nil)
+}
+
+func (a *API) handleReplaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleReplaceUser")
+	defer span.End()
+
+	id := chi.URLParam(r, "id")
+	if err := a.checkVersion(r, func() (any, error) { return a.service.GetUser(ctx, id) }); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	u := &User{Active: true}
+	if err := decodeBody(w, r, u); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	updated, err := a.service.ReplaceUser(ctx, id, u)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusOK, updated, updated.Meta, nil)
+}
+
+func (a *API) handlePatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handlePatchUser")
+	defer span.End()
+
+	id := chi.URLParam(r, "id")
+	if err := a.checkVersion(r, func() (any, error) { return a.service.GetUser(ctx, id) }); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	req := new(PatchRequest)
+	if err := decodeBody(w, r, req); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	updated, err := a.service.PatchUser(ctx, id, req)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusOK, updated, updated.Meta, nil)
+}
+
+func (a *API) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleDeleteUser")
+	defer span.End()
+
+	id := chi.URLParam(r, "id")
+	if err := a.checkVersion(r, func() (any, error) { return a.service.GetUser(ctx, id) }); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	if err := a.service.DeleteUser(ctx, id); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleListGroups")
+	defer span.End()
+
+	req := parseListRequest(r)
+	groups, total, err := a.service.ListGroups(ctx, req)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	resources := make([]any, 0, len(groups))
+	for _, g := range groups {
+		var resource any
+		if req.ExcludeMembers {
+			// Without members the version would not match the one of the
+			// group, so none is returned.
+			g.Meta.Location = resourceLocation(r, ResourceTypeGroup, g.ID)
+			resource, err = project(g, r)
+		} else {
+			resource, err = a.render(r, g, g.Meta, setMemberRefs(r, g))
+		}
+		if err != nil {
+			a.writeError(w, err, 0)
+			return
+		}
+		resources = append(resources, resource)
+	}
+	a.writeJSON(w, http.StatusOK, newListResponse(resources, total, req.StartIndex))
+}
+
+func (a *API) handleGetGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleGetGroup")
+	defer span.End()
+
+	g, err := a.service.GetGroup(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusOK, g, g.Meta, setMemberRefs(r, g))
+}
+
+func (a *API) handleCreateGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleCreateGroup")
+	defer span.End()
+
+	g := new(Group)
+	if err := decodeBody(w, r, g); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	created, err := a.service.CreateGroup(ctx, g)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusCreated, created, created.Meta, setMemberRefs(r, created))
+}
+
+func (a *API) handleReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleReplaceGroup")
+	defer span.End()
+
+	id := chi.URLParam(r, "id")
+	if err := a.checkVersion(r, func() (any, error) { return a.service.GetGroup(ctx, id) }); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	g := new(Group)
+	if err := decodeBody(w, r, g); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	updated, err := a.service.ReplaceGroup(ctx, id, g)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusOK, updated, updated.Meta, setMemberRefs(r, updated))
+}
+
+func (a *API) handlePatchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handlePatchGroup")
+	defer span.End()
+
+	id := chi.URLParam(r, "id")
+	if err := a.checkVersion(r, func() (any, error) { return a.service.GetGroup(ctx, id) }); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	req := new(PatchRequest)
+	if err := decodeBody(w, r, req); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	updated, err := a.service.PatchGroup(ctx, id, req)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	a.writeResource(w, r, http.StatusOK, updated, updated.Meta, setMemberRefs(r, updated))
+}
+
+func (a *API) handleDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "scim.API.handleDeleteGroup")
+	defer span.End()
+
+	id := chi.URLParam(r, "id")
+	if err := a.checkVersion(r, func() (any, error) { return a.service.GetGroup(ctx, id) }); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	if err := a.service.DeleteGroup(ctx, id); err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// render sets the version and location of a resource, then applies the
+// attributes selection of the request.
+func (a *API) render(r *http.Request, resource any, meta *Meta, decorate func()) (any, error) {
+	v, err := version(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Version = v
+	meta.Location = resourceLocation(r, meta.ResourceType, resourceID(resource))
+	if decorate != nil {
+		decorate()
+	}
+
+	return project(resource, r)
+}
+
+// writeResource writes a single resource with its ETag. GET requests whose
+// If-None-Match matches the version get a 304.
+func (a *API) writeResource(w http.ResponseWriter, r *http.Request, status int, resource any, meta *Meta, decorate func()) {
+	rendered, err := a.render(r, resource, meta, decorate)
+	if err != nil {
+		a.writeError(w, err, 0)
+		return
+	}
+
+	w.Header().Set("ETag", meta.Version)
+	if status == http.StatusCreated {
+		w.Header().Set("Location", meta.Location)
+	}
+	if r.Method == http.MethodGet && matchesVersion(r.Header.Get("If-None-Match"), meta.Version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	a.writeJSON(w, status, rendered)
+}
+
+// checkVersion compares the If-Match header of the request with the version
+// of the current resource.
+func (a *API) checkVersion(r *http.Request, current func() (any, error)) error {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	resource, err := current()
+	if err != nil {
+		return err
+	}
+
+	v, err := version(resource)
+	if err != nil {
+		return err
+	}
+	if !matchesVersion(header, v) {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+func (a *API) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		a.logger.Errorf("failed to encode SCIM response: %v", err)
+	}
+}
+
+// writeError writes a SCIM error, the status is derived from the error when
+// zero.
+func (a *API) writeError(w http.ResponseWriter, err error, status int) {
+	scimType := ""
+	if status == 0 {
+		status, scimType = errorStatus(err)
+	}
+
+	detail := err.Error()
+	if status == http.StatusInternalServerError {
+		a.logger.Errorf("SCIM request failed: %v", err)
+		detail = "internal server error"
+	}
+
+	a.writeJSON(w, status, &ErrorResponse{
+		Schemas:  []string{SchemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	})
+}
+
+// version returns a weak ETag derived from the content of the resource.
+func version(resource any) (string, error) {
+	var meta *Meta
+	switch r := resource.(type) {
+	case *User:
+		meta = r.Meta
+	case *Group:
+		meta = r.Meta
+	}
+
+	// The location and version are not part of the content.
+	if meta != nil {
+		saved := *meta
+		meta.Location, meta.Version = "", ""
+		defer func() { *meta = saved }()
+	}
+
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode resource: %v", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`, nil
+}
+
+// matchesVersion reports whether an If-Match or If-None-Match header matches
+// the version, ignoring the weak prefix.
+func matchesVersion(header, version string) bool {
+	if header == "" {
+		return false
+	}
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == strings.TrimPrefix(version, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSyntax, err)
+	}
+	return nil
+}
+
+func parseListRequest(r *http.Request) *ListRequest {
+	q := r.URL.Query()
+
+	req := &ListRequest{
+		Filter:     q.Get("filter"),
+		StartIndex: 1,
+		Count:      defaultCount,
+	}
+	if v, err := strconv.Atoi(q.Get("startIndex")); err == nil && v > 1 {
+		req.StartIndex = v
+	}
+	if v, err := strconv.Atoi(q.Get("count")); err == nil {
+		req.Count = min(max(v, 0), maxCount)
+	}
+
+	attributes, excluded := attributeList(q.Get("attributes")), attributeList(q.Get("excludedAttributes"))
+	req.ExcludeMembers = excluded["members"] || (len(attributes) > 0 && !attributes["members"])
+
+	return req
+}
+
+// project applies the attributes and excludedAttributes parameters of the
+// request to a resource, only top-level attributes are selected.
+func project(resource any, r *http.Request) (any, error) {
+	q := r.URL.Query()
+	attributes, excluded := attributeList(q.Get("attributes")), attributeList(q.Get("excludedAttributes"))
+	if len(attributes) == 0 && len(excluded) == 0 {
+		return resource, nil
+	}
+
+	m, err := toMap(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	for k := range m {
+		switch key := strings.ToLower(k); {
+		case key == "schemas" || key == "id":
+		case len(attributes) > 0 && !attributes[key]:
+			delete(m, k)
+		case excluded[key]:
+			delete(m, k)
+		}
+	}
+	return m, nil
+}
+
+// attributeList parses a comma-separated list of attribute paths into the
+// set of their lowercased top-level attributes.
+func attributeList(s string) map[string]bool {
+	attributes := make(map[string]bool)
+	for _, a := range strings.Split(s, ",") {
+		a = strings.TrimSpace(a)
+		for _, schema := range []string{SchemaUser, SchemaGroup} {
+			if len(a) > len(schema) && strings.EqualFold(a[:len(schema)+1], schema+":") {
+				a = a[len(schema)+1:]
+			}
+		}
+		if top, _, _ := strings.Cut(a, "."); top != "" {
+			attributes[strings.ToLower(top)] = true
+		}
+	}
+	return attributes
+}
+
+func newListResponse(resources []any, total, startIndex int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		ItemsPerPage: len(resources),
+		StartIndex:   startIndex,
+		Resources:    resources,
+	}
+}
+
+// setMemberRefs returns a function setting the $ref of the SCIM user members.
+func setMemberRefs(r *http.Request, g *Group) func() {
+	return func() {
+		for i, m := range g.Members {
+			if m.Type == ResourceTypeUser {
+				g.Members[i].Ref = resourceLocation(r, ResourceTypeUser, m.Value)
+			}
+		}
+	}
+}
+
+func resourceID(resource any) string {
+	switch r := resource.(type) {
+	case *User:
+		return r.ID
+	case *Group:
+		return r.ID
+	}
+	return ""
+}
+
+func resourceLocation(r *http.Request, resourceType, id string) string {
+	return baseURL(r) + "/" + resourceType + "s/" + id
+}
+
+// baseURL returns the absolute URL of the SCIM endpoints, honouring the
+// X-Forwarded-Proto header of reverse proxies.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + BasePath
+}
+
+func NewAPI(service ServiceInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *API {
+	a := new(API)
+
+	a.service = service
+
+	a.tracer = tracer
+	a.monitor = monitor
+	a.logger = logger
+
+	return a
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestAPI(ctrl *gomock.Controller) (http.Handler, *MockServiceInterface) {
+	mockService := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mux := chi.NewMux()
+	NewAPI(mockService, mockTracer, NewMockMonitorInterface(ctrl), mockLogger).RegisterEndpoints(mux)
+
+	router := chi.NewRouter()
+	router.Mount(BasePath, mux)
+	return router, mockService
+}
+
+func testGroup() *Group {
+	return &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          groupID,
+		DisplayName: "admins",
+		Members:     []Member{{Value: aliceID, Display: "alice", Type: ResourceTypeUser}},
+		Meta:        &Meta{ResourceType: ResourceTypeGroup},
+	}
+}
+
+func TestHandleGetGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, mockService := newTestAPI(ctrl)
+	mockService.EXPECT().GetGroup(gomock.Any(), groupID).DoAndReturn(
+		func(context.Context, string) (*Group, error) { return testGroup(), nil },
+	).Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, BasePath+"/Groups/"+groupID, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != contentType {
+		t.Fatalf("expected content type %s, got %s", contentType, ct)
+	}
+
+	etag := w.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected a weak ETag, got %q", etag)
+	}
+
+	g := new(Group)
+	if err := json.NewDecoder(w.Body).Decode(g); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if g.Meta.Version != etag {
+		t.Fatalf("expected version %s, got %s", etag, g.Meta.Version)
+	}
+	if g.Meta.Location != "http://example.com"+BasePath+"/Groups/"+groupID {
+		t.Fatalf("unexpected location %s", g.Meta.Location)
+	}
+	if g.Members[0].Ref != "http://example.com"+BasePath+"/Users/"+aliceID {
+		t.Fatalf("unexpected member reference %s", g.Members[0].Ref)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, BasePath+"/Groups/"+groupID, nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("expected status %d, got %d", http.StatusNotModified, w.Code)
+	}
+}
+
+func TestHandlePatchGroupVersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, mockService := newTestAPI(ctrl)
+	mockService.EXPECT().GetGroup(gomock.Any(), groupID).Return(testGroup(), nil)
+
+	body := `{"schemas": ["` + SchemaPatchOp + `"], "Operations": [{"op": "remove", "path": "members"}]}`
+	req := httptest.NewRequest(http.MethodPatch, BasePath+"/Groups/"+groupID, strings.NewReader(body))
+	req.Header.Set("If-Match", `W/"stale"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected status %d, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+}
+
+func TestHandleCreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, mockService := newTestAPI(ctrl)
+	mockService.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, u *User) (*User, error) {
+			if u.UserName != "alice" || !u.Active {
+				t.Fatalf("unexpected user %+v", u)
+			}
+			u.ID = aliceID
+			u.Meta = &Meta{ResourceType: ResourceTypeUser}
+			return u, nil
+		},
+	)
+
+	body := `{"schemas": ["` + SchemaUser + `"], "userName": "alice"}`
+	req := httptest.NewRequest(http.MethodPost, BasePath+"/Users", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "http://example.com"+BasePath+"/Users/"+aliceID {
+		t.Fatalf("unexpected location %s", loc)
+	}
+}
+
+func TestHandleErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		setup  func(*MockServiceInterface)
+
+		expectedStatus   int
+		expectedScimType string
+	}{
+		{
+			name:   "Not found",
+			method: http.MethodGet,
+			path:   "/Users/" + aliceID,
+			setup: func(m *MockServiceInterface) {
+				m.EXPECT().GetUser(gomock.Any(), aliceID).Return(nil, ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Duplicate",
+			method: http.MethodPost,
+			path:   "/Users",
+			body:   `{"userName": "alice"}`,
+			setup: func(m *MockServiceInterface) {
+				m.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, ErrDuplicateUser)
+			},
+			expectedStatus:   http.StatusConflict,
+			expectedScimType: "uniqueness",
+		},
+		{
+			name:             "Malformed body",
+			method:           http.MethodPost,
+			path:             "/Groups",
+			body:             `{"displayName": `,
+			expectedStatus:   http.StatusBadRequest,
+			expectedScimType: "invalidSyntax",
+		},
+		{
+			name:   "Invalid filter",
+			method: http.MethodGet,
+			path:   "/Groups?filter=displayName+xx+1",
+			setup: func(m *MockServiceInterface) {
+				m.EXPECT().ListGroups(gomock.Any(), gomock.Any()).Return(nil, 0, ErrInvalidFilter)
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedScimType: "invalidFilter",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			router, mockService := newTestAPI(ctrl)
+			if test.setup != nil {
+				test.setup(mockService)
+			}
+
+			req := httptest.NewRequest(test.method, BasePath+test.path, strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, w.Code)
+			}
+
+			e := new(ErrorResponse)
+			if err := json.NewDecoder(w.Body).Decode(e); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if e.ScimType != test.expectedScimType || len(e.Schemas) != 1 || e.Schemas[0] != SchemaError {
+				t.Fatalf("unexpected error response %+v", e)
+			}
+		})
+	}
+}
+
+func TestHandleListGroupsExcludedMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, mockService := newTestAPI(ctrl)
+	mockService.EXPECT().ListGroups(gomock.Any(), &ListRequest{StartIndex: 3, Count: maxCount, ExcludeMembers: true}).DoAndReturn(
+		func(context.Context, *ListRequest) ([]*Group, int, error) {
+			g := testGroup()
+			g.Members = nil
+			return []*Group{g}, 3, nil
+		},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, BasePath+"/Groups?excludedAttributes=members&startIndex=3&count=1000", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp struct {
+		TotalResults int              `json:"totalResults"`
+		StartIndex   int              `json:"startIndex"`
+		Resources    []map[string]any `json:"Resources"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.TotalResults != 3 || resp.StartIndex != 3 || len(resp.Resources) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if _, ok := resp.Resources[0]["members"]; ok {
+		t.Fatalf("expected members to be excluded, got %v", resp.Resources[0])
+	}
+}
+
+func TestHandleServiceProviderConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, _ := newTestAPI(ctrl)
+
+	req := httptest.NewRequest(http.MethodGet, BasePath+"/ServiceProviderConfig", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	config := new(ServiceProviderConfig)
+	if err := json.NewDecoder(w.Body).Decode(config); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if !config.Patch.Supported || !config.Filter.Supported || !config.ETag.Supported || config.Bulk.Supported {
+		t.Fatalf("unexpected features %+v", config)
+	}
+	if config.Meta.Location != "https://example.com"+BasePath+"/ServiceProviderConfig" {
+		t.Fatalf("unexpected location %s", config.Meta.Location)
+	}
+}
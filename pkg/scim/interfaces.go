@@ -0,0 +1,58 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"context"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+type ServiceInterface interface {
+	ListUsers(context.Context, *ListRequest) ([]*User, int, error)
+	GetUser(context.Context, string) (*User, error)
+	CreateUser(context.Context, *User) (*User, error)
+	ReplaceUser(context.Context, string, *User) (*User, error)
+	PatchUser(context.Context, string, *PatchRequest) (*User, error)
+	DeleteUser(context.Context, string) error
+
+	ListGroups(context.Context, *ListRequest) ([]*Group, int, error)
+	GetGroup(context.Context, string) (*Group, error)
+	CreateGroup(context.Context, *Group) (*Group, error)
+	ReplaceGroup(context.Context, string, *Group) (*Group, error)
+	PatchGroup(context.Context, string, *PatchRequest) (*Group, error)
+	DeleteGroup(context.Context, string) error
+}
+
+type DatabaseInterface interface {
+	SearchUsers(context.Context, *types.DirectoryFilter) ([]*types.User, int64, error)
+	CreateUser(context.Context, *types.User) (*types.User, error)
+	GetUser(context.Context, string) (*types.User, error)
+	GetUsersByIDs(context.Context, []string) ([]*types.User, error)
+	GetUsersByUserNames(context.Context, []string) ([]*types.User, error)
+	UpdateUser(context.Context, string, *types.User) (*types.User, error)
+	DeleteUser(context.Context, string) error
+
+	SearchGroups(context.Context, *types.DirectoryFilter) ([]*types.Group, int64, error)
+	CreateGroup(context.Context, *types.Group) (*types.Group, error)
+	GetGroup(context.Context, string) (*types.Group, error)
+	UpdateGroup(context.Context, string, *types.Group) (*types.Group, error)
+	DeleteGroup(context.Context, string) error
+
+	AddUsersToGroup(context.Context, string, []string) error
+	ListUsersInGroup(context.Context, string, *types.ListFilter) ([]*types.GroupUser, *types.Page, error)
+	ListUsersInGroups(context.Context, []string) (map[string][]string, error)
+	RemoveUsersFromGroup(context.Context, string, []string) error
+}
+
+type AuthorizerInterface interface {
+	DeleteGroup(context.Context, string) error
+}
+
+// CacheInvalidatorInterface is notified of membership changes so that cached
+// groups are not served after a write.
+type CacheInvalidatorInterface interface {
+	InvalidateUsers(context.Context, ...string)
+	InvalidateGroups(context.Context)
+}
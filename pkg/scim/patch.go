@@ -0,0 +1,286 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// patchPath is a parsed PATCH path: `attr`, `attr.sub`, `attr[filter]` or
+// `attr[filter].sub`.
+type patchPath struct {
+	attr   string
+	filter filter
+	sub    string
+}
+
+// parsePatchPath parses a PATCH path. Paths of schemas other than the core
+// schema of the resource, such as extensions, yield nil as they are not stored.
+func parsePatchPath(s, schema string) (*patchPath, error) {
+	if len(s) > len(schema) && strings.EqualFold(s[:len(schema)+1], schema+":") {
+		s = s[len(schema)+1:]
+	} else if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		return nil, nil
+	}
+
+	p := new(patchPath)
+	if start := strings.Index(s, "["); start >= 0 {
+		end := strings.LastIndex(s, "]")
+		if end < start {
+			return nil, fmt.Errorf("%w: unbalanced brackets in %q", ErrInvalidPath, s)
+		}
+
+		f, err := parseFilter(s[start+1:end], schema)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPath, err)
+		}
+
+		p.attr, p.filter = s[:start], f
+		if rest := s[end+1:]; rest != "" {
+			if !strings.HasPrefix(rest, ".") {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidPath, rest)
+			}
+			p.sub = rest[1:]
+		}
+	} else {
+		p.attr, p.sub, _ = strings.Cut(s, ".")
+	}
+
+	for _, v := range []string{p.attr, p.sub} {
+		if strings.ContainsAny(v, " .[]") {
+			return nil, fmt.Errorf("%w: invalid attribute in %q", ErrInvalidPath, s)
+		}
+	}
+	if p.attr == "" || (p.sub == "" && strings.HasSuffix(s, ".")) {
+		return nil, fmt.Errorf("%w: invalid attribute in %q", ErrInvalidPath, s)
+	}
+
+	return p, nil
+}
+
+// applyPatch applies the operations to the JSON representation of a
+// resource, see RFC 7644 section 3.5.2. Read-only attributes are restored by
+// the caller.
+func applyPatch(r map[string]any, ops []PatchOperation, schema string) error {
+	for _, op := range ops {
+		var value any
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+			}
+		}
+
+		kind := strings.ToLower(op.Op)
+		switch kind {
+		case "add", "replace":
+			if value == nil {
+				return fmt.Errorf("%w: %s requires a value", ErrInvalidValue, op.Op)
+			}
+		case "remove":
+			if op.Path == "" {
+				return fmt.Errorf("%w: remove requires a path", ErrNoTarget)
+			}
+		default:
+			return fmt.Errorf("%w: unknown operation %q", ErrInvalidSyntax, op.Op)
+		}
+
+		if op.Path == "" {
+			attrs, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%w: %s without a path requires an object", ErrInvalidValue, op.Op)
+			}
+			for k, v := range attrs {
+				p, err := parsePatchPath(k, schema)
+				if err != nil {
+					return err
+				}
+				if p == nil {
+					continue
+				}
+				if err := applyOperation(r, kind, p, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		p, err := parsePatchPath(op.Path, schema)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			continue
+		}
+		if err := applyOperation(r, kind, p, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyOperation(r map[string]any, op string, p *patchPath, value any) error {
+	key := lookupKey(r, p.attr)
+
+	if p.filter != nil {
+		return applyFilteredOperation(r, key, op, p, value)
+	}
+
+	if p.sub != "" {
+		if _, ok := r[key].([]any); ok {
+			return fmt.Errorf("%w: %s is multi-valued, use a filter", ErrInvalidPath, p.attr)
+		}
+		parent, ok := r[key].(map[string]any)
+		if !ok {
+			if op == "remove" {
+				return nil
+			}
+			parent = make(map[string]any)
+			r[key] = parent
+		}
+		return applyOperation(parent, op, &patchPath{attr: p.sub}, value)
+	}
+
+	existing := r[key]
+	switch op {
+	case "add", "replace":
+		switch e := existing.(type) {
+		case []any:
+			if op == "add" {
+				r[key] = appendValues(e, value)
+			} else {
+				r[key] = asSlice(value)
+			}
+		case map[string]any:
+			if m, ok := value.(map[string]any); ok {
+				r[key] = mergeValue(e, m)
+			} else {
+				r[key] = value
+			}
+		default:
+			r[key] = value
+		}
+	case "remove":
+		e, ok := existing.([]any)
+		if !ok || value == nil {
+			delete(r, key)
+			return nil
+		}
+		// Some providers remove members by value instead of filtering.
+		remove := make(map[string]bool)
+		for _, v := range asSlice(value) {
+			for _, id := range resolve(v, nil) {
+				remove[fmt.Sprint(id)] = true
+			}
+		}
+		kept := make([]any, 0, len(e))
+		for _, v := range e {
+			ids := resolve(v, nil)
+			if len(ids) == 1 && remove[fmt.Sprint(ids[0])] {
+				continue
+			}
+			kept = append(kept, v)
+		}
+		r[key] = kept
+	}
+
+	return nil
+}
+
+func applyFilteredOperation(r map[string]any, key, op string, p *patchPath, value any) error {
+	existing, _ := r[key].([]any)
+
+	matched := false
+	kept := make([]any, 0, len(existing))
+	for _, v := range existing {
+		e, ok := v.(map[string]any)
+		if !ok || !p.filter.match(e) {
+			kept = append(kept, v)
+			continue
+		}
+		matched = true
+
+		switch {
+		case op == "remove" && p.sub == "":
+			continue
+		case op == "remove":
+			delete(e, lookupKey(e, p.sub))
+		case p.sub == "":
+			if m, ok := value.(map[string]any); ok {
+				v = mergeValue(e, m)
+			} else {
+				return fmt.Errorf("%w: %s requires an object", ErrInvalidValue, p.attr)
+			}
+		default:
+			e[lookupKey(e, p.sub)] = value
+		}
+		kept = append(kept, v)
+	}
+
+	if !matched {
+		switch op {
+		case "remove":
+			return nil
+		case "add":
+			// Adding to `emails[type eq "work"].value` creates the entry.
+			if f, ok := p.filter.(*attrFilter); ok && f.op == "eq" && len(f.path) == 1 {
+				e := map[string]any{f.path[0]: f.value}
+				if p.sub != "" {
+					e[p.sub] = value
+				} else if m, ok := value.(map[string]any); ok {
+					e = mergeValue(e, m)
+				}
+				kept = append(kept, e)
+				break
+			}
+			fallthrough
+		default:
+			return fmt.Errorf("%w: no value of %s matches the filter", ErrNoTarget, p.attr)
+		}
+	}
+
+	r[key] = kept
+	return nil
+}
+
+// appendValues adds the values to a multi-valued attribute, skipping the
+// complex values whose `value` is already present.
+func appendValues(existing []any, value any) []any {
+	seen := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		if ids := resolve(v, nil); len(ids) == 1 {
+			seen[fmt.Sprint(ids[0])] = true
+		}
+	}
+
+	for _, v := range asSlice(value) {
+		if ids := resolve(v, nil); len(ids) == 1 {
+			id := fmt.Sprint(ids[0])
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		existing = append(existing, v)
+	}
+	return existing
+}
+
+// mergeValue sets the sub-attributes of value on existing, others are left
+// unchanged.
+func mergeValue(existing, value map[string]any) map[string]any {
+	for k, v := range value {
+		existing[lookupKey(existing, k)] = v
+	}
+	return existing
+}
+
+func asSlice(v any) []any {
+	if s, ok := v.([]any); ok {
+		return s
+	}
+	return []any{v}
+}
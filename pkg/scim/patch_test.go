@@ -0,0 +1,185 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	group := `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"id": "g1",
+		"displayName": "admins",
+		"members": [{"value": "u1", "display": "alice"}, {"value": "u2", "display": "bob"}]
+	}`
+
+	tests := []struct {
+		name     string
+		ops      string
+		expected string
+	}{
+		{
+			name: "Add members",
+			ops:  `[{"op": "add", "path": "members", "value": [{"value": "u3"}, {"value": "u1"}]}]`,
+			expected: `{
+				"displayName": "admins",
+				"members": [{"value": "u1", "display": "alice"}, {"value": "u2", "display": "bob"}, {"value": "u3"}]
+			}`,
+		},
+		{
+			name:     "Remove a member with a filter",
+			ops:      `[{"op": "Remove", "path": "members[value eq \"u1\"]"}]`,
+			expected: `{"displayName": "admins", "members": [{"value": "u2", "display": "bob"}]}`,
+		},
+		{
+			name:     "Remove a member by value",
+			ops:      `[{"op": "remove", "path": "members", "value": [{"value": "u2"}]}]`,
+			expected: `{"displayName": "admins", "members": [{"value": "u1", "display": "alice"}]}`,
+		},
+		{
+			name:     "Remove all members",
+			ops:      `[{"op": "remove", "path": "members"}]`,
+			expected: `{"displayName": "admins"}`,
+		},
+		{
+			name:     "Remove a missing member is a no-op",
+			ops:      `[{"op": "remove", "path": "members[value eq \"u9\"]"}]`,
+			expected: `{"displayName": "admins", "members": [{"value": "u1", "display": "alice"}, {"value": "u2", "display": "bob"}]}`,
+		},
+		{
+			name:     "Replace members",
+			ops:      `[{"op": "replace", "path": "members", "value": [{"value": "u3"}]}]`,
+			expected: `{"displayName": "admins", "members": [{"value": "u3"}]}`,
+		},
+		{
+			name:     "Replace without a path",
+			ops:      `[{"op": "replace", "value": {"displayName": "operators", "urn:example:extension": {"x": 1}}}]`,
+			expected: `{"displayName": "operators", "members": [{"value": "u1", "display": "alice"}, {"value": "u2", "display": "bob"}]}`,
+		},
+		{
+			name:     "Path with the schema URN",
+			ops:      `[{"op": "replace", "path": "urn:ietf:params:scim:schemas:core:2.0:Group:DisplayName", "value": "operators"}]`,
+			expected: `{"displayName": "operators", "members": [{"value": "u1", "display": "alice"}, {"value": "u2", "display": "bob"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := decode(t, group)
+			var ops []PatchOperation
+			if err := json.Unmarshal([]byte(test.ops), &ops); err != nil {
+				t.Fatalf("invalid operations: %v", err)
+			}
+
+			if err := applyPatch(r, ops, SchemaGroup); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for _, k := range []string{"schemas", "id"} {
+				delete(r, k)
+			}
+			if expected := decode(t, test.expected); !reflect.DeepEqual(r, expected) {
+				t.Fatalf("expected %v, got %v", expected, r)
+			}
+		})
+	}
+}
+
+func TestApplyPatchUserAttributes(t *testing.T) {
+	r := decode(t, `{"userName": "alice", "emails": [{"value": "alice@example.com", "type": "work"}]}`)
+
+	var ops []PatchOperation
+	if err := json.Unmarshal([]byte(`[
+		{"op": "replace", "path": "active", "value": false},
+		{"op": "add", "path": "name.givenName", "value": "Alice"},
+		{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@example.org"},
+		{"op": "add", "path": "emails[type eq \"home\"].value", "value": "alice@home.example"}
+	]`), &ops); err != nil {
+		t.Fatalf("invalid operations: %v", err)
+	}
+
+	if err := applyPatch(r, ops, SchemaUser); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := decode(t, `{
+		"userName": "alice",
+		"active": false,
+		"name": {"givenName": "Alice"},
+		"emails": [
+			{"value": "alice@example.org", "type": "work"},
+			{"value": "alice@home.example", "type": "home"}
+		]
+	}`)
+	if !reflect.DeepEqual(r, expected) {
+		t.Fatalf("expected %v, got %v", expected, r)
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		ops           string
+		expectedError error
+	}{
+		{
+			name:          "Unknown operation",
+			ops:           `[{"op": "move", "path": "displayName", "value": "x"}]`,
+			expectedError: ErrInvalidSyntax,
+		},
+		{
+			name:          "Remove without a path",
+			ops:           `[{"op": "remove"}]`,
+			expectedError: ErrNoTarget,
+		},
+		{
+			name:          "Add without a value",
+			ops:           `[{"op": "add", "path": "members"}]`,
+			expectedError: ErrInvalidValue,
+		},
+		{
+			name:          "Replace of an unmatched filter",
+			ops:           `[{"op": "replace", "path": "members[value eq \"u9\"].display", "value": "x"}]`,
+			expectedError: ErrNoTarget,
+		},
+		{
+			name:          "Invalid filter in path",
+			ops:           `[{"op": "remove", "path": "members[value zz \"u1\"]"}]`,
+			expectedError: ErrInvalidPath,
+		},
+		{
+			name:          "Unbalanced brackets",
+			ops:           `[{"op": "remove", "path": "members]value eq \"u1\"["}]`,
+			expectedError: ErrInvalidPath,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := decode(t, `{"displayName": "admins", "members": [{"value": "u1"}]}`)
+			var ops []PatchOperation
+			if err := json.Unmarshal([]byte(test.ops), &ops); err != nil {
+				t.Fatalf("invalid operations: %v", err)
+			}
+
+			if err := applyPatch(r, ops, SchemaGroup); !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+
+	m := make(map[string]any)
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	return m
+}
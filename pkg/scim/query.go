@@ -0,0 +1,250 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+type attributeKind int
+
+const (
+	kindString attributeKind = iota
+	kindBoolean
+	kindDateTime
+)
+
+// storedAttribute is a SCIM attribute stored in a column.
+type storedAttribute struct {
+	column    string
+	kind      attributeKind
+	caseExact bool
+}
+
+var (
+	userAttributes = map[string]storedAttribute{
+		"id":                {column: "id", caseExact: true},
+		"externalid":        {column: "external_id", caseExact: true},
+		"username":          {column: "user_name"},
+		"displayname":       {column: "display_name"},
+		"name.givenname":    {column: "given_name"},
+		"name.familyname":   {column: "family_name"},
+		"emails":            {column: "email"},
+		"emails.value":      {column: "email"},
+		"active":            {column: "active", kind: kindBoolean},
+		"meta.created":      {column: "created_at", kind: kindDateTime},
+		"meta.lastmodified": {column: "updated_at", kind: kindDateTime},
+	}
+	groupAttributes = map[string]storedAttribute{
+		"id":                {column: "id", caseExact: true},
+		"displayname":       {column: "name"},
+		"meta.created":      {column: "created_at", kind: kindDateTime},
+		"meta.lastmodified": {column: "updated_at", kind: kindDateTime},
+	}
+	memberAttributes = map[string]storedAttribute{
+		"value":   {column: types.MemberValue, caseExact: true},
+		"display": {column: types.MemberDisplay},
+		"type":    {column: types.MemberType},
+	}
+)
+
+// userElements are the complex attributes of a user a value path filters,
+// with the condition of their presence.
+var userElements = map[string]*types.Condition{
+	"name":   or(present("given_name"), present("family_name")),
+	"emails": present("email"),
+}
+
+// query translates a filter into a condition on the columns of the storage,
+// with the semantics of filter.match on the JSON representation.
+type query struct {
+	attributes map[string]storedAttribute
+	elements   map[string]*types.Condition
+	// members is set for the groups, whose members are filtered through
+	// types.CondMember conditions.
+	members bool
+}
+
+var (
+	userQuery  = &query{attributes: userAttributes, elements: userElements}
+	groupQuery = &query{attributes: groupAttributes, members: true}
+	// memberQuery filters the elements of the members of a group.
+	memberQuery = &query{attributes: memberAttributes}
+)
+
+// condition returns the condition of a filter, nil for no filter.
+func (q *query) condition(f filter) (*types.Condition, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return q.translate(f, "")
+}
+
+func (q *query) translate(f filter, prefix string) (*types.Condition, error) {
+	switch f := f.(type) {
+	case *logicalFilter:
+		left, err := q.translate(f.left, prefix)
+		if err != nil {
+			return nil, err
+		}
+		right, err := q.translate(f.right, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if f.and {
+			return and(left, right), nil
+		}
+		return or(left, right), nil
+	case *notFilter:
+		c, err := q.translate(f.filter, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return not(c), nil
+	case *valuePathFilter:
+		name := strings.ToLower(strings.Join(f.path, "."))
+		if prefix != "" {
+			// Value paths do not nest.
+			return or(), nil
+		}
+		if q.members && name == "members" {
+			c, err := memberQuery.translate(f.filter, "")
+			if err != nil {
+				return nil, err
+			}
+			return member(c), nil
+		}
+
+		exists, ok := q.elements[name]
+		if !ok {
+			return or(), nil
+		}
+		c, err := q.translate(f.filter, name+".")
+		if err != nil {
+			return nil, err
+		}
+		return and(exists, c), nil
+	case *attrFilter:
+		name := prefix + strings.ToLower(strings.Join(f.path, "."))
+		if q.members && (name == "members" || strings.HasPrefix(name, "members.")) {
+			return memberCondition(strings.TrimPrefix(strings.TrimPrefix(name, "members"), "."), f)
+		}
+		attr, ok := q.attributes[name]
+		return comparison(attr, ok, f.op, f.value)
+	}
+	return nil, fmt.Errorf("%w: unsupported filter", ErrInvalidFilter)
+}
+
+// memberCondition returns the condition of a filter on a sub-attribute of
+// the members, the values of every member are compared as a multi-valued
+// attribute.
+func memberCondition(name string, f *attrFilter) (*types.Condition, error) {
+	if name == "" {
+		name = "value"
+	}
+	attr, ok := memberAttributes[name]
+	if !ok {
+		return comparison(attr, ok, f.op, f.value)
+	}
+
+	switch {
+	case f.op == "eq" && f.value == nil:
+		return not(member(present(attr.column))), nil
+	case f.op == "ne" && f.value == nil:
+		return member(present(attr.column)), nil
+	case f.op == "ne":
+		// ne matches the groups where no value equals the operand.
+		eq, err := comparison(attr, ok, "eq", f.value)
+		if err != nil {
+			return nil, err
+		}
+		return or(
+			not(member(present(attr.column))),
+			member(and(present(attr.column), not(eq))),
+		), nil
+	}
+
+	c, err := comparison(attr, ok, f.op, f.value)
+	if err != nil {
+		return nil, err
+	}
+	return member(c), nil
+}
+
+// comparison returns the condition comparing an attribute with the operand.
+// Unknown attributes are never present, and operands of another type than
+// the attribute never equal its value.
+func comparison(attr storedAttribute, known bool, op string, value any) (*types.Condition, error) {
+	if !known {
+		if (op == "eq" && value == nil) || (op == "ne" && value != nil) {
+			return and(), nil
+		}
+		return or(), nil
+	}
+
+	switch {
+	case op == "pr", op == "ne" && value == nil:
+		return present(attr.column), nil
+	case op == "eq" && value == nil:
+		return not(present(attr.column)), nil
+	}
+
+	switch attr.kind {
+	case kindString:
+		if _, ok := value.(string); !ok {
+			return mismatch(op), nil
+		}
+	case kindBoolean:
+		if _, ok := value.(bool); !ok {
+			return mismatch(op), nil
+		}
+	case kindDateTime:
+		s, ok := value.(string)
+		if !ok {
+			return mismatch(op), nil
+		}
+		if op == "co" || op == "sw" || op == "ew" {
+			return nil, fmt.Errorf("%w: %s does not apply to dates", ErrInvalidFilter, op)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid date %q", ErrInvalidFilter, s)
+		}
+		value = t
+	}
+
+	return &types.Condition{Op: op, Column: attr.column, Value: value, CaseExact: attr.caseExact}, nil
+}
+
+// mismatch is the condition of an operand of another type than the attribute.
+func mismatch(op string) *types.Condition {
+	if op == "ne" {
+		return and()
+	}
+	return or()
+}
+
+func and(operands ...*types.Condition) *types.Condition {
+	return &types.Condition{Op: types.CondAnd, Operands: operands}
+}
+
+func or(operands ...*types.Condition) *types.Condition {
+	return &types.Condition{Op: types.CondOr, Operands: operands}
+}
+
+func not(c *types.Condition) *types.Condition {
+	return &types.Condition{Op: types.CondNot, Operands: []*types.Condition{c}}
+}
+
+func member(c *types.Condition) *types.Condition {
+	return &types.Condition{Op: types.CondMember, Operands: []*types.Condition{c}}
+}
+
+func present(column string) *types.Condition {
+	return &types.Condition{Op: types.CondPresent, Column: column}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestQueryCondition(t *testing.T) {
+	date := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		query  *query
+		schema string
+		filter string
+
+		expected      *types.Condition
+		expectedError error
+	}{
+		{
+			name:     "Case exact attribute",
+			query:    userQuery,
+			schema:   SchemaUser,
+			filter:   `externalId eq "bjensen"`,
+			expected: &types.Condition{Op: types.CondEq, Column: "external_id", Value: "bjensen", CaseExact: true},
+		},
+		{
+			name:   "Logical operators",
+			query:  userQuery,
+			schema: SchemaUser,
+			filter: `userName sw "bj" and not (active eq false)`,
+			expected: and(
+				&types.Condition{Op: types.CondStart, Column: "user_name", Value: "bj"},
+				not(&types.Condition{Op: types.CondEq, Column: "active", Value: false}),
+			),
+		},
+		{
+			name:     "Date",
+			query:    userQuery,
+			schema:   SchemaUser,
+			filter:   `meta.lastModified gt "2026-02-01T00:00:00Z"`,
+			expected: &types.Condition{Op: types.CondGt, Column: "updated_at", Value: date},
+		},
+		{
+			name:     "Null",
+			query:    userQuery,
+			schema:   SchemaUser,
+			filter:   `displayName eq null`,
+			expected: not(present("display_name")),
+		},
+		{
+			name:     "Value path",
+			query:    userQuery,
+			schema:   SchemaUser,
+			filter:   `emails[value ew "example.com"]`,
+			expected: and(present("email"), &types.Condition{Op: types.CondEnd, Column: "email", Value: "example.com"}),
+		},
+		{
+			name:     "Unknown attribute",
+			query:    userQuery,
+			schema:   SchemaUser,
+			filter:   `title eq "boss"`,
+			expected: or(),
+		},
+		{
+			name:     "Not equal to another type",
+			query:    userQuery,
+			schema:   SchemaUser,
+			filter:   `active ne "true"`,
+			expected: and(),
+		},
+		{
+			name:     "Member value",
+			query:    groupQuery,
+			schema:   SchemaGroup,
+			filter:   `members.value eq "` + aliceID + `"`,
+			expected: member(&types.Condition{Op: types.CondEq, Column: types.MemberValue, Value: aliceID, CaseExact: true}),
+		},
+		{
+			name:   "No member equals",
+			query:  groupQuery,
+			schema: SchemaGroup,
+			filter: `members ne "` + aliceID + `"`,
+			expected: or(
+				not(member(present(types.MemberValue))),
+				member(and(present(types.MemberValue), not(&types.Condition{Op: types.CondEq, Column: types.MemberValue, Value: aliceID, CaseExact: true}))),
+			),
+		},
+		{
+			name:     "Member value path",
+			query:    groupQuery,
+			schema:   SchemaGroup,
+			filter:   `members[type eq "User" and display co "ali"]`,
+			expected: member(and(&types.Condition{Op: types.CondEq, Column: types.MemberType, Value: "User"}, &types.Condition{Op: types.CondContain, Column: types.MemberDisplay, Value: "ali"})),
+		},
+		{
+			name:          "Substring of a date",
+			query:         groupQuery,
+			schema:        SchemaGroup,
+			filter:        `meta.created co "2026"`,
+			expectedError: ErrInvalidFilter,
+		},
+		{
+			name:          "Invalid date",
+			query:         groupQuery,
+			schema:        SchemaGroup,
+			filter:        `meta.created gt "yesterday"`,
+			expectedError: ErrInvalidFilter,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := parseListFilter(test.filter, test.schema, test.query)
+
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(c, test.expected) {
+				t.Fatalf("expected %+v, got %+v", test.expected, c)
+			}
+		})
+	}
+}
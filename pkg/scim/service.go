@@ -0,0 +1,706 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+var _ ServiceInterface = (*Service)(nil)
+
+// Service maps SCIM resources onto the users, groups and memberships of the
+// storage. Users are shared by every tenant, groups are those of the tenant
+// of the request, and members are stored under the user name of the SCIM user
+// they reference.
+type Service struct {
+	db    DatabaseInterface
+	authz AuthorizerInterface
+	cache CacheInvalidatorInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// ListUsers returns a page of the users matching the filter and their total.
+func (s *Service) ListUsers(ctx context.Context, req *ListRequest) ([]*User, int, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.ListUsers")
+	defer span.End()
+
+	where, err := parseListFilter(req.Filter, SchemaUser, userQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users, total, err := s.db.SearchUsers(ctx, directoryFilter(where, req))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %v", err)
+	}
+
+	resources := make([]*User, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, toUser(u))
+	}
+	return resources, int(total), nil
+}
+
+func (s *Service) GetUser(ctx context.Context, id string) (*User, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.GetUser")
+	defer span.End()
+
+	u, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(u), nil
+}
+
+func (s *Service) CreateUser(ctx context.Context, r *User) (*User, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.CreateUser")
+	defer span.End()
+
+	u, err := fromUser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.db.CreateUser(ctx, u)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			return nil, ErrDuplicateUser
+		}
+		return nil, fmt.Errorf("failed to create user: %v", err)
+	}
+
+	// Memberships may predate the user, an inactive user hides them.
+	s.cache.InvalidateUsers(ctx, created.UserName)
+	return toUser(created), nil
+}
+
+func (s *Service) ReplaceUser(ctx context.Context, id string, r *User) (*User, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.ReplaceUser")
+	defer span.End()
+
+	current, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, current, r)
+}
+
+// PatchUser applies the PATCH operations to the user.
+func (s *Service) PatchUser(ctx context.Context, id string, req *PatchRequest) (*User, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.PatchUser")
+	defer span.End()
+
+	current, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := toMap(toUser(current))
+	if err != nil {
+		return nil, err
+	}
+	if err := applyPatch(m, req.Operations, SchemaUser); err != nil {
+		return nil, err
+	}
+
+	r := &User{Active: true}
+	if err := fromMap(m, r); err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, current, r)
+}
+
+func (s *Service) DeleteUser(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.DeleteUser")
+	defer span.End()
+
+	current, err := s.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.DeleteUser(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to delete user: %v", err)
+	}
+
+	s.cache.InvalidateUsers(ctx, current.UserName)
+	return nil
+}
+
+func (s *Service) getUser(ctx context.Context, id string) (*types.User, error) {
+	u, err := s.db.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+	return u, nil
+}
+
+func (s *Service) updateUser(ctx context.Context, current *types.User, r *User) (*User, error) {
+	u, err := fromUser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.UpdateUser(ctx, current.ID, u)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return nil, ErrUserNotFound
+		case errors.Is(err, storage.ErrDuplicateKey):
+			return nil, ErrDuplicateUser
+		}
+		return nil, fmt.Errorf("failed to update user: %v", err)
+	}
+
+	s.cache.InvalidateUsers(ctx, current.UserName, updated.UserName)
+	return toUser(updated), nil
+}
+
+// ListGroups returns a page of the groups of the tenant matching the filter
+// and their total. Members are only loaded for the page, when they are
+// returned.
+func (s *Service) ListGroups(ctx context.Context, req *ListRequest) ([]*Group, int, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.ListGroups")
+	defer span.End()
+
+	where, err := parseListFilter(req.Filter, SchemaGroup, groupQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	groups, total, err := s.db.SearchGroups(storage.WithTenant(ctx, tenantOf(ctx)), directoryFilter(where, req))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list groups: %v", err)
+	}
+
+	members := make(map[string][]Member)
+	if !req.ExcludeMembers && len(groups) > 0 {
+		if members, err = s.loadGroupsMembers(ctx, groups); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	resources := make([]*Group, 0, len(groups))
+	for _, g := range groups {
+		resources = append(resources, toGroup(g, members[g.ID]))
+	}
+	return resources, int(total), nil
+}
+
+func (s *Service) GetGroup(ctx context.Context, id string) (*Group, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.GetGroup")
+	defer span.End()
+
+	g, err := s.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, _, err := s.loadMembers(ctx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toGroup(g, members), nil
+}
+
+// CreateGroup creates an external group in the tenant of the request with the
+// given members.
+func (s *Service) CreateGroup(ctx context.Context, r *Group) (*Group, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.CreateGroup")
+	defer span.End()
+
+	name := strings.TrimSpace(r.DisplayName)
+	if name == "" {
+		return nil, fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+
+	userNames, err := s.resolveMembers(ctx, r.Members, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := s.db.CreateGroup(ctx, &types.Group{
+		Name:     name,
+		TenantId: tenantOf(ctx),
+		Type:     types.GroupTypeExternal,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			return nil, ErrDuplicateGroup
+		}
+		return nil, fmt.Errorf("failed to create group: %v", err)
+	}
+
+	if err := s.syncMembers(ctx, g.ID, nil, userNames); err != nil {
+		return nil, err
+	}
+
+	members, _, err := s.loadMembers(ctx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toGroup(g, members), nil
+}
+
+func (s *Service) ReplaceGroup(ctx context.Context, id string, r *Group) (*Group, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.ReplaceGroup")
+	defer span.End()
+
+	current, err := s.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userNames, err := s.listUserNames(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateGroup(ctx, current, userNames, r)
+}
+
+// PatchGroup applies the PATCH operations to the group and its members.
+func (s *Service) PatchGroup(ctx context.Context, id string, req *PatchRequest) (*Group, error) {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.PatchGroup")
+	defer span.End()
+
+	current, err := s.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, userNames, err := s.loadMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := toMap(toGroup(current, members))
+	if err != nil {
+		return nil, err
+	}
+	if err := applyPatch(m, req.Operations, SchemaGroup); err != nil {
+		return nil, err
+	}
+
+	r := new(Group)
+	if err := fromMap(m, r); err != nil {
+		return nil, err
+	}
+	return s.updateGroup(ctx, current, userNames, r)
+}
+
+func (s *Service) DeleteGroup(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "scim.Service.DeleteGroup")
+	defer span.End()
+
+	if _, err := s.getGroup(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.db.DeleteGroup(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete group from db: %v", err)
+	}
+	if err := s.authz.DeleteGroup(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete group from authz: %v", err)
+	}
+
+	s.cache.InvalidateGroups(ctx)
+	return nil
+}
+
+// getGroup returns the group, groups of other tenants are not found.
+func (s *Service) getGroup(ctx context.Context, id string) (*types.Group, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrGroupNotFound
+	}
+
+	g, err := s.db.GetGroup(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get group: %v", err)
+	}
+	if g.TenantId != tenantOf(ctx) {
+		return nil, ErrGroupNotFound
+	}
+	return g, nil
+}
+
+func (s *Service) updateGroup(ctx context.Context, current *types.Group, userNames []string, r *Group) (*Group, error) {
+	name := strings.TrimSpace(r.DisplayName)
+	if name == "" {
+		return nil, fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+
+	desired, err := s.resolveMembers(ctx, r.Members, userNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != current.Name {
+		updated := *current
+		updated.Name = name
+		if _, err := s.db.UpdateGroup(ctx, current.ID, &updated); err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				return nil, ErrGroupNotFound
+			case errors.Is(err, storage.ErrDuplicateKey):
+				return nil, ErrDuplicateGroup
+			}
+			return nil, fmt.Errorf("failed to update group: %v", err)
+		}
+		s.cache.InvalidateGroups(ctx)
+	}
+
+	if err := s.syncMembers(ctx, current.ID, userNames, desired); err != nil {
+		return nil, err
+	}
+
+	return s.GetGroup(ctx, current.ID)
+}
+
+// listUserNames returns the user names the members of a group are stored
+// under, owners included.
+func (s *Service) listUserNames(ctx context.Context, groupID string) ([]string, error) {
+	users, _, err := s.db.ListUsersInGroup(ctx, groupID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %v", err)
+	}
+
+	userNames := make([]string, 0, len(users))
+	for _, user := range users {
+		userNames = append(userNames, user.ID)
+	}
+	return userNames, nil
+}
+
+// loadMembers returns the members of a group and the user names they are
+// stored under.
+func (s *Service) loadMembers(ctx context.Context, groupID string) ([]Member, []string, error) {
+	userNames, err := s.listUserNames(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users, err := s.db.GetUsersByUserNames(ctx, userNames)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get group members: %v", err)
+	}
+
+	return toMembers(userNames, usersByName(users)), userNames, nil
+}
+
+// loadGroupsMembers returns the members of each group, with one query for the
+// memberships and one for the users of every group.
+func (s *Service) loadGroupsMembers(ctx context.Context, groups []*types.Group) (map[string][]Member, error) {
+	ids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, g.ID)
+	}
+
+	memberships, err := s.db.ListUsersInGroups(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	userNames := make([]string, 0)
+	for _, names := range memberships {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				userNames = append(userNames, name)
+			}
+		}
+	}
+
+	users, err := s.db.GetUsersByUserNames(ctx, userNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %v", err)
+	}
+
+	byName := usersByName(users)
+	members := make(map[string][]Member, len(memberships))
+	for id, names := range memberships {
+		members[id] = toMembers(names, byName)
+	}
+	return members, nil
+}
+
+func usersByName(users []*types.User) map[string]*types.User {
+	byName := make(map[string]*types.User, len(users))
+	for _, u := range users {
+		byName[strings.ToLower(u.UserName)] = u
+	}
+	return byName
+}
+
+// toMembers returns the members stored under the user names, those without a
+// SCIM user are listed by their user name.
+func toMembers(userNames []string, byName map[string]*types.User) []Member {
+	members := make([]Member, 0, len(userNames))
+	for _, name := range userNames {
+		u, ok := byName[strings.ToLower(name)]
+		if !ok {
+			members = append(members, Member{Value: name, Display: name})
+			continue
+		}
+
+		display := u.DisplayName
+		if display == "" {
+			display = u.UserName
+		}
+		members = append(members, Member{Value: u.ID, Display: display, Type: ResourceTypeUser})
+	}
+	return members
+}
+
+// resolveMembers returns the user names of the members, which reference SCIM
+// users by ID. Members of the group added outside SCIM are kept by user name.
+func (s *Service) resolveMembers(ctx context.Context, members []Member, current []string) ([]string, error) {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Value)
+	}
+
+	users, err := s.db.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %v", err)
+	}
+
+	byID := make(map[string]string, len(users))
+	for _, u := range users {
+		byID[u.ID] = u.UserName
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, name := range current {
+		existing[name] = true
+	}
+
+	seen := make(map[string]bool, len(members))
+	userNames := make([]string, 0, len(members))
+	for _, id := range ids {
+		name, ok := byID[id]
+		if !ok {
+			if !existing[id] {
+				return nil, fmt.Errorf("%w: unknown member %q", ErrInvalidValue, id)
+			}
+			name = id
+		}
+		if !seen[name] {
+			seen[name] = true
+			userNames = append(userNames, name)
+		}
+	}
+
+	return userNames, nil
+}
+
+// syncMembers adds and removes members so that the group has the desired
+// members, only the difference is written.
+func (s *Service) syncMembers(ctx context.Context, groupID string, current, desired []string) error {
+	keep := make(map[string]bool, len(desired))
+	for _, name := range desired {
+		keep[name] = true
+	}
+
+	removed := make([]string, 0)
+	for _, name := range current {
+		if !keep[name] {
+			removed = append(removed, name)
+		}
+		delete(keep, name)
+	}
+
+	added := make([]string, 0, len(keep))
+	for _, name := range desired {
+		if keep[name] {
+			added = append(added, name)
+		}
+	}
+
+	if len(added) > 0 {
+		if err := s.db.AddUsersToGroup(ctx, groupID, added); err != nil {
+			return fmt.Errorf("failed to add group members: %v", err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := s.db.RemoveUsersFromGroup(ctx, groupID, removed); err != nil {
+			return fmt.Errorf("failed to remove group members: %v", err)
+		}
+	}
+
+	if changed := append(added, removed...); len(changed) > 0 {
+		s.cache.InvalidateUsers(ctx, changed...)
+	}
+	return nil
+}
+
+func toUser(u *types.User) *User {
+	r := &User{
+		Schemas:     []string{SchemaUser},
+		ID:          u.ID,
+		ExternalID:  u.ExternalID,
+		UserName:    u.UserName,
+		DisplayName: u.DisplayName,
+		Active:      Boolean(u.Active),
+		Meta: &Meta{
+			ResourceType: ResourceTypeUser,
+			Created:      &u.CreatedAt,
+			LastModified: &u.UpdatedAt,
+		},
+	}
+	if u.GivenName != "" || u.FamilyName != "" {
+		r.Name = &Name{GivenName: u.GivenName, FamilyName: u.FamilyName}
+	}
+	if u.Email != "" {
+		r.Emails = []MultiValue{{Value: u.Email, Primary: true}}
+	}
+	return r
+}
+
+func fromUser(r *User) (*types.User, error) {
+	u := &types.User{
+		UserName:    strings.TrimSpace(r.UserName),
+		ExternalID:  r.ExternalID,
+		DisplayName: r.DisplayName,
+		Email:       r.primaryEmail(),
+		Active:      bool(r.Active),
+	}
+	if u.UserName == "" {
+		return nil, fmt.Errorf("%w: userName is required", ErrInvalidValue)
+	}
+	if r.Name != nil {
+		u.GivenName = r.Name.GivenName
+		u.FamilyName = r.Name.FamilyName
+	}
+	return u, nil
+}
+
+func toGroup(g *types.Group, members []Member) *Group {
+	return &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          g.ID,
+		DisplayName: g.Name,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: ResourceTypeGroup,
+			Created:      &g.CreatedAt,
+			LastModified: &g.UpdatedAt,
+		},
+	}
+}
+
+// parseListFilter returns the condition of a list filter, nil without a
+// filter.
+func parseListFilter(s, schema string, q *query) (*types.Condition, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	f, err := parseFilter(s, schema)
+	if err != nil {
+		return nil, err
+	}
+	return q.condition(f)
+}
+
+// directoryFilter selects the page of the 1-based start index and the count.
+func directoryFilter(where *types.Condition, req *ListRequest) *types.DirectoryFilter {
+	return &types.DirectoryFilter{
+		Where:  where,
+		Offset: uint64(max(req.StartIndex, 1) - 1),
+		Limit:  uint64(max(req.Count, 0)),
+	}
+}
+
+// tenantOf returns the tenant of the request, groups of other tenants are not
+// visible over SCIM.
+func tenantOf(ctx context.Context) string {
+	if tenantID, ok := storage.TenantFromContext(ctx); ok {
+		return tenantID
+	}
+	return storage.DefaultTenantID
+}
+
+// toMap returns the JSON representation of a resource.
+func toMap(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource: %v", err)
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode resource: %v", err)
+	}
+	return m, nil
+}
+
+// fromMap decodes the JSON representation of a resource, read-only
+// attributes are ignored.
+func fromMap(m map[string]any, v any) error {
+	for _, k := range []string{"schemas", "id", "meta"} {
+		delete(m, lookupKey(m, k))
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	return nil
+}
+
+func NewService(
+	db DatabaseInterface,
+	authz AuthorizerInterface,
+	cache CacheInvalidatorInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	s := new(Service)
+
+	s.db = db
+	s.authz = authz
+	s.cache = cache
+
+	if s.cache == nil {
+		s.cache = noopCacheInvalidator{}
+	}
+
+	s.tracer = tracer
+	s.monitor = monitor
+	s.logger = logger
+
+	return s
+}
+
+type noopCacheInvalidator struct{}
+
+func (noopCacheInvalidator) InvalidateUsers(context.Context, ...string) {}
+func (noopCacheInvalidator) InvalidateGroups(context.Context)           {}
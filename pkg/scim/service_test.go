@@ -0,0 +1,367 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/storage"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package scim -destination ./mock_scim.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package scim -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package scim -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package scim -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+const (
+	groupID = "0a6e5c4c-46b7-4d2c-8d38-44a7a0b4a3f1"
+	aliceID = "7f1b8e56-2d4f-4a57-9a8b-52f3c0d2b6a1"
+	bobID   = "c3d2e1f0-8a9b-4c5d-9e6f-0a1b2c3d4e5f"
+)
+
+func newTestService(ctrl *gomock.Controller) (*Service, *MockDatabaseInterface, *MockAuthorizerInterface, *MockCacheInvalidatorInterface) {
+	mockDB := NewMockDatabaseInterface(ctrl)
+	mockAuthz := NewMockAuthorizerInterface(ctrl)
+	mockCache := NewMockCacheInvalidatorInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+			return ctx, trace.SpanFromContext(ctx)
+		},
+	)
+
+	s := NewService(mockDB, mockAuthz, mockCache, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	return s, mockDB, mockAuthz, mockCache
+}
+
+func TestServiceCreateUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *User
+
+		expectedUser  *types.User
+		dbErr         error
+		expectedError error
+	}{
+		{
+			name: "Primary email and name are stored",
+			input: &User{
+				UserName: " alice ",
+				Name:     &Name{GivenName: "Alice", FamilyName: "Liddell"},
+				Emails:   []MultiValue{{Value: "a@home.example"}, {Value: "alice@example.com", Primary: true}},
+				Active:   true,
+			},
+			expectedUser: &types.User{UserName: "alice", GivenName: "Alice", FamilyName: "Liddell", Email: "alice@example.com", Active: true},
+		},
+		{
+			name:          "Duplicate user name",
+			input:         &User{UserName: "alice", Active: true},
+			expectedUser:  &types.User{UserName: "alice", Active: true},
+			dbErr:         storage.ErrDuplicateKey,
+			expectedError: ErrDuplicateUser,
+		},
+		{
+			name:          "Missing user name",
+			input:         &User{Active: true},
+			expectedError: ErrInvalidValue,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, mockDB, _, mockCache := newTestService(ctrl)
+
+			if test.expectedUser != nil {
+				mockDB.EXPECT().CreateUser(gomock.Any(), test.expectedUser).DoAndReturn(
+					func(_ context.Context, u *types.User) (*types.User, error) {
+						if test.dbErr != nil {
+							return nil, test.dbErr
+						}
+						created := *u
+						created.ID = aliceID
+						return &created, nil
+					},
+				)
+			}
+			if test.expectedError == nil {
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), "alice")
+			}
+
+			u, err := s.CreateUser(context.TODO(), test.input)
+
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if u.ID != aliceID || u.UserName != "alice" || !reflect.DeepEqual(u.Emails, []MultiValue{{Value: "alice@example.com", Primary: true}}) {
+				t.Fatalf("unexpected user %+v", u)
+			}
+		})
+	}
+}
+
+func TestServiceListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mockDB, _, _ := newTestService(ctrl)
+
+	mockDB.EXPECT().SearchUsers(gomock.Any(), &types.DirectoryFilter{
+		Where:  &types.Condition{Op: types.CondEq, Column: "active", Value: true},
+		Offset: 1,
+		Limit:  10,
+	}).Return([]*types.User{{ID: "u3", UserName: "carol", Active: true}}, int64(2), nil)
+
+	users, total, err := s.ListUsers(context.TODO(), &ListRequest{Filter: "active eq true", StartIndex: 2, Count: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("expected 2 results, got %d", total)
+	}
+	if len(users) != 1 || users[0].UserName != "carol" {
+		t.Fatalf("expected the second active user, got %+v", users)
+	}
+
+	if _, _, err := s.ListUsers(context.TODO(), &ListRequest{Filter: "userName xx 1"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidFilter, err)
+	}
+}
+
+func TestServiceListGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		tenant string
+		req    *ListRequest
+
+		expectedTenant  string
+		expectedMembers map[string][]Member
+	}{
+		{
+			name:           "Members are loaded for the page",
+			req:            &ListRequest{Filter: `members[value eq "` + aliceID + `"]`, StartIndex: 1, Count: 2},
+			expectedTenant: storage.DefaultTenantID,
+			expectedMembers: map[string][]Member{
+				groupID: {{Value: aliceID, Display: "alice", Type: ResourceTypeUser}, {Value: "legacy@example.com", Display: "legacy@example.com"}},
+				bobID:   {{Value: aliceID, Display: "alice", Type: ResourceTypeUser}},
+			},
+		},
+		{
+			name:            "Excluded members are not loaded",
+			req:             &ListRequest{Filter: `members[value eq "` + aliceID + `"]`, StartIndex: 1, Count: 2, ExcludeMembers: true},
+			expectedTenant:  storage.DefaultTenantID,
+			expectedMembers: map[string][]Member{},
+		},
+		{
+			name:            "Groups of the tenant of the request",
+			tenant:          "tenant-a",
+			req:             &ListRequest{Filter: `members[value eq "` + aliceID + `"]`, StartIndex: 1, Count: 2, ExcludeMembers: true},
+			expectedTenant:  "tenant-a",
+			expectedMembers: map[string][]Member{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, mockDB, _, _ := newTestService(ctrl)
+
+			ctx := context.TODO()
+			if test.tenant != "" {
+				ctx = storage.WithTenant(ctx, test.tenant)
+			}
+
+			expectedFilter := &types.DirectoryFilter{
+				Where: &types.Condition{Op: types.CondMember, Operands: []*types.Condition{
+					{Op: types.CondEq, Column: types.MemberValue, Value: aliceID, CaseExact: true},
+				}},
+				Limit: 2,
+			}
+			mockDB.EXPECT().SearchGroups(gomock.Any(), expectedFilter).DoAndReturn(
+				func(ctx context.Context, _ *types.DirectoryFilter) ([]*types.Group, int64, error) {
+					if tenantID, _ := storage.TenantFromContext(ctx); tenantID != test.expectedTenant {
+						t.Fatalf("expected tenant %q, got %q", test.expectedTenant, tenantID)
+					}
+					return []*types.Group{
+						{ID: groupID, Name: "admins", TenantId: test.expectedTenant},
+						{ID: bobID, Name: "users", TenantId: test.expectedTenant},
+					}, 3, nil
+				},
+			)
+			if !test.req.ExcludeMembers {
+				mockDB.EXPECT().ListUsersInGroups(gomock.Any(), []string{groupID, bobID}).Return(map[string][]string{
+					groupID: {"alice", "legacy@example.com"},
+					bobID:   {"alice"},
+				}, nil)
+				mockDB.EXPECT().GetUsersByUserNames(gomock.Any(), gomock.Any()).Return([]*types.User{{ID: aliceID, UserName: "alice"}}, nil)
+			}
+
+			groups, total, err := s.ListGroups(ctx, test.req)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if total != 3 || len(groups) != 2 {
+				t.Fatalf("expected 2 groups of 3, got %d of %d", len(groups), total)
+			}
+			for _, g := range groups {
+				if !reflect.DeepEqual(g.Members, test.expectedMembers[g.ID]) {
+					t.Fatalf("expected members %+v, got %+v", test.expectedMembers[g.ID], g.Members)
+				}
+			}
+		})
+	}
+}
+
+func TestServicePatchGroupMembers(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  string
+
+		dbUsers []*types.User
+
+		expectedAdded   []string
+		expectedRemoved []string
+		expectedError   error
+	}{
+		{
+			name:          "Add a SCIM user",
+			ops:           `[{"op": "add", "path": "members", "value": [{"value": "` + bobID + `"}]}]`,
+			dbUsers:       []*types.User{{ID: aliceID, UserName: "alice"}, {ID: bobID, UserName: "bob"}},
+			expectedAdded: []string{"bob"},
+		},
+		{
+			name:            "Remove a SCIM user",
+			ops:             `[{"op": "remove", "path": "members[value eq \"` + aliceID + `\"]"}]`,
+			expectedRemoved: []string{"alice"},
+		},
+		{
+			name:            "Remove a member added outside SCIM",
+			ops:             `[{"op": "remove", "path": "members[value eq \"legacy@example.com\"]"}]`,
+			dbUsers:         []*types.User{{ID: aliceID, UserName: "alice"}},
+			expectedRemoved: []string{"legacy@example.com"},
+		},
+		{
+			name:          "Unknown member",
+			ops:           `[{"op": "add", "path": "members", "value": [{"value": "ghost"}]}]`,
+			dbUsers:       []*types.User{{ID: aliceID, UserName: "alice"}},
+			expectedError: ErrInvalidValue,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, mockDB, _, mockCache := newTestService(ctrl)
+
+			group := &types.Group{ID: groupID, Name: "admins", TenantId: storage.DefaultTenantID}
+			members := []*types.GroupUser{{ID: "alice"}, {ID: "legacy@example.com", Role: types.RoleOwner}}
+
+			mockDB.EXPECT().GetGroup(gomock.Any(), groupID).Return(group, nil).AnyTimes()
+			mockDB.EXPECT().ListUsersInGroup(gomock.Any(), groupID, nil).DoAndReturn(
+				func(context.Context, string, *types.ListFilter) ([]*types.GroupUser, *types.Page, error) {
+					return members, new(types.Page), nil
+				},
+			).AnyTimes()
+			mockDB.EXPECT().GetUsersByUserNames(gomock.Any(), gomock.Any()).Return([]*types.User{{ID: aliceID, UserName: "alice"}}, nil).AnyTimes()
+			mockDB.EXPECT().GetUsersByIDs(gomock.Any(), gomock.Any()).Return(test.dbUsers, nil)
+
+			if test.expectedAdded != nil {
+				mockDB.EXPECT().AddUsersToGroup(gomock.Any(), groupID, test.expectedAdded).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), test.expectedAdded[0])
+			}
+			if test.expectedRemoved != nil {
+				mockDB.EXPECT().RemoveUsersFromGroup(gomock.Any(), groupID, test.expectedRemoved).Return(nil)
+				mockCache.EXPECT().InvalidateUsers(gomock.Any(), test.expectedRemoved[0])
+			}
+
+			var ops []PatchOperation
+			if err := json.Unmarshal([]byte(test.ops), &ops); err != nil {
+				t.Fatalf("invalid operations: %v", err)
+			}
+
+			_, err := s.PatchGroup(context.TODO(), groupID, &PatchRequest{Operations: ops})
+
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestServiceGetGroupOfOtherTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mockDB, _, _ := newTestService(ctrl)
+
+	mockDB.EXPECT().GetGroup(gomock.Any(), groupID).Return(&types.Group{ID: groupID, TenantId: "other"}, nil)
+
+	if _, err := s.GetGroup(context.TODO(), groupID); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrGroupNotFound, err)
+	}
+	if _, err := s.GetGroup(context.TODO(), "not-a-uuid"); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrGroupNotFound, err)
+	}
+}
+
+func TestServiceGetGroupOfRequestTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mockDB, _, _ := newTestService(ctrl)
+
+	mockDB.EXPECT().GetGroup(gomock.Any(), groupID).Return(&types.Group{ID: groupID, Name: "admins", TenantId: "other"}, nil)
+	mockDB.EXPECT().ListUsersInGroup(gomock.Any(), groupID, nil).Return([]*types.GroupUser{}, new(types.Page), nil)
+	mockDB.EXPECT().GetUsersByUserNames(gomock.Any(), []string{}).Return([]*types.User{}, nil)
+
+	g, err := s.GetGroup(storage.WithTenant(context.TODO(), "other"), groupID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if g.DisplayName != "admins" {
+		t.Fatalf("unexpected group %+v", g)
+	}
+}
+
+func TestServiceDeleteGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mockDB, mockAuthz, mockCache := newTestService(ctrl)
+
+	mockDB.EXPECT().GetGroup(gomock.Any(), groupID).Return(&types.Group{ID: groupID, TenantId: storage.DefaultTenantID}, nil)
+	mockDB.EXPECT().DeleteGroup(gomock.Any(), groupID).Return(nil)
+	mockAuthz.EXPECT().DeleteGroup(gomock.Any(), groupID).Return(nil)
+	mockCache.EXPECT().InvalidateGroups(gomock.Any())
+
+	if err := s.DeleteGroup(context.TODO(), groupID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
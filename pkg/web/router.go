@@ -14,6 +14,10 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	decisionspb "github.com/canonical/hook-service/gen/hook/decisions/v1"
+	explainpb "github.com/canonical/hook-service/gen/hook/explain/v1"
+	groupspb "github.com/canonical/hook-service/gen/hook/groups/v1"
+	historypb "github.com/canonical/hook-service/gen/hook/history/v1"
 	"github.com/canonical/hook-service/internal/authorization"
 	"github.com/canonical/hook-service/internal/db"
 	"github.com/canonical/hook-service/internal/http/types"
@@ -25,20 +29,31 @@ import (
 	"github.com/canonical/hook-service/internal/tracing"
 	"github.com/canonical/hook-service/pkg/authentication"
 	authz_api "github.com/canonical/hook-service/pkg/authorization"
+	"github.com/canonical/hook-service/pkg/decisions"
+	"github.com/canonical/hook-service/pkg/explain"
 	groups_api "github.com/canonical/hook-service/pkg/groups"
+	"github.com/canonical/hook-service/pkg/history"
 	"github.com/canonical/hook-service/pkg/hooks"
 	"github.com/canonical/hook-service/pkg/metrics"
+	"github.com/canonical/hook-service/pkg/scim"
 	"github.com/canonical/hook-service/pkg/status"
 )
 
 func NewRouter(
 	token string,
+	signatureVerifier *hooks.SignatureVerifier,
 	authenticationEnabled bool,
 	wpool pool.WorkerPoolInterface,
 	s storage.StorageInterface,
 	dbClient db.DBClientInterface,
 	authz authorization.AuthorizerInterface,
 	tenantValidator tenants.TenantValidatorInterface,
+	groupSources []hooks.GroupSource,
+	claimMapper *hooks.ClaimMapper,
+	policy *hooks.PolicyConfig,
+	tenantConfig *hooks.TenantConfig,
+	decisionCache *hooks.DecisionCache,
+	decisionLog *hooks.DecisionLog,
 	jwtVerifier authentication.TokenVerifierInterface,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
@@ -67,17 +82,18 @@ func NewRouter(
 	}
 
 	var authMiddleware *hooks.AuthMiddleware = nil
-	if token != "" {
-		authMiddleware = hooks.NewAuthMiddleware(token, tracer, logger)
+	if token != "" || signatureVerifier != nil {
+		authMiddleware = hooks.NewAuthMiddleware(token, signatureVerifier, tracer, logger)
 	}
 
-	authzService := authz_api.NewService(s, authz, tracer, monitor, logger)
-	groupService := groups_api.NewService(s, authz, tracer, monitor, logger)
+	authzService := authz_api.NewService(s, authz, decisionCache, tracer, monitor, logger)
+	groupService := groups_api.NewService(s, authz, decisionCache, tracer, monitor, logger)
+	decisionService := decisions.NewService(s, tracer, monitor, logger)
+	historyService := history.NewService(s, tracer, monitor, logger)
+	scimService := scim.NewService(s, authz, decisionCache, tracer, monitor, logger)
 
-	groupClients := []hooks.ClientInterface{}
-	if s != nil {
-		groupClients = append(groupClients, hooks.NewLocalStorageClient(s, tracer, monitor, logger))
-	}
+	hookService := hooks.NewService(groupSources, decisionCache.Authorizer(authz), tenantValidator, policy, tenantConfig, decisionLog, wpool, tracer, monitor, logger)
+	explainService := explain.NewService(hookService, claimMapper, tracer, monitor, logger)
 
 	gRPCGatewayMux := runtime.NewServeMux(
 		runtime.WithForwardResponseRewriter(types.ForwardErrorResponseRewriter),
@@ -95,19 +111,45 @@ func NewRouter(
 	// Register gRPC Gateway handlers
 	v0_authz.RegisterAppAuthorizationServiceHandlerServer(context.Background(), gRPCGatewayMux, authz_api.NewGrpcServer(authzService, tracer, monitor, logger))
 	v0_groups.RegisterAuthzGroupsServiceHandlerServer(context.Background(), gRPCGatewayMux, groups_api.NewGrpcServer(groupService, tracer, monitor, logger))
+	groupspb.RegisterGroupNestingServiceHandlerServer(context.Background(), gRPCGatewayMux, groups_api.NewNestingGrpcServer(groupService, tracer, monitor, logger))
+	groupspb.RegisterGroupOwnersServiceHandlerServer(context.Background(), gRPCGatewayMux, groups_api.NewOwnersGrpcServer(groupService, tracer, monitor, logger))
+	groupspb.RegisterGroupAttributesServiceHandlerServer(context.Background(), gRPCGatewayMux, groups_api.NewAttributesGrpcServer(groupService, tracer, monitor, logger))
+	groupspb.RegisterGroupDeletionServiceHandlerServer(context.Background(), gRPCGatewayMux, groups_api.NewDeletionGrpcServer(groupService, tracer, monitor, logger))
+	groupspb.RegisterGroupListingServiceHandlerServer(context.Background(), gRPCGatewayMux, groups_api.NewListingGrpcServer(groupService, tracer, monitor, logger))
+	groupspb.RegisterAppGrantListingServiceHandlerServer(context.Background(), gRPCGatewayMux, authz_api.NewListingGrpcServer(authzService, tracer, monitor, logger))
+	decisionspb.RegisterDecisionsServiceHandlerServer(context.Background(), gRPCGatewayMux, decisions.NewGrpcServer(decisionService, tracer, monitor, logger))
+	historypb.RegisterHistoryServiceHandlerServer(context.Background(), gRPCGatewayMux, history.NewGrpcServer(historyService, tracer, monitor, logger))
+	explainpb.RegisterExplainServiceHandlerServer(context.Background(), gRPCGatewayMux, explain.NewGrpcServer(explainService, tracer, monitor, logger))
 
 	// Mount gRPC Gateway under /api/v0/ and protect with JWT auth middleware
 	authzRouter := chi.NewRouter()
+	jwtAuthMiddleware := authentication.NewMiddleware(jwtVerifier, tracer, monitor, logger)
+	if authenticationEnabled {
+		// Group owners manage the members of their groups without access to the whole API
+		delegatedRouter := authzRouter.With(jwtAuthMiddleware.AuthenticateDelegated(), jwtAuthMiddleware.Tenant(), jwtAuthMiddleware.Actor(storage.SourceAPI))
+		delegatedRouter.Handle("/groups/{id}/users", gRPCGatewayMux)
+		delegatedRouter.Handle("/groups/{id}/users:search", gRPCGatewayMux)
+		delegatedRouter.Handle("/groups/{id}/users/{user_id}", gRPCGatewayMux)
+		authzRouter.With(jwtAuthMiddleware.Authenticate(), jwtAuthMiddleware.Tenant(), jwtAuthMiddleware.Actor(storage.SourceAPI)).Handle("/*", gRPCGatewayMux)
+	} else {
+		authzRouter.Use(jwtAuthMiddleware.Tenant(), jwtAuthMiddleware.Actor(storage.SourceAPI))
+		authzRouter.Mount("/", gRPCGatewayMux)
+	}
+
+	// Mount the SCIM endpoints under /scim/v2/ behind the same JWT auth middleware,
+	// groups are those of the tenant of the request
+	scimRouter := chi.NewRouter()
 	if authenticationEnabled {
-		jwtAuthMiddleware := authentication.NewMiddleware(jwtVerifier, tracer, monitor, logger)
-		authzRouter.Use(jwtAuthMiddleware.Authenticate())
+		scimRouter.Use(jwtAuthMiddleware.Authenticate())
 	}
-	authzRouter.Mount("/", gRPCGatewayMux)
+	scimRouter.Use(jwtAuthMiddleware.Tenant(), jwtAuthMiddleware.Actor(storage.SourceSCIM))
+	scim.NewAPI(scimService, tracer, monitor, logger).RegisterEndpoints(scimRouter)
 
 	// Register unprottected HTTP handlers
 	hooks.NewAPI(
-		hooks.NewService(groupClients, authz, tenantValidator, wpool, tracer, monitor, logger),
+		hookService,
 		authMiddleware,
+		claimMapper,
 		tracer,
 		monitor,
 		logger).RegisterEndpoints(router)
@@ -115,6 +157,7 @@ func NewRouter(
 	status.NewAPI(tracer, monitor, logger).RegisterEndpoints(router)
 
 	router.Mount("/api/v0/authz", authzRouter)
+	router.Mount(scim.BasePath, scimRouter)
 
 	return tracing.NewMiddleware(monitor, logger).OpenTelemetry(router)
 }
@@ -0,0 +1,363 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/signing"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// dispatchBatchSize is the number of events or replayed deliveries read at
+// once for a subscriber.
+const dispatchBatchSize = 100
+
+// errLeaseLost stops the deliveries to a subscriber once another instance
+// took over its cursor.
+var errLeaseLost = errors.New("lost the lease on the webhook cursor")
+
+// Config tunes the Dispatcher.
+type Config struct {
+	Subscribers []Subscriber
+
+	// MaxAttempts is the number of attempts of a delivery before its event
+	// is dead-lettered.
+	MaxAttempts int
+	// MinBackoff is the wait after the first failed attempt, it doubles
+	// after each attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds each attempt.
+	Timeout time.Duration
+
+	// PollInterval is the wait between two reads of the outbox.
+	PollInterval time.Duration
+	// SettleDelay is the age of the events read from the outbox. It must
+	// exceed the duration of the transactions writing them, or events
+	// committed late are skipped.
+	SettleDelay time.Duration
+}
+
+// Dispatcher delivers the outbox events to the subscribers, in order, with
+// retries. Events still failing after the last attempt are dead-lettered
+// and the subscriber moves on to the next ones. Each subscriber has its own
+// cursor, leased by one instance at a time so that replicas do not deliver
+// the same events. A nil Dispatcher is valid and delivers nothing.
+type Dispatcher struct {
+	db     DatabaseInterface
+	config Config
+	client *http.Client
+	// signers sign the events with the secret of each subscriber.
+	signers map[string]*signing.Signer
+
+	// owner identifies the instance in the cursor leases.
+	owner string
+	lease time.Duration
+
+	now func() time.Time
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Run delivers the events to every subscriber until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range d.config.Subscribers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.run(ctx, sub)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (d *Dispatcher) run(ctx context.Context, sub Subscriber) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.dispatch(ctx, sub); err != nil && ctx.Err() == nil {
+			d.logger.Errorf("failed to dispatch events to webhook %s: %v", sub.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch delivers the replayed deliveries, then the new events, to a
+// subscriber while this instance holds its cursor.
+func (d *Dispatcher) dispatch(ctx context.Context, sub Subscriber) error {
+	ctx, span := d.tracer.Start(ctx, "webhooks.Dispatcher.dispatch")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscriber", sub.Name))
+
+	cursor, ok, err := d.db.ClaimWebhookCursor(ctx, sub.Name, d.owner, d.lease)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Another instance delivers to this subscriber.
+		return nil
+	}
+
+	if err := d.replay(ctx, sub); err != nil {
+		return err
+	}
+
+	for {
+		events, err := d.db.ListOutboxEvents(ctx, cursor, d.config.SettleDelay, dispatchBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, e := range events {
+			if !sub.accepts(e) {
+				continue
+			}
+			if err := d.deliver(ctx, sub, e); err != nil {
+				return err
+			}
+			if err := d.advance(ctx, sub, e.ID); err != nil {
+				return err
+			}
+		}
+
+		// Also moves past the events the subscriber does not accept.
+		cursor = events[len(events)-1].ID
+		if err := d.advance(ctx, sub, cursor); err != nil {
+			return err
+		}
+
+		if len(events) < dispatchBatchSize {
+			return nil
+		}
+	}
+}
+
+// replay delivers the dead-lettered events replayed for a subscriber, they
+// are dead-lettered again when every attempt fails.
+func (d *Dispatcher) replay(ctx context.Context, sub Subscriber) error {
+	pending, err := d.db.ListWebhookDeliveries(ctx, &types.WebhookDeliveryFilter{
+		Subscriber: sub.Name,
+		Status:     types.WebhookDeliveryPending,
+		Limit:      dispatchBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, dl := range pending {
+		attempts, err := d.attempt(ctx, sub, dl.Event)
+		if ctx.Err() != nil || errors.Is(err, errLeaseLost) {
+			return err
+		}
+
+		status, lastError := types.WebhookDeliveryDelivered, ""
+		if err != nil {
+			status, lastError = types.WebhookDeliveryFailed, err.Error()
+			d.logger.Errorf("replay of event %d to webhook %s failed after %d attempts: %v", dl.Event.ID, sub.Name, attempts, err)
+		}
+
+		if err := d.db.UpdateWebhookDelivery(ctx, dl.ID, status, attempts, lastError); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliver sends an event to a subscriber, dead-lettering it when every
+// attempt fails.
+func (d *Dispatcher) deliver(ctx context.Context, sub Subscriber, e *types.OutboxEvent) error {
+	attempts, err := d.attempt(ctx, sub, e)
+	if err == nil || ctx.Err() != nil || errors.Is(err, errLeaseLost) {
+		return err
+	}
+
+	d.logger.Errorf("delivery of event %d to webhook %s failed after %d attempts, dead-lettering it: %v", e.ID, sub.Name, attempts, err)
+
+	return d.db.CreateWebhookDelivery(ctx, &types.WebhookDelivery{
+		Subscriber: sub.Name,
+		Status:     types.WebhookDeliveryFailed,
+		Attempts:   attempts,
+		LastError:  err.Error(),
+		Event:      e,
+	})
+}
+
+// attempt posts an event until the subscriber accepts it or MaxAttempts is
+// reached, backing off between attempts, and returns the number of attempts.
+func (d *Dispatcher) attempt(ctx context.Context, sub Subscriber, e *types.OutboxEvent) (int, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %v", err)
+	}
+
+	for n := 1; ; n++ {
+		err := d.post(ctx, sub, e, body)
+		if err == nil {
+			deliveries.WithLabelValues(sub.Name, resultDelivered).Inc()
+			return n, nil
+		}
+		if n >= d.config.MaxAttempts {
+			deliveries.WithLabelValues(sub.Name, resultDeadLettered).Inc()
+			return n, err
+		}
+
+		deliveries.WithLabelValues(sub.Name, resultRetried).Inc()
+		wait := d.backoff(n)
+		d.logger.Warnf("attempt %d of event %d to webhook %s failed, retrying in %s: %v", n, e.ID, sub.Name, wait, err)
+
+		select {
+		case <-ctx.Done():
+			return n, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		// Keep the lease while retrying.
+		if err := d.advance(ctx, sub, 0); err != nil {
+			return n, err
+		}
+	}
+}
+
+// post sends a signed event to a subscriber, any status other than 2xx is
+// a failure.
+func (d *Dispatcher) post(ctx context.Context, sub Subscriber, e *types.OutboxEvent, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp, signature := d.signers[sub.Name].Sign(d.now(), body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, strconv.FormatInt(e.ID, 10))
+	req.Header.Set(EventTypeHeader, string(e.Type))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection is reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxEventSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// advance moves the cursor of a subscriber to eventID and renews the lease,
+// an eventID of 0 only renews it.
+func (d *Dispatcher) advance(ctx context.Context, sub Subscriber, eventID int64) error {
+	ok, err := d.db.AdvanceWebhookCursor(ctx, sub.Name, d.owner, eventID, d.lease)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errLeaseLost
+	}
+	return nil
+}
+
+// backoff is the wait after the nth failed attempt.
+func (d *Dispatcher) backoff(n int) time.Duration {
+	wait := d.config.MinBackoff
+	for i := 1; i < n && wait < d.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.config.MaxBackoff)
+}
+
+// NewDispatcher validates the config and returns a Dispatcher reading the
+// outbox from db, or nil when there is no subscriber.
+func NewDispatcher(db DatabaseInterface, config Config, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) (*Dispatcher, error) {
+	switch {
+	case config.MaxAttempts < 1:
+		return nil, fmt.Errorf("%w: max attempts must be at least 1", ErrInvalidConfig)
+	case config.MinBackoff <= 0 || config.MaxBackoff < config.MinBackoff:
+		return nil, fmt.Errorf("%w: backoff must be positive and the max backoff at least the min backoff", ErrInvalidConfig)
+	case config.Timeout <= 0 || config.PollInterval <= 0:
+		return nil, fmt.Errorf("%w: timeout and poll interval must be positive", ErrInvalidConfig)
+	case config.SettleDelay < 0:
+		return nil, fmt.Errorf("%w: negative settle delay", ErrInvalidConfig)
+	}
+
+	if len(config.Subscribers) == 0 {
+		return nil, nil
+	}
+
+	d := new(Dispatcher)
+
+	d.db = db
+	d.config = config
+	d.client = new(http.Client)
+
+	d.signers = make(map[string]*signing.Signer, len(config.Subscribers))
+	for _, sub := range config.Subscribers {
+		signer, err := signing.NewSigner([]string{sub.Secret}, DefaultSignatureWindow)
+		if err != nil {
+			return nil, fmt.Errorf("%w: subscriber %q: %v", ErrInvalidConfig, sub.Name, err)
+		}
+		d.signers[sub.Name] = signer
+	}
+
+	hostname, _ := os.Hostname()
+	d.owner = hostname + "/" + uuid.NewString()
+	// The lease outlives the longest wait between two renewals: a poll, or
+	// a failed attempt and the backoff that follows it.
+	d.lease = 2 * (config.PollInterval + config.Timeout + config.MaxBackoff)
+
+	d.now = time.Now
+
+	registerMetrics(logger)
+
+	d.tracer = tracer
+	d.monitor = monitor
+	d.logger = logger
+
+	return d, nil
+}
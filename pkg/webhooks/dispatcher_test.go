@@ -0,0 +1,306 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const testSecret = "s3cr3t"
+
+// standIn is a subscriber receiving the events with a Receiver, failing
+// the first failures requests.
+type standIn struct {
+	mu       sync.Mutex
+	events   []*types.OutboxEvent
+	failures int
+}
+
+func (s *standIn) handle(_ context.Context, e *types.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *standIn) received() []*types.OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.events
+}
+
+func newStandIn(t *testing.T, failures int) (*standIn, Subscriber) {
+	t.Helper()
+
+	s := &standIn{failures: failures}
+	rc, err := NewReceiver([]string{testSecret}, s.handle, logging.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+
+	return s, Subscriber{Name: "chatops", URL: srv.URL, Secret: testSecret}
+}
+
+func newTestDispatcher(t *testing.T, db DatabaseInterface, sub Subscriber, maxAttempts int) *Dispatcher {
+	t.Helper()
+
+	logger := logging.NewNoopLogger()
+	d, err := NewDispatcher(db, Config{
+		Subscribers:  []Subscriber{sub},
+		MaxAttempts:  maxAttempts,
+		MinBackoff:   time.Millisecond,
+		MaxBackoff:   time.Millisecond,
+		Timeout:      time.Second,
+		PollInterval: time.Second,
+	}, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("hook-service-test", logger), logger)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return d
+}
+
+func TestDispatcherDeliversEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver, sub := newStandIn(t, 0)
+	sub.Events = []types.HistoryAction{types.HistoryMemberAdded}
+
+	events := []*types.OutboxEvent{
+		{ID: 11, Type: types.HistoryMemberAdded, GroupID: "g1", UserID: "alice@example.com", After: map[string]interface{}{"role": "member"}},
+		{ID: 12, Type: types.HistoryAppGranted, GroupID: "g1"},
+	}
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	d := newTestDispatcher(t, mockDB, sub, 3)
+
+	gomock.InOrder(
+		mockDB.EXPECT().ClaimWebhookCursor(gomock.Any(), "chatops", d.owner, d.lease).Return(int64(10), true, nil),
+		mockDB.EXPECT().ListWebhookDeliveries(gomock.Any(), &types.WebhookDeliveryFilter{Subscriber: "chatops", Status: types.WebhookDeliveryPending, Limit: dispatchBatchSize}).Return(nil, nil),
+		mockDB.EXPECT().ListOutboxEvents(gomock.Any(), int64(10), time.Duration(0), uint64(dispatchBatchSize)).Return(events, nil),
+		mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(11), d.lease).Return(true, nil),
+		// The app grant is not delivered but the cursor moves past it.
+		mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(12), d.lease).Return(true, nil),
+	)
+
+	if err := d.dispatch(context.TODO(), sub); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got := receiver.received()
+	if len(got) != 1 || got[0].ID != 11 || got[0].UserID != "alice@example.com" || got[0].After["role"] != "member" {
+		t.Fatalf("expected event 11 to be delivered, got %v", got)
+	}
+}
+
+func TestDispatcherRetriesFailedDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver, sub := newStandIn(t, 2)
+	event := &types.OutboxEvent{ID: 11, Type: types.HistoryMemberRemoved}
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	d := newTestDispatcher(t, mockDB, sub, 3)
+
+	mockDB.EXPECT().ClaimWebhookCursor(gomock.Any(), "chatops", d.owner, d.lease).Return(int64(10), true, nil)
+	mockDB.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().ListOutboxEvents(gomock.Any(), int64(10), gomock.Any(), gomock.Any()).Return([]*types.OutboxEvent{event}, nil)
+	// The lease is renewed before each retry.
+	mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(0), d.lease).Times(2).Return(true, nil)
+	mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(11), d.lease).Times(2).Return(true, nil)
+
+	if err := d.dispatch(context.TODO(), sub); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := receiver.received(); len(got) != 1 || got[0].ID != 11 {
+		t.Fatalf("expected event 11 to be delivered on the third attempt, got %v", got)
+	}
+}
+
+func TestDispatcherDeadLettersEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver, sub := newStandIn(t, 100)
+	event := &types.OutboxEvent{ID: 11, Type: types.HistoryMemberAdded}
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	d := newTestDispatcher(t, mockDB, sub, 2)
+
+	mockDB.EXPECT().ClaimWebhookCursor(gomock.Any(), "chatops", d.owner, d.lease).Return(int64(10), true, nil)
+	mockDB.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().ListOutboxEvents(gomock.Any(), int64(10), gomock.Any(), gomock.Any()).Return([]*types.OutboxEvent{event}, nil)
+	mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(0), d.lease).Return(true, nil)
+	mockDB.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, dl *types.WebhookDelivery) error {
+			if dl.Subscriber != "chatops" || dl.Event != event || dl.Status != types.WebhookDeliveryFailed || dl.Attempts != 2 {
+				t.Errorf("unexpected dead letter %+v", dl)
+			}
+			if dl.LastError == "" {
+				t.Error("expected the dead letter to hold the last error")
+			}
+			return nil
+		},
+	)
+	mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(11), d.lease).Times(2).Return(true, nil)
+
+	if err := d.dispatch(context.TODO(), sub); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := receiver.received(); len(got) != 0 {
+		t.Fatalf("expected no event to be accepted, got %v", got)
+	}
+}
+
+func TestDispatcherReplaysDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver, sub := newStandIn(t, 0)
+	// Replays ignore the filters of the subscriber.
+	sub.Events = []types.HistoryAction{types.HistoryMemberAdded}
+	replayed := &types.WebhookDelivery{ID: 3, Subscriber: "chatops", Status: types.WebhookDeliveryPending, Event: &types.OutboxEvent{ID: 5, Type: types.HistoryAppRevoked}}
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	d := newTestDispatcher(t, mockDB, sub, 1)
+
+	mockDB.EXPECT().ClaimWebhookCursor(gomock.Any(), "chatops", d.owner, d.lease).Return(int64(10), true, nil)
+	mockDB.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Any()).Return([]*types.WebhookDelivery{replayed}, nil)
+	mockDB.EXPECT().UpdateWebhookDelivery(gomock.Any(), int64(3), types.WebhookDeliveryDelivered, 1, "").Return(nil)
+	mockDB.EXPECT().ListOutboxEvents(gomock.Any(), int64(10), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	if err := d.dispatch(context.TODO(), sub); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := receiver.received(); len(got) != 1 || got[0].ID != 5 {
+		t.Fatalf("expected event 5 to be delivered, got %v", got)
+	}
+}
+
+func TestDispatcherSkipsLeasedSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver, sub := newStandIn(t, 0)
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	d := newTestDispatcher(t, mockDB, sub, 1)
+
+	mockDB.EXPECT().ClaimWebhookCursor(gomock.Any(), "chatops", d.owner, d.lease).Return(int64(0), false, nil)
+
+	if err := d.dispatch(context.TODO(), sub); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := receiver.received(); len(got) != 0 {
+		t.Fatalf("expected no event to be delivered, got %v", got)
+	}
+}
+
+func TestDispatcherStopsWhenLeaseIsLost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver, sub := newStandIn(t, 0)
+	events := []*types.OutboxEvent{{ID: 11, Type: types.HistoryMemberAdded}, {ID: 12, Type: types.HistoryMemberAdded}}
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	d := newTestDispatcher(t, mockDB, sub, 1)
+
+	mockDB.EXPECT().ClaimWebhookCursor(gomock.Any(), "chatops", d.owner, d.lease).Return(int64(10), true, nil)
+	mockDB.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().ListOutboxEvents(gomock.Any(), int64(10), gomock.Any(), gomock.Any()).Return(events, nil)
+	mockDB.EXPECT().AdvanceWebhookCursor(gomock.Any(), "chatops", d.owner, int64(11), d.lease).Return(false, nil)
+
+	if err := d.dispatch(context.TODO(), sub); !errors.Is(err, errLeaseLost) {
+		t.Fatalf("expected error %v, got %v", errLeaseLost, err)
+	}
+
+	if got := receiver.received(); len(got) != 1 {
+		t.Fatalf("expected the deliveries to stop after event 11, got %v", got)
+	}
+}
+
+func TestDispatcherBackoff(t *testing.T) {
+	d := &Dispatcher{config: Config{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := d.backoff(i + 1); got != want {
+			t.Errorf("backoff after attempt %d: expected %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+func TestNewDispatcher(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	valid := Config{
+		Subscribers:  []Subscriber{{Name: "chatops", URL: "http://localhost:8000", Secret: testSecret}},
+		MaxAttempts:  3,
+		MinBackoff:   time.Second,
+		MaxBackoff:   time.Minute,
+		Timeout:      time.Second,
+		PollInterval: time.Second,
+		SettleDelay:  time.Minute,
+	}
+
+	tests := []struct {
+		name          string
+		mutate        func(*Config)
+		expectNil     bool
+		expectedError error
+	}{
+		{name: "Valid config", mutate: func(*Config) {}},
+		{name: "No subscriber", mutate: func(c *Config) { c.Subscribers = nil }, expectNil: true},
+		{name: "No attempt", mutate: func(c *Config) { c.MaxAttempts = 0 }, expectedError: ErrInvalidConfig},
+		{name: "Inverted backoff", mutate: func(c *Config) { c.MaxBackoff = time.Millisecond }, expectedError: ErrInvalidConfig},
+		{name: "No timeout", mutate: func(c *Config) { c.Timeout = 0 }, expectedError: ErrInvalidConfig},
+		{name: "Negative settle delay", mutate: func(c *Config) { c.SettleDelay = -time.Second }, expectedError: ErrInvalidConfig},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := valid
+			test.mutate(&config)
+
+			d, err := NewDispatcher(nil, config, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("hook-service-test", logger), logger)
+
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if (d == nil) != test.expectNil {
+				t.Fatalf("expected nil dispatcher %t, got %v", test.expectNil, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import "errors"
+
+var (
+	ErrInvalidSubscriber = errors.New("invalid webhook subscriber")
+	ErrInvalidConfig     = errors.New("invalid webhook dispatcher configuration")
+	ErrInvalidStatus     = errors.New("invalid delivery status")
+)
@@ -0,0 +1,27 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+type ServiceInterface interface {
+	ListDeliveries(context.Context, *ListOptions) ([]*types.WebhookDelivery, string, error)
+	ReplayDeliveries(context.Context, string, []int64) (int64, error)
+}
+
+type DatabaseInterface interface {
+	ListOutboxEvents(context.Context, int64, time.Duration, uint64) ([]*types.OutboxEvent, error)
+	PurgeOutboxEvents(context.Context, time.Time) (int64, error)
+	ClaimWebhookCursor(context.Context, string, string, time.Duration) (int64, bool, error)
+	AdvanceWebhookCursor(context.Context, string, string, int64, time.Duration) (bool, error)
+	CreateWebhookDelivery(context.Context, *types.WebhookDelivery) error
+	UpdateWebhookDelivery(context.Context, int64, types.WebhookDeliveryStatus, int, string) error
+	ListWebhookDeliveries(context.Context, *types.WebhookDeliveryFilter) ([]*types.WebhookDelivery, error)
+	ReplayWebhookDeliveries(context.Context, string, []int64) (int64, error)
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/canonical/hook-service/internal/logging"
+)
+
+const (
+	resultDelivered    = "delivered"
+	resultRetried      = "retried"
+	resultDeadLettered = "dead_lettered"
+)
+
+var (
+	deliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hook_service_webhook_deliveries_total",
+		Help: "Total number of webhook delivery attempts by subscriber and result",
+	}, []string{"subscriber", "result"})
+
+	registerOnce sync.Once
+)
+
+// registerMetrics registers the webhook collectors once per process,
+// dispatchers are built more than once in tests.
+func registerMetrics(logger logging.LoggerInterface) {
+	registerOnce.Do(func() {
+		err := prometheus.Register(deliveries)
+		switch err.(type) {
+		case nil:
+		case prometheus.AlreadyRegisteredError:
+			logger.Debugf("metric %v already registered", deliveries)
+		default:
+			logger.Errorf("metric %v could not be registered", deliveries)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/monitoring"
+	"github.com/canonical/hook-service/internal/purge"
+	"github.com/canonical/hook-service/internal/tracing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+var _ ServiceInterface = (*Service)(nil)
+
+// ListOptions filters and paginates the dead-lettered deliveries, empty
+// fields match every delivery.
+type ListOptions struct {
+	Subscriber string
+	Status     types.WebhookDeliveryStatus
+	PageSize   int
+	PageToken  string
+}
+
+type Service struct {
+	db DatabaseInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// ListDeliveries returns a page of dead-lettered deliveries, oldest first,
+// and the token of the next page or an empty string on the last page.
+func (s *Service) ListDeliveries(ctx context.Context, opts *ListOptions) ([]*types.WebhookDelivery, string, error) {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.ListDeliveries")
+	defer span.End()
+
+	size, err := types.PageSize(opts.PageSize, DefaultPageSize, MaxPageSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch opts.Status {
+	case "", types.WebhookDeliveryFailed, types.WebhookDeliveryPending, types.WebhookDeliveryDelivered:
+	default:
+		return nil, "", fmt.Errorf("%w: %q", ErrInvalidStatus, opts.Status)
+	}
+
+	afterID, err := types.ParseIDPageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	span.SetAttributes(
+		attribute.String("webhook.subscriber", opts.Subscriber),
+		attribute.String("webhook.status", string(opts.Status)),
+		attribute.Int("page.size", size),
+	)
+
+	// Fetch one more delivery than requested to know if there is a next page.
+	deliveries, err := s.db.ListWebhookDeliveries(ctx, &types.WebhookDeliveryFilter{
+		Subscriber: opts.Subscriber,
+		Status:     opts.Status,
+		AfterID:    afterID,
+		Limit:      uint64(size) + 1,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(deliveries) > size {
+		deliveries = deliveries[:size]
+		next = types.IDPageToken(deliveries[size-1].ID)
+	}
+
+	return deliveries, next, nil
+}
+
+// ReplayDeliveries hands failed deliveries back to the dispatcher and
+// returns how many were replayed. Without IDs every failed delivery of the
+// subscriber, or of every subscriber, is replayed.
+func (s *Service) ReplayDeliveries(ctx context.Context, subscriber string, ids []int64) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.ReplayDeliveries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.subscriber", subscriber),
+		attribute.Int("webhook.deliveries", len(ids)),
+	)
+
+	return s.db.ReplayWebhookDeliveries(ctx, subscriber, ids)
+}
+
+// PurgeEvents deletes the outbox events older than retention, except those
+// of failed or pending deliveries.
+func (s *Service) PurgeEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.PurgeEvents")
+	defer span.End()
+
+	return s.db.PurgeOutboxEvents(ctx, time.Now().Add(-retention))
+}
+
+// RunRetention purges the outbox events older than retention every interval
+// until ctx is cancelled.
+func (s *Service) RunRetention(ctx context.Context, retention, interval time.Duration) error {
+	return purge.Run(ctx, interval, fmt.Sprintf("outbox events older than %s", retention), func(ctx context.Context) (int64, error) {
+		return s.PurgeEvents(ctx, retention)
+	}, s.logger)
+}
+
+func NewService(db DatabaseInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Service {
+	s := new(Service)
+
+	s.db = db
+
+	s.monitor = monitor
+	s.tracer = tracer
+	s.logger = logger
+
+	return s
+}
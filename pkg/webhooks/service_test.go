@@ -0,0 +1,142 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package webhooks -destination ./mock_webhooks.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package webhooks -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package webhooks -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package webhooks -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestServiceListDeliveries(t *testing.T) {
+	page := []*types.WebhookDelivery{{ID: 7}, {ID: 8}, {ID: 9}}
+
+	tests := []struct {
+		name string
+		opts *ListOptions
+
+		expectedFilter *types.WebhookDeliveryFilter
+		dbResult       []*types.WebhookDelivery
+		dbErr          error
+
+		expected      []*types.WebhookDelivery
+		expectedNext  string
+		expectedError error
+	}{
+		{
+			name:           "Default page size",
+			opts:           &ListOptions{Subscriber: "billing", Status: types.WebhookDeliveryFailed},
+			expectedFilter: &types.WebhookDeliveryFilter{Subscriber: "billing", Status: types.WebhookDeliveryFailed, Limit: DefaultPageSize + 1},
+			dbResult:       page,
+			expected:       page,
+		},
+		{
+			name:           "Next page token when more deliveries exist",
+			opts:           &ListOptions{PageSize: 2},
+			expectedFilter: &types.WebhookDeliveryFilter{Limit: 3},
+			dbResult:       page,
+			expected:       page[:2],
+			expectedNext:   types.IDPageToken(8),
+		},
+		{
+			name:           "Page token selects newer deliveries",
+			opts:           &ListOptions{PageSize: 2, PageToken: types.IDPageToken(8)},
+			expectedFilter: &types.WebhookDeliveryFilter{AfterID: 8, Limit: 3},
+			dbResult:       page[2:],
+			expected:       page[2:],
+		},
+		{
+			name:          "Invalid page token",
+			opts:          &ListOptions{PageToken: "not a token"},
+			expectedError: types.ErrInvalidPageToken,
+		},
+		{
+			name:          "Page token of another listing",
+			opts:          &ListOptions{PageToken: types.StreamToken(types.SortByName, &types.Cursor{Value: "billing", ID: "8"})},
+			expectedError: types.ErrInvalidPageToken,
+		},
+		{
+			name:          "Page size too large",
+			opts:          &ListOptions{PageSize: MaxPageSize + 1},
+			expectedError: types.ErrInvalidPageSize,
+		},
+		{
+			name:          "Unknown status",
+			opts:          &ListOptions{Status: "lost"},
+			expectedError: ErrInvalidStatus,
+		},
+		{
+			name:           "Storage error",
+			opts:           &ListOptions{},
+			expectedFilter: &types.WebhookDeliveryFilter{Limit: DefaultPageSize + 1},
+			dbErr:          errors.New("connection refused"),
+			expectedError:  errors.New("connection refused"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := NewMockDatabaseInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+			if test.expectedFilter != nil {
+				mockDB.EXPECT().ListWebhookDeliveries(gomock.Any(), test.expectedFilter).Return(test.dbResult, test.dbErr)
+			}
+
+			s := NewService(mockDB, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+			deliveries, next, err := s.ListDeliveries(context.TODO(), test.opts)
+
+			if test.expectedError != nil {
+				if err == nil || (!errors.Is(err, test.expectedError) && err.Error() != test.expectedError.Error()) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(deliveries, test.expected) {
+				t.Fatalf("expected deliveries %v, got %v", test.expected, deliveries)
+			}
+			if next != test.expectedNext {
+				t.Fatalf("expected next page token %q, got %q", test.expectedNext, next)
+			}
+		})
+	}
+}
+
+func TestServiceReplayDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDatabaseInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+	mockDB.EXPECT().ReplayWebhookDeliveries(gomock.Any(), "billing", []int64{3, 4}).Return(int64(2), nil)
+
+	s := NewService(mockDB, mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	n, err := s.ReplayDeliveries(context.TODO(), "billing", []int64{3, 4})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 replayed deliveries, got %d", n)
+	}
+}
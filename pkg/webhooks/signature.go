@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/signing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+const (
+	// EventIDHeader carries the ID of the event, the same event can be
+	// delivered more than once and receivers use it to drop duplicates.
+	EventIDHeader = "X-Webhook-Id"
+	// EventTypeHeader carries the type of the event, such as member.added.
+	EventTypeHeader = "X-Webhook-Event"
+	// TimestampHeader carries the Unix time in seconds at which the request
+	// was signed.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader carries the request signature as `v1=<hex HMAC-SHA256>`.
+	SignatureHeader = "X-Webhook-Signature"
+
+	// DefaultSignatureWindow is the max age of a request accepted by the
+	// Receiver.
+	DefaultSignatureWindow = 5 * time.Minute
+
+	maxEventSize = 1 << 20
+)
+
+// Receiver is a webhook endpoint checking the signature of the requests and
+// passing their events to a function. It stands in for a subscriber in tests
+// and local setups. Requests with an invalid signature get a 401, events
+// the function fails to handle a 500, and the others a 204.
+type Receiver struct {
+	signer *signing.Signer
+	handle func(context.Context, *types.OutboxEvent) error
+
+	logger logging.LoggerInterface
+}
+
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := rc.signer.Verify(r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body); err != nil {
+		rc.logger.Warnf("rejected webhook event %s: %v", r.Header.Get(EventIDHeader), err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	e := new(types.OutboxEvent)
+	if err := json.Unmarshal(body, e); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := rc.handle(r.Context(), e); err != nil {
+		rc.logger.Errorf("failed to handle webhook event %d: %v", e.ID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NewReceiver returns a Receiver accepting the requests signed with one of
+// secrets, a current and a previous one during rotation, within
+// DefaultSignatureWindow.
+func NewReceiver(secrets []string, handle func(context.Context, *types.OutboxEvent) error, logger logging.LoggerInterface) (*Receiver, error) {
+	signer, err := signing.NewSigner(secrets, DefaultSignatureWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := new(Receiver)
+
+	rc.signer = signer
+	rc.handle = handle
+
+	rc.logger = logger
+
+	return rc, nil
+}
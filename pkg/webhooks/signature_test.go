@@ -0,0 +1,77 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/canonical/hook-service/internal/logging"
+	"github.com/canonical/hook-service/internal/signing"
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestReceiver(t *testing.T) {
+	body := []byte(`{"id":7,"type":"app.granted","group_id":"g1"}`)
+	now := time.Now()
+
+	sign := func(secret string) (string, string) {
+		signer, err := signing.NewSigner([]string{secret}, DefaultSignatureWindow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return signer.Sign(now, body)
+	}
+	timestamp, signature := sign(testSecret)
+	_, previousSignature := sign("previous")
+	_, otherSignature := sign("other")
+
+	tests := []struct {
+		name           string
+		signature      string
+		handleErr      error
+		expectedStatus int
+		expectHandled  bool
+	}{
+		{name: "Accepted event", signature: signature, expectedStatus: http.StatusNoContent, expectHandled: true},
+		{name: "Previous secret during rotation", signature: previousSignature, expectedStatus: http.StatusNoContent, expectHandled: true},
+		{name: "Invalid signature", signature: otherSignature, expectedStatus: http.StatusUnauthorized},
+		{name: "Handler failure", signature: signature, handleErr: errors.New("full"), expectedStatus: http.StatusInternalServerError, expectHandled: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var handled *types.OutboxEvent
+			rc, err := NewReceiver([]string{testSecret, "previous"}, func(_ context.Context, e *types.OutboxEvent) error {
+				handled = e
+				return test.handleErr
+			}, logging.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set(TimestampHeader, timestamp)
+			req.Header.Set(SignatureHeader, test.signature)
+			rec := httptest.NewRecorder()
+
+			rc.ServeHTTP(rec, req)
+
+			if rec.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, rec.Code)
+			}
+			if (handled != nil) != test.expectHandled {
+				t.Fatalf("expected handled %t, got %v", test.expectHandled, handled)
+			}
+			if handled != nil && (handled.ID != 7 || handled.Type != types.HistoryAppGranted || handled.GroupID != "g1") {
+				t.Fatalf("unexpected event %+v", handled)
+			}
+		})
+	}
+}
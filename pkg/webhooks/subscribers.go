@@ -0,0 +1,79 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+// Subscriber is an HTTP endpoint receiving the outbox events.
+type Subscriber struct {
+	// Name identifies the cursor and the dead letters of the subscriber,
+	// renaming a subscriber starts it again after the latest event.
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+	// Events are the event types delivered to the subscriber, empty means
+	// every type.
+	Events []types.HistoryAction `json:"events,omitempty"`
+	// Tenants are the tenants whose events are delivered to the subscriber,
+	// empty means every tenant.
+	Tenants []string `json:"tenants,omitempty"`
+}
+
+// accepts tells if the event is delivered to the subscriber.
+func (s *Subscriber) accepts(e *types.OutboxEvent) bool {
+	if len(s.Events) > 0 && !slices.Contains(s.Events, e.Type) {
+		return false
+	}
+	if len(s.Tenants) > 0 && !slices.Contains(s.Tenants, e.TenantID) {
+		return false
+	}
+	return true
+}
+
+// ParseSubscribers decodes and validates a JSON array of subscribers, an
+// empty string yields no subscriber.
+func ParseSubscribers(raw string) ([]Subscriber, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	subscribers := make([]Subscriber, 0)
+	if err := json.Unmarshal([]byte(raw), &subscribers); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSubscriber, err)
+	}
+
+	seen := make(map[string]struct{}, len(subscribers))
+	for _, s := range subscribers {
+		if s.Name == "" {
+			return nil, fmt.Errorf("%w: subscriber name is empty", ErrInvalidSubscriber)
+		}
+		if _, ok := seen[s.Name]; ok {
+			return nil, fmt.Errorf("%w: subscriber %q is declared twice", ErrInvalidSubscriber, s.Name)
+		}
+		seen[s.Name] = struct{}{}
+
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: subscriber %q needs an http or https URL", ErrInvalidSubscriber, s.Name)
+		}
+		if s.Secret == "" {
+			return nil, fmt.Errorf("%w: subscriber %q has no secret", ErrInvalidSubscriber, s.Name)
+		}
+
+		for _, e := range s.Events {
+			if !slices.Contains(types.WebhookEventTypes, e) {
+				return nil, fmt.Errorf("%w: unknown event type %q for subscriber %q", ErrInvalidSubscriber, e, s.Name)
+			}
+		}
+	}
+
+	return subscribers, nil
+}
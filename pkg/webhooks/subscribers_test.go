@@ -0,0 +1,108 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package webhooks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/canonical/hook-service/internal/types"
+)
+
+func TestParseSubscribers(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		expectedCount int
+		expectedError error
+	}{
+		{
+			name: "Empty",
+			raw:  "",
+		},
+		{
+			name:          "Valid subscribers",
+			raw:           `[{"name": "chatops", "url": "https://bot.example.com/hooks", "secret": "s1"}, {"name": "billing", "url": "http://billing:8000", "secret": "s2", "events": ["app.granted", "app.revoked"], "tenants": ["acme"]}]`,
+			expectedCount: 2,
+		},
+		{
+			name:          "Malformed JSON",
+			raw:           `{"name": "chatops"}`,
+			expectedError: ErrInvalidSubscriber,
+		},
+		{
+			name:          "Missing name",
+			raw:           `[{"url": "https://bot.example.com/hooks", "secret": "s1"}]`,
+			expectedError: ErrInvalidSubscriber,
+		},
+		{
+			name:          "Duplicate name",
+			raw:           `[{"name": "chatops", "url": "https://a.example.com", "secret": "s1"}, {"name": "chatops", "url": "https://b.example.com", "secret": "s2"}]`,
+			expectedError: ErrInvalidSubscriber,
+		},
+		{
+			name:          "Unsupported URL scheme",
+			raw:           `[{"name": "chatops", "url": "ftp://bot.example.com", "secret": "s1"}]`,
+			expectedError: ErrInvalidSubscriber,
+		},
+		{
+			name:          "Missing secret",
+			raw:           `[{"name": "chatops", "url": "https://bot.example.com/hooks"}]`,
+			expectedError: ErrInvalidSubscriber,
+		},
+		{
+			name:          "Unknown event type",
+			raw:           `[{"name": "chatops", "url": "https://bot.example.com/hooks", "secret": "s1", "events": ["group.updated"]}]`,
+			expectedError: ErrInvalidSubscriber,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subscribers, err := ParseSubscribers(test.raw)
+
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %v, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(subscribers) != test.expectedCount {
+				t.Fatalf("expected %d subscribers, got %d", test.expectedCount, len(subscribers))
+			}
+		})
+	}
+}
+
+func TestSubscriberAccepts(t *testing.T) {
+	sub := Subscriber{
+		Events:  []types.HistoryAction{types.HistoryMemberAdded},
+		Tenants: []string{"acme"},
+	}
+
+	tests := []struct {
+		name     string
+		event    *types.OutboxEvent
+		expected bool
+	}{
+		{name: "Matching event", event: &types.OutboxEvent{Type: types.HistoryMemberAdded, TenantID: "acme"}, expected: true},
+		{name: "Other type", event: &types.OutboxEvent{Type: types.HistoryAppGranted, TenantID: "acme"}},
+		{name: "Other tenant", event: &types.OutboxEvent{Type: types.HistoryMemberAdded, TenantID: "default"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sub.accepts(test.event); got != test.expected {
+				t.Fatalf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+
+	if !(&Subscriber{}).accepts(&types.OutboxEvent{Type: types.HistoryGroupDeleted}) {
+		t.Fatal("expected a subscriber without filters to accept every event")
+	}
+}